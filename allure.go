@@ -0,0 +1,277 @@
+package httpexpect
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"moul.io/http2curl/v2"
+)
+
+// AllureRecorder implements Printer.
+// Records every request as a step of Allure test result, with request,
+// response, and curl command attached, and writes result to Allure results
+// directory, which can be then rendered by Allure report tools.
+//
+// See https://allurereport.org/docs/how-it-works-test-result-file/.
+//
+// AllureRecorder is safe for concurrent use. One instance should be
+// created per test.
+//
+// Example:
+//
+//	recorder := httpexpect.NewAllureRecorder(t.Name(), "allure-results")
+//	defer func() {
+//		recorder.WriteResult(t.Failed())
+//	}()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			recorder,
+//		},
+//	})
+type AllureRecorder struct {
+	mu          sync.Mutex
+	name        string
+	dir         string
+	start       time.Time
+	steps       []allureStep
+	pending     *allureStep
+	attachments map[string][]byte
+}
+
+const defaultAllureDir = "allure-results"
+
+// NewAllureRecorder returns a new AllureRecorder for test with given name.
+//
+// Results are written into given directory. If dir is empty,
+// "allure-results" is used.
+func NewAllureRecorder(testName, dir string) *AllureRecorder {
+	if dir == "" {
+		dir = defaultAllureDir
+	}
+
+	return &AllureRecorder{
+		name:        testName,
+		dir:         dir,
+		start:       time.Now(),
+		attachments: map[string][]byte{},
+	}
+}
+
+// Request implements Printer.Request.
+func (a *AllureRecorder) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// previous request didn't receive response, record it as broken
+	if a.pending != nil {
+		a.pending.Status = "broken"
+		a.pending.Stop = a.pending.Start
+		a.steps = append(a.steps, *a.pending)
+	}
+
+	step := allureStep{
+		Name:   req.Method + " " + req.URL.String(),
+		Stage:  "finished",
+		Start:  allureTime(time.Now()),
+		Status: "broken",
+	}
+
+	if dump, err := httputil.DumpRequest(req, true); err == nil {
+		step.Attachments = append(step.Attachments,
+			a.attach("request", "text/plain", "txt", dump))
+	}
+
+	if cmd, err := http2curl.GetCurlCommand(req); err == nil {
+		step.Attachments = append(step.Attachments,
+			a.attach("curl", "text/plain", "txt", []byte(cmd.String())))
+	}
+
+	a.pending = &step
+}
+
+// Response implements Printer.Response.
+func (a *AllureRecorder) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var step allureStep
+
+	if a.pending != nil {
+		step = *a.pending
+		a.pending = nil
+	} else {
+		step = allureStep{
+			Stage: "finished",
+			Start: allureTime(time.Now().Add(-duration)),
+		}
+		if resp.Request != nil && resp.Request.URL != nil {
+			step.Name = resp.Request.Method + " " + resp.Request.URL.String()
+		}
+	}
+
+	step.Status = "passed"
+	step.Stop = step.Start + duration.Milliseconds()
+	step.Parameters = append(step.Parameters, allureParameter{
+		Name:  "status",
+		Value: strconv.Itoa(resp.StatusCode),
+	})
+
+	if dump, err := httputil.DumpResponse(resp, true); err == nil {
+		step.Attachments = append(step.Attachments,
+			a.attach("response", "text/plain", "txt", dump))
+	}
+
+	a.steps = append(a.steps, step)
+}
+
+// WriteResult writes test result with all recorded steps and attachments
+// into results directory. Test status is "failed" if failed is true, and
+// "passed" otherwise. Directory is created if it doesn't exist.
+//
+// Usually failed comes from t.Failed().
+func (a *AllureRecorder) WriteResult(failed bool) error {
+	a.mu.Lock()
+
+	steps := append([]allureStep{}, a.steps...)
+	if a.pending != nil {
+		steps = append(steps, *a.pending)
+	}
+
+	attachments := make(map[string][]byte, len(a.attachments))
+	for source, content := range a.attachments {
+		attachments[source] = content
+	}
+
+	a.mu.Unlock()
+
+	status := "passed"
+	if failed {
+		status = "failed"
+	}
+
+	historyID := md5.Sum([]byte(a.name))
+
+	result := allureResult{
+		UUID:      allureUUID(),
+		HistoryID: hex.EncodeToString(historyID[:]),
+		Name:      a.name,
+		FullName:  a.name,
+		Status:    status,
+		Stage:     "finished",
+		Start:     allureTime(a.start),
+		Stop:      allureTime(time.Now()),
+		Steps:     steps,
+		Labels: []allureParameter{
+			{Name: "framework", Value: "httpexpect"},
+			{Name: "language", Value: "go"},
+		},
+	}
+
+	if err := os.MkdirAll(a.dir, 0755); err != nil {
+		return err
+	}
+
+	for source, content := range attachments {
+		err := ioutil.WriteFile(filepath.Join(a.dir, source), content, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(
+		filepath.Join(a.dir, result.UUID+"-result.json"), b, 0644)
+}
+
+// must be called with mutex locked
+func (a *AllureRecorder) attach(
+	name, mimeType, ext string, content []byte,
+) allureAttachment {
+	source := fmt.Sprintf("%s-attachment.%s", allureUUID(), ext)
+
+	a.attachments[source] = content
+
+	return allureAttachment{
+		Name:   name,
+		Source: source,
+		Type:   mimeType,
+	}
+}
+
+type allureResult struct {
+	UUID      string            `json:"uuid"`
+	HistoryID string            `json:"historyId"`
+	Name      string            `json:"name"`
+	FullName  string            `json:"fullName"`
+	Status    string            `json:"status"`
+	Stage     string            `json:"stage"`
+	Start     int64             `json:"start"`
+	Stop      int64             `json:"stop"`
+	Steps     []allureStep      `json:"steps"`
+	Labels    []allureParameter `json:"labels"`
+}
+
+type allureStep struct {
+	Name        string             `json:"name"`
+	Status      string             `json:"status"`
+	Stage       string             `json:"stage"`
+	Start       int64              `json:"start"`
+	Stop        int64              `json:"stop"`
+	Parameters  []allureParameter  `json:"parameters,omitempty"`
+	Attachments []allureAttachment `json:"attachments,omitempty"`
+}
+
+type allureParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type allureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+// allure uses unix time in milliseconds
+func allureTime(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// random version 4 UUID
+func allureUUID() string {
+	var b [16]byte
+
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,163 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allureReadResult(t *testing.T, dir string) (map[string]interface{}, []string) {
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+
+	var (
+		result      map[string]interface{}
+		attachments []string
+	)
+
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), "-result.json") {
+			b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(b, &result))
+		} else {
+			attachments = append(attachments, f.Name())
+		}
+	}
+
+	require.NotNil(t, result)
+
+	return result, attachments
+}
+
+func TestAllureRecorder_Steps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recorder := NewAllureRecorder("TestFoo", filepath.Join(dir, "results"))
+
+	req1, _ := http.NewRequest("POST", "http://example.com/users",
+		bytes.NewBufferString(`{"name":"john"}`))
+
+	resp1 := &http.Response{
+		StatusCode: http.StatusCreated,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":1}`)),
+	}
+
+	req2, _ := http.NewRequest("GET", "http://example.com/timeout", nil)
+
+	recorder.Request(req1)
+	recorder.Response(resp1, time.Millisecond*10)
+	recorder.Request(req2)
+
+	require.NoError(t, recorder.WriteResult(true))
+
+	result, attachments := allureReadResult(t, filepath.Join(dir, "results"))
+
+	// request and curl for both requests, response for first one
+	assert.Equal(t, 5, len(attachments))
+
+	v := NewValue(t, result)
+
+	v.Path("$.name").String().Equal("TestFoo")
+	v.Path("$.fullName").String().Equal("TestFoo")
+	v.Path("$.status").String().Equal("failed")
+	v.Path("$.uuid").String().IsUUID()
+
+	steps := v.Path("$.steps").Array()
+	steps.Length().Equal(2)
+
+	step1 := steps.Element(0).Object()
+	step1.Value("name").String().Equal("POST http://example.com/users")
+	step1.Value("status").String().Equal("passed")
+	step1.Path("$.parameters[0].value").String().Equal("201")
+	step1.Path("$.attachments[*].name").Array().
+		Elements("request", "curl", "response")
+
+	step2 := steps.Element(1).Object()
+	step2.Value("name").String().Equal("GET http://example.com/timeout")
+	step2.Value("status").String().Equal("broken")
+	step2.Path("$.attachments[*].name").Array().Elements("request", "curl")
+
+	for _, source := range attachments {
+		b, err := ioutil.ReadFile(filepath.Join(dir, "results", source))
+		require.NoError(t, err)
+		assert.NotEmpty(t, b)
+	}
+
+	// request body is still readable after recording
+	body, err := ioutil.ReadAll(req1.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"john"}`, string(body))
+}
+
+func TestAllureRecorder_Passed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recorder := NewAllureRecorder("TestBar", dir)
+
+	require.NoError(t, recorder.WriteResult(false))
+
+	result, attachments := allureReadResult(t, dir)
+
+	assert.Empty(t, attachments)
+
+	v := NewValue(t, result)
+
+	v.Path("$.status").String().Equal("passed")
+	v.Path("$.steps").Array().Empty()
+}
+
+func TestAllureRecorder_Expect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("teapot"))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	recorder := NewAllureRecorder(t.Name(), dir)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+		Printers: []Printer{recorder},
+	})
+
+	e.GET("/tea").
+		Expect().
+		Status(http.StatusTeapot).
+		Body().Equal("teapot")
+
+	require.NoError(t, recorder.WriteResult(false))
+
+	result, _ := allureReadResult(t, dir)
+
+	v := NewValue(t, result)
+
+	v.Path("$.steps").Array().Length().Equal(1)
+	v.Path("$.steps[0].status").String().Equal("passed")
+	v.Path("$.steps[0].parameters[0].value").String().Equal("418")
+}
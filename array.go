@@ -70,6 +70,20 @@ func (a *Array) Raw() []interface{} {
 	return a.value
 }
 
+// Alias is similar to Value.Alias.
+//
+// Example:
+//
+//	items := NewArray(t, []interface{}{1, 2})
+//	items.Alias("items").Length().Equal(2)
+func (a *Array) Alias(name string) *Array {
+	opChain := a.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	a.chain.setAlias(name)
+	return a
+}
+
 // Decode unmarshals the underlying value attached to the Array to a target variable.
 // target should be one of these:
 //
@@ -115,6 +129,14 @@ func (a *Array) Path(path string) *Value {
 	return jsonPath(opChain, a.value, path)
 }
 
+// Pointer is similar to Value.Pointer.
+func (a *Array) Pointer(pointer string) *Value {
+	opChain := a.chain.enter("Pointer(%q)", pointer)
+	defer opChain.leave()
+
+	return jsonPointer(opChain, a.value, pointer)
+}
+
 // Schema is similar to Value.Schema.
 func (a *Array) Schema(schema interface{}) *Array {
 	opChain := a.chain.enter("Schema()")
@@ -315,6 +337,68 @@ func (a *Array) Every(fn func(index int, value *Value)) *Array {
 	return a
 }
 
+// Any runs the passed function on the Elements in the array, and succeeds
+// if assertions inside function succeeded for at least one element.
+//
+// Failed assertions inside function are not reported, unless none of the
+// elements passed them. Any stops on the first element that passed.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"foo", 123})
+//
+//	array.Any(func(index int, value *httpexpect.Value) {
+//		value.Number().Gt(100)
+//	})
+func (a *Array) Any(fn func(index int, value *Value)) *Array {
+	opChain := a.chain.enter("Any()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return a
+	}
+
+	for index, element := range a.value {
+		passed := false
+
+		func() {
+			valueChain := opChain.replace("Any[%v]", index)
+			defer valueChain.leave()
+
+			valueChain.setRoot()
+			valueChain.setSeverity(SeverityLog)
+
+			fn(index, newValue(valueChain, element))
+
+			passed = !valueChain.treeFailed()
+		}()
+
+		if passed {
+			return a
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{a.value},
+		Errors: []error{
+			errors.New("expected: at least one array element passes assertions"),
+		},
+	})
+
+	return a
+}
+
 // Filter accepts a function that returns a boolean. The function is ran
 // over the array elements. If the function returns true, the element passes
 // the filter and is added to the new array of filtered elements. If false,
@@ -654,6 +738,9 @@ func (a *Array) NotEmpty() *Array {
 //
 // value should be a slice of any type.
 //
+// Expected value may contain placeholders, like "$any" or "$uuid";
+// see PlaceholderAny for details.
+//
 // Example:
 //
 //	array := NewArray(t, []interface{}{"foo", 123})
@@ -677,7 +764,11 @@ func (a *Array) Equal(value interface{}) *Array {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return a
+	}
+
+	if !equalValues(opChain, expected, a.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{a.value},
@@ -713,7 +804,11 @@ func (a *Array) NotEqual(value interface{}) *Array {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return a
+	}
+
+	if equalValues(opChain, expected, a.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{a.value},
@@ -901,7 +996,11 @@ func (a *Array) Elements(values ...interface{}) *Array {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return a
+	}
+
+	if !equalValues(opChain, expected, a.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{a.value},
@@ -941,7 +1040,11 @@ func (a *Array) NotElements(values ...interface{}) *Array {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return a
+	}
+
+	if equalValues(opChain, expected, a.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{a.value},
@@ -10,7 +10,9 @@ func TestArray_Failed(t *testing.T) {
 	check := func(value *Array) {
 		value.chain.assertFailed(t)
 
+		value.Alias("foo")
 		value.Path("$")
+		value.Pointer("")
 		value.Schema("")
 
 		assert.NotNil(t, value.Length())
@@ -43,6 +45,9 @@ func TestArray_Failed(t *testing.T) {
 		value.Every(func(_ int, val *Value) {
 			val.String().NotEmpty()
 		})
+		value.Any(func(_ int, val *Value) {
+			val.String().NotEmpty()
+		})
 		value.Filter(func(_ int, val *Value) bool {
 			val.String().NotEmpty()
 			return true
@@ -933,6 +938,58 @@ func TestArray_Every(t *testing.T) {
 	})
 }
 
+func TestArray_Any(t *testing.T) {
+	t.Run("Assertion passed for one", func(ts *testing.T) {
+		reporter := newMockReporter(ts)
+		array := NewArray(reporter, []interface{}{"", 123, "bar", "baz"})
+		invoked := 0
+		array.Any(func(_ int, val *Value) {
+			invoked++
+			val.String().NotEmpty()
+		})
+		assert.Equal(t, 3, invoked)
+		array.chain.assertNotFailed(ts)
+	})
+
+	t.Run("Assertion failed for all", func(ts *testing.T) {
+		reporter := newMockReporter(ts)
+		array := NewArray(reporter, []interface{}{"", 123, ""})
+		invoked := 0
+		array.Any(func(_ int, val *Value) {
+			invoked++
+			val.String().NotEmpty()
+		})
+		assert.Equal(t, 3, invoked)
+		array.chain.assertFailed(ts)
+	})
+
+	t.Run("Empty array", func(ts *testing.T) {
+		reporter := newMockReporter(ts)
+		array := NewArray(reporter, []interface{}{})
+		array.Any(func(_ int, val *Value) {})
+		array.chain.assertFailed(ts)
+	})
+
+	t.Run("Test correct index", func(ts *testing.T) {
+		reporter := newMockReporter(ts)
+		array := NewArray(reporter, []interface{}{1, 2, 3})
+		array.Any(func(idx int, val *Value) {
+			if v, ok := val.Raw().(float64); ok {
+				assert.Equal(ts, idx, int(v)-1)
+			}
+			val.Number().Equal(3)
+		})
+		array.chain.assertNotFailed(ts)
+	})
+
+	t.Run("Chain fail on nil function value", func(ts *testing.T) {
+		reporter := newMockReporter(ts)
+		array := NewArray(reporter, []interface{}{1, 2, 3})
+		array.Any(nil)
+		array.chain.assertFailed(ts)
+	})
+}
+
 func TestArray_Transform(t *testing.T) {
 	t.Run("Square Integers", func(ts *testing.T) {
 		reporter := newMockReporter(ts)
@@ -1511,6 +1568,67 @@ func TestArray_IsOrdered(t *testing.T) {
 	}
 }
 
+func TestArray_IsOrderedJSONNumber(t *testing.T) {
+	config := newMockConfig(newMockReporter(t))
+	config.UseJSONNumber = true
+
+	tests := []struct {
+		name    string
+		values  []interface{}
+		ordered bool
+	}{
+		{
+			name:    "small and large",
+			values:  []interface{}{1, uint64(9007199254740993)},
+			ordered: true,
+		},
+		{
+			name:    "large and small",
+			values:  []interface{}{int64(9007199254740993), 1.5},
+			ordered: false,
+		},
+		{
+			name: "large only",
+			values: []interface{}{
+				int64(-9007199254740993),
+				int64(9007199254740993),
+				int64(9007199254740994),
+			},
+			ordered: true,
+		},
+		{
+			name: "large differing beyond float64 precision",
+			values: []interface{}{
+				int64(9007199254740994),
+				int64(9007199254740993),
+			},
+			ordered: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewArrayC(config, tt.values)
+			a.chain.assertNotFailed(t)
+
+			a.IsOrdered()
+			if tt.ordered {
+				a.chain.assertNotFailed(t)
+			} else {
+				a.chain.assertFailed(t)
+			}
+			a.chain.clearFailed()
+
+			a.NotOrdered()
+			if tt.ordered {
+				a.chain.assertFailed(t)
+			} else {
+				a.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
 func TestArray_NotOrdered(t *testing.T) {
 	type args struct {
 		values      []interface{}
@@ -114,9 +114,16 @@ type AssertionContext struct {
 
 	// Chain of nested assertion names
 	// Example value:
-	//   {`Request("GET")`, `Expect()`, `JSON()`, `NotNull()`}
+	//   {`Request("GET", "/path")`, `Expect()`, `JSON()`, `NotNull()`}
 	Path []string
 
+	// Same as Path, but starting from the alias instead of the full path
+	// Set by Alias() methods, e.g. Value.Alias()
+	// Example value:
+	//   {`user`, `Value("id")`, `Number()`, `Gt()`}
+	// Empty if no alias was set
+	AliasedPath []string
+
 	// Request being sent
 	// May be nil if request was not yet sent
 	Request *Request
@@ -0,0 +1,233 @@
+package httpexpect
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from AWS Signature Version 4 test suite
+func TestAWSSigner_TestSuite(t *testing.T) {
+	creds := AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+
+	cases := []struct {
+		name          string
+		method        string
+		url           string
+		header        http.Header
+		body          string
+		signedHeaders string
+		signature     string
+	}{
+		{
+			name:          "get-vanilla",
+			method:        "GET",
+			url:           "https://example.amazonaws.com/",
+			signedHeaders: "host;x-amz-date",
+			signature: "5fa00fa31553b73ebf1942676e86291e" +
+				"8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:          "post-vanilla",
+			method:        "POST",
+			url:           "https://example.amazonaws.com/",
+			signedHeaders: "host;x-amz-date",
+			signature: "5da7c1a2acd57cee7505fc6676e4e544" +
+				"621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:          "get-vanilla-query-order-key-case",
+			method:        "GET",
+			url:           "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			signedHeaders: "host;x-amz-date",
+			signature: "b97d918cfa904a5beff61c982a1b6f45" +
+				"8b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:          "post-x-www-form-urlencoded",
+			method:        "POST",
+			url:           "https://example.amazonaws.com/",
+			header:        http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+			body:          "Param1=value1",
+			signedHeaders: "content-type;host;x-amz-date",
+			signature: "ff11897932ad3f4e8b18135d722051e5" +
+				"ac45fc38421b1da7b9d196a0fe09473a",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, body)
+			require.NoError(t, err)
+
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+
+			signer := newAWSSigner(creds, "us-east-1", "service")
+			signer.now = func() time.Time {
+				return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+			}
+
+			require.NoError(t, signer.sign(req))
+
+			assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+			assert.Equal(t,
+				"AWS4-HMAC-SHA256 "+
+					"Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+					"SignedHeaders="+tc.signedHeaders+", "+
+					"Signature="+tc.signature,
+				req.Header.Get("Authorization"))
+
+			if tc.body != "" {
+				b, err := ioutil.ReadAll(req.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tc.body, string(b))
+			}
+		})
+	}
+}
+
+func TestAWSSigner_Headers(t *testing.T) {
+	t.Run("session token", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+		require.NoError(t, err)
+
+		signer := newAWSSigner(AWSCredentials{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+			SessionToken:    "token",
+		}, "us-east-1", "execute-api")
+
+		require.NoError(t, signer.sign(req))
+
+		assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+		assert.Empty(t, req.Header.Get("X-Amz-Content-Sha256"))
+		assert.Contains(t, req.Header.Get("Authorization"),
+			"SignedHeaders=host;x-amz-date;x-amz-security-token,")
+	})
+
+	t.Run("s3 payload hash", func(t *testing.T) {
+		req, err := http.NewRequest("PUT", "https://bucket.s3.amazonaws.com/key",
+			strings.NewReader(""))
+		require.NoError(t, err)
+
+		signer := newAWSSigner(AWSCredentials{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+		}, "us-east-1", "s3")
+
+		require.NoError(t, signer.sign(req))
+
+		assert.Equal(t,
+			"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			req.Header.Get("X-Amz-Content-Sha256"))
+		assert.Contains(t, req.Header.Get("Authorization"),
+			"SignedHeaders=host;x-amz-content-sha256;x-amz-date,")
+	})
+
+	t.Run("ignored headers", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+		require.NoError(t, err)
+
+		req.Header.Set("User-Agent", "test")
+		req.Header.Set("Authorization", "Basic old")
+		req.Header.Set("X-Foo", "  a   b ")
+		req.Header.Add("X-Foo", "c")
+
+		signer := newAWSSigner(AWSCredentials{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+		}, "us-east-1", "execute-api")
+
+		require.NoError(t, signer.sign(req))
+
+		canon, signed := signer.canonHeaders(req)
+		assert.Equal(t, "host:example.amazonaws.com\n"+
+			"x-amz-date:"+req.Header.Get("X-Amz-Date")+"\n"+
+			"x-foo:a b,c\n", canon)
+		assert.Equal(t, "host;x-amz-date;x-foo", signed)
+
+		assert.Contains(t, req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	})
+}
+
+func TestAWSSigner_Canon(t *testing.T) {
+	t.Run("path", func(t *testing.T) {
+		req, err := http.NewRequest("GET",
+			"https://example.amazonaws.com/foo%20bar/baz", nil)
+		require.NoError(t, err)
+
+		signer := newAWSSigner(AWSCredentials{}, "us-east-1", "execute-api")
+		assert.Equal(t, "/foo%2520bar/baz", signer.canonPath(req.URL))
+
+		signer = newAWSSigner(AWSCredentials{}, "us-east-1", "s3")
+		assert.Equal(t, "/foo%20bar/baz", signer.canonPath(req.URL))
+
+		req, err = http.NewRequest("GET", "https://example.amazonaws.com", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "/", signer.canonPath(req.URL))
+	})
+
+	t.Run("query", func(t *testing.T) {
+		req, err := http.NewRequest("GET",
+			"https://example.amazonaws.com/?b=2&a-b=3&a=1&a=0&c=x+y&d=%7E", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "a=0&a=1&a-b=3&b=2&c=x%20y&d=~", awsCanonQuery(req.URL))
+	})
+}
+
+func TestRequest_AWSSigV4(t *testing.T) {
+	var (
+		gotAuth string
+		gotDate string
+		gotBody string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotDate = r.Header.Get("X-Amz-Date")
+
+		b, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(b)
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	})
+
+	e.POST("/items").
+		WithAWSSigV4(AWSCredentials{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "secret",
+		}, "us-east-1", "execute-api").
+		WithJSON(map[string]interface{}{"foo": "bar"}).
+		Expect().
+		Status(http.StatusOK)
+
+	assert.NotEmpty(t, gotDate)
+	assert.True(t, strings.HasPrefix(gotAuth,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"+gotDate[:8]+
+			"/us-east-1/execute-api/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-date, Signature="))
+	assert.Equal(t, `{"foo":"bar"}`, gotBody)
+}
@@ -0,0 +1,341 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Binary provides methods to inspect attached []byte value
+// (binary content of response body).
+//
+// In failure reports, binary values are printed as hex dump.
+type Binary struct {
+	noCopy noCopy
+	chain  *chain
+	value  []byte
+}
+
+// NewBinary returns a new Binary instance.
+//
+// If reporter is nil, the function panics.
+// Value may be nil.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte{0x89, 'P', 'N', 'G'})
+//	bin.HasPrefix([]byte("\x89PNG"))
+func NewBinary(reporter Reporter, value []byte) *Binary {
+	return newBinary(newChainWithDefaults("Binary()", reporter), value)
+}
+
+// NewBinaryC returns a new Binary instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// Value may be nil.
+//
+// Example:
+//
+//	bin := NewBinaryC(config, []byte{0x89, 'P', 'N', 'G'})
+//	bin.HasPrefix([]byte("\x89PNG"))
+func NewBinaryC(config Config, value []byte) *Binary {
+	return newBinary(newChainWithConfig("Binary()", config.withDefaults()), value)
+}
+
+func newBinary(parent *chain, val []byte) *Binary {
+	return &Binary{chain: parent.clone(), value: val}
+}
+
+// Raw returns underlying value attached to Binary.
+// This is the value originally passed to NewBinary.
+//
+// Example:
+//
+//	bin := NewBinary(t, data)
+//	assert.Equal(t, data, bin.Raw())
+func (b *Binary) Raw() []byte {
+	return b.value
+}
+
+// Length returns a new Number instance with number of bytes.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte{1, 2, 3})
+//	bin.Length().Equal(3)
+func (b *Binary) Length() *Number {
+	opChain := b.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(b.value)))
+}
+
+// SHA256 returns a new String instance with hex-encoded SHA-256
+// checksum of bytes.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte("hello"))
+//	bin.SHA256().Equal(
+//		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+func (b *Binary) SHA256() *String {
+	opChain := b.chain.enter("SHA256()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	sum := sha256.Sum256(b.value)
+
+	return newString(opChain, hex.EncodeToString(sum[:]))
+}
+
+// Empty succeeds if there are no bytes.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte{})
+//	bin.Empty()
+func (b *Binary) Empty() *Binary {
+	opChain := b.chain.enter("Empty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !(len(b.value) == 0) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{binaryDump(b.value)},
+			Errors: []error{
+				errors.New("expected: binary is empty"),
+			},
+		})
+	}
+
+	return b
+}
+
+// NotEmpty succeeds if there is at least one byte.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte{1})
+//	bin.NotEmpty()
+func (b *Binary) NotEmpty() *Binary {
+	opChain := b.chain.enter("NotEmpty()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if len(b.value) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{binaryDump(b.value)},
+			Errors: []error{
+				errors.New("expected: binary is non-empty"),
+			},
+		})
+	}
+
+	return b
+}
+
+// Equal succeeds if bytes are equal to given slice.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte{1, 2, 3})
+//	bin.Equal([]byte{1, 2, 3})
+func (b *Binary) Equal(value []byte) *Binary {
+	opChain := b.chain.enter("Equal()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.Equal(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{binaryDump(b.value)},
+			Expected: &AssertionValue{binaryDump(value)},
+			Errors: []error{
+				errors.New("expected: binaries are equal"),
+			},
+		})
+	}
+
+	return b
+}
+
+// NotEqual succeeds if bytes are not equal to given slice.
+//
+// Example:
+//
+//	bin := NewBinary(t, []byte{1, 2, 3})
+//	bin.NotEqual([]byte{3, 2, 1})
+func (b *Binary) NotEqual(value []byte) *Binary {
+	opChain := b.chain.enter("NotEqual()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if bytes.Equal(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{binaryDump(b.value)},
+			Expected: &AssertionValue{binaryDump(value)},
+			Errors: []error{
+				errors.New("expected: binaries are non-equal"),
+			},
+		})
+	}
+
+	return b
+}
+
+// HasPrefix succeeds if bytes start with given prefix,
+// e.g. file format magic number.
+//
+// Example:
+//
+//	bin := NewBinary(t, data)
+//	bin.HasPrefix([]byte("\x89PNG\r\n\x1a\n"))
+func (b *Binary) HasPrefix(value []byte) *Binary {
+	opChain := b.chain.enter("HasPrefix()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.HasPrefix(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsSubset,
+			Actual:   &AssertionValue{binaryDump(b.value)},
+			Expected: &AssertionValue{binaryDump(value)},
+			Errors: []error{
+				errors.New("expected: binary has prefix"),
+			},
+		})
+	}
+
+	return b
+}
+
+// NotHasPrefix succeeds if bytes don't start with given prefix.
+//
+// Example:
+//
+//	bin := NewBinary(t, data)
+//	bin.NotHasPrefix([]byte("%PDF"))
+func (b *Binary) NotHasPrefix(value []byte) *Binary {
+	opChain := b.chain.enter("NotHasPrefix()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if bytes.HasPrefix(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsSubset,
+			Actual:   &AssertionValue{binaryDump(b.value)},
+			Expected: &AssertionValue{binaryDump(value)},
+			Errors: []error{
+				errors.New("expected: binary does not have prefix"),
+			},
+		})
+	}
+
+	return b
+}
+
+// HasSuffix succeeds if bytes end with given suffix.
+//
+// Example:
+//
+//	bin := NewBinary(t, data)
+//	bin.HasSuffix([]byte("%%EOF\n"))
+func (b *Binary) HasSuffix(value []byte) *Binary {
+	opChain := b.chain.enter("HasSuffix()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if !bytes.HasSuffix(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsSubset,
+			Actual:   &AssertionValue{binaryDump(b.value)},
+			Expected: &AssertionValue{binaryDump(value)},
+			Errors: []error{
+				errors.New("expected: binary has suffix"),
+			},
+		})
+	}
+
+	return b
+}
+
+// NotHasSuffix succeeds if bytes don't end with given suffix.
+//
+// Example:
+//
+//	bin := NewBinary(t, data)
+//	bin.NotHasSuffix([]byte{0})
+func (b *Binary) NotHasSuffix(value []byte) *Binary {
+	opChain := b.chain.enter("NotHasSuffix()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return b
+	}
+
+	if bytes.HasSuffix(b.value, value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsSubset,
+			Actual:   &AssertionValue{binaryDump(b.value)},
+			Expected: &AssertionValue{binaryDump(value)},
+			Errors: []error{
+				errors.New("expected: binary does not have suffix"),
+			},
+		})
+	}
+
+	return b
+}
+
+// maximum number of bytes included into hex dump in failure report
+const binaryDumpLimit = 256
+
+// binaryDump is printed in failure report as hex dump;
+// long values are truncated
+type binaryDump []byte
+
+func (d binaryDump) String() string {
+	if len(d) == 0 {
+		return "(0 bytes)"
+	}
+
+	if len(d) <= binaryDumpLimit {
+		return fmt.Sprintf("(%d bytes)\n%s", len(d), hex.Dump(d))
+	}
+
+	return fmt.Sprintf("(%d bytes, first %d shown)\n%s",
+		len(d), binaryDumpLimit, hex.Dump(d[:binaryDumpLimit]))
+}
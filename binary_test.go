@@ -0,0 +1,145 @@
+package httpexpect
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinary_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newBinary(chain, []byte{1, 2, 3})
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Length())
+	assert.NotNil(t, value.SHA256())
+
+	value.Length().chain.assertFailed(t)
+	value.SHA256().chain.assertFailed(t)
+
+	value.Empty()
+	value.NotEmpty()
+	value.Equal(nil)
+	value.NotEqual(nil)
+	value.HasPrefix(nil)
+	value.NotHasPrefix(nil)
+	value.HasSuffix(nil)
+	value.NotHasSuffix(nil)
+}
+
+func TestBinary_Constructors(t *testing.T) {
+	data := []byte{1, 2, 3}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewBinary(reporter, data)
+		value.Equal(data)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewBinaryC(Config{
+			Reporter: reporter,
+		}, data)
+		value.Equal(data)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newBinary(chain, data)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestBinary_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewBinary(reporter, []byte("hello"))
+
+	assert.Equal(t, []byte("hello"), value.Raw())
+	assert.Equal(t, 5.0, value.Length().Raw())
+	assert.Equal(t,
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		value.SHA256().Raw())
+
+	value.chain.assertNotFailed(t)
+}
+
+func TestBinary_Empty(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	for _, data := range [][]byte{nil, {}} {
+		value := NewBinary(reporter, data)
+
+		value.Empty().chain.assertNotFailed(t)
+		value.NotEmpty().chain.assertFailed(t)
+	}
+
+	value := NewBinary(reporter, []byte{0})
+
+	value.Empty().chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEmpty().chain.assertNotFailed(t)
+}
+
+func TestBinary_Equal(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewBinary(reporter, []byte{1, 2, 3})
+
+	value.Equal([]byte{1, 2, 3}).chain.assertNotFailed(t)
+	value.NotEqual([]byte{1, 2, 3}).chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.Equal([]byte{1, 2}).chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqual([]byte{1, 2}).chain.assertNotFailed(t)
+}
+
+func TestBinary_PrefixSuffix(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewBinary(reporter, []byte("%PDF-1.4\n...%%EOF\n"))
+
+	value.HasPrefix([]byte("%PDF")).chain.assertNotFailed(t)
+	value.HasPrefix(nil).chain.assertNotFailed(t)
+	value.NotHasPrefix([]byte("\x89PNG")).chain.assertNotFailed(t)
+	value.HasSuffix([]byte("%%EOF\n")).chain.assertNotFailed(t)
+	value.NotHasSuffix([]byte{0}).chain.assertNotFailed(t)
+
+	value.HasPrefix([]byte("\x89PNG")).chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotHasPrefix([]byte("%PDF")).chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.HasSuffix([]byte{0}).chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotHasSuffix([]byte("EOF\n")).chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestBinary_Dump(t *testing.T) {
+	assert.Equal(t, "(0 bytes)", binaryDump(nil).String())
+
+	assert.Equal(t,
+		"(3 bytes)\n"+
+			"00000000  61 62 63                                          |abc|\n",
+		binaryDump("abc").String())
+
+	long := binaryDump(bytes.Repeat([]byte{'x'}, binaryDumpLimit+1)).String()
+
+	assert.True(t, strings.HasPrefix(long, "(257 bytes, first 256 shown)\n"))
+	assert.Equal(t, binaryDumpLimit/16+1, strings.Count(long, "\n"))
+}
@@ -66,11 +66,15 @@ func (binder Binder) RoundTrip(origReq *http.Request) (*http.Response, error) {
 
 	binder.Handler.ServeHTTP(recorder, &req)
 
+	result := recorder.Result()
+
 	resp := http.Response{
 		Request:    &req,
 		StatusCode: recorder.Code,
 		Status:     http.StatusText(recorder.Code),
-		Header:     recorder.Result().Header,
+		Header:     result.Header,
+		Trailer:    result.Trailer,
+		TLS:        req.TLS,
 	}
 
 	if recorder.Flushed {
@@ -156,7 +160,13 @@ func (binder FastBinder) RoundTrip(stdreq *http.Request) (*http.Response, error)
 
 	binder.Handler(&ctx)
 
-	return fast2std(stdreq, &ctx.Response), nil
+	stdresp := fast2std(stdreq, &ctx.Response)
+
+	if tlsConn, ok := conn.(connTLS); ok {
+		stdresp.TLS = tlsConn.state
+	}
+
+	return stdresp, nil
 }
 
 func std2fast(stdreq *http.Request) *fasthttp.Request {
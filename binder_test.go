@@ -117,6 +117,7 @@ func TestBinder_TLS(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
 	assert.Nil(t, resp.Request.TLS)
+	assert.Nil(t, resp.TLS)
 
 	handler.https = true
 	req, _ = http.NewRequest("GET", "https://example.com/path", strings.NewReader("body"))
@@ -124,6 +125,7 @@ func TestBinder_TLS(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
 	assert.Nil(t, resp.Request.TLS)
+	assert.Nil(t, resp.TLS)
 
 	handler.https = false
 	req, _ = http.NewRequest("GET", "http://example.com/path", strings.NewReader("body"))
@@ -131,6 +133,7 @@ func TestBinder_TLS(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, resp)
 	assert.Nil(t, resp.Request.TLS)
+	assert.Nil(t, resp.TLS)
 
 	handler.https = true
 	req, _ = http.NewRequest("GET", "https://example.com/path", strings.NewReader("body"))
@@ -139,6 +142,7 @@ func TestBinder_TLS(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.NotNil(t, resp.Request.TLS)
 	assert.Same(t, tlsState, resp.Request.TLS)
+	assert.Same(t, tlsState, resp.TLS)
 }
 
 func TestBinder_Chunked(t *testing.T) {
@@ -358,6 +362,7 @@ func TestFastBinder_TLS(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.False(t, isHTTPS)
 	assert.False(t, isTLS)
+	assert.Nil(t, resp.TLS)
 
 	req, _ = http.NewRequest("GET", "https://example.com/path", strings.NewReader("body"))
 	resp, err = httpClient.Do(req)
@@ -365,6 +370,7 @@ func TestFastBinder_TLS(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.True(t, isHTTPS)
 	assert.False(t, isTLS)
+	assert.Nil(t, resp.TLS)
 
 	req, _ = http.NewRequest("GET", "http://example.com/path", strings.NewReader("body"))
 	resp, err = httpsClient.Do(req)
@@ -372,6 +378,7 @@ func TestFastBinder_TLS(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.False(t, isHTTPS)
 	assert.False(t, isTLS)
+	assert.Nil(t, resp.TLS)
 
 	req, _ = http.NewRequest("GET", "https://example.com/path", strings.NewReader("body"))
 	resp, err = httpsClient.Do(req)
@@ -379,6 +386,7 @@ func TestFastBinder_TLS(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.True(t, isHTTPS)
 	assert.True(t, isTLS)
+	assert.Same(t, tlsState, resp.TLS)
 }
 
 func TestFastBinder_Chunked(t *testing.T) {
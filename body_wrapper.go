@@ -126,6 +126,15 @@ func (bw *bodyWrapper) GetBody() (io.ReadCloser, error) {
 	return ioutil.NopCloser(bytes.NewReader(bw.origBytes)), nil
 }
 
+// Get body contents read so far
+// If reading failed, returns data read before error
+func (bw *bodyWrapper) Bytes() []byte {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	return bw.origBytes
+}
+
 func (bw *bodyWrapper) initialize() error {
 	if !bw.isInitialized {
 		bw.isInitialized = true
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"testing"
@@ -255,3 +256,30 @@ func TestBodyWrapper_ErrorRewind(t *testing.T) {
 		assert.NotNil(t, err)
 	}
 }
+
+func TestBodyWrapper_Bytes(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		wrp := newBodyWrapper(newMockBody("test_body"), nil)
+
+		assert.Nil(t, wrp.Bytes())
+
+		_, err := ioutil.ReadAll(wrp)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte("test_body"), wrp.Bytes())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		body := &mockBody{
+			reader: bytes.NewBufferString("test_body"),
+			eofErr: errors.New("read_error"),
+		}
+
+		wrp := newBodyWrapper(body, nil)
+
+		_, err := ioutil.ReadAll(wrp)
+		assert.Error(t, err)
+
+		assert.Equal(t, []byte("test_body"), wrp.Bytes())
+	})
+}
@@ -49,6 +49,20 @@ func (b *Boolean) Raw() bool {
 	return b.value
 }
 
+// Alias is similar to Value.Alias.
+//
+// Example:
+//
+//	boolean := NewBoolean(t, true)
+//	boolean.Alias("user.active").True()
+func (b *Boolean) Alias(name string) *Boolean {
+	opChain := b.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	b.chain.setAlias(name)
+	return b
+}
+
 // Decode unmarshals the underlying value attached to the Boolean to a target variable.
 // target should be one of these:
 //
@@ -12,6 +12,7 @@ func TestBoolean_Failed(t *testing.T) {
 
 	value := newBoolean(chain, false)
 
+	value.Alias("foo")
 	value.Path("$")
 	value.Schema("")
 
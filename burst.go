@@ -0,0 +1,258 @@
+package httpexpect
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Burst provides methods to inspect aggregated results of requests sent
+// concurrently by Expect.Concurrently.
+type Burst struct {
+	noCopy    noCopy
+	chain     *chain
+	responses []*Response
+}
+
+// Concurrently sends n identical requests in parallel and returns a new
+// Burst instance with aggregated results.
+//
+// For every request, a new Request instance is created with given method
+// and path, and then passed to builder, which may be nil. Builder is
+// invoked before sending any requests, so that all requests are sent as
+// simultaneously as possible.
+//
+// Network errors are reported as usual. Other assertions, like status
+// code checks, should be made on aggregated results. This is useful for
+// testing idempotency, concurrency control, and rate limiting.
+//
+// Example:
+//
+//	burst := e.Concurrently(10, "POST", "/orders", func(req *httpexpect.Request) {
+//		req.WithHeader("Idempotency-Key", "123").WithJSON(order)
+//	})
+//
+//	burst.Successes().Equal(10)
+//	burst.StatusCodes().Equal(map[string]int{"201": 1, "200": 9})
+//	burst.Latency(95).Lt(time.Second)
+func (e *Expect) Concurrently(
+	n int, method, path string, builder func(*Request),
+) *Burst {
+	opChain := e.chain.enter("Concurrently(%d)", n)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBurst(opChain, nil)
+	}
+
+	if n <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive number of requests"),
+			},
+		})
+		return newBurst(opChain, nil)
+	}
+
+	requests := make([]*Request, n)
+
+	for i := range requests {
+		func() {
+			reqChain := opChain.replace("Concurrently[%d]", i)
+			defer reqChain.leave()
+
+			requests[i] = e.withChain(reqChain).Request(method, path)
+
+			if builder != nil {
+				builder(requests[i])
+			}
+		}()
+	}
+
+	responses := make([]*Response, n)
+
+	var wg sync.WaitGroup
+
+	for i := range requests {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			responses[i] = requests[i].Expect()
+		}(i)
+	}
+
+	wg.Wait()
+
+	return newBurst(opChain, responses)
+}
+
+func newBurst(parent *chain, responses []*Response) *Burst {
+	return &Burst{
+		chain:     parent.clone(),
+		responses: responses,
+	}
+}
+
+// Responses returns responses of all requests, in order of creation.
+//
+// Responses of requests failed with network error are failed too.
+//
+// Example:
+//
+//	burst := e.Concurrently(10, "GET", "/fruits", nil)
+//
+//	for _, resp := range burst.Responses() {
+//		resp.JSON().Array().NotEmpty()
+//	}
+func (b *Burst) Responses() []*Response {
+	opChain := b.chain.enter("Responses()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return []*Response{}
+	}
+
+	return append([]*Response{}, b.responses...)
+}
+
+// Successes returns a new Number instance with number of responses
+// with 2xx status code.
+//
+// Example:
+//
+//	burst := e.Concurrently(10, "GET", "/fruits", nil)
+//	burst.Successes().Equal(10)
+func (b *Burst) Successes() *Number {
+	opChain := b.chain.enter("Successes()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	count := 0
+
+	for _, resp := range b.responses {
+		if resp.httpResp == nil {
+			continue
+		}
+
+		if code := resp.httpResp.StatusCode; code >= 200 && code < 300 {
+			count++
+		}
+	}
+
+	return newNumber(opChain, float64(count))
+}
+
+// Errors returns a new Number instance with number of requests
+// which didn't receive response, e.g. due to network error.
+//
+// Example:
+//
+//	burst := e.Concurrently(10, "GET", "/fruits", nil)
+//	burst.Errors().Equal(0)
+func (b *Burst) Errors() *Number {
+	opChain := b.chain.enter("Errors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	count := 0
+
+	for _, resp := range b.responses {
+		if resp.httpResp == nil {
+			count++
+		}
+	}
+
+	return newNumber(opChain, float64(count))
+}
+
+// StatusCodes returns a new Object instance with number of responses
+// per status code. Keys are status codes converted to strings.
+//
+// Example:
+//
+//	burst := e.Concurrently(10, "GET", "/fruits", nil)
+//	burst.StatusCodes().Equal(map[string]int{"200": 5, "429": 5})
+//	burst.StatusCodes().Value("429").Number().Gt(0)
+func (b *Burst) StatusCodes() *Object {
+	opChain := b.chain.enter("StatusCodes()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	codes := map[string]interface{}{}
+
+	for _, resp := range b.responses {
+		if resp.httpResp == nil {
+			continue
+		}
+
+		key := strconv.Itoa(resp.httpResp.StatusCode)
+
+		count, _ := codes[key].(float64)
+		codes[key] = count + 1
+	}
+
+	return newObject(opChain, codes)
+}
+
+// Latency returns a new Duration instance with given percentile of
+// round-trip times of received responses. Percentile should be in
+// range (0; 100]; e.g. 50 is median and 100 is maximum.
+//
+// If no responses were received, returned Duration is not set.
+//
+// Example:
+//
+//	burst := e.Concurrently(10, "GET", "/fruits", nil)
+//	burst.Latency(95).Lt(100 * time.Millisecond)
+func (b *Burst) Latency(percentile int) *Duration {
+	opChain := b.chain.enter("Latency(%d)", percentile)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if percentile <= 0 || percentile > 100 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected percentile value, want (0; 100]"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	var latencies []time.Duration
+
+	for _, resp := range b.responses {
+		if resp.httpResp != nil && resp.rtt != nil {
+			latencies = append(latencies, *resp.rtt)
+		}
+	}
+
+	if len(latencies) == 0 {
+		return newDuration(opChain, nil)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	latency := metricsPercentile(latencies, percentile)
+
+	return newDuration(opChain, &latency)
+}
@@ -0,0 +1,155 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurst_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	burst := newBurst(chain, nil)
+
+	assert.NotNil(t, burst.Responses())
+	assert.NotNil(t, burst.Successes())
+	assert.NotNil(t, burst.Errors())
+	assert.NotNil(t, burst.StatusCodes())
+	assert.NotNil(t, burst.Latency(95))
+
+	burst.chain.assertFailed(t)
+}
+
+func TestBurst_Concurrently(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+		headers  []string
+	)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		headers = append(headers, r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+
+		if n <= 3 {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	burst := e.Concurrently(10, "POST", "/orders", func(req *Request) {
+		req.WithHeader("Idempotency-Key", "123")
+	})
+
+	burst.Successes().Equal(3)
+	burst.Errors().Equal(0)
+	burst.StatusCodes().Equal(map[string]int{"200": 3, "429": 7})
+	burst.Latency(50).IsSet().Lt(time.Minute)
+	burst.Latency(100).IsSet().Ge(time.Duration(0))
+
+	assert.Equal(t, 10, len(burst.Responses()))
+	for _, resp := range burst.Responses() {
+		resp.StatusList(http.StatusOK, http.StatusTooManyRequests)
+	}
+
+	burst.chain.assertNotFailed(t)
+
+	assert.Equal(t, 10, requests)
+	for _, h := range headers {
+		assert.Equal(t, "123", h)
+	}
+}
+
+type burstHandler struct {
+	mu       sync.Mutex
+	failures []AssertionContext
+}
+
+func (h *burstHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *burstHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, *ctx)
+}
+
+func TestBurst_Errors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	handler := &burstHandler{}
+
+	e := WithConfig(Config{
+		BaseURL:          server.URL,
+		AssertionHandler: handler,
+	})
+
+	burst := e.Concurrently(3, "GET", "/", func(req *Request) {
+		req.WithMaxRetries(0)
+	})
+
+	var paths []string
+	for _, ctx := range handler.failures {
+		paths = append(paths, ctx.Path[0])
+	}
+
+	assert.ElementsMatch(t,
+		[]string{"Concurrently[0]", "Concurrently[1]", "Concurrently[2]"}, paths)
+
+	failures := len(handler.failures)
+
+	burst.Successes().Equal(0)
+	burst.Errors().Equal(3)
+	burst.StatusCodes().Empty()
+	burst.Latency(95).NotSet()
+
+	assert.Equal(t, failures, len(handler.failures))
+}
+
+func TestBurst_Usage(t *testing.T) {
+	t.Run("zero requests", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		})
+
+		burst := e.Concurrently(0, "GET", "/", nil)
+		burst.chain.assertFailed(t)
+	})
+
+	t.Run("bad percentile", func(t *testing.T) {
+		for _, p := range []int{-1, 0, 101} {
+			e := WithConfig(Config{
+				Reporter: newMockReporter(t),
+				Client:   &mockClient{},
+			})
+
+			burst := e.Concurrently(1, "GET", "/", nil)
+			burst.chain.assertNotFailed(t)
+
+			burst.Latency(p)
+			burst.chain.assertFailed(t)
+		}
+	})
+}
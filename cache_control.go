@@ -0,0 +1,335 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl provides methods to inspect directives of "Cache-Control"
+// header value.
+type CacheControl struct {
+	noCopy     noCopy
+	chain      *chain
+	directives map[string]string
+}
+
+// NewCacheControl returns a new CacheControl instance.
+//
+// Value should be "Cache-Control" header value, as defined in RFC 9111.
+// Directive names are case-insensitive.
+//
+// If reporter is nil, the function panics.
+// If value can't be parsed, failure is reported.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "public, max-age=3600")
+//
+//	cc.Public().True()
+//	cc.MaxAge().Equal(time.Hour)
+func NewCacheControl(reporter Reporter, value string) *CacheControl {
+	return newCacheControl(newChainWithDefaults("CacheControl()", reporter), value)
+}
+
+// NewCacheControlC returns a new CacheControl instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value can't be parsed, failure is reported.
+//
+// See NewCacheControl for usage example.
+func NewCacheControlC(config Config, value string) *CacheControl {
+	return newCacheControl(
+		newChainWithConfig("CacheControl()", config.withDefaults()), value)
+}
+
+func newCacheControl(parent *chain, val string) *CacheControl {
+	c := &CacheControl{chain: parent.clone(), directives: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	directives, err := parseCacheControl(val)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New(`invalid "Cache-Control" value`),
+				err,
+			},
+		})
+	} else {
+		c.directives = directives
+	}
+
+	return c
+}
+
+// Raw returns parsed directives, mapped by lowercase directive name to
+// directive argument. For directives without argument, value is empty.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "no-cache, max-age=0")
+//	assert.Equal(t, map[string]string{"no-cache": "", "max-age": "0"}, cc.Raw())
+func (c *CacheControl) Raw() map[string]string {
+	return c.directives
+}
+
+// Directives returns a new Object instance with all directives.
+//
+// Keys are lowercase directive names. Values are directive arguments
+// as strings, or true for directives without argument.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "public, stale-while-revalidate=60")
+//	cc.Directives().Equal(map[string]interface{}{
+//		"public":                 true,
+//		"stale-while-revalidate": "60",
+//	})
+func (c *CacheControl) Directives() *Object {
+	opChain := c.chain.enter("Directives()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	obj := map[string]interface{}{}
+
+	for name, arg := range c.directives {
+		if arg == "" {
+			obj[name] = true
+		} else {
+			obj[name] = arg
+		}
+	}
+
+	return newObject(opChain, obj)
+}
+
+// MaxAge returns a new Duration instance with value of "max-age" directive.
+//
+// If directive is missing or its value is not a non-negative integer,
+// failure is reported.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "max-age=3600")
+//	cc.MaxAge().Equal(time.Hour)
+func (c *CacheControl) MaxAge() *Duration {
+	opChain := c.chain.enter("MaxAge()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, c.getSeconds(opChain, "max-age"))
+}
+
+// SMaxAge returns a new Duration instance with value of "s-maxage"
+// directive, which applies to shared caches like CDNs and proxies.
+//
+// If directive is missing or its value is not a non-negative integer,
+// failure is reported.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "public, max-age=60, s-maxage=3600")
+//	cc.SMaxAge().Equal(time.Hour)
+func (c *CacheControl) SMaxAge() *Duration {
+	opChain := c.chain.enter("SMaxAge()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, c.getSeconds(opChain, "s-maxage"))
+}
+
+// NoStore returns a new Boolean instance with true value if "no-store"
+// directive is present.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "no-store")
+//	cc.NoStore().True()
+func (c *CacheControl) NoStore() *Boolean {
+	return c.hasDirective("NoStore()", "no-store")
+}
+
+// NoCache returns a new Boolean instance with true value if "no-cache"
+// directive is present.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "no-cache")
+//	cc.NoCache().True()
+func (c *CacheControl) NoCache() *Boolean {
+	return c.hasDirective("NoCache()", "no-cache")
+}
+
+// Private returns a new Boolean instance with true value if "private"
+// directive is present.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "private, max-age=60")
+//	cc.Private().True()
+func (c *CacheControl) Private() *Boolean {
+	return c.hasDirective("Private()", "private")
+}
+
+// Public returns a new Boolean instance with true value if "public"
+// directive is present.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "public, max-age=60")
+//	cc.Public().True()
+func (c *CacheControl) Public() *Boolean {
+	return c.hasDirective("Public()", "public")
+}
+
+// MustRevalidate returns a new Boolean instance with true value if
+// "must-revalidate" directive is present.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "max-age=60, must-revalidate")
+//	cc.MustRevalidate().True()
+func (c *CacheControl) MustRevalidate() *Boolean {
+	return c.hasDirective("MustRevalidate()", "must-revalidate")
+}
+
+// Immutable returns a new Boolean instance with true value if "immutable"
+// directive is present.
+//
+// Example:
+//
+//	cc := NewCacheControl(t, "public, max-age=31536000, immutable")
+//	cc.Immutable().True()
+func (c *CacheControl) Immutable() *Boolean {
+	return c.hasDirective("Immutable()", "immutable")
+}
+
+func (c *CacheControl) hasDirective(method, name string) *Boolean {
+	opChain := c.chain.enter(method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	_, ok := c.directives[name]
+
+	return newBoolean(opChain, ok)
+}
+
+func (c *CacheControl) getSeconds(opChain *chain, name string) *time.Duration {
+	arg, ok := c.directives[name]
+	if !ok {
+		names := []string{}
+		for n := range c.directives {
+			names = append(names, n)
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{names},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: cache control contains directive"),
+			},
+		})
+		return nil
+	}
+
+	seconds, err := strconv.ParseUint(arg, 10, 32)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{arg},
+			Errors: []error{
+				fmt.Errorf("invalid %q directive value, want non-negative integer",
+					name),
+			},
+		})
+		return nil
+	}
+
+	d := time.Duration(seconds) * time.Second
+
+	return &d
+}
+
+// parseCacheControl parses "Cache-Control" value into map of directives
+//
+// Directive is a token, optionally followed by "=" and token or quoted
+// string. Names are converted to lowercase, quotes are removed from
+// arguments. If directive is repeated, the first occurrence is used.
+func parseCacheControl(value string) (map[string]string, error) {
+	directives := map[string]string{}
+
+	for value != "" {
+		value = strings.TrimLeft(value, " \t")
+
+		var item string
+
+		if end := strings.IndexAny(value, ",\""); end < 0 {
+			item, value = value, ""
+		} else if value[end] == ',' {
+			item, value = value[:end], value[end+1:]
+		} else {
+			// argument is quoted string, which may contain commas
+			closing := strings.IndexByte(value[end+1:], '"')
+			if closing < 0 {
+				return nil, errors.New("unterminated quoted string")
+			}
+			closing += end + 1
+
+			item, value = value[:closing+1], value[closing+1:]
+
+			rest := strings.TrimLeft(value, " \t")
+			if rest != "" && rest[0] != ',' {
+				return nil, fmt.Errorf("unexpected characters after %q", item)
+			}
+			value = strings.TrimPrefix(rest, ",")
+		}
+
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, arg := item, ""
+		if eq := strings.IndexByte(item, '='); eq >= 0 {
+			name, arg = strings.TrimSpace(item[:eq]), strings.TrimSpace(item[eq+1:])
+
+			if strings.HasPrefix(arg, `"`) {
+				if len(arg) < 2 || !strings.HasSuffix(arg, `"`) {
+					return nil, fmt.Errorf("invalid quoted string in %q", item)
+				}
+				arg = arg[1 : len(arg)-1]
+			}
+		}
+
+		if name == "" || strings.ContainsAny(name, " \t\"") {
+			return nil, fmt.Errorf("invalid directive %q", item)
+		}
+
+		name = strings.ToLower(name)
+
+		if _, ok := directives[name]; !ok {
+			directives[name] = arg
+		}
+	}
+
+	return directives, nil
+}
@@ -0,0 +1,196 @@
+package httpexpect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheControl_Failed(t *testing.T) {
+	check := func(value *CacheControl, isNil bool) {
+		value.chain.assertFailed(t)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+		assert.NotNil(t, value.Directives())
+		assert.NotNil(t, value.MaxAge())
+		assert.NotNil(t, value.SMaxAge())
+		assert.NotNil(t, value.NoStore())
+		assert.NotNil(t, value.NoCache())
+		assert.NotNil(t, value.Private())
+		assert.NotNil(t, value.Public())
+		assert.NotNil(t, value.MustRevalidate())
+		assert.NotNil(t, value.Immutable())
+	}
+
+	t.Run("failed_chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCacheControl(chain, "no-store")
+
+		check(value, false)
+	})
+
+	t.Run("invalid_value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newCacheControl(chain, `private="unterminated`)
+
+		check(value, true)
+	})
+
+	t.Run("failed_chain_invalid_value", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCacheControl(chain, `private="unterminated`)
+
+		check(value, true)
+	})
+}
+
+func TestCacheControl_Constructors(t *testing.T) {
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCacheControl(reporter, "public, max-age=60")
+		value.Public().True()
+		value.MaxAge().Equal(time.Minute)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCacheControlC(Config{
+			Reporter: reporter,
+		}, "public, max-age=60")
+		value.Public().True()
+		value.MaxAge().Equal(time.Minute)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCacheControl(chain, "no-store")
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestCacheControl_Parse(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected map[string]string
+		isValid  bool
+	}{
+		{
+			value:    "",
+			expected: map[string]string{},
+			isValid:  true,
+		},
+		{
+			value:    "no-store",
+			expected: map[string]string{"no-store": ""},
+			isValid:  true,
+		},
+		{
+			value: "Public, MAX-AGE=60 ,s-maxage=3600",
+			expected: map[string]string{
+				"public": "", "max-age": "60", "s-maxage": "3600",
+			},
+			isValid: true,
+		},
+		{
+			value: `private="Set-Cookie, X-Token", no-cache`,
+			expected: map[string]string{
+				"private": "Set-Cookie, X-Token", "no-cache": "",
+			},
+			isValid: true,
+		},
+		{
+			value:    "max-age=60, max-age=0",
+			expected: map[string]string{"max-age": "60"},
+			isValid:  true,
+		},
+		{
+			value:    "no-cache,,",
+			expected: map[string]string{"no-cache": ""},
+			isValid:  true,
+		},
+		{value: `private="unterminated`, isValid: false},
+		{value: `private="a" public`, isValid: false},
+		{value: "=60", isValid: false},
+		{value: "max age=60", isValid: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			directives, err := parseCacheControl(tc.value)
+			if !tc.isValid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, directives)
+		})
+	}
+}
+
+func TestCacheControl_Directives(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewCacheControl(reporter,
+		"private, no-cache, must-revalidate, stale-if-error=60")
+
+	value.Directives().Equal(map[string]interface{}{
+		"private":         true,
+		"no-cache":        true,
+		"must-revalidate": true,
+		"stale-if-error":  "60",
+	})
+
+	value.Private().True()
+	value.Public().False()
+	value.NoCache().True()
+	value.NoStore().False()
+	value.MustRevalidate().True()
+	value.Immutable().False()
+
+	value.chain.assertNotFailed(t)
+}
+
+func TestCacheControl_MaxAge(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("present", func(t *testing.T) {
+		value := NewCacheControl(reporter, "max-age=0, s-maxage=3600")
+		value.MaxAge().Equal(0)
+		value.SMaxAge().Equal(time.Hour)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("quoted", func(t *testing.T) {
+		value := NewCacheControl(reporter, `max-age="60"`)
+		value.MaxAge().Equal(time.Minute)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		value := NewCacheControl(reporter, "no-store")
+		value.MaxAge()
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, v := range []string{"max-age", "max-age=-1", "max-age=1.5"} {
+			value := NewCacheControl(reporter, v)
+			value.MaxAge()
+			value.chain.assertFailed(t)
+		}
+	})
+}
@@ -1,14 +1,22 @@
 package httpexpect
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"strconv"
 )
 
 func canonNumber(opChain *chain, in interface{}) (out float64, ok bool) {
 	ok = true
+	if num, isNum := in.(json.Number); isNum {
+		if f, err := num.Float64(); err == nil {
+			return f, true
+		}
+	}
 	defer func() {
 		if err := recover(); err != nil {
 			opChain.fail(AssertionFailure{
@@ -77,7 +85,7 @@ func canonValue(opChain *chain, in interface{}) (interface{}, bool) {
 	}
 
 	var out interface{}
-	if err := json.Unmarshal(b, &out); err != nil {
+	if err := unmarshalJSON(opChain, b, &out); err != nil {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
 			Actual: &AssertionValue{in},
@@ -92,6 +100,152 @@ func canonValue(opChain *chain, in interface{}) (interface{}, bool) {
 	return out, true
 }
 
+// unmarshalJSON decodes JSON document into canonical form; numbers are
+// decoded as float64, or, if chain has Config.UseJSONNumber enabled, large
+// integers are kept as json.Number
+func unmarshalJSON(opChain *chain, data []byte, out *interface{}) error {
+	if opChain.useStrictJSON() {
+		if err := checkStrictJSON(data); err != nil {
+			return err
+		}
+	}
+
+	if !opChain.useJSONNumber() {
+		return json.Unmarshal(data, out)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(out); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+
+	*out = canonJSONNumbers(*out)
+
+	return nil
+}
+
+// checkStrictJSON scans JSON document and reports duplicate object keys
+// and data after the top-level value, see Config.StrictJSON
+func checkStrictJSON(data []byte) error {
+	type frame struct {
+		object bool
+		path   string
+		keys   map[string]struct{}
+		key    string
+		hasKey bool
+		index  int
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var stack []*frame
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		var path string
+
+		if len(stack) != 0 {
+			top := stack[len(stack)-1]
+
+			if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+				stack = stack[:len(stack)-1]
+				if len(stack) == 0 {
+					break
+				}
+				continue
+			}
+
+			if top.object {
+				if !top.hasKey {
+					key := token.(string)
+					path := joinObjectPath(top.path, key)
+					if _, ok := top.keys[key]; ok {
+						return fmt.Errorf("duplicate key %q", path)
+					}
+					top.keys[key] = struct{}{}
+					top.key, top.hasKey = path, true
+					continue
+				}
+				path, top.hasKey = top.key, false
+			} else {
+				path = fmt.Sprintf("%s[%d]", top.path, top.index)
+				top.index++
+			}
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			stack = append(stack, &frame{
+				object: delim == '{',
+				path:   path,
+				keys:   map[string]struct{}{},
+			})
+			continue
+		}
+
+		if len(stack) == 0 {
+			break
+		}
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after top-level value")
+	}
+
+	return nil
+}
+
+// isCanonNumber checks if canonical value is a number: float64, or
+// json.Number if Config.UseJSONNumber is enabled
+func isCanonNumber(value interface{}) bool {
+	switch value.(type) {
+	case float64, json.Number:
+		return true
+	}
+	return false
+}
+
+// max integer that is exactly representable as float64
+const maxExactFloatInt = 1 << 53
+
+func canonJSONNumbers(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = canonJSONNumbers(elem)
+		}
+
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = canonJSONNumbers(elem)
+		}
+
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			if i > maxExactFloatInt || i < -maxExactFloatInt {
+				return json.Number(strconv.FormatInt(i, 10))
+			}
+		} else if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return json.Number(strconv.FormatUint(u, 10))
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+	}
+
+	return in
+}
+
 func canonDecode(opChain *chain, value interface{}, target interface{}) {
 	if target == nil {
 		opChain.fail(AssertionFailure{
@@ -126,3 +280,27 @@ func canonDecode(opChain *chain, value interface{}, target interface{}) {
 		return
 	}
 }
+
+// canonYAML converts value decoded by yaml.v2 to a value that would be
+// decoded from equivalent JSON, i.e. replaces map[interface{}]interface{}
+// with map[string]interface{}
+func canonYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			result[fmt.Sprint(key)] = canonYAML(elem)
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = canonYAML(elem)
+		}
+		return result
+
+	default:
+		return value
+	}
+}
@@ -1,6 +1,7 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,6 +33,12 @@ func TestCanon_Number(t *testing.T) {
 	chain.assertNotFailed(t)
 	chain.clearFailed()
 
+	d4, ok := canonNumber(chain, json.Number("123"))
+	assert.True(t, ok)
+	assert.Equal(t, 123.0, d4)
+	chain.assertNotFailed(t)
+	chain.clearFailed()
+
 	_, ok = canonNumber(chain, "123")
 	assert.False(t, ok)
 	chain.assertFailed(t)
@@ -43,6 +50,142 @@ func TestCanon_Number(t *testing.T) {
 	chain.clearFailed()
 }
 
+func TestCanon_JSONNumber(t *testing.T) {
+	doc := []byte(`{"small": 123, "float": 1.5, "big": 9007199254740993,
+		"neg": -9007199254740993, "max": 18446744073709551615,
+		"edge": 9007199254740992, "list": [1, 9223372036854775807]}`)
+
+	t.Run("disabled", func(t *testing.T) {
+		chain := newMockChain(t).enter("test")
+		defer chain.leave()
+
+		var out interface{}
+		assert.NoError(t, unmarshalJSON(chain, doc, &out))
+
+		m := out.(map[string]interface{})
+		assert.Equal(t, 123.0, m["small"])
+		assert.Equal(t, float64(9007199254740992), m["big"])
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.jsonNumber = true
+
+		opChain := chain.enter("test")
+		defer opChain.leave()
+
+		var out interface{}
+		assert.NoError(t, unmarshalJSON(opChain, doc, &out))
+
+		assert.Equal(t, map[string]interface{}{
+			"small": 123.0,
+			"float": 1.5,
+			"big":   json.Number("9007199254740993"),
+			"neg":   json.Number("-9007199254740993"),
+			"max":   json.Number("18446744073709551615"),
+			"edge":  float64(9007199254740992),
+			"list": []interface{}{
+				1.0,
+				json.Number("9223372036854775807"),
+			},
+		}, out)
+
+		canon, ok := canonValue(opChain, out)
+		assert.True(t, ok)
+		assert.Equal(t, out, canon)
+
+		canon, ok = canonValue(opChain, uint64(18446744073709551615))
+		assert.True(t, ok)
+		assert.Equal(t, json.Number("18446744073709551615"), canon)
+
+		assert.Error(t, unmarshalJSON(opChain, []byte(`{} x`), &out))
+		assert.Error(t, unmarshalJSON(opChain, []byte(`{`), &out))
+		assert.NoError(t, unmarshalJSON(opChain, []byte(" 1 \n"), &out))
+	})
+}
+
+func TestCanon_StrictJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{
+			name: "valid",
+			doc:  `{"a": {"b": [1, {"c": 2}]}, "d": null, "": 3}`,
+		},
+		{
+			name: "scalar",
+			doc:  " 123 \n",
+		},
+		{
+			name: "same key in different objects",
+			doc:  `[{"a": 1}, {"a": 2}]`,
+		},
+		{
+			name: "duplicate key",
+			doc:  `{"a": 1, "a": 2}`,
+			err:  `duplicate key "a"`,
+		},
+		{
+			name: "duplicate empty key",
+			doc:  `{"": 1, "": 2}`,
+			err:  `duplicate key ""`,
+		},
+		{
+			name: "nested duplicate key",
+			doc:  `{"a": {"b": [1, {"c": 2, "c": 3}]}}`,
+			err:  `duplicate key "a.b[1].c"`,
+		},
+		{
+			name: "trailing garbage",
+			doc:  `{"a": 1} x`,
+			err:  "unexpected data after top-level value",
+		},
+		{
+			name: "trailing document",
+			doc:  `{"a": 1} {"a": 2}`,
+			err:  "unexpected data after top-level value",
+		},
+		{
+			name: "truncated",
+			doc:  `{"a": 1`,
+			err:  "EOF",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkStrictJSON([]byte(tc.doc))
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+
+	t.Run("chain", func(t *testing.T) {
+		doc := []byte(`{"a": 1, "a": 2}`)
+
+		chain := newMockChain(t)
+
+		opChain := chain.enter("test")
+		defer opChain.leave()
+
+		var out interface{}
+		assert.NoError(t, unmarshalJSON(opChain, doc, &out))
+		assert.Equal(t, map[string]interface{}{"a": 2.0}, out)
+
+		chain.strictJSON = true
+
+		strictChain := chain.enter("test")
+		defer strictChain.leave()
+
+		assert.Error(t, unmarshalJSON(strictChain, doc, &out))
+	})
+}
+
 func TestCanon_Array(t *testing.T) {
 	type (
 		myArray []interface{}
@@ -0,0 +1,457 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// CassetteMode defines whether Cassette records or replays interactions.
+type CassetteMode int
+
+const (
+	// CassetteAuto replays interactions if cassette file exists, and
+	// records them otherwise.
+	CassetteAuto CassetteMode = iota
+
+	// CassetteRecord always sends requests using underlying transport and
+	// records interactions, overwriting existing cassette file.
+	CassetteRecord
+
+	// CassetteReplay never sends requests and replays interactions from
+	// cassette file. Request that has no recorded interaction fails.
+	CassetteReplay
+
+	// CassettePassthrough sends requests using underlying transport and
+	// neither records nor replays anything.
+	CassettePassthrough
+)
+
+// CassetteModeEnv is the name of environment variable that overrides
+// CassetteAuto mode. Supported values are "auto", "record", "replay",
+// and "passthrough".
+//
+// Example:
+//
+//	HTTPEXPECT_CASSETTE_MODE=record go test ./...
+const CassetteModeEnv = "HTTPEXPECT_CASSETTE_MODE"
+
+// CassetteRedactedHeaders is a list of headers which values are replaced
+// in cassette files, unless Cassette.Redactor is set.
+var CassetteRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+}
+
+// CassetteRedactedParams is a list of query and form parameters, and JSON
+// keys, which values are replaced in cassette files, unless
+// Cassette.Redactor is set.
+var CassetteRedactedParams = []string{
+	"api_key",
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"password",
+}
+
+// Cassette implements http.RoundTripper that records real responses to
+// a file (cassette) and replays them in later runs.
+//
+// Cassette allows integration tests to run offline and deterministically:
+// tests are run once against real server to record cassette, and then
+// cassette is committed and replayed by subsequent runs.
+//
+// Recorded requests are matched by method, URL, and body. If the same
+// request was recorded several times, recorded responses are replayed
+// in the same order.
+//
+// If Mode is CassetteAuto, it may be overridden by CassetteModeEnv
+// environment variable, e.g. to re-record all cassettes.
+//
+// Since cassettes are usually committed, sensitive data, like Authorization
+// and Cookie headers or api_key query parameter, is replaced before writing
+// cassette file. See Cassette.Redactor.
+//
+// Cassette is safe for concurrent use.
+//
+// Example:
+//
+//	cassette := httpexpect.NewCassette("testdata/users.json", nil)
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: cassette,
+//		},
+//	})
+type Cassette struct {
+	// Path to cassette file.
+	Path string
+
+	// Defines whether to record or replay interactions.
+	// If zero, CassetteAuto is used.
+	Mode CassetteMode
+
+	// Transport used to send requests when recording.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Redactor applied to request URL, and to request and response
+	// headers and bodies before they are written to cassette file.
+	// When replaying, URL and body of request are redacted in the same
+	// way before matching them with recorded ones, and redacted response
+	// body is returned.
+	// If nil, values of CassetteRedactedHeaders and CassetteRedactedParams
+	// are replaced.
+	Redactor *Redactor
+
+	mu           sync.Mutex
+	started      bool
+	mode         CassetteMode
+	interactions []cassetteInteraction
+	used         []bool
+}
+
+// NewCassette returns a new Cassette given a path to cassette file and
+// underlying transport.
+//
+// If transport is nil, http.DefaultTransport is used.
+//
+// Example:
+//
+//	client := &http.Client{
+//		Transport: NewCassette("testdata/users.json", nil),
+//	}
+func NewCassette(path string, transport http.RoundTripper) *Cassette {
+	return &Cassette{
+		Path:      path,
+		Transport: transport,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	mode, err := c.getMode()
+	if err != nil {
+		return nil, err
+	}
+
+	switch mode {
+	case CassetteReplay:
+		return c.replay(req)
+
+	case CassetteRecord:
+		return c.record(req)
+
+	case CassetteAuto, CassettePassthrough:
+	}
+
+	return c.transport().RoundTrip(req)
+}
+
+// start cassette on first request; mutex is not held during network
+// calls, so that concurrent requests are not serialized
+func (c *Cassette) getMode() (CassetteMode, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.started {
+		if err := c.start(); err != nil {
+			return c.mode, err
+		}
+		c.started = true
+	}
+
+	return c.mode, nil
+}
+
+func (c *Cassette) start() error {
+	c.mode = c.Mode
+
+	if c.mode == CassetteAuto {
+		if env := os.Getenv(CassetteModeEnv); env != "" {
+			mode, err := parseCassetteMode(env)
+			if err != nil {
+				return err
+			}
+			c.mode = mode
+		}
+	}
+
+	if c.mode == CassetteAuto {
+		if _, err := os.Stat(c.Path); err == nil {
+			c.mode = CassetteReplay
+		} else {
+			c.mode = CassetteRecord
+		}
+	}
+
+	if c.mode == CassetteReplay {
+		data, err := ioutil.ReadFile(c.Path)
+		if err != nil {
+			return fmt.Errorf("cassette: %s", err.Error())
+		}
+
+		var file cassetteFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("cassette: %s: %s", c.Path, err.Error())
+		}
+
+		c.interactions = file.Interactions
+		c.used = make([]bool, len(c.interactions))
+	}
+
+	return nil
+}
+
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	body, err := cassetteReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	redactor := c.redactor()
+
+	url := redactor.RedactString(req.URL.String())
+	body = redactor.RedactBody(body)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] {
+			continue
+		}
+
+		if interaction.Request.Method != req.Method ||
+			interaction.Request.URL != url {
+			continue
+		}
+
+		recordedBody, err := interaction.Request.Body.decode()
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %s: %s", c.Path, err.Error())
+		}
+
+		if !bytes.Equal(recordedBody, body) {
+			continue
+		}
+
+		respBody, err := interaction.Response.Body.decode()
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %s: %s", c.Path, err.Error())
+		}
+
+		c.used[i] = true
+
+		return &http.Response{
+			Status: fmt.Sprintf("%d %s",
+				interaction.Response.StatusCode,
+				http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header,
+			Body:          ioutil.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette: %s: no recorded interaction for %s %s",
+		c.Path, req.Method, url)
+}
+
+func (c *Cassette) record(req *http.Request) (*http.Response, error) {
+	body, err := cassetteReadBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	redactor := c.redactor()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, cassetteInteraction{
+		Request: cassetteRequest{
+			Method: req.Method,
+			URL:    redactor.RedactString(req.URL.String()),
+			Header: redactor.RedactHeader(req.Header),
+			Body:   newCassetteBody(redactor.RedactBody(body)),
+		},
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactor.RedactHeader(resp.Header),
+			Body:       newCassetteBody(redactor.RedactBody(respBody)),
+		},
+	})
+
+	// cassette is saved after every interaction, since there is
+	// no reliable point when the test is finished
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(cassetteFile{
+		Interactions: c.interactions,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(c.Path, data, 0644); err != nil {
+		return fmt.Errorf("cassette: %s", err.Error())
+	}
+
+	return nil
+}
+
+func (c *Cassette) redactor() *Redactor {
+	if c.Redactor != nil {
+		return c.Redactor
+	}
+	return &Redactor{
+		Headers:  CassetteRedactedHeaders,
+		JSONKeys: CassetteRedactedParams,
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(?:` +
+				strings.Join(quoteMetaAll(CassetteRedactedParams), "|") +
+				`)=[^&\s"]*`),
+		},
+	}
+}
+
+func quoteMetaAll(list []string) []string {
+	result := make([]string, len(list))
+	for i, s := range list {
+		result[i] = regexp.QuoteMeta(s)
+	}
+	return result
+}
+
+func (c *Cassette) transport() http.RoundTripper {
+	if c.Transport != nil {
+		return c.Transport
+	}
+	return http.DefaultTransport
+}
+
+func parseCassetteMode(s string) (CassetteMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto":
+		return CassetteAuto, nil
+	case "record":
+		return CassetteRecord, nil
+	case "replay":
+		return CassetteReplay, nil
+	case "passthrough":
+		return CassettePassthrough, nil
+	}
+
+	return CassetteAuto, fmt.Errorf("cassette: invalid %s value %q",
+		CassetteModeEnv, s)
+}
+
+// cassetteReadBody reads request body and replaces it with a new
+// reader, so that request can still be sent
+func cassetteReadBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+type cassetteFile struct {
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string       `json:"method"`
+	URL    string       `json:"url"`
+	Header http.Header  `json:"header,omitempty"`
+	Body   cassetteBody `json:"body"`
+}
+
+type cassetteResponse struct {
+	StatusCode int          `json:"status_code"`
+	Header     http.Header  `json:"header,omitempty"`
+	Body       cassetteBody `json:"body"`
+}
+
+// cassetteBody holds body as plain text if it's valid UTF-8,
+// and as base64 otherwise
+type cassetteBody struct {
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+func newCassetteBody(body []byte) cassetteBody {
+	if utf8.Valid(body) {
+		return cassetteBody{Text: string(body)}
+	}
+
+	return cassetteBody{
+		Text:     base64.StdEncoding.EncodeToString(body),
+		Encoding: "base64",
+	}
+}
+
+func (b cassetteBody) decode() ([]byte, error) {
+	switch b.Encoding {
+	case "":
+		if b.Text == "" {
+			return nil, nil
+		}
+		return []byte(b.Text), nil
+
+	case "base64":
+		return base64.StdEncoding.DecodeString(b.Text)
+	}
+
+	return nil, fmt.Errorf("unsupported body encoding %q", b.Encoding)
+}
@@ -0,0 +1,513 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCassetteHandler(counter *int) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		*counter++
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("hello " + string(body)))
+	})
+
+	mux.HandleFunc("/binary", func(w http.ResponseWriter, r *http.Request) {
+		*counter++
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte{0xff, 0x00, 0xfe})
+	})
+
+	mux.HandleFunc("/counter", func(w http.ResponseWriter, r *http.Request) {
+		*counter++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"counter":` + strconv.Itoa(*counter) + `}`))
+	})
+
+	return mux
+}
+
+func testCassettePath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+
+	return filepath.Join(dir, "cassette.json"), func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func testCassetteExpect(t *testing.T, cassette *Cassette) *Expect {
+	return WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: cassette,
+		},
+	})
+}
+
+func TestCassette_RecordReplay(t *testing.T) {
+	path, cleanup := testCassettePath(t)
+	defer cleanup()
+
+	counter := 0
+
+	t.Run("record", func(t *testing.T) {
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+
+		e := testCassetteExpect(t, cassette)
+
+		e.POST("/text").WithText("world").
+			Expect().
+			Status(http.StatusOK).
+			Body().Equal("hello world")
+
+		e.GET("/binary").
+			Expect().
+			Status(http.StatusOK).
+			Body().Equal("\xff\x00\xfe")
+
+		e.POST("/counter").
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().ValueEqual("counter", 3)
+
+		e.POST("/counter").
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().ValueEqual("counter", 4)
+
+		assert.Equal(t, 4, counter)
+
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+
+		e := testCassetteExpect(t, cassette)
+
+		e.POST("/text").WithText("world").
+			Expect().
+			Status(http.StatusOK).
+			ContentType("text/plain").
+			Body().Equal("hello world")
+
+		e.GET("/binary").
+			Expect().
+			Status(http.StatusOK).
+			Body().Equal("\xff\x00\xfe")
+
+		e.POST("/counter").
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().ValueEqual("counter", 3)
+
+		e.POST("/counter").
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().ValueEqual("counter", 4)
+
+		assert.Equal(t, 4, counter)
+	})
+
+	t.Run("replay missing", func(t *testing.T) {
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+
+		for n := 0; n < 2; n++ {
+			req, _ := http.NewRequest("POST", "http://example.com/text",
+				bytes.NewBufferString("world"))
+
+			resp, err := cassette.RoundTrip(req)
+
+			// only one such interaction was recorded
+			if n == 0 {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+			} else {
+				assert.Error(t, err)
+				assert.Nil(t, resp)
+			}
+		}
+
+		req, _ := http.NewRequest("POST", "http://example.com/text",
+			bytes.NewBufferString("other"))
+
+		resp, err := cassette.RoundTrip(req)
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+
+		assert.Equal(t, 4, counter)
+	})
+}
+
+func TestCassette_Modes(t *testing.T) {
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("POST", "http://example.com/text",
+			bytes.NewBufferString("world"))
+		return req
+	}
+
+	t.Run("replay without file", func(t *testing.T) {
+		path, cleanup := testCassettePath(t)
+		defer cleanup()
+
+		counter := 0
+
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+		cassette.Mode = CassetteReplay
+
+		resp, err := cassette.RoundTrip(newRequest())
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+
+		assert.Equal(t, 0, counter)
+	})
+
+	t.Run("record overwrites file", func(t *testing.T) {
+		path, cleanup := testCassettePath(t)
+		defer cleanup()
+
+		require.NoError(t, ioutil.WriteFile(path, []byte("garbage"), 0644))
+
+		counter := 0
+
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+		cassette.Mode = CassetteRecord
+
+		resp, err := cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, 1, counter)
+
+		cassette = NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+		cassette.Mode = CassetteReplay
+
+		resp, err = cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, 1, counter)
+	})
+
+	t.Run("passthrough", func(t *testing.T) {
+		path, cleanup := testCassettePath(t)
+		defer cleanup()
+
+		counter := 0
+
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+		cassette.Mode = CassettePassthrough
+
+		resp, err := cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, 1, counter)
+
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		path, cleanup := testCassettePath(t)
+		defer cleanup()
+
+		cassette := NewCassette(path, &mockTransport{
+			err: errors.New("test error"),
+		})
+
+		resp, err := cassette.RoundTrip(newRequest())
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestCassette_Env(t *testing.T) {
+	oldEnv, hadEnv := os.LookupEnv(CassetteModeEnv)
+	defer func() {
+		if hadEnv {
+			_ = os.Setenv(CassetteModeEnv, oldEnv)
+		} else {
+			_ = os.Unsetenv(CassetteModeEnv)
+		}
+	}()
+
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("GET", "http://example.com/binary", nil)
+		return req
+	}
+
+	path, cleanup := testCassettePath(t)
+	defer cleanup()
+
+	counter := 0
+
+	t.Run("record", func(t *testing.T) {
+		require.NoError(t, os.Setenv(CassetteModeEnv, "record"))
+
+		for n := 1; n <= 2; n++ {
+			cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+
+			_, err := cassette.RoundTrip(newRequest())
+			require.NoError(t, err)
+
+			assert.Equal(t, n, counter)
+		}
+	})
+
+	t.Run("explicit mode", func(t *testing.T) {
+		require.NoError(t, os.Setenv(CassetteModeEnv, "record"))
+
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+		cassette.Mode = CassetteReplay
+
+		_, err := cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, counter)
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		require.NoError(t, os.Setenv(CassetteModeEnv, "auto"))
+
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+
+		_, err := cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, counter)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.NoError(t, os.Setenv(CassetteModeEnv, "bad"))
+
+		cassette := NewCassette(path, NewBinder(createCassetteHandler(&counter)))
+
+		_, err := cassette.RoundTrip(newRequest())
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, counter)
+	})
+}
+
+func TestCassette_Redact(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Session", "session-secret")
+		if r.Header.Get("Authorization") == "Bearer auth-secret" {
+			_, _ = w.Write([]byte("authorized"))
+		}
+	})
+
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("GET", "http://example.com/secret", nil)
+		req.Header.Set("Authorization", "Bearer auth-secret")
+		req.Header.Set("Cookie", "session=cookie-secret")
+		req.Header.Set("X-Request-Id", "123")
+		return req
+	}
+
+	t.Run("default", func(t *testing.T) {
+		path, cleanup := testCassettePath(t)
+		defer cleanup()
+
+		cassette := NewCassette(path, NewBinder(handler))
+		cassette.Mode = CassetteRecord
+
+		req := newRequest()
+
+		resp, err := cassette.RoundTrip(req)
+		require.NoError(t, err)
+
+		// request that is actually sent is not modified
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, "authorized", string(body))
+		assert.Equal(t, "Bearer auth-secret", req.Header.Get("Authorization"))
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(data), "auth-secret")
+		assert.NotContains(t, string(data), "cookie-secret")
+		assert.Contains(t, string(data), "[REDACTED]")
+		assert.Contains(t, string(data), "123")
+		assert.Contains(t, string(data), "session-secret")
+
+		// redacted headers don't affect replay
+		cassette = NewCassette(path, nil)
+		cassette.Mode = CassetteReplay
+
+		resp, err = cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, "session-secret", resp.Header.Get("X-Session"))
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		path, cleanup := testCassettePath(t)
+		defer cleanup()
+
+		cassette := NewCassette(path, NewBinder(handler))
+		cassette.Mode = CassetteRecord
+		cassette.Redactor = &Redactor{
+			Headers: []string{"X-Session", "X-Request-Id"},
+		}
+
+		_, err := cassette.RoundTrip(newRequest())
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(data), "session-secret")
+		assert.NotContains(t, string(data), "123")
+	})
+}
+
+func TestCassette_RedactURLAndBody(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("api_key") == "query-secret" &&
+			r.Form.Get("client_secret") == "form-secret" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token-secret","expires_in":3600}`))
+		}
+	})
+
+	newRequest := func() *http.Request {
+		req, _ := http.NewRequest("POST", "http://example.com/token?api_key=query-secret",
+			bytes.NewBufferString("grant_type=client_credentials&client_secret=form-secret"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	path, cleanup := testCassettePath(t)
+	defer cleanup()
+
+	cassette := NewCassette(path, NewBinder(handler))
+	cassette.Mode = CassetteRecord
+
+	resp, err := cassette.RoundTrip(newRequest())
+	require.NoError(t, err)
+
+	// response that is actually received is not modified
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"access_token":"token-secret","expires_in":3600}`, string(body))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "query-secret")
+	assert.NotContains(t, string(data), "form-secret")
+	assert.NotContains(t, string(data), "token-secret")
+	assert.Contains(t, string(data), "grant_type=client_credentials")
+	assert.Contains(t, string(data), "3600")
+
+	// request is redacted in the same way when matching
+	cassette = NewCassette(path, nil)
+	cassette.Mode = CassetteReplay
+
+	resp, err = cassette.RoundTrip(newRequest())
+	require.NoError(t, err)
+
+	body, _ = ioutil.ReadAll(resp.Body)
+	assert.Equal(t,
+		`{"access_token":"[REDACTED]","expires_in":3600}`, string(body))
+}
+
+func TestCassette_Concurrent(t *testing.T) {
+	const numRequests = 4
+
+	var started sync.WaitGroup
+	started.Add(numRequests)
+
+	// every request blocks until all requests are in flight,
+	// so the test hangs if cassette serializes them
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started.Done()
+		select {
+		case <-allStarted:
+		case <-time.After(5 * time.Second):
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+
+	path, cleanup := testCassettePath(t)
+	defer cleanup()
+
+	t.Run("record", func(t *testing.T) {
+		cassette := NewCassette(path, NewBinder(handler))
+		cassette.Mode = CassetteRecord
+
+		var wg sync.WaitGroup
+
+		for n := 0; n < numRequests; n++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+
+				req, _ := http.NewRequest("GET",
+					"http://example.com/"+strconv.Itoa(n), nil)
+
+				resp, err := cassette.RoundTrip(req)
+				if assert.NoError(t, err) {
+					assert.Equal(t, http.StatusOK, resp.StatusCode)
+				}
+			}(n)
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		cassette := NewCassette(path, nil)
+		cassette.Mode = CassetteReplay
+
+		var wg sync.WaitGroup
+
+		for n := 0; n < numRequests; n++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+
+				req, _ := http.NewRequest("GET",
+					"http://example.com/"+strconv.Itoa(n), nil)
+
+				resp, err := cassette.RoundTrip(req)
+				if assert.NoError(t, err) {
+					body, _ := ioutil.ReadAll(resp.Body)
+					assert.Equal(t, "/"+strconv.Itoa(n), string(body))
+				}
+			}(n)
+		}
+
+		wg.Wait()
+	})
+}
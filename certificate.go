@@ -0,0 +1,191 @@
+package httpexpect
+
+import (
+	"crypto/x509"
+	"errors"
+	"time"
+)
+
+// Certificate provides methods to inspect attached x509.Certificate value.
+type Certificate struct {
+	noCopy noCopy
+	chain  *chain
+	value  *x509.Certificate
+}
+
+// NewCertificate returns a new Certificate instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	cert := NewCertificate(t, resp.TLS.PeerCertificates[0])
+//
+//	cert.CommonName().Equal("example.com")
+//	cert.DNSNames().Contains("example.com", "www.example.com")
+//	cert.NotAfter().Gt(time.Now().Add(time.Hour * 24 * 30))
+func NewCertificate(reporter Reporter, value *x509.Certificate) *Certificate {
+	return newCertificate(newChainWithDefaults("Certificate()", reporter), value)
+}
+
+// NewCertificateC returns a new Certificate instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewCertificate for usage example.
+func NewCertificateC(config Config, value *x509.Certificate) *Certificate {
+	return newCertificate(
+		newChainWithConfig("Certificate()", config.withDefaults()), value)
+}
+
+func newCertificate(parent *chain, val *x509.Certificate) *Certificate {
+	c := &Certificate{chain: parent.clone(), value: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil certificate"),
+			},
+		})
+	} else {
+		c.value = val
+	}
+
+	return c
+}
+
+// Raw returns underlying x509.Certificate value attached to Certificate.
+// This is the value originally passed to NewCertificate.
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	assert.Equal(t, c, cert.Raw())
+func (c *Certificate) Raw() *x509.Certificate {
+	return c.value
+}
+
+// CommonName returns a new String instance with common name (CN)
+// of certificate subject.
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	cert.CommonName().Equal("example.com")
+func (c *Certificate) CommonName() *String {
+	opChain := c.chain.enter("CommonName()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, c.value.Subject.CommonName)
+}
+
+// Issuer returns a new String instance with common name (CN)
+// of certificate issuer.
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	cert.Issuer().Equal("Example CA")
+func (c *Certificate) Issuer() *String {
+	opChain := c.chain.enter("Issuer()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, c.value.Issuer.CommonName)
+}
+
+// DNSNames returns a new Array instance with DNS names from
+// certificate subject alternative names (SANs).
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	cert.DNSNames().Contains("example.com")
+func (c *Certificate) DNSNames() *Array {
+	opChain := c.chain.enter("DNSNames()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	names := []interface{}{}
+	for _, name := range c.value.DNSNames {
+		names = append(names, name)
+	}
+
+	return newArray(opChain, names)
+}
+
+// IPAddresses returns a new Array instance with IP addresses from
+// certificate subject alternative names (SANs).
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	cert.IPAddresses().Contains("127.0.0.1")
+func (c *Certificate) IPAddresses() *Array {
+	opChain := c.chain.enter("IPAddresses()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	addrs := []interface{}{}
+	for _, addr := range c.value.IPAddresses {
+		addrs = append(addrs, addr.String())
+	}
+
+	return newArray(opChain, addrs)
+}
+
+// NotBefore returns a new DateTime instance with time when
+// certificate becomes valid.
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	cert.NotBefore().Lt(time.Now())
+func (c *Certificate) NotBefore() *DateTime {
+	opChain := c.chain.enter("NotBefore()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, c.value.NotBefore)
+}
+
+// NotAfter returns a new DateTime instance with certificate
+// expiration time.
+//
+// Example:
+//
+//	cert := NewCertificate(t, c)
+//	cert.NotAfter().Gt(time.Now().Add(time.Hour * 24 * 30))
+func (c *Certificate) NotAfter() *DateTime {
+	opChain := c.chain.enter("NotAfter()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, c.value.NotAfter)
+}
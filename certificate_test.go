@@ -0,0 +1,128 @@
+package httpexpect
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificate_Failed(t *testing.T) {
+	check := func(value *Certificate, isNil bool) {
+		value.chain.assertFailed(t)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+		assert.NotNil(t, value.CommonName())
+		assert.NotNil(t, value.Issuer())
+		assert.NotNil(t, value.DNSNames())
+		assert.NotNil(t, value.IPAddresses())
+		assert.NotNil(t, value.NotBefore())
+		assert.NotNil(t, value.NotAfter())
+	}
+
+	t.Run("failed_chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCertificate(chain, &x509.Certificate{})
+
+		check(value, false)
+	})
+
+	t.Run("nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newCertificate(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed_chain_nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCertificate(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestCertificate_Constructors(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "example.com"},
+	}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCertificate(reporter, cert)
+		value.CommonName().Equal("example.com")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCertificateC(Config{
+			Reporter: reporter,
+		}, cert)
+		value.CommonName().Equal("example.com")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCertificate(chain, cert)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestCertificate_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewCertificate(reporter, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "example.com"},
+		Issuer:      pkix.Name{CommonName: "Example CA"},
+		DNSNames:    []string{"example.com", "www.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:   time.Unix(1000, 0),
+		NotAfter:    time.Unix(2000, 0),
+	})
+
+	value.chain.assertNotFailed(t)
+
+	value.CommonName().chain.assertNotFailed(t)
+	value.Issuer().chain.assertNotFailed(t)
+	value.DNSNames().chain.assertNotFailed(t)
+	value.IPAddresses().chain.assertNotFailed(t)
+	value.NotBefore().chain.assertNotFailed(t)
+	value.NotAfter().chain.assertNotFailed(t)
+
+	assert.Equal(t, "example.com", value.CommonName().Raw())
+	assert.Equal(t, "Example CA", value.Issuer().Raw())
+	assert.Equal(t, []interface{}{"example.com", "www.example.com"},
+		value.DNSNames().Raw())
+	assert.Equal(t, []interface{}{"127.0.0.1"}, value.IPAddresses().Raw())
+	assert.True(t, time.Unix(1000, 0).Equal(value.NotBefore().Raw()))
+	assert.True(t, time.Unix(2000, 0).Equal(value.NotAfter().Raw()))
+
+	value.chain.assertNotFailed(t)
+}
+
+func TestCertificate_Empty(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewCertificate(reporter, &x509.Certificate{})
+
+	assert.Equal(t, "", value.CommonName().Raw())
+	assert.Equal(t, []interface{}{}, value.DNSNames().Raw())
+	assert.Equal(t, []interface{}{}, value.IPAddresses().Raw())
+
+	value.chain.assertNotFailed(t)
+}
@@ -78,6 +78,23 @@ type chain struct {
 	context  AssertionContext
 	handler  AssertionHandler
 	severity AssertionSeverity
+
+	// decode JSON numbers as json.Number, see Config.UseJSONNumber
+	jsonNumber bool
+
+	// reject duplicate keys in JSON documents, see Config.StrictJSON
+	strictJSON bool
+
+	// recognize placeholders in expected values, see Config.UsePlaceholders
+	placeholders bool
+
+	// invert result of the next assertion entered on this chain, see Value.Not
+	negateNext bool
+	// result of this assertion is inverted: failures are suppressed, and
+	// success is reported as failure
+	negated bool
+	// failure was suppressed because assertion is negated
+	negatedFailed bool
 }
 
 // If enabled, chain will panic if used incorrectly or gets illformed AssertionFailure.
@@ -104,9 +121,12 @@ func newChainWithConfig(name string, config Config) *chain {
 	config.validate()
 
 	c := &chain{
-		context:  AssertionContext{},
-		handler:  config.AssertionHandler,
-		severity: SeverityError,
+		context:      AssertionContext{},
+		handler:      config.AssertionHandler,
+		severity:     SeverityError,
+		jsonNumber:   config.UseJSONNumber,
+		strictJSON:   config.StrictJSON,
+		placeholders: config.UsePlaceholders,
 	}
 
 	c.context.TestName = config.TestName
@@ -176,6 +196,59 @@ func (c *chain) setRoot() {
 	c.parent = nil
 }
 
+// Check if JSON numbers should be decoded as json.Number.
+// Child chains inherit this setting from parent.
+func (c *chain) useJSONNumber() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.jsonNumber
+}
+
+// Check if JSON documents should be parsed in strict mode.
+// Child chains inherit this setting from parent.
+func (c *chain) useStrictJSON() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.strictJSON
+}
+
+// Check if placeholders should be recognized in expected values.
+// Child chains inherit this setting from parent.
+func (c *chain) usePlaceholders() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.placeholders
+}
+
+// Invert result of the next assertion entered on this chain.
+// Affects only the first enter() call, nested and child chains are not
+// negated.
+func (c *chain) setNegateNext(negate bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.negateNext = negate
+}
+
+// Clear pending negation set by setNegateNext and return its previous value.
+// Used by operations that are not negated themselves, like Value.Not.
+func (c *chain) takeNegateNext() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	negate := c.negateNext
+	c.negateNext = false
+
+	return negate
+}
+
 // Set severity of reported failures.
 // Chain always overrides failure severity with configured one.
 func (c *chain) setSeverity(severity AssertionSeverity) {
@@ -202,6 +275,41 @@ func (c *chain) setRequestName(name string) {
 	c.context.RequestName = name
 }
 
+// Append element to the path stored in AssertionContext.
+// Child chains inherit path from parent.
+func (c *chain) appendPath(name string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.context.Path = append(c.context.Path, fmt.Sprintf(name, args...))
+
+	if len(c.context.AliasedPath) != 0 {
+		c.context.AliasedPath = append(c.context.AliasedPath, fmt.Sprintf(name, args...))
+	}
+}
+
+// Replace path stored in AssertionContext with alias, when reporting
+// failures. Elements appended to the path later are appended to alias too.
+// Child chains inherit aliased path from parent.
+func (c *chain) setAlias(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	if name == "" {
+		c.context.AliasedPath = nil
+	} else {
+		c.context.AliasedPath = []string{name}
+	}
+}
+
 // Store request pointer in AssertionContext.
 // Child chains inherit context from parent.
 func (c *chain) setRequest(req *Request) {
@@ -236,6 +344,20 @@ func (c *chain) setResponse(resp *Response) {
 	c.context.Response = resp
 }
 
+// Clear request and response pointers in AssertionContext.
+// Used when chain is reused to send a new request.
+func (c *chain) clearRequest() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.context.Request = nil
+	c.context.Response = nil
+}
+
 // Create chain clone.
 // Typically is called between enter() and leave().
 func (c *chain) clone() *chain {
@@ -248,16 +370,28 @@ func (c *chain) clone() *chain {
 
 	contextCopy := c.context
 	contextCopy.Path = append(([]string)(nil), contextCopy.Path...)
+	contextCopy.AliasedPath = append(([]string)(nil), contextCopy.AliasedPath...)
+
+	// since the new clone doesn't have children yet, flagFailedChildren
+	// is not inherited
+	flags := c.flags & ^flagFailedChildren
+
+	// if negated assertion failed, its failure is suppressed, but values
+	// that it returns are still invalid
+	if c.negatedFailed {
+		flags |= flagFailed
+	}
 
 	return &chain{
-		parent: c,
-		state:  stateCloned,
-		// since the new clone doesn't have children yet, flagFailedChildren
-		// is not inherited
-		flags:    (c.flags & ^flagFailedChildren),
-		context:  contextCopy,
-		handler:  c.handler,
-		severity: c.severity,
+		parent:       c,
+		state:        stateCloned,
+		flags:        flags,
+		context:      contextCopy,
+		handler:      c.handler,
+		severity:     c.severity,
+		jsonNumber:   c.jsonNumber,
+		strictJSON:   c.strictJSON,
+		placeholders: c.placeholders,
 	}
 }
 
@@ -268,8 +402,21 @@ func (c *chain) enter(name string, args ...interface{}) *chain {
 	chainCopy := c.clone()
 
 	chainCopy.state = stateEntered
+
+	c.mu.Lock()
+	if c.negateNext {
+		chainCopy.negated = true
+		c.negateNext = false
+	}
+	c.mu.Unlock()
+
 	if name != "" {
 		chainCopy.context.Path = append(chainCopy.context.Path, fmt.Sprintf(name, args...))
+
+		if len(chainCopy.context.AliasedPath) != 0 {
+			chainCopy.context.AliasedPath = append(chainCopy.context.AliasedPath,
+				fmt.Sprintf(name, args...))
+		}
 	}
 
 	return chainCopy
@@ -299,6 +446,12 @@ func (c *chain) replace(name string, args ...interface{}) *chain {
 		chainCopy.context.Path[len(chainCopy.context.Path)-1] = fmt.Sprintf(name, args...)
 	}
 
+	// alias itself is never replaced, only elements appended after it
+	if len(chainCopy.context.AliasedPath) > 1 {
+		aliasedPath := chainCopy.context.AliasedPath
+		aliasedPath[len(aliasedPath)-1] = fmt.Sprintf(name, args...)
+	}
+
 	return chainCopy
 }
 
@@ -315,8 +468,33 @@ func (c *chain) leave() {
 		parent        *chain
 		reportSuccess bool
 		reportFailure bool
+		invert        bool
+		name          string
 	)
 
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.negated {
+			invert = c.flags&(flagFailed|flagFailedChildren) == 0 && !c.negatedFailed
+			c.negated = false
+
+			if len(c.context.Path) != 0 {
+				name = c.context.Path[len(c.context.Path)-1]
+			}
+		}
+	}()
+
+	if invert {
+		c.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("expected: negated assertion %s fails, but it succeeded", name),
+			},
+		})
+	}
+
 	func() {
 		c.mu.Lock()
 		defer c.mu.Unlock()
@@ -376,6 +554,14 @@ func (c *chain) fail(failure AssertionFailure) {
 		if c.flags&flagFailed != 0 {
 			return
 		}
+
+		// negated assertion succeeds when it fails; usage errors
+		// are reported as usual
+		if c.negated && failure.Type != AssertUsage {
+			c.negatedFailed = true
+			return
+		}
+
 		c.flags |= flagFailed
 
 		failure.Severity = c.severity
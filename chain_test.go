@@ -358,6 +358,9 @@ func TestChain_Panics(t *testing.T) {
 			func(chain *chain) {
 				chain.setRequestName("")
 			},
+			func(chain *chain) {
+				chain.appendPath("")
+			},
 			func(chain *chain) {
 				chain.setRequest(nil)
 			},
@@ -486,6 +489,55 @@ func TestChain_Path(t *testing.T) {
 	assert.Equal(t, "root.foo.bar.baz", path(opChain3))
 	assert.Equal(t, "root.xxx", path(opChain1r))
 	assert.Equal(t, "root.foo.bar.yyy", path(opChain3r))
+
+	rootClone := rootChain.clone()
+	rootClone.appendPath("qux(%d)", 1)
+	opChain4 := rootClone.enter("quux")
+
+	assert.Equal(t, "root", path(rootChain))
+	assert.Equal(t, "root.qux(1)", path(rootClone))
+	assert.Equal(t, "root.qux(1).quux", path(opChain4))
+}
+
+func TestChain_Alias(t *testing.T) {
+	path := func(c *chain) string {
+		return strings.Join(c.context.Path, ".")
+	}
+
+	aliasedPath := func(c *chain) string {
+		return strings.Join(c.context.AliasedPath, ".")
+	}
+
+	rootChain := newChainWithDefaults("root", newMockReporter(t))
+	opChain1 := rootChain.enter("foo")
+
+	assert.Equal(t, "", aliasedPath(opChain1))
+
+	opChain1.setAlias("alias")
+
+	assert.Equal(t, "root.foo", path(opChain1))
+	assert.Equal(t, "alias", aliasedPath(opChain1))
+
+	opChain2 := opChain1.enter("bar")
+
+	assert.Equal(t, "root.foo.bar", path(opChain2))
+	assert.Equal(t, "alias.bar", aliasedPath(opChain2))
+	assert.Equal(t, "alias", aliasedPath(opChain1))
+
+	opChain2r := opChain2.replace("baz")
+
+	assert.Equal(t, "root.foo.baz", path(opChain2r))
+	assert.Equal(t, "alias.baz", aliasedPath(opChain2r))
+
+	opChain1r := opChain1.replace("qux")
+
+	assert.Equal(t, "root.qux", path(opChain1r))
+	assert.Equal(t, "alias", aliasedPath(opChain1r))
+
+	opChain1.setAlias("")
+
+	assert.Equal(t, "", aliasedPath(opChain1))
+	assert.Equal(t, "", aliasedPath(opChain1.enter("bar")))
 }
 
 func TestChain_Handler(t *testing.T) {
@@ -569,3 +621,92 @@ func TestChain_Severity(t *testing.T) {
 		assert.Equal(t, SeverityLog, handler.failure.Severity)
 	})
 }
+
+func TestChain_Negate(t *testing.T) {
+	newFailure := func() AssertionFailure {
+		failure := mockFailure()
+		failure.Type = AssertOperation
+		return failure
+	}
+
+	t.Run("failure suppressed", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		chain.setNegateNext(true)
+
+		opChain := chain.enter("test")
+		opChain.fail(newFailure())
+		opChain.leave()
+
+		assert.NotNil(t, handler.ctx)
+		assert.Nil(t, handler.failure)
+		assert.False(t, chain.treeFailed())
+	})
+
+	t.Run("success inverted", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		chain.setNegateNext(true)
+
+		opChain := chain.enter("Equal()")
+		opChain.leave()
+
+		assert.True(t, chain.failed())
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, AssertOperation, handler.failure.Type)
+			assert.Contains(t, handler.failure.Errors[0].Error(), "Equal()")
+		}
+	})
+
+	t.Run("usage error", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		chain.setNegateNext(true)
+
+		opChain := chain.enter("test")
+		opChain.fail(mockFailure())
+		opChain.leave()
+
+		assert.True(t, chain.failed())
+		assert.NotNil(t, handler.failure)
+	})
+
+	t.Run("only next assertion", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		chain.setNegateNext(true)
+
+		opChain := chain.enter("test")
+		child := opChain.clone()
+		opChain.fail(newFailure())
+		opChain.leave()
+
+		assert.False(t, chain.treeFailed())
+
+		opChain = child.enter("test")
+		opChain.leave()
+
+		opChain = chain.enter("test")
+		opChain.leave()
+
+		assert.False(t, chain.treeFailed())
+
+		opChain = chain.enter("test")
+		opChain.fail(newFailure())
+		opChain.leave()
+
+		assert.True(t, chain.failed())
+	})
+}
@@ -0,0 +1,83 @@
+package httpexpect
+
+import (
+	"sort"
+	"strings"
+)
+
+// Codec encodes request bodies and decodes response bodies of custom
+// content type, e.g. CBOR, Avro, or vendor-specific media type.
+//
+// Codecs are registered in Config.Codecs by media type and are used by
+// Request.WithEncoded and Response.Decoded.
+//
+// Marshal and Unmarshal have the same semantics as json.Marshal and
+// json.Unmarshal, so many encoding libraries can be plugged in using
+// CodecFuncs.
+type Codec interface {
+	// Marshal returns encoded representation of value.
+	Marshal(value interface{}) ([]byte, error)
+
+	// Unmarshal decodes data and stores result in value pointed to
+	// by second argument.
+	Unmarshal(data []byte, value interface{}) error
+}
+
+// CodecFuncs implements Codec using given functions.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Codecs: map[string]httpexpect.Codec{
+//			"application/cbor": httpexpect.CodecFuncs{
+//				MarshalFunc:   cbor.Marshal,
+//				UnmarshalFunc: cbor.Unmarshal,
+//			},
+//		},
+//	})
+type CodecFuncs struct {
+	MarshalFunc   func(value interface{}) ([]byte, error)
+	UnmarshalFunc func(data []byte, value interface{}) error
+}
+
+// Marshal implements Codec.Marshal.
+func (c CodecFuncs) Marshal(value interface{}) ([]byte, error) {
+	return c.MarshalFunc(value)
+}
+
+// Unmarshal implements Codec.Unmarshal.
+func (c CodecFuncs) Unmarshal(data []byte, value interface{}) error {
+	return c.UnmarshalFunc(data, value)
+}
+
+// find codec for media type; media types are case-insensitive
+func findCodec(codecs map[string]Codec, mediaType string) (Codec, bool) {
+	if codec, ok := codecs[mediaType]; ok {
+		return codec, true
+	}
+
+	for key, codec := range codecs {
+		if strings.EqualFold(key, mediaType) {
+			return codec, true
+		}
+	}
+
+	return nil, false
+}
+
+// sorted list of registered media types, for failure reports
+func codecTypes(codecs map[string]Codec) AssertionList {
+	var types []string
+	for key := range codecs {
+		types = append(types, key)
+	}
+	sort.Strings(types)
+
+	list := AssertionList{}
+	for _, t := range types {
+		list = append(list, t)
+	}
+
+	return list
+}
@@ -0,0 +1,149 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCodec_Find(t *testing.T) {
+	jsonCodec := CodecFuncs{json.Marshal, json.Unmarshal}
+	yamlCodec := CodecFuncs{yaml.Marshal, yaml.Unmarshal}
+
+	codecs := map[string]Codec{
+		"application/vnd.test+json": jsonCodec,
+		"Application/X-Test-YAML":   yamlCodec,
+	}
+
+	codec, ok := findCodec(codecs, "application/vnd.test+json")
+	assert.True(t, ok)
+	assert.NotNil(t, codec)
+
+	codec, ok = findCodec(codecs, "application/x-test-yaml")
+	assert.True(t, ok)
+	assert.NotNil(t, codec)
+
+	_, ok = findCodec(codecs, "application/json")
+	assert.False(t, ok)
+
+	_, ok = findCodec(nil, "application/json")
+	assert.False(t, ok)
+
+	assert.Equal(t,
+		AssertionList{"Application/X-Test-YAML", "application/vnd.test+json"},
+		codecTypes(codecs))
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	failingCodec := CodecFuncs{
+		MarshalFunc: func(interface{}) ([]byte, error) {
+			return nil, errors.New("marshal error")
+		},
+		UnmarshalFunc: func([]byte, interface{}) error {
+			return errors.New("unmarshal error")
+		},
+	}
+
+	newConfig := func(t *testing.T) Config {
+		return Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+			Codecs: map[string]Codec{
+				"application/x-test-json": CodecFuncs{json.Marshal, json.Unmarshal},
+				"application/x-test-yaml": CodecFuncs{yaml.Marshal, yaml.Unmarshal},
+				"application/x-test-fail": failingCodec,
+			},
+		}
+	}
+
+	object := map[string]interface{}{
+		"foo": 123,
+		"bar": []interface{}{"baz"},
+	}
+
+	expected := map[string]interface{}{
+		"foo": 123.0,
+		"bar": []interface{}{"baz"},
+	}
+
+	for _, mediaType := range []string{
+		"application/x-test-json",
+		"application/x-test-yaml",
+	} {
+		t.Run(mediaType, func(t *testing.T) {
+			req := NewRequestC(newConfig(t), "POST", "/")
+			req.WithEncoded(mediaType, object)
+
+			resp := req.Expect()
+			assert.Equal(t, mediaType, resp.Raw().Header.Get("Content-Type"))
+
+			assert.Equal(t, expected, resp.Decoded().Raw())
+			assert.Equal(t, expected, resp.Decoded(ContentOpts{
+				MediaType: mediaType,
+			}).Raw())
+
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("unknown request type", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "POST", "/")
+		req.WithEncoded("application/cbor", object)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("no codecs", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "POST", "/")
+		req.WithEncoded("application/cbor", object)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "POST", "/")
+		req.WithEncoded("application/x-test-fail", object)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("unknown response type", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "POST", "/")
+		req.WithText("hello")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		resp.Decoded()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.Decoded(ContentOpts{MediaType: "text/plain"})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("unexpected response type", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "POST", "/")
+		req.WithEncoded("application/x-test-json", object)
+
+		resp := req.Expect()
+
+		resp.Decoded(ContentOpts{MediaType: "application/x-test-yaml"})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("unmarshal error", func(t *testing.T) {
+		req := NewRequestC(newConfig(t), "POST", "/")
+		req.WithBytes([]byte("foo"))
+		req.WithHeader("Content-Type", "application/x-test-fail")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Nil(t, resp.Decoded().Raw())
+		resp.chain.assertFailed(t)
+	})
+}
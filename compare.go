@@ -1,8 +1,10 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 )
 
 func arrayComparator(opChain *chain, array []interface{}) func(x, y *Value) bool {
@@ -16,7 +18,7 @@ func arrayComparatorValidate(opChain *chain, array []interface{}) bool {
 	var prev interface{}
 	for index, curr := range array {
 		switch curr.(type) {
-		case bool, float64, string, nil:
+		case bool, float64, json.Number, string, nil:
 			// ok, do nothing
 
 		default:
@@ -43,7 +45,7 @@ func arrayComparatorValidate(opChain *chain, array []interface{}) bool {
 			return false
 		}
 
-		if index > 0 && fmt.Sprintf("%T", curr) != fmt.Sprintf("%T", prev) {
+		if index > 0 && comparatorKind(curr) != comparatorKind(prev) {
 			opChain.fail(AssertionFailure{
 				Type: AssertEqual,
 				Actual: &AssertionValue{
@@ -79,11 +81,9 @@ func arrayComparatorConstruct(array []interface{}) func(x, y *Value) bool {
 				yVal := y.Raw().(bool)
 				return (!xVal && yVal)
 			}
-		case float64:
+		case float64, json.Number:
 			return func(x, y *Value) bool {
-				xVal := x.Raw().(float64)
-				yVal := y.Raw().(float64)
-				return xVal < yVal
+				return compareNumbers(x.Raw(), y.Raw()) < 0
 			}
 		case string:
 			return func(x, y *Value) bool {
@@ -102,6 +102,46 @@ func arrayComparatorConstruct(array []interface{}) func(x, y *Value) bool {
 	return nil
 }
 
+// numbers are float64, or json.Number for large integers if
+// Config.UseJSONNumber is enabled; both are compared as numbers
+func comparatorKind(value interface{}) string {
+	if _, ok := value.(json.Number); ok {
+		return fmt.Sprintf("%T", float64(0))
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// compareNumbers compares two canonical numbers (float64 or json.Number)
+// and returns -1, 0, or +1; json.Number is compared exactly
+func compareNumbers(x, y interface{}) int {
+	if xf, ok := x.(float64); ok {
+		if yf, ok := y.(float64); ok {
+			switch {
+			case xf < yf:
+				return -1
+			case xf > yf:
+				return +1
+			default:
+				return 0
+			}
+		}
+	}
+
+	return bigNumber(x).Cmp(bigNumber(y))
+}
+
+func bigNumber(value interface{}) *big.Float {
+	switch v := value.(type) {
+	case float64:
+		return big.NewFloat(v)
+	case json.Number:
+		if f, ok := new(big.Float).SetPrec(128).SetString(string(v)); ok {
+			return f
+		}
+	}
+	return new(big.Float)
+}
+
 type typeName string
 
 func (t typeName) String() string {
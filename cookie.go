@@ -251,3 +251,145 @@ func (c *Cookie) MaxAge() *Duration {
 		return newDuration(opChain, &age)
 	}
 }
+
+// HaveSecure succeeds if cookie has Secure attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveSecure()
+func (c *Cookie) HaveSecure() *Cookie {
+	opChain := c.chain.enter("HaveSecure()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.value.Secure {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has Secure attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotHaveSecure succeeds if cookie does not have Secure attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHaveSecure()
+func (c *Cookie) NotHaveSecure() *Cookie {
+	opChain := c.chain.enter("NotHaveSecure()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.Secure {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have Secure attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// HaveHTTPOnly succeeds if cookie has HttpOnly attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.HaveHTTPOnly()
+func (c *Cookie) HaveHTTPOnly() *Cookie {
+	opChain := c.chain.enter("HaveHTTPOnly()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.value.HttpOnly {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie has HttpOnly attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotHaveHTTPOnly succeeds if cookie does not have HttpOnly attribute.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.NotHaveHTTPOnly()
+func (c *Cookie) NotHaveHTTPOnly() *Cookie {
+	opChain := c.chain.enter("NotHaveHTTPOnly()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.value.HttpOnly {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{c.value},
+			Errors: []error{
+				errors.New("expected: cookie does not have HttpOnly attribute"),
+			},
+		})
+	}
+
+	return c
+}
+
+// SameSite returns a new String instance with cookie SameSite attribute.
+//
+// Returned value is "Strict", "Lax", or "None". If cookie does not have
+// SameSite attribute or its value is unknown, empty string is returned.
+//
+// Example:
+//
+//	cookie := NewCookie(t, &http.Cookie{...})
+//	cookie.SameSite().Equal("Strict")
+func (c *Cookie) SameSite() *String {
+	opChain := c.chain.enter("SameSite()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	switch c.value.SameSite {
+	case http.SameSiteStrictMode:
+		return newString(opChain, "Strict")
+
+	case http.SameSiteLaxMode:
+		return newString(opChain, "Lax")
+
+	case http.SameSiteNoneMode:
+		return newString(opChain, "None")
+
+	case http.SameSiteDefaultMode:
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, "")
+}
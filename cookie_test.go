@@ -24,9 +24,14 @@ func TestCookie_Failed(t *testing.T) {
 		assert.NotNil(t, value.Path())
 		assert.NotNil(t, value.Expires())
 		assert.NotNil(t, value.MaxAge())
+		assert.NotNil(t, value.SameSite())
 
 		value.HaveMaxAge()
 		value.NotHaveMaxAge()
+		value.HaveSecure()
+		value.NotHaveSecure()
+		value.HaveHTTPOnly()
+		value.NotHaveHTTPOnly()
 	}
 
 	t.Run("failed_chain", func(t *testing.T) {
@@ -191,3 +196,89 @@ func TestCookie_MaxAge(t *testing.T) {
 		value.MaxAge().Equal(3 * time.Second).chain.assertNotFailed(t)
 	})
 }
+
+func TestCookie_Secure(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("unset", func(t *testing.T) {
+		value := NewCookie(reporter, &http.Cookie{
+			Secure: false,
+		})
+
+		value.HaveSecure().chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.NotHaveSecure().chain.assertNotFailed(t)
+		value.chain.clearFailed()
+	})
+
+	t.Run("set", func(t *testing.T) {
+		value := NewCookie(reporter, &http.Cookie{
+			Secure: true,
+		})
+
+		value.HaveSecure().chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.NotHaveSecure().chain.assertFailed(t)
+		value.chain.clearFailed()
+	})
+}
+
+func TestCookie_HTTPOnly(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("unset", func(t *testing.T) {
+		value := NewCookie(reporter, &http.Cookie{
+			HttpOnly: false,
+		})
+
+		value.HaveHTTPOnly().chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.NotHaveHTTPOnly().chain.assertNotFailed(t)
+		value.chain.clearFailed()
+	})
+
+	t.Run("set", func(t *testing.T) {
+		value := NewCookie(reporter, &http.Cookie{
+			HttpOnly: true,
+		})
+
+		value.HaveHTTPOnly().chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.NotHaveHTTPOnly().chain.assertFailed(t)
+		value.chain.clearFailed()
+	})
+}
+
+func TestCookie_SameSite(t *testing.T) {
+	cases := []struct {
+		name     string
+		sameSite http.SameSite
+		expected string
+	}{
+		{"unset", 0, ""},
+		{"default", http.SameSiteDefaultMode, ""},
+		{"strict", http.SameSiteStrictMode, "Strict"},
+		{"lax", http.SameSiteLaxMode, "Lax"},
+		{"none", http.SameSiteNoneMode, "None"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewCookie(reporter, &http.Cookie{
+				SameSite: tc.sameSite,
+			})
+
+			value.SameSite().chain.assertNotFailed(t)
+			assert.Equal(t, tc.expected, value.SameSite().Raw())
+
+			value.SameSite().Equal(tc.expected)
+			value.chain.assertNotFailed(t)
+		})
+	}
+}
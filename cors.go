@@ -0,0 +1,378 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS provides methods to inspect CORS (Cross-Origin Resource Sharing)
+// response headers.
+type CORS struct {
+	noCopy noCopy
+	chain  *chain
+	header http.Header
+}
+
+// NewCORS returns a new CORS instance.
+//
+// Header should contain response headers, typically of a response to
+// preflight request (see Request.WithCORSPreflight).
+//
+// If reporter is nil, the function panics.
+// If header is nil, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, resp.Header)
+//
+//	cors.AllowsOrigin("https://example.com")
+//	cors.AllowsMethod("PUT")
+func NewCORS(reporter Reporter, header http.Header) *CORS {
+	return newCORS(newChainWithDefaults("CORS()", reporter), header)
+}
+
+// NewCORSC returns a new CORS instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If header is nil, failure is reported.
+//
+// See NewCORS for usage example.
+func NewCORSC(config Config, header http.Header) *CORS {
+	return newCORS(newChainWithConfig("CORS()", config.withDefaults()), header)
+}
+
+func newCORS(parent *chain, val http.Header) *CORS {
+	c := &CORS{chain: parent.clone(), header: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil header"),
+			},
+		})
+	} else {
+		c.header = val
+	}
+
+	return c
+}
+
+// Raw returns underlying http.Header value attached to CORS.
+// This is the value originally passed to NewCORS.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	assert.Equal(t, header, cors.Raw())
+func (c *CORS) Raw() http.Header {
+	return c.header
+}
+
+// AllowOrigin returns a new String instance with value of
+// "Access-Control-Allow-Origin" header.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowOrigin().Equal("https://example.com")
+func (c *CORS) AllowOrigin() *String {
+	opChain := c.chain.enter("AllowOrigin()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value, ok := c.getHeader(opChain, "Access-Control-Allow-Origin")
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
+
+// AllowMethods returns a new Array instance with methods listed in
+// "Access-Control-Allow-Methods" header.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowMethods().ContainsAll("GET", "PUT")
+func (c *CORS) AllowMethods() *Array {
+	return c.listHeader("AllowMethods()", "Access-Control-Allow-Methods")
+}
+
+// AllowHeaders returns a new Array instance with headers listed in
+// "Access-Control-Allow-Headers" header.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowHeaders().Contains("Content-Type")
+func (c *CORS) AllowHeaders() *Array {
+	return c.listHeader("AllowHeaders()", "Access-Control-Allow-Headers")
+}
+
+// ExposeHeaders returns a new Array instance with headers listed in
+// "Access-Control-Expose-Headers" header.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.ExposeHeaders().Contains("X-Request-Id")
+func (c *CORS) ExposeHeaders() *Array {
+	return c.listHeader("ExposeHeaders()", "Access-Control-Expose-Headers")
+}
+
+// AllowCredentials returns a new Boolean instance with true value if
+// "Access-Control-Allow-Credentials" header is set to "true".
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowCredentials().True()
+func (c *CORS) AllowCredentials() *Boolean {
+	opChain := c.chain.enter("AllowCredentials()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	value := c.header.Get("Access-Control-Allow-Credentials")
+
+	return newBoolean(opChain, value == "true")
+}
+
+// MaxAge returns a new Duration instance with value of
+// "Access-Control-Max-Age" header.
+//
+// If header is missing or is not a valid number of seconds,
+// failure is reported.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.MaxAge().Ge(time.Hour)
+func (c *CORS) MaxAge() *Duration {
+	opChain := c.chain.enter("MaxAge()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value, ok := c.getHeader(opChain, "Access-Control-Max-Age")
+	if !ok {
+		return newDuration(opChain, nil)
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Access-Control-Max-Age" header`),
+				err,
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	d := time.Duration(seconds) * time.Second
+
+	return newDuration(opChain, &d)
+}
+
+// AllowsOrigin succeeds if "Access-Control-Allow-Origin" header allows
+// given origin, i.e. is equal to origin or is "*".
+//
+// Wildcard is not accepted if "Access-Control-Allow-Credentials" is true,
+// because browsers reject such responses.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsOrigin("https://example.com")
+func (c *CORS) AllowsOrigin(origin string) *CORS {
+	opChain := c.chain.enter("AllowsOrigin()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	value, ok := c.getHeader(opChain, "Access-Control-Allow-Origin")
+	if !ok {
+		return c
+	}
+
+	credentials := c.header.Get("Access-Control-Allow-Credentials") == "true"
+
+	switch {
+	case value == origin:
+
+	case value == "*" && credentials:
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{origin},
+			Errors: []error{
+				errors.New(
+					"expected: explicit origin when credentials are allowed"),
+			},
+		})
+
+	case value == "*":
+
+	default:
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{origin},
+			Errors: []error{
+				errors.New("expected: origin is allowed"),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsMethod succeeds if "Access-Control-Allow-Methods" header lists
+// given method, or contains "*" and credentials are not allowed.
+//
+// Simple methods (GET, HEAD, POST) are always allowed.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsMethod("DELETE")
+func (c *CORS) AllowsMethod(method string) *CORS {
+	opChain := c.chain.enter("AllowsMethod()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+		return c
+	}
+
+	c.checkListed(opChain, "Access-Control-Allow-Methods", method, false)
+
+	return c
+}
+
+// AllowsHeader succeeds if "Access-Control-Allow-Headers" header lists
+// given request header, or contains "*" and credentials are not allowed.
+// Header names are compared case-insensitively.
+//
+// Example:
+//
+//	cors := NewCORS(t, header)
+//	cors.AllowsHeader("Authorization")
+func (c *CORS) AllowsHeader(header string) *CORS {
+	opChain := c.chain.enter("AllowsHeader()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	c.checkListed(opChain, "Access-Control-Allow-Headers", header, true)
+
+	return c
+}
+
+func (c *CORS) checkListed(
+	opChain *chain, key, value string, caseInsensitive bool,
+) {
+	values, ok := c.getList(opChain, key)
+	if !ok {
+		return
+	}
+
+	credentials := c.header.Get("Access-Control-Allow-Credentials") == "true"
+
+	for _, v := range values {
+		if v == value || (caseInsensitive && strings.EqualFold(v, value)) {
+			return
+		}
+		if v == "*" && !credentials {
+			return
+		}
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertContainsElement,
+		Actual:   &AssertionValue{values},
+		Expected: &AssertionValue{value},
+		Errors: []error{
+			fmt.Errorf("expected: %q header contains value", key),
+		},
+	})
+}
+
+func (c *CORS) listHeader(method, key string) *Array {
+	opChain := c.chain.enter(method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	values, ok := c.getList(opChain, key)
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	elements := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, v)
+	}
+
+	return newArray(opChain, elements)
+}
+
+func (c *CORS) getList(opChain *chain, key string) ([]string, bool) {
+	if _, ok := c.getHeader(opChain, key); !ok {
+		return nil, false
+	}
+
+	values := []string{}
+
+	for _, line := range c.header.Values(key) {
+		for _, v := range strings.Split(line, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+
+	return values, true
+}
+
+func (c *CORS) getHeader(opChain *chain, key string) (string, bool) {
+	return checkHeader(opChain, c.header, key)
+}
@@ -0,0 +1,299 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_Failed(t *testing.T) {
+	check := func(value *CORS, isNil bool) {
+		value.chain.assertFailed(t)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+		assert.NotNil(t, value.AllowOrigin())
+		assert.NotNil(t, value.AllowMethods())
+		assert.NotNil(t, value.AllowHeaders())
+		assert.NotNil(t, value.ExposeHeaders())
+		assert.NotNil(t, value.AllowCredentials())
+		assert.NotNil(t, value.MaxAge())
+
+		value.AllowsOrigin("https://example.com")
+		value.AllowsMethod("PUT")
+		value.AllowsHeader("Authorization")
+	}
+
+	t.Run("failed_chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCORS(chain, http.Header{})
+
+		check(value, false)
+	})
+
+	t.Run("nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newCORS(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed_chain_nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCORS(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestCORS_Constructors(t *testing.T) {
+	header := http.Header{
+		"Access-Control-Allow-Origin": {"*"},
+	}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCORS(reporter, header)
+		value.AllowOrigin().Equal("*")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewCORSC(Config{
+			Reporter: reporter,
+		}, header)
+		value.AllowOrigin().Equal("*")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newCORS(chain, header)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestCORS_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("present", func(t *testing.T) {
+		value := NewCORS(reporter, http.Header{
+			"Access-Control-Allow-Origin":      {"https://example.com"},
+			"Access-Control-Allow-Methods":     {"GET, PUT", "DELETE"},
+			"Access-Control-Allow-Headers":     {"Content-Type,Authorization"},
+			"Access-Control-Expose-Headers":    {"X-Request-Id"},
+			"Access-Control-Allow-Credentials": {"true"},
+			"Access-Control-Max-Age":           {"600"},
+		})
+
+		value.AllowOrigin().Equal("https://example.com")
+		value.AllowMethods().Equal([]interface{}{"GET", "PUT", "DELETE"})
+		value.AllowHeaders().Equal([]interface{}{"Content-Type", "Authorization"})
+		value.ExposeHeaders().Equal([]interface{}{"X-Request-Id"})
+		value.AllowCredentials().True()
+		value.MaxAge().Equal(10 * time.Minute)
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		value := NewCORS(reporter, http.Header{})
+		value.AllowCredentials().False()
+		value.chain.assertNotFailed(t)
+
+		for _, fn := range []func(*CORS){
+			func(c *CORS) { c.AllowOrigin() },
+			func(c *CORS) { c.AllowMethods() },
+			func(c *CORS) { c.AllowHeaders() },
+			func(c *CORS) { c.ExposeHeaders() },
+			func(c *CORS) { c.MaxAge() },
+		} {
+			value := NewCORS(reporter, http.Header{})
+			fn(value)
+			value.chain.assertFailed(t)
+		}
+	})
+
+	t.Run("invalid max-age", func(t *testing.T) {
+		value := NewCORS(reporter, http.Header{
+			"Access-Control-Max-Age": {"forever"},
+		})
+		value.MaxAge()
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestCORS_Allows(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		name    string
+		header  http.Header
+		check   func(*CORS)
+		isValid bool
+	}{
+		{
+			name: "origin exact",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"https://example.com"},
+			},
+			check:   func(c *CORS) { c.AllowsOrigin("https://example.com") },
+			isValid: true,
+		},
+		{
+			name: "origin mismatch",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"https://example.com"},
+			},
+			check:   func(c *CORS) { c.AllowsOrigin("https://evil.com") },
+			isValid: false,
+		},
+		{
+			name: "origin wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"*"},
+			},
+			check:   func(c *CORS) { c.AllowsOrigin("https://example.com") },
+			isValid: true,
+		},
+		{
+			name: "origin wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			check:   func(c *CORS) { c.AllowsOrigin("https://example.com") },
+			isValid: false,
+		},
+		{
+			name:    "simple method",
+			header:  http.Header{},
+			check:   func(c *CORS) { c.AllowsMethod("POST") },
+			isValid: true,
+		},
+		{
+			name: "listed method",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"PUT, DELETE"},
+			},
+			check:   func(c *CORS) { c.AllowsMethod("DELETE") },
+			isValid: true,
+		},
+		{
+			name: "method case",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"put"},
+			},
+			check:   func(c *CORS) { c.AllowsMethod("PUT") },
+			isValid: false,
+		},
+		{
+			name: "method wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"*"},
+			},
+			check:   func(c *CORS) { c.AllowsMethod("PATCH") },
+			isValid: true,
+		},
+		{
+			name: "method wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Methods":     {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			check:   func(c *CORS) { c.AllowsMethod("PATCH") },
+			isValid: false,
+		},
+		{
+			name:    "method missing",
+			header:  http.Header{},
+			check:   func(c *CORS) { c.AllowsMethod("PUT") },
+			isValid: false,
+		},
+		{
+			name: "header case",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"content-type, authorization"},
+			},
+			check:   func(c *CORS) { c.AllowsHeader("Authorization") },
+			isValid: true,
+		},
+		{
+			name: "header not listed",
+			header: http.Header{
+				"Access-Control-Allow-Headers": {"content-type"},
+			},
+			check:   func(c *CORS) { c.AllowsHeader("Authorization") },
+			isValid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := NewCORS(reporter, tc.header)
+			tc.check(value)
+
+			if tc.isValid {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+		w.Header().Set("Access-Control-Allow-Methods",
+			r.Header.Get("Access-Control-Request-Method"))
+		w.Header().Set("Access-Control-Allow-Headers",
+			r.Header.Get("Access-Control-Request-Headers"))
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Max-Age", "3600")
+		w.Header().Add("Vary", "Origin")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	cors := e.OPTIONS("/fruits").
+		WithCORSPreflight("https://example.com", "PUT", "Content-Type", "X-Token").
+		Expect().
+		Status(http.StatusNoContent).
+		CORS()
+
+	cors.AllowsOrigin("https://example.com").
+		AllowsMethod("PUT").
+		AllowsHeader("Content-Type").
+		AllowsHeader("X-Token")
+
+	cors.AllowHeaders().Equal([]interface{}{"content-type", "x-token"})
+	cors.AllowCredentials().True()
+	cors.MaxAge().Equal(time.Hour)
+}
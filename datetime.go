@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -358,6 +359,94 @@ func (dt *DateTime) NotInRange(min, max time.Time) *DateTime {
 	return dt
 }
 
+// WithinDuration succeeds if DateTime differs from given value by no more
+// than delta, i.e. is within range [value-delta; value+delta].
+//
+// Useful to check server-generated timestamps against time.Now().
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Now())
+//	dt.WithinDuration(time.Now(), time.Minute)
+func (dt *DateTime) WithinDuration(value time.Time, delta time.Duration) *DateTime {
+	opChain := dt.chain.enter("WithinDuration()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if delta < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative delta argument: %s", delta),
+			},
+		})
+		return dt
+	}
+
+	min, max := value.Add(-delta), value.Add(delta)
+
+	if dt.value.Before(min) || dt.value.After(max) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertInRange,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				fmt.Errorf("expected: time point is within %s from given value",
+					delta),
+			},
+		})
+	}
+
+	return dt
+}
+
+// NotWithinDuration succeeds if DateTime differs from given value by more
+// than delta, i.e. is not within range [value-delta; value+delta].
+//
+// Example:
+//
+//	dt := NewDateTime(t, time.Unix(0, 0))
+//	dt.NotWithinDuration(time.Now(), time.Minute)
+func (dt *DateTime) NotWithinDuration(
+	value time.Time, delta time.Duration,
+) *DateTime {
+	opChain := dt.chain.enter("NotWithinDuration()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return dt
+	}
+
+	if delta < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected negative delta argument: %s", delta),
+			},
+		})
+		return dt
+	}
+
+	min, max := value.Add(-delta), value.Add(delta)
+
+	if !dt.value.Before(min) && !dt.value.After(max) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotInRange,
+			Actual:   &AssertionValue{dt.value},
+			Expected: &AssertionValue{AssertionRange{min, max}},
+			Errors: []error{
+				fmt.Errorf("expected: time point is not within %s from given value",
+					delta),
+			},
+		})
+	}
+
+	return dt
+}
+
 // Gt succeeds if DateTime is greater than given value.
 //
 // Example:
@@ -25,6 +25,8 @@ func TestDateTime_Failed(t *testing.T) {
 	value.Le(tm)
 	value.InRange(tm, tm)
 	value.NotInRange(tm, tm)
+	value.WithinDuration(tm, 0)
+	value.NotWithinDuration(tm, 0)
 	value.GetZone()
 	value.GetYear()
 	value.GetMonth()
@@ -196,6 +198,54 @@ func TestDateTime_InRange(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestDateTime_WithinDuration(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	tm := time.Unix(100, 0)
+
+	value := NewDateTime(reporter, tm)
+
+	value.WithinDuration(tm, 0)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.NotWithinDuration(tm, 0)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.WithinDuration(tm.Add(time.Second), time.Second)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.WithinDuration(tm.Add(-time.Second), time.Second)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.NotWithinDuration(tm.Add(time.Second), time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.WithinDuration(tm.Add(time.Second+1), time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.WithinDuration(tm.Add(-time.Second-1), time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotWithinDuration(tm.Add(-time.Second-1), time.Second)
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.WithinDuration(tm, -time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotWithinDuration(tm, -time.Second)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
 func TestDateTimeGetters(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -97,3 +97,53 @@ func TestE2EChunked_BinderFast(t *testing.T) {
 		},
 	}))
 }
+
+func createTrailerHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Checksum")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`hello`))
+		w.(http.Flusher).Flush()
+		w.Header().Set("Checksum", "1234")
+		w.Header().Set(http.TrailerPrefix+"Status", "done")
+	})
+
+	return mux
+}
+
+func testTrailerHandler(e *Expect) {
+	resp := e.GET("/").
+		Expect().
+		Status(http.StatusOK)
+
+	resp.Body().Equal(`hello`)
+
+	resp.Trailers().ContainsKey("Checksum")
+	resp.Trailers().ContainsKey("Status")
+
+	resp.Trailer("Checksum").Equal("1234")
+	resp.Trailer("Status").Equal("done")
+}
+
+func TestE2EChunked_TrailerLive(t *testing.T) {
+	handler := createTrailerHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testTrailerHandler(Default(t, server.URL))
+}
+
+func TestE2EChunked_TrailerBinder(t *testing.T) {
+	handler := createTrailerHandler()
+
+	testTrailerHandler(WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}))
+}
@@ -0,0 +1,71 @@
+package httpexpect
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func createCompressionHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_, _ = w.Write([]byte(`{"foo":"bar"}`))
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+
+		gw := gzip.NewWriter(w)
+		_, _ = gw.Write([]byte(`{"foo":"` + strings.Repeat("bar", 100) + `"}`))
+		_ = gw.Close()
+	})
+
+	return mux
+}
+
+func testCompressionHandler(e *Expect) {
+	resp := e.GET("/").
+		WithHeader("Accept-Encoding", "gzip").
+		Expect().
+		Status(http.StatusOK).
+		ContentEncoding("gzip")
+
+	resp.CompressionRatio().Gt(5)
+	resp.JSON().Object().Value("foo").String().Length().Equal(300)
+
+	resp = e.GET("/").
+		WithHeader("Accept-Encoding", "identity").
+		Expect().
+		Status(http.StatusOK).
+		ContentEncoding()
+
+	resp.CompressionRatio().Equal(1)
+	resp.JSON().Object().Value("foo").Equal("bar")
+}
+
+func TestE2ECompression_Live(t *testing.T) {
+	handler := createCompressionHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testCompressionHandler(Default(t, server.URL))
+}
+
+func TestE2ECompression_Binder(t *testing.T) {
+	handler := createCompressionHandler()
+
+	testCompressionHandler(WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(handler),
+		},
+	}))
+}
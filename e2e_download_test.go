@@ -0,0 +1,150 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2EDownload_Stream(t *testing.T) {
+	chunk := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+
+	const numChunks = 64
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		for i := 0; i < numChunks; i++ {
+			_, _ = w.Write(chunk)
+			w.(http.Flusher).Flush()
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	hash := sha256.New()
+	for i := 0; i < numChunks; i++ {
+		_, _ = hash.Write(chunk)
+	}
+	expectedSum := hex.EncodeToString(hash.Sum(nil))
+
+	t.Run("success", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+		})
+
+		path := filepath.Join(dir, "artifact")
+
+		resp := e.GET("/").
+			WithDownload(path).
+			Expect().
+			Status(http.StatusOK)
+
+		resp.DownloadedLength().Equal(len(chunk) * numChunks)
+		resp.DownloadedSHA256().Equal(expectedSum)
+
+		// body is not kept in memory
+		resp.Body().Empty()
+
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk)*numChunks, len(data))
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		resp := e.GET("/").
+			WithDownload(filepath.Join(dir, "missing", "artifact")).
+			Expect()
+
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("empty path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		req := e.GET("/").WithDownload("")
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestE2EDownload_Retry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "artifact")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("previous"), 0644))
+
+	attempt := 0
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// file is not touched by retried attempts
+		data, _ := ioutil.ReadFile(path)
+		assert.Equal(t, "previous", string(data))
+
+		attempt++
+		if attempt < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("error page"))
+		} else {
+			_, _ = w.Write([]byte("artifact"))
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	resp := e.GET("/").
+		WithDownload(path).
+		WithMaxRetries(3).
+		WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
+		WithRetryDelay(0, 0).
+		Expect().
+		Status(http.StatusOK)
+
+	resp.DownloadedLength().Equal(len("artifact"))
+
+	assert.Equal(t, 3, attempt)
+
+	// file contains only body of the last attempt
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "artifact", string(data))
+
+	// temporary files of retried attempts are removed
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(files))
+}
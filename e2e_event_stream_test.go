@@ -0,0 +1,93 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func createEventStreamHandler(endless bool) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		for i := 1; i <= 3; i++ {
+			_, _ = fmt.Fprintf(w, "id: %d\nevent: progress\ndata: {\"value\": %d}\n\n",
+				i, i*10)
+			w.(http.Flusher).Flush()
+		}
+
+		if endless {
+			_, _ = w.Write([]byte(": waiting\n"))
+			w.(http.Flusher).Flush()
+
+			<-r.Context().Done()
+		}
+	})
+
+	return mux
+}
+
+func testEventStreamHandler(e *Expect, timeout time.Duration) {
+	req := e.GET("/events")
+
+	if timeout != 0 {
+		req.WithTimeout(timeout)
+	}
+
+	stream := req.
+		Expect().
+		Status(http.StatusOK).
+		EventStream()
+
+	stream.Length().Equal(3)
+
+	for i := 1; i <= 3; i++ {
+		event := stream.NextEvent()
+
+		event.ID().Equal(fmt.Sprint(i))
+		event.Name().Equal("progress")
+		event.JSON().Object().ValueEqual("value", i*10)
+	}
+}
+
+func TestE2EEventStream_Live(t *testing.T) {
+	server := httptest.NewServer(createEventStreamHandler(false))
+	defer server.Close()
+
+	testEventStreamHandler(Default(t, server.URL), 0)
+}
+
+func TestE2EEventStream_LiveTimeout(t *testing.T) {
+	server := httptest.NewServer(createEventStreamHandler(true))
+	defer server.Close()
+
+	t.Run("default", func(t *testing.T) {
+		testEventStreamHandler(Default(t, server.URL), 200*time.Millisecond)
+	})
+
+	t.Run("debug printer", func(t *testing.T) {
+		testEventStreamHandler(WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+			Printers: []Printer{
+				NewDebugPrinter(t, true),
+			},
+		}), 200*time.Millisecond)
+	})
+}
+
+func TestE2EEventStream_Binder(t *testing.T) {
+	testEventStreamHandler(WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(createEventStreamHandler(false)),
+		},
+	}), 0)
+}
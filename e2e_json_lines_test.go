@@ -0,0 +1,77 @@
+package httpexpect
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func createJSONLinesHandler(endless bool) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/lines", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+
+		for i := 1; i <= 3; i++ {
+			_, _ = fmt.Fprintf(w, "{\"value\": %d}\n", i*10)
+			w.(http.Flusher).Flush()
+		}
+
+		if endless {
+			_, _ = w.Write([]byte(`{"value": `))
+			w.(http.Flusher).Flush()
+
+			<-r.Context().Done()
+		}
+	})
+
+	return mux
+}
+
+func testJSONLinesHandler(e *Expect, timeout time.Duration) {
+	req := e.GET("/lines")
+
+	if timeout != 0 {
+		req.WithTimeout(timeout)
+	}
+
+	lines := req.
+		Expect().
+		Status(http.StatusOK).
+		JSONLines(JSONLinesOpts{MaxLines: 3})
+
+	lines.Length().Equal(3)
+
+	for i := 1; i <= 3; i++ {
+		lines.Element(i-1).Object().ValueEqual("value", i*10)
+	}
+}
+
+func TestE2EJSONLines_Live(t *testing.T) {
+	server := httptest.NewServer(createJSONLinesHandler(false))
+	defer server.Close()
+
+	testJSONLinesHandler(Default(t, server.URL), 0)
+}
+
+func TestE2EJSONLines_LiveTimeout(t *testing.T) {
+	server := httptest.NewServer(createJSONLinesHandler(true))
+	defer server.Close()
+
+	t.Run("default", func(t *testing.T) {
+		testJSONLinesHandler(Default(t, server.URL), 200*time.Millisecond)
+	})
+
+	t.Run("debug printer", func(t *testing.T) {
+		testJSONLinesHandler(WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+			Printers: []Printer{
+				NewDebugPrinter(t, true),
+			},
+		}), 200*time.Millisecond)
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -80,3 +82,47 @@ func TestE2EPrinter_Multiple(t *testing.T) {
 	assert.Equal(t, "test_request", string(p2.reqBody))
 	assert.Equal(t, "test_response", string(p2.respBody))
 }
+
+type replacingPrinter struct{}
+
+func (replacingPrinter) Request(req *http.Request) {
+	if req.Body != nil {
+		_, _ = ioutil.ReadAll(req.Body)
+		req.Body = ioutil.NopCloser(strings.NewReader(""))
+	}
+}
+
+func (replacingPrinter) Response(resp *http.Response, rtt time.Duration) {
+	if resp.Body != nil {
+		_, _ = ioutil.ReadAll(resp.Body)
+		resp.Body = ioutil.NopCloser(strings.NewReader(""))
+	}
+}
+
+func TestE2EPrinter_ReplacedBody(t *testing.T) {
+	handler := createPrinterHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	p := &mockPrinter{}
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+		Printers: []Printer{
+			replacingPrinter{},
+			p,
+			replacingPrinter{},
+		},
+	})
+
+	e.POST("/test").
+		WithText("test_request").
+		Expect().
+		Text().
+		Equal("test_response")
+
+	assert.Equal(t, "test_request", string(p.reqBody))
+	assert.Equal(t, "test_response", string(p.respBody))
+}
@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -167,6 +169,35 @@ func testRedirects(t *testing.T, createFn func(Reporter) *Expect) {
 			WithMaxRedirects(0).
 			Expect().chain.assertNotFailed(t)
 	})
+
+	t.Run("redirect-chain", func(t *testing.T) {
+		e := createFn(NewAssertReporter(t))
+
+		resp := e.POST("/double_redirect").
+			WithText(`custom_response`).
+			WithRedirectPolicy(FollowAllRedirects).
+			Expect()
+
+		resp.Status(http.StatusOK).Body().Equal(`custom_response`)
+
+		redirects := resp.Redirects()
+		require.Equal(t, 2, len(redirects))
+
+		redirects[0].Status(http.StatusTemporaryRedirect)
+		redirects[0].Header("Location").HasSuffix("/redirect308")
+
+		redirects[1].Status(http.StatusPermanentRedirect)
+		redirects[1].Header("Location").HasSuffix("/content")
+
+		assert.Equal(t, 0, len(e.GET("/redirect301").
+			WithRedirectPolicy(DontFollowRedirects).
+			Expect().
+			Redirects()))
+
+		assert.Equal(t, 0, len(e.GET("/content").
+			Expect().
+			Redirects()))
+	})
 }
 
 func TestE2ERedirect_Live(t *testing.T) {
@@ -113,3 +113,48 @@ func TestE2ETimeout_LargeBody(t *testing.T) {
 			String()
 	}
 }
+
+func TestE2ETimeout_Timings(t *testing.T) {
+	handler := createTimeoutHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	timings := e.GET("/small").
+		WithTimeout(time.Minute).
+		Expect().
+		Status(http.StatusOK).
+		Timings()
+
+	timings.Value("connect").Number().Gt(0)
+	timings.Value("ttfb").Number().Gt(0)
+	timings.Value("total").Number().Gt(0)
+}
+
+func TestE2ETimeout_Connection(t *testing.T) {
+	handler := createTimeoutHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	resp1 := e.GET("/small").
+		WithTimeout(time.Minute).
+		Expect().
+		Status(http.StatusOK)
+
+	resp1.JSON().String()
+	resp1.ConnectionReused().False()
+	resp1.RemoteAddr().Equal(server.Listener.Addr().String())
+
+	resp2 := e.GET("/small").
+		WithTimeout(time.Minute).
+		Expect().
+		Status(http.StatusOK)
+
+	resp2.ConnectionReused().True()
+	resp2.RemoteAddr().Equal(server.Listener.Addr().String())
+}
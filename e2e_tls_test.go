@@ -2,12 +2,19 @@ package httpexpect
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 )
 
@@ -138,3 +145,195 @@ func TestE2EAutoTLS_BinderFast(t *testing.T) {
 		})
 	}
 }
+
+func createMutualTLSServer(t *testing.T, clientCA []byte) *httptest.Server {
+	pool, err := NewCertPool(clientCA)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+
+	server.StartTLS()
+
+	return server
+}
+
+func TestE2EMutualTLS_Config(t *testing.T) {
+	certPEM, keyPEM := createTestCert(t, "client")
+
+	server := createMutualTLSServer(t, certPEM)
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+		TLSClientConfig: &tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: []tls.Certificate{cert},
+		},
+	})
+
+	e.GET("/").
+		Expect().
+		Status(http.StatusOK).
+		Body().Equal("client")
+}
+
+func TestE2EMutualTLS_Request(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPEM, keyPEM := createTestCert(t, "client")
+	certFile, keyFile := writeTestCert(t, dir, certPEM, keyPEM)
+
+	server := createMutualTLSServer(t, certPEM)
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: newMockReporter(t),
+	})
+
+	t.Run("with cert", func(t *testing.T) {
+		e.GET("/").
+			WithTLSConfig(&tls.Config{RootCAs: rootCAs}).
+			WithClientCert(certFile, keyFile).
+			Expect().
+			Status(http.StatusOK).
+			Body().Equal("client").
+			chain.assertNotFailed(t)
+	})
+
+	t.Run("without cert", func(t *testing.T) {
+		e.GET("/").
+			WithTLSConfig(&tls.Config{RootCAs: rootCAs}).
+			Expect().
+			chain.assertFailed(t)
+	})
+
+	t.Run("without root ca", func(t *testing.T) {
+		e.GET("/").
+			WithClientCert(certFile, keyFile).
+			Expect().
+			chain.assertFailed(t)
+	})
+}
+
+func TestE2ETLS_IdleConnections(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		conns = map[net.Conn]bool{}
+	)
+
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch state {
+		case http.StateNew:
+			conns[conn] = true
+		case http.StateClosed, http.StateHijacked:
+			delete(conns, conn)
+		}
+	}
+
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	for i := 0; i < 5; i++ {
+		e.GET("/").
+			WithTLSConfig(&tls.Config{RootCAs: rootCAs}).
+			Expect().
+			Status(http.StatusOK)
+	}
+
+	// connections of per-request transports should not be kept in pool
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(conns) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestE2ETLS_State(t *testing.T) {
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	t.Run("https", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+			Client:   server.Client(),
+		})
+
+		state := e.GET("/").
+			Expect().
+			Status(http.StatusOK).
+			TLS()
+
+		state.Version().Equal("TLS 1.3")
+		state.CipherSuite().NotEmpty()
+		state.NegotiatedProtocol().Equal("h2")
+
+		cert := state.Certificate()
+
+		cert.DNSNames().Contains("example.com")
+		cert.IPAddresses().Contains("127.0.0.1")
+		cert.NotBefore().Lt(time.Now())
+		cert.NotAfter().Gt(time.Now())
+	})
+
+	t.Run("http", func(t *testing.T) {
+		server := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		e.GET("/").
+			Expect().
+			TLS().
+			chain.assertFailed(t)
+	})
+}
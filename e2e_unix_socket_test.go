@@ -0,0 +1,95 @@
+package httpexpect
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createUnixSocketServer(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "test.sock")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/host", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	})
+
+	mux.Handle("/test", createWebsocketHandler(wsHandlerOpts{}))
+
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return path, func() {
+		_ = server.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestE2EUnixSocket_Config(t *testing.T) {
+	path, cleanup := createUnixSocketServer(t)
+	defer cleanup()
+
+	e := WithConfig(Config{
+		BaseURL:  "http://localhost",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewUnixSocketTransport(path),
+		},
+		WebsocketDialer: NewUnixSocketDialer(path),
+	})
+
+	e.GET("/host").
+		Expect().
+		Status(http.StatusOK).
+		Body().Equal("localhost")
+
+	ws := e.GET("/test").WithWebsocketUpgrade().
+		Expect().
+		Status(http.StatusSwitchingProtocols).
+		Websocket()
+	defer ws.Disconnect()
+
+	ws.WriteText("hello").
+		Expect().
+		TextMessage().Body().Equal("hello")
+}
+
+func TestE2EUnixSocket_Request(t *testing.T) {
+	path, cleanup := createUnixSocketServer(t)
+	defer cleanup()
+
+	e := Default(t, "http://example.com")
+
+	e.GET("/host").
+		WithUnixSocket(path).
+		Expect().
+		Status(http.StatusOK).
+		Body().Equal("example.com")
+
+	ws := e.GET("/test").
+		WithUnixSocket(path).
+		WithWebsocketUpgrade().
+		Expect().
+		Status(http.StatusSwitchingProtocols).
+		Websocket()
+	defer ws.Disconnect()
+
+	ws.WriteText("hello").
+		Expect().
+		TextMessage().Body().Equal("hello")
+}
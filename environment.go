@@ -1,7 +1,12 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -128,6 +133,8 @@ func (e *Environment) GetBool(key string) bool {
 //
 // If value does not exist, or is not signed or unsigned integer that can be
 // represented as int without overflow, reports failure and returns zero.
+// Integer json.Number values, stored from JSON when Config.UseJSONNumber
+// is enabled, are supported too.
 //
 // Example:
 //
@@ -182,6 +189,11 @@ func (e *Environment) GetInt(key string) int {
 		casted = int(num)
 		ok = (uint64(num) <= maxInt)
 
+	case json.Number:
+		i, err := strconv.ParseInt(string(num), 10, 64)
+		casted = int(i)
+		ok = err == nil && i >= minInt && i <= maxInt
+
 	default:
 		opChain.fail(AssertionFailure{
 			Type:   AssertType,
@@ -211,8 +223,8 @@ func (e *Environment) GetInt(key string) int {
 
 // GetFloat returns value stored in the environment, casted to float64.
 //
-// If value does not exist, or is not floating point value, reports failure
-// and returns zero value.
+// If value does not exist, or is not floating point value or json.Number,
+// reports failure and returns zero value.
 //
 // Example:
 //
@@ -235,6 +247,21 @@ func (e *Environment) GetFloat(key string) float64 {
 	case float64:
 		casted = num
 
+	case json.Number:
+		f, err := num.Float64()
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: number convertible to float64"),
+					err,
+				},
+			})
+			return 0
+		}
+		casted = f
+
 	default:
 		opChain.fail(AssertionFailure{
 			Type:   AssertType,
@@ -393,3 +420,71 @@ func envValue(chain *chain, env map[string]interface{}, key string) (interface{}
 
 	return v, true
 }
+
+// Substitute "{key}" placeholders in template with values stored in
+// environment. Non-string values are formatted using envFormat.
+func envExpand(chain *chain, env *Environment, template string) (string, bool) {
+	var b strings.Builder
+
+	rest := template
+
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			if strings.IndexByte(rest, '}') >= 0 {
+				break
+			}
+			b.WriteString(rest)
+			return b.String(), true
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 || strings.IndexByte(rest[:start], '}') >= 0 {
+			break
+		}
+		end += start
+
+		key := rest[start+1 : end]
+		if key == "" || strings.IndexByte(key, '{') >= 0 {
+			break
+		}
+
+		value, ok := envValue(chain, env.data, key)
+		if !ok {
+			return "", false
+		}
+
+		b.WriteString(rest[:start])
+		b.WriteString(envFormat(value))
+
+		rest = rest[end+1:]
+	}
+
+	chain.fail(AssertionFailure{
+		Type: AssertUsage,
+		Errors: []error{
+			fmt.Errorf("invalid environment template %q", template),
+		},
+	})
+
+	return "", false
+}
+
+// Format environment value for substitution into string.
+// Integral floats, like JSON numbers stored using Value.Store, are
+// formatted without exponent, e.g. 12345678 instead of 1.2345678e+07.
+func envFormat(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case float32:
+		if v == float32(math.Trunc(float64(v))) && !math.IsInf(float64(v), 0) {
+			return strconv.FormatFloat(float64(v), 'f', -1, 32)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -1,8 +1,10 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"testing"
 	"time"
 
@@ -452,3 +454,84 @@ func TestEnvironment_Time(t *testing.T) {
 			})
 	}
 }
+
+func TestEnvironment_Expand(t *testing.T) {
+	tests := []struct {
+		template string
+		result   string
+		ok       bool
+	}{
+		{"", "", true},
+		{"plain", "plain", true},
+		{"{str}", "foo", true},
+		{"Bearer {str}", "Bearer foo", true},
+		{"{str}-{num}-{bool}", "foo-123-true", true},
+		{"{id}", "12345678", true},
+		{"{big}", "9007199254740993", true},
+		{"{frac}", "0.5", true},
+		{"{a.b}", "dotted", true},
+		{"{missing}", "", false},
+		{"{str", "", false},
+		{"str}", "", false},
+		{"}{str}", "", false},
+		{"{}", "", false},
+		{"{{str}}", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			chain := newMockChain(t)
+
+			env := newEnvironment(chain)
+			env.Put("str", "foo")
+			env.Put("num", 123.0)
+			env.Put("bool", true)
+			env.Put("a.b", "dotted")
+			env.Put("id", 12345678.0)
+			env.Put("big", json.Number("9007199254740993"))
+			env.Put("frac", 0.5)
+
+			opChain := chain.enter("test")
+			result, ok := envExpand(opChain, env, tt.template)
+			opChain.leave()
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.result, result)
+
+			if tt.ok {
+				chain.assertNotFailed(t)
+			} else {
+				chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestEnvironment_JSONNumber(t *testing.T) {
+	config := newMockConfig(newMockReporter(t))
+	config.UseJSONNumber = true
+
+	env := NewEnvironment(config.Reporter)
+
+	config.Environment = env
+
+	NewValueC(config, map[string]interface{}{
+		"small": 123,
+		"large": int64(9007199254740993),
+	}).Object().Value("large").Store("large")
+
+	env.Put("small", json.Number("123"))
+
+	assert.Equal(t, 123, env.GetInt("small"))
+	assert.Equal(t, 123.0, env.GetFloat("small"))
+
+	if strconv.IntSize == 64 {
+		assert.Equal(t, int64(9007199254740993), int64(env.GetInt("large")))
+	}
+	assert.Equal(t, 9007199254740992.0, env.GetFloat("large"))
+
+	env.chain.assertNotFailed(t)
+
+	env.Put("bad", json.Number("1.5"))
+	env.GetInt("bad")
+	env.chain.assertFailed(t)
+}
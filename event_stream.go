@@ -0,0 +1,375 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventStream provides methods to inspect Server-Sent Events stream,
+// i.e. sequence of events parsed from "text/event-stream" body.
+//
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html.
+//
+// Event stream is usually endless; to read it, set request timeout.
+// When timeout expires while reading event stream body, events received
+// before timeout are available and no failure is reported.
+//
+// Example:
+//
+//	stream := e.GET("/events").
+//		WithTimeout(time.Second).
+//		Expect().
+//		Status(http.StatusOK).
+//		EventStream()
+//
+//	stream.NextEvent().Name().Equal("status")
+//	stream.NextEvent().JSON().Object().ValueEqual("progress", 100)
+type EventStream struct {
+	noCopy noCopy
+	chain  *chain
+	events []*eventStreamEvent
+	next   int
+}
+
+type eventStreamEvent struct {
+	id    string
+	name  string
+	data  string
+	retry *time.Duration
+}
+
+// NewEventStream returns a new EventStream instance.
+//
+// If reporter is nil, the function panics.
+// Body may be nil.
+//
+// Example:
+//
+//	stream := NewEventStream(t, []byte("event: ping\ndata: hello\n\n"))
+//	stream.NextEvent().Data().Equal("hello")
+func NewEventStream(reporter Reporter, body []byte) *EventStream {
+	return newEventStream(newChainWithDefaults("EventStream()", reporter), body)
+}
+
+// NewEventStreamC returns a new EventStream instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// Body may be nil.
+//
+// Example:
+//
+//	stream := NewEventStreamC(config, []byte("event: ping\ndata: hello\n\n"))
+//	stream.NextEvent().Data().Equal("hello")
+func NewEventStreamC(config Config, body []byte) *EventStream {
+	return newEventStream(
+		newChainWithConfig("EventStream()", config.withDefaults()), body)
+}
+
+func newEventStream(parent *chain, body []byte) *EventStream {
+	s := &EventStream{chain: parent.clone()}
+
+	opChain := s.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	s.events = parseEventStream(body)
+
+	return s
+}
+
+// Length returns a new Number instance with number of events in stream.
+//
+// Example:
+//
+//	stream := NewEventStream(t, body)
+//	stream.Length().Equal(3)
+func (s *EventStream) Length() *Number {
+	opChain := s.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(s.events)))
+}
+
+// Event returns a new Event instance for event with given index.
+//
+// If index is out of bounds, Event reports failure and returns empty
+// (but non-nil) instance.
+//
+// Example:
+//
+//	stream := NewEventStream(t, body)
+//	stream.Event(0).Name().Equal("status")
+func (s *EventStream) Event(index int) *Event {
+	opChain := s.chain.enter("Event(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newEvent(opChain, nil)
+	}
+
+	if index < 0 || index >= len(s.events) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(s.events) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid event index"),
+			},
+		})
+		return newEvent(opChain, nil)
+	}
+
+	return newEvent(opChain, s.events[index])
+}
+
+// NextEvent returns a new Event instance for next event in stream.
+//
+// First call returns first event, second call returns second event,
+// and so on. If there are no more events, NextEvent reports failure
+// and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	stream := NewEventStream(t, body)
+//	stream.NextEvent().Name().Equal("started")
+//	stream.NextEvent().Name().Equal("finished")
+func (s *EventStream) NextEvent() *Event {
+	opChain := s.chain.enter("NextEvent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newEvent(opChain, nil)
+	}
+
+	if s.next >= len(s.events) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertLt,
+			Actual: &AssertionValue{s.next},
+			Expected: &AssertionValue{
+				len(s.events),
+			},
+			Errors: []error{
+				errors.New("expected: event stream has more events"),
+			},
+		})
+		return newEvent(opChain, nil)
+	}
+
+	event := s.events[s.next]
+	s.next++
+
+	return newEvent(opChain, event)
+}
+
+// parse events according to "text/event-stream" interpretation rules;
+// incomplete event at the end of stream is discarded
+func parseEventStream(body []byte) []*eventStreamEvent {
+	events := []*eventStreamEvent{}
+
+	var (
+		lastID string
+		name   string
+		data   bytes.Buffer
+		retry  *time.Duration
+	)
+
+	body = bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n"))
+	body = bytes.ReplaceAll(body, []byte("\r"), []byte("\n"))
+
+	lines := strings.Split(string(body), "\n")
+
+	// last element is either empty or incomplete line
+	for _, line := range lines[:len(lines)-1] {
+		if line == "" {
+			if data.Len() != 0 {
+				if name == "" {
+					name = "message"
+				}
+				events = append(events, &eventStreamEvent{
+					id:    lastID,
+					name:  name,
+					data:  strings.TrimSuffix(data.String(), "\n"),
+					retry: retry,
+				})
+			}
+			name = ""
+			data.Reset()
+			retry = nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+
+		switch field {
+		case "event":
+			name = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 63); err == nil {
+				d := time.Duration(ms) * time.Millisecond
+				retry = &d
+			}
+		}
+	}
+
+	return events
+}
+
+// Event provides methods to inspect single Server-Sent Event.
+type Event struct {
+	noCopy noCopy
+	chain  *chain
+	value  *eventStreamEvent
+}
+
+func newEvent(parent *chain, val *eventStreamEvent) *Event {
+	e := &Event{chain: parent.clone(), value: val}
+
+	if val == nil {
+		e.value = &eventStreamEvent{}
+	}
+
+	return e
+}
+
+// Raw returns id, name (type) and data of event.
+//
+// Example:
+//
+//	id, name, data := stream.NextEvent().Raw()
+func (e *Event) Raw() (id, name, data string) {
+	return e.value.id, e.value.name, e.value.data
+}
+
+// ID returns a new String instance with event id.
+//
+// If event has no "id" field, it inherits id of previous event;
+// if there is no such event, id is empty.
+//
+// Example:
+//
+//	stream.NextEvent().ID().Equal("42")
+func (e *Event) ID() *String {
+	opChain := e.chain.enter("ID()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, e.value.id)
+}
+
+// Name returns a new String instance with event name (type).
+//
+// If event has no "event" field, name is "message".
+//
+// Example:
+//
+//	stream.NextEvent().Name().Equal("status")
+func (e *Event) Name() *String {
+	opChain := e.chain.enter("Name()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, e.value.name)
+}
+
+// Data returns a new String instance with event data.
+//
+// If event has multiple "data" fields, they are joined with newlines.
+//
+// Example:
+//
+//	stream.NextEvent().Data().Equal("hello")
+func (e *Event) Data() *String {
+	opChain := e.chain.enter("Data()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, e.value.data)
+}
+
+// JSON returns a new Value instance with JSON decoded from event data.
+//
+// Example:
+//
+//	stream.NextEvent().JSON().Object().ValueEqual("progress", 100)
+func (e *Event) JSON() *Value {
+	opChain := e.chain.enter("JSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal([]byte(e.value.data), &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				e.value.data,
+			},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, value)
+}
+
+// Retry returns a new Duration instance with reconnection time from
+// event "retry" field.
+//
+// If event has no "retry" field, returned Duration is not set.
+//
+// Example:
+//
+//	stream.NextEvent().Retry().Equal(time.Second * 3)
+func (e *Event) Retry() *Duration {
+	opChain := e.chain.enter("Retry()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, e.value.retry)
+}
@@ -0,0 +1,210 @@
+package httpexpect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStream_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newEventStream(chain, []byte("data: foo\n\n"))
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Length())
+	assert.NotNil(t, value.Event(0))
+	assert.NotNil(t, value.NextEvent())
+
+	event := value.NextEvent()
+
+	assert.NotNil(t, event.ID())
+	assert.NotNil(t, event.Name())
+	assert.NotNil(t, event.Data())
+	assert.NotNil(t, event.JSON())
+	assert.NotNil(t, event.Retry())
+
+	event.chain.assertFailed(t)
+	event.Data().chain.assertFailed(t)
+	event.JSON().chain.assertFailed(t)
+}
+
+func TestEventStream_Constructors(t *testing.T) {
+	body := []byte("data: foo\n\n")
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewEventStream(reporter, body)
+		value.Length().Equal(1)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewEventStreamC(Config{
+			Reporter: reporter,
+		}, body)
+		value.Length().Equal(1)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newEventStream(chain, body)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestEventStream_Parse(t *testing.T) {
+	type event struct {
+		id    string
+		name  string
+		data  string
+		retry time.Duration
+	}
+
+	cases := []struct {
+		name   string
+		body   string
+		events []event
+	}{
+		{
+			name:   "empty",
+			body:   "",
+			events: []event{},
+		},
+		{
+			name: "single",
+			body: "data: hello\n\n",
+			events: []event{
+				{name: "message", data: "hello"},
+			},
+		},
+		{
+			name: "fields",
+			body: "id: 1\nevent: status\nretry: 3000\ndata: {\"a\": 1}\n\n",
+			events: []event{
+				{id: "1", name: "status", data: `{"a": 1}`, retry: 3 * time.Second},
+			},
+		},
+		{
+			name: "multiline data",
+			body: "data: foo\ndata:bar\ndata\n\n",
+			events: []event{
+				{name: "message", data: "foo\nbar\n"},
+			},
+		},
+		{
+			name: "comments and unknown fields",
+			body: ": keep-alive\nfoo: bar\ndata: x\n\n:ping\n\n",
+			events: []event{
+				{name: "message", data: "x"},
+			},
+		},
+		{
+			name: "id inherited",
+			body: "id: 5\ndata: a\n\ndata: b\n\nid\ndata: c\n\n",
+			events: []event{
+				{id: "5", name: "message", data: "a"},
+				{id: "5", name: "message", data: "b"},
+				{id: "", name: "message", data: "c"},
+			},
+		},
+		{
+			name: "no data",
+			body: "event: ping\n\ndata: a\n\n",
+			events: []event{
+				{name: "message", data: "a"},
+			},
+		},
+		{
+			name: "crlf",
+			body: "event: a\r\ndata: 1\r\n\r\nevent: b\rdata: 2\r\r",
+			events: []event{
+				{name: "a", data: "1"},
+				{name: "b", data: "2"},
+			},
+		},
+		{
+			name: "incomplete",
+			body: "data: a\n\ndata: b\n",
+			events: []event{
+				{name: "message", data: "a"},
+			},
+		},
+		{
+			name: "bad retry",
+			body: "retry: 1s\ndata: a\n\n",
+			events: []event{
+				{name: "message", data: "a"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := parseEventStream([]byte(tc.body))
+
+			actual := []event{}
+			for _, e := range events {
+				ev := event{id: e.id, name: e.name, data: e.data}
+				if e.retry != nil {
+					ev.retry = *e.retry
+				}
+				actual = append(actual, ev)
+			}
+
+			assert.Equal(t, tc.events, actual)
+		})
+	}
+}
+
+func TestEventStream_NextEvent(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	stream := NewEventStream(reporter, []byte(
+		"event: start\ndata: {\"n\": 1}\n\n"+
+			"id: 2\nretry: 100\ndata: two\n\n"))
+
+	stream.Length().Equal(2)
+
+	event := stream.NextEvent()
+	event.Name().Equal("start")
+	event.ID().Empty()
+	event.JSON().Object().ValueEqual("n", 1)
+	event.Retry().NotSet()
+	event.chain.assertNotFailed(t)
+
+	id, name, data := event.Raw()
+	assert.Equal(t, "", id)
+	assert.Equal(t, "start", name)
+	assert.Equal(t, `{"n": 1}`, data)
+
+	event = stream.NextEvent()
+	event.Name().Equal("message")
+	event.ID().Equal("2")
+	event.Data().Equal("two")
+	event.Retry().Equal(100 * time.Millisecond)
+	event.chain.assertNotFailed(t)
+
+	event.JSON().chain.assertFailed(t)
+	stream.chain.clearFailed()
+
+	stream.Event(0).Name().Equal("start")
+	stream.Event(1).Data().Equal("two")
+	stream.chain.assertNotFailed(t)
+
+	event = stream.NextEvent()
+	event.chain.assertFailed(t)
+	stream.chain.assertFailed(t)
+	stream.chain.clearFailed()
+
+	stream.Event(2).chain.assertFailed(t)
+	stream.chain.clearFailed()
+
+	stream.Event(-1).chain.assertFailed(t)
+}
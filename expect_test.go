@@ -1,11 +1,13 @@
 package httpexpect
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -44,6 +46,35 @@ func TestExpect_Methods(t *testing.T) {
 	assert.Equal(t, "DELETE", reqs[7].httpReq.Method)
 }
 
+func TestExpect_PathTemplate(t *testing.T) {
+	client := &mockClient{}
+
+	reporter := NewAssertReporter(t)
+
+	config := Config{
+		BaseURL:  "http://example.com",
+		Client:   client,
+		Reporter: reporter,
+	}
+
+	e := WithConfig(config)
+
+	req1 := e.GET("/users/{id}", 42)
+	assert.Equal(t, "/users/42", req1.path)
+	assert.Equal(t,
+		[]string{`Request("GET", "/users/{id}")`}, req1.chain.context.Path)
+
+	req2 := e.GET("/users/{id}").WithPath("id", 43)
+	assert.Equal(t, "/users/43", req2.path)
+	assert.Equal(t,
+		[]string{`Request("GET", "/users/{id}")`}, req2.chain.context.Path)
+
+	req3 := NewRequestC(config, "GET", "/users/{id}", 44)
+	assert.Equal(t, "/users/44", req3.path)
+	assert.Equal(t,
+		[]string{`Request("GET", "/users/{id}")`}, req3.chain.context.Path)
+}
+
 func TestExpect_Builders(t *testing.T) {
 	client := &mockClient{}
 
@@ -236,6 +267,253 @@ func TestExpect_MatchersCopying(t *testing.T) {
 	assert.Equal(t, 1, counter2b)
 }
 
+func TestExpect_Eventually(t *testing.T) {
+	newExpect := func(t *testing.T, doneAfter int) (*Expect, *mockReporter, *int) {
+		counter := 0
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			counter++
+			if counter >= doneAfter {
+				_, _ = w.Write([]byte("done"))
+			} else {
+				_, _ = w.Write([]byte("pending"))
+			}
+		})
+
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		})
+
+		return e, reporter, &counter
+	}
+
+	check := func(e *Expect) {
+		e.GET("/job").
+			Expect().
+			Status(http.StatusOK).
+			Body().Equal("done")
+	}
+
+	t.Run("succeeds immediately", func(t *testing.T) {
+		e, reporter, counter := newExpect(t, 1)
+
+		e.Eventually(time.Millisecond, time.Second, check)
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 1, *counter)
+		e.chain.assertNotFailed(t)
+	})
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		e, reporter, counter := newExpect(t, 3)
+
+		e.Eventually(time.Millisecond, time.Second, check)
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 3, *counter)
+		e.chain.assertNotFailed(t)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		e, reporter, counter := newExpect(t, 1000)
+
+		e.Eventually(time.Millisecond, 20*time.Millisecond, check)
+
+		assert.True(t, reporter.reported)
+		assert.Greater(t, *counter, 1)
+		e.chain.assertFailed(t)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		e, reporter, counter := newExpect(t, 1000)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		e.config.Context = ctx
+
+		e.Eventually(time.Millisecond, time.Hour, check)
+
+		// one attempt before noticing cancellation, and the last one
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 2, *counter)
+		e.chain.assertFailed(t)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		e, reporter, _ := newExpect(t, 1)
+
+		e.Eventually(time.Millisecond, time.Second, nil)
+
+		assert.True(t, reporter.reported)
+		e.chain.assertFailed(t)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			interval time.Duration
+			timeout  time.Duration
+		}{
+			{"zero interval", 0, time.Second},
+			{"negative interval", -time.Millisecond, time.Second},
+			{"zero timeout", time.Millisecond, 0},
+			{"negative timeout", time.Millisecond, -time.Second},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				e, reporter, counter := newExpect(t, 1)
+
+				e.Eventually(tc.interval, tc.timeout, check)
+
+				assert.True(t, reporter.reported)
+				assert.Equal(t, 0, *counter)
+				e.chain.assertFailed(t)
+			})
+		}
+	})
+
+	t.Run("failed chain", func(t *testing.T) {
+		e, reporter, counter := newExpect(t, 1)
+
+		e.chain.setFailed()
+
+		e.Eventually(time.Millisecond, time.Second, check)
+
+		assert.False(t, reporter.reported)
+		assert.Equal(t, 0, *counter)
+	})
+}
+
+func TestExpect_CookieJar(t *testing.T) {
+	handler := http.NewServeMux()
+
+	handler.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "123", Path: "/"})
+		http.SetCookie(w, &http.Cookie{Name: "admin", Value: "1", Path: "/admin"})
+	})
+
+	handler.HandleFunc("/restricted", func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err != nil || c.Value != "123" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	newExpect := func(t *testing.T, jar http.CookieJar) (*Expect, *mockReporter) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+				Jar:       jar,
+			},
+		})
+
+		return e, reporter
+	}
+
+	t.Run("session", func(t *testing.T) {
+		e, reporter := newExpect(t, nil)
+
+		assert.Nil(t, e.CookieJar())
+
+		e.GET("/restricted").Expect().Status(http.StatusUnauthorized)
+
+		session := e.WithCookieJar(nil)
+		assert.NotNil(t, session.CookieJar())
+		assert.Nil(t, e.CookieJar())
+
+		session.POST("/login").Expect().Status(http.StatusOK)
+		session.GET("/restricted").Expect().Status(http.StatusOK)
+
+		session.Cookies("/").ContainsOnly("session")
+		session.Cookies("/admin/users").ContainsOnly("session", "admin")
+		session.Cookies("http://example.com/admin").ContainsOnly("session", "admin")
+		session.Cookies("http://example.org/").Empty()
+
+		session.Cookie("/", "session").Value().Equal("123")
+		session.Cookie("/admin", "admin").Value().Equal("1")
+
+		assert.False(t, reporter.reported)
+		session.chain.assertNotFailed(t)
+	})
+
+	t.Run("existing jar", func(t *testing.T) {
+		jar := NewCookieJar()
+
+		e, reporter := newExpect(t, jar)
+
+		assert.Same(t, jar, e.CookieJar())
+
+		e.POST("/login").Expect().Status(http.StatusOK)
+		e.Cookie("/", "session").Value().Equal("123")
+
+		other := e.WithCookieJar(NewCookieJar())
+		assert.NotSame(t, jar, other.CookieJar())
+		other.Cookies("/").Empty()
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("missing cookie", func(t *testing.T) {
+		e, reporter := newExpect(t, NewCookieJar())
+
+		cookie := e.Cookie("/", "session")
+
+		assert.True(t, reporter.reported)
+		cookie.chain.assertFailed(t)
+	})
+
+	t.Run("no jar", func(t *testing.T) {
+		e, reporter := newExpect(t, nil)
+
+		arr := e.Cookies("/")
+
+		assert.True(t, reporter.reported)
+		arr.chain.assertFailed(t)
+
+		e, reporter = newExpect(t, nil)
+
+		cookie := e.Cookie("/", "session")
+
+		assert.True(t, reporter.reported)
+		cookie.chain.assertFailed(t)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		e, reporter := newExpect(t, NewCookieJar())
+
+		arr := e.Cookies("http://[::1")
+
+		assert.True(t, reporter.reported)
+		arr.chain.assertFailed(t)
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Reporter: reporter,
+			Client:   &mockClient{},
+		})
+
+		assert.Nil(t, e.CookieJar())
+
+		e.WithCookieJar(nil)
+
+		assert.True(t, reporter.reported)
+	})
+}
+
 func TestExpect_Values(t *testing.T) {
 	client := &mockClient{}
 
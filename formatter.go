@@ -42,6 +42,24 @@ type DefaultFormatter struct {
 	// Exclude diff from failure report.
 	DisableDiffs bool
 
+	// Include dump of originating request and response into failure report,
+	// so that failure message is self-contained without enabling printers.
+	// Dump includes method, URL, trace context (see Request.WithTraceContext),
+	// status, and response body excerpt.
+	EnableDumps bool
+
+	// Maximum number of body bytes included into dump when EnableDumps is set.
+	// Use zero for default limit, and negative value to disable truncation.
+	DumpBodyLimit int
+
+	// Colorize diff in failure report using ANSI escape sequences.
+	// Useful when failure messages are printed to a terminal.
+	EnableColors bool
+
+	// If not nil, used to hide sensitive data in failure report.
+	// See Redactor for details.
+	Redactor *Redactor
+
 	// Wrap text to keep lines below given width.
 	// Use zero for default width, and negative value to disable wrapping.
 	LineWidth int
@@ -114,6 +132,12 @@ type FormatData struct {
 	HaveDiff bool
 	Diff     string
 
+	HaveRequest bool
+	Request     string
+
+	HaveResponse bool
+	Response     string
+
 	LineWidth int
 }
 
@@ -163,6 +187,10 @@ func (f *DefaultFormatter) buildFormatData(
 	f.fillDescription(&data, ctx)
 
 	if failure != nil {
+		if f.Redactor != nil {
+			failure = f.redactFailure(failure)
+		}
+
 		data.AssertType = failure.Type.String()
 		data.AssertSeverity = failure.Severity.String()
 
@@ -185,11 +213,53 @@ func (f *DefaultFormatter) buildFormatData(
 		if failure.Delta != nil {
 			f.fillDelta(&data, ctx, failure)
 		}
+
+		if f.EnableDumps {
+			f.fillDumps(&data, ctx, failure)
+		}
+
+		if f.Redactor != nil {
+			f.redactData(&data)
+		}
 	}
 
 	return &data
 }
 
+func (f *DefaultFormatter) redactFailure(failure *AssertionFailure) *AssertionFailure {
+	redactValue := func(value *AssertionValue) *AssertionValue {
+		if value == nil {
+			return nil
+		}
+		return &AssertionValue{f.Redactor.redactValue(value.Value)}
+	}
+
+	result := *failure
+
+	result.Actual = redactValue(failure.Actual)
+	result.Expected = redactValue(failure.Expected)
+	result.Reference = redactValue(failure.Reference)
+	result.Delta = redactValue(failure.Delta)
+
+	return &result
+}
+
+func (f *DefaultFormatter) redactData(data *FormatData) {
+	for i := range data.Errors {
+		data.Errors[i] = f.Redactor.RedactString(data.Errors[i])
+	}
+
+	data.Actual = f.Redactor.RedactString(data.Actual)
+
+	for i := range data.Expected {
+		data.Expected[i] = f.Redactor.RedactString(data.Expected[i])
+	}
+
+	data.Reference = f.Redactor.RedactString(data.Reference)
+	data.Delta = f.Redactor.RedactString(data.Delta)
+	data.Diff = f.Redactor.RedactString(data.Diff)
+}
+
 func (f *DefaultFormatter) fillDescription(
 	data *FormatData, ctx *AssertionContext,
 ) {
@@ -199,7 +269,11 @@ func (f *DefaultFormatter) fillDescription(
 	}
 
 	if !f.DisablePaths {
-		data.AssertPath = ctx.Path
+		if len(ctx.AliasedPath) != 0 {
+			data.AssertPath = ctx.AliasedPath
+		} else {
+			data.AssertPath = ctx.Path
+		}
 	}
 
 	if f.LineWidth != 0 {
@@ -209,6 +283,33 @@ func (f *DefaultFormatter) fillDescription(
 	}
 }
 
+func (f *DefaultFormatter) fillDumps(
+	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
+) {
+	var httpReq *http.Request
+	if ctx.Request != nil {
+		httpReq = ctx.Request.httpReq
+	}
+
+	var httpResp *http.Response
+	if ctx.Response != nil {
+		httpResp = ctx.Response.httpResp
+		if httpReq == nil && httpResp != nil {
+			httpReq = httpResp.Request
+		}
+	}
+
+	if httpReq != nil && httpReq.URL != nil {
+		data.HaveRequest = true
+		data.Request = f.formatRequestDump(httpReq)
+	}
+
+	if httpResp != nil {
+		data.HaveResponse = true
+		data.Response = f.formatResponseDump(httpResp, ctx.Response.content)
+	}
+}
+
 func (f *DefaultFormatter) fillErrors(
 	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
 ) {
@@ -324,6 +425,12 @@ func (f *DefaultFormatter) fillExpected(
 			f.formatValue(failure.Expected.Value),
 		}
 
+		if failure.Type == AssertContainsSubset &&
+			!f.DisableDiffs && failure.Actual != nil && failure.Expected != nil {
+			data.Diff, data.HaveDiff = f.formatSubsetDiff(
+				failure.Expected.Value, failure.Actual.Value)
+		}
+
 	case AssertBelongs, AssertNotBelongs:
 		data.HaveExpected = true
 		data.ExpectedKind = kindValueList
@@ -402,6 +509,60 @@ func (f *DefaultFormatter) formatTyped(value interface{}) string {
 	return fmt.Sprintf("%T(%#v)", value, value)
 }
 
+func (f *DefaultFormatter) formatRequestDump(req *http.Request) string {
+	dump := req.Method + " " + req.URL.String()
+
+	if traceparent := req.Header.Get(traceparentHeader); traceparent != "" {
+		dump += "\ntraceparent: " + traceparent
+	}
+
+	if f.Redactor != nil {
+		dump = f.Redactor.RedactString(dump)
+	}
+
+	return dump
+}
+
+func (f *DefaultFormatter) formatResponseDump(
+	resp *http.Response, body []byte,
+) string {
+	var sb strings.Builder
+
+	sb.WriteString(resp.Status)
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		sb.WriteString("\nContent-Type: ")
+		sb.WriteString(contentType)
+	}
+
+	if len(body) != 0 {
+		if f.Redactor != nil {
+			body = f.Redactor.RedactBody(body)
+		}
+
+		limit := f.DumpBodyLimit
+		if limit == 0 {
+			limit = defaultDumpBodyLimit
+		}
+
+		sb.WriteString("\n\n")
+		if limit > 0 && len(body) > limit {
+			sb.Write(body[:limit])
+			sb.WriteString(fmt.Sprintf("... (%d bytes truncated)", len(body)-limit))
+		} else {
+			sb.Write(body)
+		}
+	}
+
+	dump := sb.String()
+
+	if f.Redactor != nil {
+		dump = f.Redactor.RedactString(dump)
+	}
+
+	return dump
+}
+
 func (f *DefaultFormatter) formatValue(value interface{}) string {
 	if isNumber(value) {
 		return fmt.Sprintf("%v", value)
@@ -494,6 +655,7 @@ func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, boo
 
 	config := formatter.AsciiFormatterConfig{
 		ShowArrayIndex: true,
+		Coloring:       f.EnableColors,
 	}
 	fa := formatter.NewAsciiFormatter(expected, config)
 
@@ -507,6 +669,47 @@ func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, boo
 	return diffText, true
 }
 
+// formatSubsetDiff is like formatDiff, but takes into account only those
+// keys of actual that are present in expected, so that the diff shows only
+// mismatching keys and not everything that was left out of the subset.
+func (f *DefaultFormatter) formatSubsetDiff(
+	expected, actual interface{},
+) (string, bool) {
+	ve, ok := expected.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	va, ok := actual.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	return f.formatDiff(ve, extractSubset(va, ve))
+}
+
+func extractSubset(outer, inner map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(inner))
+
+	for k, iv := range inner {
+		ov, ok := outer[k]
+		if !ok {
+			continue
+		}
+
+		if ovm, ok := ov.(map[string]interface{}); ok {
+			if ivm, ok := iv.(map[string]interface{}); ok {
+				out[k] = extractSubset(ovm, ivm)
+				continue
+			}
+		}
+
+		out[k] = ov
+	}
+
+	return out
+}
+
 func exctractRange(value interface{}) *AssertionRange {
 	switch rng := value.(type) {
 	case AssertionRange:
@@ -561,6 +764,8 @@ func isHTTP(value interface{}) bool {
 const (
 	defaultIndent    = "  "
 	defaultLineWidth = 60
+
+	defaultDumpBodyLimit = 1024
 )
 
 var defaultTemplateFuncs = template.FuncMap{
@@ -674,4 +879,14 @@ allowed delta:
 diff:
 {{ .Diff | indent }}
 {{- end -}}
+{{- if .HaveRequest }}
+
+request:
+{{ .Request | indent }}
+{{- end -}}
+{{- if .HaveResponse }}
+
+response:
+{{ .Response | indent }}
+{{- end -}}
 `
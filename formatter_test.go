@@ -2,6 +2,8 @@ package httpexpect
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -96,6 +98,72 @@ func TestFormat_Diff(t *testing.T) {
 	checkOK([]interface{}{"a"}, []interface{}{})
 }
 
+func TestFormat_DiffColors(t *testing.T) {
+	expected := map[string]interface{}{"a": 1.0, "b": 2.0}
+	actual := map[string]interface{}{"a": 1.0, "b": 3.0}
+
+	t.Run("disabled", func(t *testing.T) {
+		f := &DefaultFormatter{}
+
+		s, ok := f.formatDiff(expected, actual)
+		assert.True(t, ok)
+		assert.NotContains(t, s, "\x1b[")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		f := &DefaultFormatter{
+			EnableColors: true,
+		}
+
+		s, ok := f.formatDiff(expected, actual)
+		assert.True(t, ok)
+		assert.Contains(t, s, "\x1b[")
+	})
+}
+
+func TestFormat_SubsetDiff(t *testing.T) {
+	checkOK := func(a, b interface{}) string {
+		s, ok := mockDefaultFormatter.formatSubsetDiff(a, b)
+		assert.True(t, ok)
+		assert.NotEqual(t, "", s)
+		return s
+	}
+
+	checkNotOK := func(a, b interface{}) {
+		s, ok := mockDefaultFormatter.formatSubsetDiff(a, b)
+		assert.False(t, ok)
+		assert.Equal(t, "", s)
+	}
+
+	checkNotOK(map[string]interface{}{}, []interface{}{})
+	checkNotOK([]interface{}{}, map[string]interface{}{})
+	checkNotOK("foo", "bar")
+
+	checkNotOK(
+		map[string]interface{}{"a": 1.0},
+		map[string]interface{}{"a": 1.0, "b": 2.0})
+	checkNotOK(
+		map[string]interface{}{"a": map[string]interface{}{"x": 1.0}},
+		map[string]interface{}{"a": map[string]interface{}{"x": 1.0, "y": 2.0}})
+
+	s := checkOK(
+		map[string]interface{}{
+			"a": map[string]interface{}{"x": 1.0},
+			"c": 3.0,
+		},
+		map[string]interface{}{
+			"a":      map[string]interface{}{"x": 2.0, "y": 2.0},
+			"b":      2.0,
+			"ignore": "me",
+		})
+
+	assert.Contains(t, s, `"x"`)
+	assert.Contains(t, s, `"c"`)
+	assert.NotContains(t, s, `"y"`)
+	assert.NotContains(t, s, `"b"`)
+	assert.NotContains(t, s, `"ignore"`)
+}
+
 func TestFormat_FailureActual(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -551,3 +619,108 @@ func TestFormat_FailureDelta(t *testing.T) {
 		})
 	}
 }
+
+func TestFormat_FailureDumps(t *testing.T) {
+	httpReq, _ := http.NewRequest("GET", "http://example.com/users?id=1", nil)
+
+	httpResp := &http.Response{
+		Status:  "404 Not Found",
+		Header:  http.Header{"Content-Type": {"application/json"}},
+		Request: httpReq,
+	}
+
+	ctx := &AssertionContext{
+		Response: &Response{
+			httpResp: httpResp,
+			content:  []byte(`{"error":"not found"}`),
+		},
+	}
+
+	fl := &AssertionFailure{
+		Type: AssertOperation,
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		df := &DefaultFormatter{}
+
+		fd := df.buildFormatData(ctx, fl)
+		assert.False(t, fd.HaveRequest)
+		assert.False(t, fd.HaveResponse)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		df := &DefaultFormatter{
+			EnableDumps: true,
+		}
+
+		fd := df.buildFormatData(ctx, fl)
+		assert.True(t, fd.HaveRequest)
+		assert.Equal(t, "GET http://example.com/users?id=1", fd.Request)
+		assert.True(t, fd.HaveResponse)
+		assert.Equal(t,
+			"404 Not Found\nContent-Type: application/json\n\n"+
+				`{"error":"not found"}`,
+			fd.Response)
+
+		msg := df.FormatFailure(ctx, fl)
+		assert.Contains(t, msg, "request:\n  GET http://example.com/users?id=1")
+		assert.Contains(t, msg, "response:\n  404 Not Found")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		df := &DefaultFormatter{
+			EnableDumps:   true,
+			DumpBodyLimit: 5,
+		}
+
+		fd := df.buildFormatData(ctx, fl)
+		assert.Equal(t,
+			"404 Not Found\nContent-Type: application/json\n\n"+
+				`{"err... (16 bytes truncated)`,
+			fd.Response)
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		df := &DefaultFormatter{
+			EnableDumps: true,
+			Redactor: &Redactor{
+				JSONKeys: []string{"error"},
+				Patterns: []*regexp.Regexp{regexp.MustCompile(`id=\d+`)},
+			},
+		}
+
+		fd := df.buildFormatData(ctx, fl)
+		assert.NotContains(t, fd.Request, "id=1")
+		assert.NotContains(t, fd.Response, "not found")
+	})
+
+	t.Run("trace context", func(t *testing.T) {
+		df := &DefaultFormatter{
+			EnableDumps: true,
+		}
+
+		tracedReq, _ := http.NewRequest("GET", "http://example.com", nil)
+		tracedReq.Header.Set("traceparent",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		fd := df.buildFormatData(&AssertionContext{
+			Request: &Request{httpReq: tracedReq},
+		}, fl)
+		assert.Equal(t,
+			"GET http://example.com\n"+
+				"traceparent: 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			fd.Request)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		df := &DefaultFormatter{
+			EnableDumps: true,
+		}
+
+		fd := df.buildFormatData(&AssertionContext{
+			Request: &Request{httpReq: httpReq},
+		}, fl)
+		assert.True(t, fd.HaveRequest)
+		assert.False(t, fd.HaveResponse)
+	})
+}
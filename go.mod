@@ -4,6 +4,7 @@ go 1.14
 
 require (
 	github.com/ajg/form v1.5.1
+	github.com/andybalholm/brotli v1.0.4
 	github.com/fasthttp/websocket v1.4.3-rc.6
 	github.com/fatih/structs v1.1.0
 	github.com/google/go-querystring v1.1.0
@@ -17,6 +18,8 @@ require (
 	github.com/yalp/jsonpath v0.0.0-20180802001716-5cc68e5049a0
 	github.com/yudai/gojsondiff v1.0.0
 	golang.org/x/net v0.0.0-20220225172249-27dd8689420f
+	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v2 v2.4.0
 	moul.io/http2curl/v2 v2.3.0
 )
 
@@ -29,5 +32,4 @@ require (
 	github.com/xeipuuv/gojsonpointer v0.0.0-20190905194746-02993c407bfb // indirect
 	github.com/yudai/golcs v0.0.0-20170316035057-ecda9a501e82 // indirect
 	github.com/yudai/pp v2.0.1+incompatible // indirect
-	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
@@ -0,0 +1,235 @@
+package httpexpect
+
+import (
+	"errors"
+)
+
+// GraphQL provides methods to inspect GraphQL response envelope, i.e.
+// JSON object with "data" and "errors" keys.
+//
+// See https://spec.graphql.org/October2021/#sec-Response-Format.
+//
+// Example:
+//
+//	gql := e.POST("/graphql").
+//		WithGraphQL(`query { user(id: 1) { name } }`, nil).
+//		Expect().
+//		Status(http.StatusOK).
+//		GraphQL()
+//
+//	gql.NoErrors()
+//	gql.Data().Path("$.user.name").String().Equal("john")
+type GraphQL struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+// NewGraphQL returns a new GraphQL instance.
+//
+// If reporter is nil, the function panics.
+// If value is not a valid GraphQL response envelope, failure is reported.
+//
+// Example:
+//
+//	gql := NewGraphQL(t, map[string]interface{}{
+//		"data": map[string]interface{}{"user": nil},
+//	})
+func NewGraphQL(reporter Reporter, value interface{}) *GraphQL {
+	return newGraphQL(newChainWithDefaults("GraphQL()", reporter), value)
+}
+
+// NewGraphQLC returns a new GraphQL instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is not a valid GraphQL response envelope, failure is reported.
+//
+// Example:
+//
+//	gql := NewGraphQLC(config, map[string]interface{}{
+//		"data": map[string]interface{}{"user": nil},
+//	})
+func NewGraphQLC(config Config, value interface{}) *GraphQL {
+	return newGraphQL(newChainWithConfig("GraphQL()", config.withDefaults()), value)
+}
+
+func newGraphQL(parent *chain, val interface{}) *GraphQL {
+	g := &GraphQL{chain: parent.clone(), value: nil}
+
+	opChain := g.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return g
+	}
+
+	canon, ok := canonValue(opChain, val)
+	if !ok {
+		return g
+	}
+
+	object, ok := canon.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: GraphQL response is an object"),
+			},
+		})
+		return g
+	}
+
+	_, hasData := object["data"]
+	errs, hasErrors := object["errors"]
+
+	if !hasData && !hasErrors {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New(
+					`expected: GraphQL response contains "data" or "errors" key`),
+			},
+		})
+		return g
+	}
+
+	if _, ok := errs.([]interface{}); errs != nil && !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New(`expected: GraphQL "errors" key is null or array`),
+			},
+		})
+		return g
+	}
+
+	g.value = object
+
+	return g
+}
+
+// Raw returns underlying value attached to GraphQL.
+// This is the value originally passed to NewGraphQL, converted to
+// canonical form.
+//
+// Example:
+//
+//	gql := NewGraphQL(t, map[string]interface{}{"data": nil})
+//	assert.Equal(t, map[string]interface{}{"data": nil}, gql.Raw())
+func (g *GraphQL) Raw() map[string]interface{} {
+	return g.value
+}
+
+// Data returns a new Value instance with "data" key of GraphQL response.
+//
+// If there is no "data" key, returned Value contains null.
+//
+// Example:
+//
+//	gql := NewGraphQL(t, map[string]interface{}{
+//		"data": map[string]interface{}{"user": "john"},
+//	})
+//	gql.Data().Object().ValueEqual("user", "john")
+func (g *GraphQL) Data() *Value {
+	opChain := g.chain.enter("Data()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, g.value["data"])
+}
+
+// Errors returns a new Array instance with "errors" key of GraphQL
+// response.
+//
+// If there is no "errors" key, returned Array is empty.
+//
+// Example:
+//
+//	gql := NewGraphQL(t, map[string]interface{}{
+//		"errors": []interface{}{
+//			map[string]interface{}{"message": "not found"},
+//		},
+//	})
+//	gql.Errors().Element(0).Object().ValueEqual("message", "not found")
+func (g *GraphQL) Errors() *Array {
+	opChain := g.chain.enter("Errors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, g.errors())
+}
+
+// NoErrors succeeds if GraphQL response has no errors, i.e. "errors" key
+// is missing, null, or empty array.
+//
+// Example:
+//
+//	gql := NewGraphQL(t, map[string]interface{}{"data": nil})
+//	gql.NoErrors()
+func (g *GraphQL) NoErrors() *GraphQL {
+	opChain := g.chain.enter("NoErrors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return g
+	}
+
+	if errs := g.errors(); len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{errs},
+			Errors: []error{
+				errors.New("expected: GraphQL response has no errors"),
+			},
+		})
+	}
+
+	return g
+}
+
+// HasErrors succeeds if GraphQL response has at least one error.
+//
+// Example:
+//
+//	gql := NewGraphQL(t, map[string]interface{}{
+//		"errors": []interface{}{
+//			map[string]interface{}{"message": "not found"},
+//		},
+//	})
+//	gql.HasErrors()
+func (g *GraphQL) HasErrors() *GraphQL {
+	opChain := g.chain.enter("HasErrors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return g
+	}
+
+	if errs := g.errors(); len(errs) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{errs},
+			Errors: []error{
+				errors.New("expected: GraphQL response has errors"),
+			},
+		})
+	}
+
+	return g
+}
+
+func (g *GraphQL) errors() []interface{} {
+	if errs, ok := g.value["errors"].([]interface{}); ok {
+		return errs
+	}
+	return []interface{}{}
+}
@@ -0,0 +1,168 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQL_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newGraphQL(chain, map[string]interface{}{"data": nil})
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Data())
+	assert.NotNil(t, value.Errors())
+
+	value.Data().chain.assertFailed(t)
+	value.Errors().chain.assertFailed(t)
+
+	value.NoErrors()
+	value.HasErrors()
+}
+
+func TestGraphQL_Constructors(t *testing.T) {
+	data := map[string]interface{}{"data": nil}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewGraphQL(reporter, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewGraphQLC(Config{
+			Reporter: reporter,
+		}, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newGraphQL(chain, data)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestGraphQL_Envelope(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		fail  bool
+	}{
+		{
+			name:  "data",
+			value: map[string]interface{}{"data": map[string]interface{}{}},
+		},
+		{
+			name:  "null data",
+			value: map[string]interface{}{"data": nil},
+		},
+		{
+			name:  "errors",
+			value: map[string]interface{}{"errors": []interface{}{}},
+		},
+		{
+			name:  "null errors",
+			value: map[string]interface{}{"data": nil, "errors": nil},
+		},
+		{
+			name:  "nil",
+			value: nil,
+			fail:  true,
+		},
+		{
+			name:  "not object",
+			value: []interface{}{"data"},
+			fail:  true,
+		},
+		{
+			name:  "no keys",
+			value: map[string]interface{}{"user": "john"},
+			fail:  true,
+		},
+		{
+			name:  "errors not array",
+			value: map[string]interface{}{"data": nil, "errors": "bad"},
+			fail:  true,
+		},
+		{
+			name:  "not marshalable",
+			value: func() {},
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewGraphQL(reporter, tc.value)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				assert.Nil(t, value.Raw())
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.NotNil(t, value.Raw())
+			}
+		})
+	}
+}
+
+func TestGraphQL_Data(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewGraphQL(reporter, map[string]interface{}{
+		"data": map[string]interface{}{
+			"user": map[string]interface{}{
+				"name": "john",
+			},
+		},
+	})
+
+	value.Data().Path("$.user.name").String().Equal("john")
+	value.Data().chain.assertNotFailed(t)
+
+	value.Errors().Empty()
+	value.Errors().chain.assertNotFailed(t)
+
+	value.NoErrors()
+	value.chain.assertNotFailed(t)
+
+	value.HasErrors()
+	value.chain.assertFailed(t)
+}
+
+func TestGraphQL_Errors(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewGraphQL(reporter, map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{
+				"message": "not found",
+				"path":    []interface{}{"user"},
+			},
+		},
+	})
+
+	value.Data().Null()
+	value.Data().chain.assertNotFailed(t)
+
+	value.Errors().Length().Equal(1)
+	value.Errors().Element(0).Object().ValueEqual("message", "not found")
+	value.Errors().chain.assertNotFailed(t)
+
+	value.HasErrors()
+	value.chain.assertNotFailed(t)
+
+	value.NoErrors()
+	value.chain.assertFailed(t)
+}
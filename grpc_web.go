@@ -0,0 +1,311 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GRPCWeb provides methods to inspect gRPC-Web response, i.e. sequence
+// of length-prefixed message frames optionally followed by trailers frame.
+//
+// See https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-WEB.md.
+//
+// GRPCWeb doesn't depend on protobuf; messages are exposed as raw bytes
+// and may be unmarshaled by user, e.g. using proto.Unmarshal().
+//
+// Example:
+//
+//	grpc := e.POST("/pkg.Service/Method").
+//		WithGRPCWeb(requestBytes).
+//		Expect().
+//		Status(http.StatusOK).
+//		GRPCWeb()
+//
+//	grpc.Status(0)
+//	grpc.Length().Equal(1)
+//
+//	var reply pb.Reply
+//	err := proto.Unmarshal(grpc.Raw()[0], &reply)
+type GRPCWeb struct {
+	noCopy   noCopy
+	chain    *chain
+	messages [][]byte
+	trailers http.Header
+}
+
+// NewGRPCWeb returns a new GRPCWeb instance.
+//
+// If reporter is nil, the function panics.
+// If body is not a valid sequence of gRPC-Web frames, failure is reported.
+//
+// Example:
+//
+//	grpc := NewGRPCWeb(t, body)
+//	grpc.Status(0)
+func NewGRPCWeb(reporter Reporter, body []byte) *GRPCWeb {
+	return newGRPCWeb(newChainWithDefaults("GRPCWeb()", reporter), body, nil)
+}
+
+// NewGRPCWebC returns a new GRPCWeb instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If body is not a valid sequence of gRPC-Web frames, failure is reported.
+//
+// Example:
+//
+//	grpc := NewGRPCWebC(config, body)
+//	grpc.Status(0)
+func NewGRPCWebC(config Config, body []byte) *GRPCWeb {
+	return newGRPCWeb(newChainWithConfig("GRPCWeb()", config.withDefaults()), body, nil)
+}
+
+func newGRPCWeb(parent *chain, body []byte, header http.Header) *GRPCWeb {
+	g := &GRPCWeb{chain: parent.clone(), trailers: http.Header{}}
+
+	opChain := g.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return g
+	}
+
+	// trailers-only responses carry grpc-status in headers
+	for k, v := range header {
+		if strings.HasPrefix(strings.ToLower(k), "grpc-") {
+			g.trailers[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+
+	messages, trailers, err := grpcWebDecode(body)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{body},
+			Errors: []error{
+				errors.New("expected: valid gRPC-Web frames"),
+				err,
+			},
+		})
+		return g
+	}
+
+	for k, v := range trailers {
+		g.trailers[k] = v
+	}
+
+	g.messages = messages
+
+	return g
+}
+
+// Raw returns messages decoded from data frames of gRPC-Web response.
+//
+// Example:
+//
+//	grpc := NewGRPCWeb(t, body)
+//	err := proto.Unmarshal(grpc.Raw()[0], &reply)
+func (g *GRPCWeb) Raw() [][]byte {
+	return g.messages
+}
+
+// Length returns a new Number instance with number of messages in
+// gRPC-Web response.
+//
+// Example:
+//
+//	grpc := NewGRPCWeb(t, body)
+//	grpc.Length().Equal(1)
+func (g *GRPCWeb) Length() *Number {
+	opChain := g.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(g.messages)))
+}
+
+// Trailers returns a new Object instance with gRPC-Web trailers map.
+//
+// Keys are canonicalized like HTTP header keys, e.g. "grpc-status"
+// becomes "Grpc-Status".
+//
+// Example:
+//
+//	grpc := NewGRPCWeb(t, body)
+//	grpc.Trailers().ContainsKey("Grpc-Status")
+func (g *GRPCWeb) Trailers() *Object {
+	opChain := g.chain.enter("Trailers()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	var value map[string]interface{}
+	value, _ = canonMap(opChain, g.trailers)
+
+	return newObject(opChain, value)
+}
+
+// Trailer returns a new String instance with given gRPC-Web trailer.
+//
+// Example:
+//
+//	grpc := NewGRPCWeb(t, body)
+//	grpc.Trailer("grpc-message").Equal("not found")
+func (g *GRPCWeb) Trailer(name string) *String {
+	opChain := g.chain.enter("Trailer(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, g.trailers.Get(name))
+}
+
+// Status succeeds if gRPC-Web response has "grpc-status" trailer equal
+// to given gRPC status code.
+//
+// Example:
+//
+//	grpc := NewGRPCWeb(t, body)
+//	grpc.Status(5) // NOT_FOUND
+func (g *GRPCWeb) Status(code int) *GRPCWeb {
+	opChain := g.chain.enter("Status()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return g
+	}
+
+	value := g.trailers.Get("Grpc-Status")
+
+	if value == "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{g.trailers},
+			Expected: &AssertionValue{
+				"Grpc-Status",
+			},
+			Errors: []error{
+				errors.New(`expected: gRPC-Web response has "grpc-status" trailer`),
+			},
+		})
+		return g
+	}
+
+	actual, err := strconv.Atoi(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`expected: "grpc-status" trailer is integer`),
+				err,
+			},
+		})
+		return g
+	}
+
+	if actual != code {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actual},
+			Expected: &AssertionValue{code},
+			Errors: []error{
+				errors.New("expected: gRPC status codes are equal"),
+			},
+		})
+	}
+
+	return g
+}
+
+const (
+	grpcWebHeaderLen    = 5
+	grpcWebTrailerFlag  = 0x80
+	grpcWebCompressFlag = 0x01
+)
+
+func grpcWebEncode(messages [][]byte) []byte {
+	var buf bytes.Buffer
+
+	for _, msg := range messages {
+		var prefix [grpcWebHeaderLen]byte
+		binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+
+		buf.Write(prefix[:])
+		buf.Write(msg)
+	}
+
+	return buf.Bytes()
+}
+
+func grpcWebDecode(body []byte) ([][]byte, http.Header, error) {
+	messages := [][]byte{}
+	trailers := http.Header{}
+
+	for len(body) != 0 {
+		if len(body) < grpcWebHeaderLen {
+			return nil, nil, fmt.Errorf(
+				"truncated frame header: got %d bytes, need %d",
+				len(body), grpcWebHeaderLen)
+		}
+
+		flag := body[0]
+		size := binary.BigEndian.Uint32(body[1:grpcWebHeaderLen])
+
+		body = body[grpcWebHeaderLen:]
+
+		if uint64(len(body)) < uint64(size) {
+			return nil, nil, fmt.Errorf(
+				"truncated frame payload: got %d bytes, need %d",
+				len(body), size)
+		}
+
+		payload := body[:size]
+		body = body[size:]
+
+		if flag&grpcWebCompressFlag != 0 {
+			return nil, nil, errors.New("compressed frames are not supported")
+		}
+
+		if flag&grpcWebTrailerFlag != 0 {
+			if err := grpcWebParseTrailers(payload, trailers); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			messages = append(messages, payload)
+		}
+	}
+
+	return messages, trailers, nil
+}
+
+func grpcWebParseTrailers(payload []byte, trailers http.Header) error {
+	for _, line := range strings.Split(string(payload), "\r\n") {
+		if line == "" {
+			continue
+		}
+
+		pos := strings.IndexByte(line, ':')
+		if pos < 0 {
+			return fmt.Errorf("invalid trailer line %q", line)
+		}
+
+		key := strings.TrimSpace(line[:pos])
+		val := strings.TrimSpace(line[pos+1:])
+
+		trailers.Add(key, val)
+	}
+
+	return nil
+}
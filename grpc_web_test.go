@@ -0,0 +1,221 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testGRPCWebTrailers(trailers string) []byte {
+	frame := []byte{0x80, 0, 0, 0, byte(len(trailers))}
+	return append(frame, []byte(trailers)...)
+}
+
+func TestGRPCWeb_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newGRPCWeb(chain, nil, nil)
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Length())
+	assert.NotNil(t, value.Trailers())
+	assert.NotNil(t, value.Trailer("foo"))
+
+	value.Length().chain.assertFailed(t)
+	value.Trailers().chain.assertFailed(t)
+	value.Trailer("foo").chain.assertFailed(t)
+
+	value.Status(0)
+}
+
+func TestGRPCWeb_Constructors(t *testing.T) {
+	body := grpcWebEncode([][]byte{[]byte("foo")})
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewGRPCWeb(reporter, body)
+		assert.Equal(t, [][]byte{[]byte("foo")}, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewGRPCWebC(Config{
+			Reporter: reporter,
+		}, body)
+		assert.Equal(t, [][]byte{[]byte("foo")}, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newGRPCWeb(chain, body, nil)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestGRPCWeb_Decode(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     []byte
+		fail     bool
+		messages [][]byte
+		trailers http.Header
+	}{
+		{
+			name:     "empty",
+			body:     []byte{},
+			messages: [][]byte{},
+			trailers: http.Header{},
+		},
+		{
+			name:     "messages",
+			body:     grpcWebEncode([][]byte{[]byte("foo"), {}, []byte("bar")}),
+			messages: [][]byte{[]byte("foo"), {}, []byte("bar")},
+			trailers: http.Header{},
+		},
+		{
+			name: "messages and trailers",
+			body: append(
+				grpcWebEncode([][]byte{[]byte("foo")}),
+				testGRPCWebTrailers(
+					"grpc-status: 0\r\ngrpc-message: ok\r\nx-foo:bar\r\n")...),
+			messages: [][]byte{[]byte("foo")},
+			trailers: http.Header{
+				"Grpc-Status":  {"0"},
+				"Grpc-Message": {"ok"},
+				"X-Foo":        {"bar"},
+			},
+		},
+		{
+			name: "truncated header",
+			body: []byte{0, 0, 0},
+			fail: true,
+		},
+		{
+			name: "truncated payload",
+			body: []byte{0, 0, 0, 0, 5, 'f', 'o', 'o'},
+			fail: true,
+		},
+		{
+			name: "compressed frame",
+			body: []byte{1, 0, 0, 0, 3, 'f', 'o', 'o'},
+			fail: true,
+		},
+		{
+			name: "invalid trailers",
+			body: testGRPCWebTrailers("grpc-status\r\n"),
+			fail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewGRPCWeb(reporter, tc.body)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				assert.Nil(t, value.Raw())
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.Equal(t, tc.messages, value.Raw())
+				assert.Equal(t, tc.trailers, value.trailers)
+			}
+		})
+	}
+}
+
+func TestGRPCWeb_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := append(
+		grpcWebEncode([][]byte{[]byte("foo"), []byte("bar")}),
+		testGRPCWebTrailers("grpc-status: 5\r\ngrpc-message: not found\r\n")...)
+
+	value := NewGRPCWeb(reporter, body)
+
+	value.Length().Equal(2)
+	value.chain.assertNotFailed(t)
+
+	value.Trailers().ContainsKey("Grpc-Status")
+	value.Trailers().ValueEqual("Grpc-Message", []string{"not found"})
+	value.chain.assertNotFailed(t)
+
+	value.Trailer("grpc-message").Equal("not found")
+	value.Trailer("Grpc-Message").Equal("not found")
+	value.Trailer("missing").Empty()
+	value.chain.assertNotFailed(t)
+}
+
+func TestGRPCWeb_Status(t *testing.T) {
+	cases := []struct {
+		name     string
+		trailers string
+		header   http.Header
+		code     int
+		fail     bool
+	}{
+		{
+			name:     "equal",
+			trailers: "grpc-status: 5\r\n",
+			code:     5,
+		},
+		{
+			name:     "not equal",
+			trailers: "grpc-status: 5\r\n",
+			code:     0,
+			fail:     true,
+		},
+		{
+			name:     "missing",
+			trailers: "grpc-message: foo\r\n",
+			code:     0,
+			fail:     true,
+		},
+		{
+			name:     "not integer",
+			trailers: "grpc-status: foo\r\n",
+			code:     0,
+			fail:     true,
+		},
+		{
+			name:   "from header",
+			header: http.Header{"Grpc-Status": {"7"}},
+			code:   7,
+		},
+		{
+			name:     "trailers override header",
+			trailers: "grpc-status: 0\r\n",
+			header:   http.Header{"Grpc-Status": {"7"}},
+			code:     0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := newMockChain(t)
+
+			var body []byte
+			if tc.trailers != "" {
+				body = testGRPCWebTrailers(tc.trailers)
+			}
+
+			value := newGRPCWeb(chain, body, tc.header)
+			value.chain.assertNotFailed(t)
+
+			value.Status(tc.code)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,662 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HAL provides methods to inspect HAL (Hypertext Application Language)
+// resource, i.e. JSON object with "_links" and "_embedded" keys, and to
+// follow its links.
+//
+// See https://datatracker.ietf.org/doc/html/draft-kelly-json-hal.
+//
+// Example:
+//
+//	order := e.GET("/orders/123").
+//		Expect().
+//		Status(http.StatusOK).
+//		HAL()
+//
+//	order.Link("self").Href().Equal("/orders/123")
+//	order.FollowLink("customer").Status(http.StatusOK)
+type HAL struct {
+	noCopy noCopy
+	chain  *chain
+	config Config
+	value  map[string]interface{}
+	resp   *Response
+}
+
+// NewHAL returns a new HAL instance.
+//
+// If reporter is nil, the function panics.
+// If value is not a valid HAL resource, failure is reported.
+//
+// HAL created this way has no response attached, hence FollowLink
+// always reports failure. Use Response.HAL to follow links.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{
+//		"_links": map[string]interface{}{
+//			"self": map[string]interface{}{"href": "/orders/123"},
+//		},
+//	})
+func NewHAL(reporter Reporter, value interface{}) *HAL {
+	config := Config{Reporter: reporter}
+	config = config.withDefaults()
+
+	return newHAL(newChainWithConfig("HAL()", config), config, value, nil)
+}
+
+// NewHALC returns a new HAL instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is not a valid HAL resource, failure is reported.
+//
+// See NewHAL for usage example.
+func NewHALC(config Config, value interface{}) *HAL {
+	config = config.withDefaults()
+
+	return newHAL(newChainWithConfig("HAL()", config), config, value, nil)
+}
+
+func newHAL(parent *chain, config Config, val interface{}, resp *Response) *HAL {
+	h := &HAL{chain: parent.clone(), config: config, value: nil, resp: resp}
+
+	opChain := h.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h
+	}
+
+	canon, ok := canonValue(opChain, val)
+	if !ok {
+		return h
+	}
+
+	object, ok := canon.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: HAL resource is an object"),
+			},
+		})
+		return h
+	}
+
+	if err := validateHAL(object); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: valid HAL resource"),
+				err,
+			},
+		})
+		return h
+	}
+
+	h.value = object
+
+	return h
+}
+
+func validateHAL(resource map[string]interface{}) error {
+	if member, ok := resource["_links"]; ok {
+		links, ok := member.(map[string]interface{})
+		if !ok {
+			return errors.New(`"_links" must be an object`)
+		}
+
+		for rel, link := range links {
+			switch l := link.(type) {
+			case map[string]interface{}:
+				if err := validateHALLink(l); err != nil {
+					return fmt.Errorf(`link %q: %v`, rel, err)
+				}
+			case []interface{}:
+				for i, elem := range l {
+					if err := validateHALLink(elem); err != nil {
+						return fmt.Errorf(`link %q[%d]: %v`, rel, i, err)
+					}
+				}
+			default:
+				return fmt.Errorf(`link %q must be an object or an array`, rel)
+			}
+		}
+	}
+
+	if member, ok := resource["_embedded"]; ok {
+		if _, ok := member.(map[string]interface{}); !ok {
+			return errors.New(`"_embedded" must be an object`)
+		}
+	}
+
+	return nil
+}
+
+func validateHALLink(val interface{}) error {
+	link, ok := val.(map[string]interface{})
+	if !ok {
+		return errors.New("link must be an object")
+	}
+
+	if _, ok := link["href"].(string); !ok {
+		return errors.New(`link must have string "href"`)
+	}
+
+	if templated, ok := link["templated"]; ok {
+		if _, ok := templated.(bool); !ok {
+			return errors.New(`link "templated" must be a boolean`)
+		}
+	}
+
+	return nil
+}
+
+// Raw returns underlying value attached to HAL.
+// This is the value originally passed to NewHAL, converted to
+// canonical form.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{"total": 1})
+//	assert.Equal(t, map[string]interface{}{"total": 1}, hal.Raw())
+func (h *HAL) Raw() map[string]interface{} {
+	return h.value
+}
+
+// Links returns a new Object instance with "_links" key of HAL resource.
+//
+// If there is no "_links" key, returned Object is empty.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{
+//		"_links": map[string]interface{}{
+//			"self": map[string]interface{}{"href": "/orders/123"},
+//		},
+//	})
+//	hal.Links().Keys().ContainsOnly("self")
+func (h *HAL) Links() *Object {
+	opChain := h.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	links, _ := h.value["_links"].(map[string]interface{})
+	if links == nil {
+		links = map[string]interface{}{}
+	}
+
+	return newObject(opChain, links)
+}
+
+// Link returns a new HALLink instance with link of given relation type.
+//
+// If relation has array of links, the first one is returned.
+// If there is no such link, failure is reported.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{
+//		"_links": map[string]interface{}{
+//			"find": map[string]interface{}{
+//				"href":      "/orders{?id}",
+//				"templated": true,
+//			},
+//		},
+//	})
+//	hal.Link("find").Href().Equal("/orders{?id}")
+//	hal.Link("find").Templated().True()
+func (h *HAL) Link(rel string) *HALLink {
+	opChain := h.chain.enter("Link(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHALLink(opChain, nil)
+	}
+
+	link, ok := h.link(opChain, rel)
+	if !ok {
+		return newHALLink(opChain, nil)
+	}
+
+	return newHALLink(opChain, link)
+}
+
+// ContainsLink succeeds if HAL resource has link of given relation type.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{
+//		"_links": map[string]interface{}{
+//			"cancel": map[string]interface{}{"href": "/orders/123/cancel"},
+//		},
+//	})
+//	hal.ContainsLink("cancel")
+func (h *HAL) ContainsLink(rel string) *HAL {
+	opChain := h.chain.enter("ContainsLink(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h
+	}
+
+	h.link(opChain, rel)
+
+	return h
+}
+
+// NotContainsLink succeeds if HAL resource has no link of given relation
+// type.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{
+//		"_links": map[string]interface{}{
+//			"self": map[string]interface{}{"href": "/orders/123"},
+//		},
+//	})
+//	hal.NotContainsLink("cancel")
+func (h *HAL) NotContainsLink(rel string) *HAL {
+	opChain := h.chain.enter("NotContainsLink(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h
+	}
+
+	links, _ := h.value["_links"].(map[string]interface{})
+
+	if _, ok := links[rel]; ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotContainsKey,
+			Actual: &AssertionValue{links},
+			Expected: &AssertionValue{
+				rel,
+			},
+			Errors: []error{
+				fmt.Errorf("expected: HAL resource has no %q link", rel),
+			},
+		})
+	}
+
+	return h
+}
+
+// Embedded returns a new Value instance with embedded resource (or array
+// of resources) of given relation type, i.e. given key of "_embedded".
+//
+// If there is no such embedded resource, failure is reported.
+//
+// Example:
+//
+//	hal := NewHAL(t, map[string]interface{}{
+//		"_embedded": map[string]interface{}{
+//			"items": []interface{}{
+//				map[string]interface{}{"id": 1},
+//			},
+//		},
+//	})
+//	hal.Embedded("items").Array().Length().Equal(1)
+func (h *HAL) Embedded(rel string) *Value {
+	opChain := h.chain.enter("Embedded(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	embedded, _ := h.value["_embedded"].(map[string]interface{})
+
+	resource, ok := embedded[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{embedded},
+			Expected: &AssertionValue{
+				rel,
+			},
+			Errors: []error{
+				fmt.Errorf("expected: HAL resource has embedded %q", rel),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, resource)
+}
+
+// FollowLink sends GET request to link of given relation type and returns
+// a new Response instance.
+//
+// Relative href is resolved against URL of request that produced HAL
+// resource. The request has same headers as that request, except
+// cookies (which are handled by client) and Content-* headers. If link
+// points to another host (not a subdomain), "Authorization" header is
+// not sent, like http.Client does on redirects.
+//
+// If link is templated, it is expanded as URI Template (RFC 6570) using
+// given variables; undefined variables are expanded to nothing. Simple
+// string expansion and "+", "#", "/", ";", "?", and "&" operators are
+// supported, without value modifiers.
+//
+// If there is no such link, or HAL was not created by Response.HAL,
+// failure is reported.
+//
+// Example:
+//
+//	order := e.GET("/orders/123").Expect().HAL()
+//
+//	order.FollowLink("customer").Status(http.StatusOK)
+//	order.FollowLink("items", map[string]interface{}{"page": 2}).
+//		Status(http.StatusOK)
+func (h *HAL) FollowLink(rel string, vars ...map[string]interface{}) *Response {
+	opChain := h.chain.enter("FollowLink(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newResponse(responseOpts{
+			config: h.config,
+			chain:  opChain,
+		})
+	}
+
+	if len(vars) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple vars arguments"),
+			},
+		})
+		return newResponse(responseOpts{
+			config: h.config,
+			chain:  opChain,
+		})
+	}
+
+	if h.resp == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("can't follow link: HAL has no response attached"),
+			},
+		})
+		return newResponse(responseOpts{
+			config: h.config,
+			chain:  opChain,
+		})
+	}
+
+	link, ok := h.link(opChain, rel)
+	if !ok {
+		return newResponse(responseOpts{
+			config: h.config,
+			chain:  opChain,
+		})
+	}
+
+	href := link["href"].(string)
+
+	if templated, _ := link["templated"].(bool); templated {
+		var values map[string]interface{}
+		if len(vars) != 0 {
+			values = vars[0]
+		}
+		href = expandURITemplate(href, values)
+	}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{href},
+			Errors: []error{
+				fmt.Errorf("invalid url in HAL %q link", rel),
+				err,
+			},
+		})
+		return newResponse(responseOpts{
+			config: h.config,
+			chain:  opChain,
+		})
+	}
+
+	if base := h.resp.requestURL(); base != nil {
+		u = base.ResolveReference(u)
+	}
+
+	return h.resp.resend(opChain, http.MethodGet, u, nil)
+}
+
+func (h *HAL) link(opChain *chain, rel string) (map[string]interface{}, bool) {
+	links, _ := h.value["_links"].(map[string]interface{})
+
+	var link map[string]interface{}
+
+	switch l := links[rel].(type) {
+	case map[string]interface{}:
+		link = l
+	case []interface{}:
+		if len(l) != 0 {
+			link, _ = l[0].(map[string]interface{})
+		}
+	}
+
+	if link == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{links},
+			Expected: &AssertionValue{
+				rel,
+			},
+			Errors: []error{
+				fmt.Errorf("expected: HAL resource has %q link", rel),
+			},
+		})
+		return nil, false
+	}
+
+	return link, true
+}
+
+// expandURITemplate expands URI Template (RFC 6570) with given variables;
+// value modifiers (prefix and explode) are ignored
+func expandURITemplate(template string, vars map[string]interface{}) string {
+	var sb strings.Builder
+
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(template[:start])
+		sb.WriteString(expandURITemplateExpr(template[start+1:end], vars))
+
+		template = template[end+1:]
+	}
+
+	sb.WriteString(template)
+
+	return sb.String()
+}
+
+func expandURITemplateExpr(expr string, vars map[string]interface{}) string {
+	var op byte
+	if expr != "" && strings.IndexByte("+#./;?&", expr[0]) >= 0 {
+		op, expr = expr[0], expr[1:]
+	}
+
+	first, sep, named, ifEmpty := "", ",", false, ""
+	allowReserved := false
+
+	switch op {
+	case '+':
+		allowReserved = true
+	case '#':
+		first, allowReserved = "#", true
+	case '.':
+		first, sep = ".", "."
+	case '/':
+		first, sep = "/", "/"
+	case ';':
+		first, sep, named = ";", ";", true
+	case '?':
+		first, sep, named, ifEmpty = "?", "&", true, "="
+	case '&':
+		first, sep, named, ifEmpty = "&", "&", true, "="
+	}
+
+	var parts []string
+
+	for _, name := range strings.Split(expr, ",") {
+		name = strings.TrimSuffix(name, "*")
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			name = name[:i]
+		}
+
+		value, ok := vars[name]
+		if !ok || value == nil {
+			continue
+		}
+
+		str := fmt.Sprint(value)
+
+		if allowReserved {
+			str = (&url.URL{Path: str}).EscapedPath()
+		} else {
+			str = strings.ReplaceAll(url.QueryEscape(str), "+", "%20")
+		}
+
+		switch {
+		case !named:
+			parts = append(parts, str)
+		case str == "":
+			parts = append(parts, name+ifEmpty)
+		default:
+			parts = append(parts, name+"="+str)
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return first + strings.Join(parts, sep)
+}
+
+// HALLink provides methods to inspect link object of HAL resource.
+type HALLink struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+func newHALLink(parent *chain, val map[string]interface{}) *HALLink {
+	return &HALLink{chain: parent.clone(), value: val}
+}
+
+// Raw returns underlying link object.
+//
+// Example:
+//
+//	link := hal.Link("self")
+//	assert.Equal(t, "/orders/123", link.Raw()["href"])
+func (l *HALLink) Raw() map[string]interface{} {
+	return l.value
+}
+
+// Href returns a new String instance with "href" of link.
+//
+// Example:
+//
+//	hal.Link("self").Href().Equal("/orders/123")
+func (l *HALLink) Href() *String {
+	opChain := l.chain.enter("Href()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	href, _ := l.value["href"].(string)
+
+	return newString(opChain, href)
+}
+
+// Templated returns a new Boolean instance with "templated" of link.
+//
+// If link has no "templated" key, returned Boolean is false.
+//
+// Example:
+//
+//	hal.Link("find").Templated().True()
+func (l *HALLink) Templated() *Boolean {
+	opChain := l.chain.enter("Templated()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	templated, _ := l.value["templated"].(bool)
+
+	return newBoolean(opChain, templated)
+}
+
+// Title returns a new String instance with "title" of link.
+//
+// If link has no "title" key, returned String is empty.
+//
+// Example:
+//
+//	hal.Link("customer").Title().Equal("John Doe")
+func (l *HALLink) Title() *String {
+	opChain := l.chain.enter("Title()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	title, _ := l.value["title"].(string)
+
+	return newString(opChain, title)
+}
+
+// Name returns a new String instance with "name" of link.
+//
+// If link has no "name" key, returned String is empty.
+//
+// Example:
+//
+//	hal.Link("curies").Name().Equal("acme")
+func (l *HALLink) Name() *String {
+	opChain := l.chain.enter("Name()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	name, _ := l.value["name"].(string)
+
+	return newString(opChain, name)
+}
@@ -0,0 +1,405 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHAL_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	config := newMockConfig(newMockReporter(t))
+
+	value := newHAL(chain, config, map[string]interface{}{}, nil)
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Links())
+	assert.NotNil(t, value.Link("self"))
+	assert.NotNil(t, value.Link("self").Href())
+	assert.NotNil(t, value.Link("self").Templated())
+	assert.NotNil(t, value.Link("self").Title())
+	assert.NotNil(t, value.Link("self").Name())
+	assert.NotNil(t, value.Embedded("items"))
+	assert.NotNil(t, value.FollowLink("self"))
+
+	value.Links().chain.assertFailed(t)
+	value.Link("self").chain.assertFailed(t)
+	value.Link("self").Href().chain.assertFailed(t)
+	value.Link("self").Templated().chain.assertFailed(t)
+	value.Link("self").Title().chain.assertFailed(t)
+	value.Link("self").Name().chain.assertFailed(t)
+	value.Embedded("items").chain.assertFailed(t)
+	value.FollowLink("self").chain.assertFailed(t)
+
+	value.ContainsLink("self")
+	value.NotContainsLink("self")
+}
+
+func TestHAL_Constructors(t *testing.T) {
+	data := map[string]interface{}{
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": "/orders/123"},
+		},
+	}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewHAL(reporter, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewHALC(Config{
+			Reporter: reporter,
+		}, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newHAL(chain, newMockConfig(newMockReporter(t)), data, nil)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestHAL_Validation(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		fail  bool
+	}{
+		{
+			name:  "empty",
+			value: map[string]interface{}{},
+		},
+		{
+			name: "links",
+			value: map[string]interface{}{
+				"_links": map[string]interface{}{
+					"self": map[string]interface{}{"href": "/orders"},
+					"find": map[string]interface{}{
+						"href":      "/orders{?id}",
+						"templated": true,
+					},
+					"curies": []interface{}{
+						map[string]interface{}{"href": "/docs/{rel}", "name": "acme"},
+					},
+				},
+				"_embedded": map[string]interface{}{
+					"orders": []interface{}{},
+				},
+			},
+		},
+		{
+			name:  "nil",
+			value: nil,
+			fail:  true,
+		},
+		{
+			name:  "not object",
+			value: []interface{}{},
+			fail:  true,
+		},
+		{
+			name:  "links not object",
+			value: map[string]interface{}{"_links": []interface{}{}},
+			fail:  true,
+		},
+		{
+			name: "link not object",
+			value: map[string]interface{}{
+				"_links": map[string]interface{}{"self": "/orders"},
+			},
+			fail: true,
+		},
+		{
+			name: "link without href",
+			value: map[string]interface{}{
+				"_links": map[string]interface{}{
+					"self": map[string]interface{}{"title": "orders"},
+				},
+			},
+			fail: true,
+		},
+		{
+			name: "link array element without href",
+			value: map[string]interface{}{
+				"_links": map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{}},
+				},
+			},
+			fail: true,
+		},
+		{
+			name: "templated not boolean",
+			value: map[string]interface{}{
+				"_links": map[string]interface{}{
+					"self": map[string]interface{}{
+						"href":      "/orders",
+						"templated": "yes",
+					},
+				},
+			},
+			fail: true,
+		},
+		{
+			name:  "embedded not object",
+			value: map[string]interface{}{"_embedded": "bad"},
+			fail:  true,
+		},
+		{
+			name:  "not marshalable",
+			value: func() {},
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewHAL(reporter, tc.value)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				assert.Nil(t, value.Raw())
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.NotNil(t, value.Raw())
+			}
+		})
+	}
+}
+
+func TestHAL_Links(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewHAL(reporter, map[string]interface{}{
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": "/orders/123"},
+			"find": map[string]interface{}{
+				"href":      "/orders{?id}",
+				"templated": true,
+			},
+			"customer": map[string]interface{}{
+				"href":  "/customers/7",
+				"title": "John Doe",
+			},
+			"curies": []interface{}{
+				map[string]interface{}{"href": "/docs/{rel}", "name": "acme"},
+				map[string]interface{}{"href": "/other/{rel}", "name": "other"},
+			},
+		},
+		"_embedded": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": 1},
+			},
+		},
+	})
+
+	value.Links().Keys().ContainsOnly("self", "find", "customer", "curies")
+
+	value.Link("self").Href().Equal("/orders/123")
+	value.Link("self").Templated().False()
+	value.Link("find").Href().Equal("/orders{?id}")
+	value.Link("find").Templated().True()
+	value.Link("customer").Title().Equal("John Doe")
+	value.Link("curies").Name().Equal("acme")
+
+	value.ContainsLink("self")
+	value.NotContainsLink("cancel")
+
+	value.Embedded("items").Array().Length().Equal(1)
+
+	value.chain.assertNotFailed(t)
+
+	value.Link("cancel").chain.assertFailed(t)
+	value.Embedded("payments").chain.assertFailed(t)
+
+	value.ContainsLink("cancel")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotContainsLink("self")
+	value.chain.assertFailed(t)
+}
+
+func TestHAL_ExpandURITemplate(t *testing.T) {
+	vars := map[string]interface{}{
+		"id":    123,
+		"q":     "hello world",
+		"path":  "/foo/bar",
+		"empty": "",
+		"null":  nil,
+	}
+
+	cases := []struct {
+		template string
+		expected string
+	}{
+		{"/orders", "/orders"},
+		{"/orders/{id}", "/orders/123"},
+		{"/search?q={q}", "/search?q=hello%20world"},
+		{"{+path}/here", "/foo/bar/here"},
+		{"/x{#path}", "/x#/foo/bar"},
+		{"/x{.id}", "/x.123"},
+		{"/x{/id,q}", "/x/123/hello%20world"},
+		{"/x{;id,empty}", "/x;id=123;empty"},
+		{"/orders{?id,q}", "/orders?id=123&q=hello%20world"},
+		{"/orders?a=1{&id}", "/orders?a=1&id=123"},
+		{"/orders{?empty}", "/orders?empty="},
+		{"/orders{?missing,null}", "/orders"},
+		{"/orders/{id:2}{?q*}", "/orders/123?q=hello%20world"},
+		{"/orders/{id", "/orders/{id"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.template, func(t *testing.T) {
+			assert.Equal(t, tc.expected, expandURITemplate(tc.template, vars))
+		})
+	}
+}
+
+func TestHAL_FollowLink(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/orders/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"_links": map[string]interface{}{
+				"self":     map[string]interface{}{"href": "/orders/123"},
+				"customer": map[string]interface{}{"href": "../customers/7"},
+				"items": map[string]interface{}{
+					"href":      "/orders/123/items{?page}",
+					"templated": true,
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("/customers/7", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"name":  "John Doe",
+			"token": r.Header.Get("Authorization"),
+		})
+	})
+
+	mux.HandleFunc("/orders/123/items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"page": r.URL.Query().Get("page"),
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	order := e.GET("/orders/123").
+		WithHeader("Authorization", "Bearer token").
+		Expect().
+		Status(http.StatusOK).
+		HAL()
+
+	order.FollowLink("self").Status(http.StatusOK).
+		HAL().Link("self").Href().Equal("/orders/123")
+
+	customer := order.FollowLink("customer").Status(http.StatusOK).HAL()
+	assert.Equal(t, "John Doe", customer.Raw()["name"])
+	assert.Equal(t, "Bearer token", customer.Raw()["token"])
+
+	order.FollowLink("items").Status(http.StatusOK).
+		JSON(ContentOpts{MediaType: "application/hal+json"}).
+		Object().ValueEqual("page", "")
+
+	order.FollowLink("items", map[string]interface{}{"page": 2}).
+		Status(http.StatusOK).
+		JSON(ContentOpts{MediaType: "application/hal+json"}).
+		Object().ValueEqual("page", "2")
+}
+
+func TestHAL_FollowLinkFailures(t *testing.T) {
+	data := map[string]interface{}{
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": "/orders/123"},
+		},
+	}
+
+	t.Run("no response", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewHAL(reporter, data)
+
+		value.FollowLink("self").chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("no link", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/hal+json"},
+			},
+			Body: newMockBody(`{"_links": {"self": {"href": "/orders/123"}}}`),
+		})
+
+		value := resp.HAL()
+		value.chain.assertNotFailed(t)
+
+		value.FollowLink("cancel").chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/hal+json"},
+			},
+			Body: newMockBody(`{"_links": {"bad": {"href": "http://[::1"}}}`),
+		})
+
+		value := resp.HAL()
+		value.chain.assertNotFailed(t)
+
+		value.FollowLink("bad").chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("multiple vars", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/hal+json"},
+			},
+			Body: newMockBody(`{"_links": {"self": {"href": "/orders/123"}}}`),
+		})
+
+		value := resp.HAL()
+
+		value.FollowLink("self",
+			map[string]interface{}{}, map[string]interface{}{}).chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+}
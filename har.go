@@ -0,0 +1,404 @@
+package httpexpect
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// HARRecorder implements Printer.
+// Records all requests and responses into a log in HAR 1.2 format,
+// which can be then written to a file and opened in a browser or other
+// tools that support HAR.
+//
+// See http://www.softwareishard.com/blog/har-12-spec/.
+//
+// HARRecorder is safe for concurrent use. The same instance may be shared
+// between multiple Expect instances to collect traffic of the whole test run.
+//
+// Example:
+//
+//	recorder := NewHARRecorder()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			recorder,
+//		},
+//	})
+//
+//	defer recorder.WriteFile("traffic.har")
+type HARRecorder struct {
+	mu      sync.Mutex
+	entries []harEntry
+	pending map[*http.Request]*harPending
+	seq     int
+}
+
+type harPending struct {
+	seq   int
+	entry harEntry
+}
+
+// NewHARRecorder returns a new empty HARRecorder.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{
+		pending: make(map[*http.Request]*harPending),
+	}
+}
+
+// Request implements Printer.Request.
+func (h *HARRecorder) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	entry := harEntry{
+		StartedDateTime: time.Now().Format(time.RFC3339Nano),
+		Request:         harMakeRequest(req),
+		Response:        harMakeResponse(nil),
+		Cache:           struct{}{},
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// request without response (e.g. because of network error) is kept
+	// in pending and written as is
+	h.seq++
+	h.pending[req] = &harPending{seq: h.seq, entry: entry}
+}
+
+// Response implements Printer.Response.
+func (h *HARRecorder) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		return
+	}
+
+	response := harMakeResponse(resp)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var entry harEntry
+
+	if req := h.findPending(resp.Request); req != nil {
+		entry = h.pending[req].entry
+		delete(h.pending, req)
+	} else {
+		entry = harEntry{
+			StartedDateTime: time.Now().Add(-duration).Format(time.RFC3339Nano),
+			Request:         harMakeRequest(resp.Request),
+			Cache:           struct{}{},
+		}
+	}
+
+	ms := float64(duration) / float64(time.Millisecond)
+
+	entry.Response = response
+	entry.Time = ms
+	entry.Timings = harTimings{
+		Send:    0,
+		Wait:    ms,
+		Receive: 0,
+	}
+
+	h.entries = append(h.entries, entry)
+}
+
+// Find pending request which response belongs to.
+// Response is paired with its request; if request was redirected, with
+// original request. If request was copied before being sent (e.g. by
+// Redactor), the oldest pending request with same method and URL is used.
+func (h *HARRecorder) findPending(req *http.Request) *http.Request {
+	for r := req; r != nil; {
+		if _, ok := h.pending[r]; ok {
+			return r
+		}
+		if r.Response == nil {
+			break
+		}
+		r = r.Response.Request
+	}
+
+	var (
+		found *http.Request
+		seq   int
+	)
+
+	for r := req; r != nil && r.URL != nil; {
+		for pendingReq, p := range h.pending {
+			if p.entry.Request.Method == r.Method &&
+				p.entry.Request.URL == r.URL.String() &&
+				(found == nil || p.seq < seq) {
+				found = pendingReq
+				seq = p.seq
+			}
+		}
+
+		if found != nil || r.Response == nil {
+			break
+		}
+		r = r.Response.Request
+	}
+
+	return found
+}
+
+// WriteTo writes recorded log in HAR format to given writer.
+// Implements io.WriterTo.
+func (h *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	h.mu.Lock()
+
+	entries := append([]harEntry{}, h.entries...)
+
+	pending := make([]*harPending, 0, len(h.pending))
+	for _, p := range h.pending {
+		pending = append(pending, p)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].seq < pending[j].seq
+	})
+	for _, p := range pending {
+		entries = append(entries, p.entry)
+	}
+
+	h.mu.Unlock()
+
+	doc := harDocument{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{
+				Name:    "httpexpect",
+				Version: "v2",
+			},
+			Entries: entries,
+		},
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// WriteFile writes recorded log in HAR format to given file.
+// If file already exists, it is truncated.
+func (h *HARRecorder) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harCookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func harMakeRequest(req *http.Request) harRequest {
+	r := harRequest{
+		Cookies:     []harCookie{},
+		Headers:     []harNameValue{},
+		QueryString: []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if req == nil {
+		return r
+	}
+
+	r.Method = req.Method
+	r.HTTPVersion = req.Proto
+	r.Headers = harMakeNameValues(req.Header)
+
+	if req.URL != nil {
+		r.URL = req.URL.String()
+		r.QueryString = harMakeNameValues(req.URL.Query())
+	}
+
+	for _, c := range req.Cookies() {
+		r.Cookies = append(r.Cookies, harCookie{
+			Name:  c.Name,
+			Value: c.Value,
+		})
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := ioutil.ReadAll(req.Body)
+
+		r.BodySize = len(body)
+		r.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     string(body),
+		}
+	} else {
+		r.BodySize = 0
+	}
+
+	return r
+}
+
+func harMakeResponse(resp *http.Response) harResponse {
+	r := harResponse{
+		Cookies:     []harCookie{},
+		Headers:     []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if resp == nil {
+		return r
+	}
+
+	r.Status = resp.StatusCode
+	r.StatusText = http.StatusText(resp.StatusCode)
+	r.HTTPVersion = resp.Proto
+	r.Headers = harMakeNameValues(resp.Header)
+	r.RedirectURL = resp.Header.Get("Location")
+
+	for _, c := range resp.Cookies() {
+		cookie := harCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		if !c.Expires.IsZero() {
+			cookie.Expires = c.Expires.Format(time.RFC3339)
+		}
+		r.Cookies = append(r.Cookies, cookie)
+	}
+
+	r.Content.MimeType = resp.Header.Get("Content-Type")
+
+	if resp.Body != nil {
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		r.BodySize = len(body)
+		r.Content.Size = len(body)
+
+		if utf8.Valid(body) {
+			r.Content.Text = string(body)
+		} else {
+			r.Content.Text = base64.StdEncoding.EncodeToString(body)
+			r.Content.Encoding = "base64"
+		}
+	}
+
+	return r
+}
+
+func harMakeNameValues(m map[string][]string) []harNameValue {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := []harNameValue{}
+
+	for _, name := range names {
+		for _, value := range m[name] {
+			result = append(result, harNameValue{name, value})
+		}
+	}
+
+	return result
+}
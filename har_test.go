@@ -0,0 +1,290 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func harDecode(t *testing.T, recorder *HARRecorder) map[string]interface{} {
+	var buf bytes.Buffer
+
+	_, err := recorder.WriteTo(&buf)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	return doc
+}
+
+func TestHARRecorder_Empty(t *testing.T) {
+	recorder := NewHARRecorder()
+
+	doc := harDecode(t, recorder)
+
+	v := NewValue(t, doc)
+	v.Path("$.log.version").String().Equal("1.2")
+	v.Path("$.log.creator.name").String().Equal("httpexpect")
+	v.Path("$.log.entries").Array().Empty()
+}
+
+func TestHARRecorder_Entries(t *testing.T) {
+	recorder := NewHARRecorder()
+
+	req1, _ := http.NewRequest("POST", "http://example.com/path?a=1&b=2",
+		bytes.NewBufferString("body1"))
+	req1.Header.Set("Content-Type", "text/plain")
+	req1.AddCookie(&http.Cookie{Name: "session", Value: "123"})
+
+	resp1 := &http.Response{
+		StatusCode: http.StatusCreated,
+		Proto:      "HTTP/1.1",
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+			"Set-Cookie":   {"foo=bar; Path=/; Secure; HttpOnly"},
+		},
+		Body:    ioutil.NopCloser(bytes.NewBufferString(`{"ok":true}`)),
+		Request: req1,
+	}
+
+	req2, _ := http.NewRequest("GET", "http://example.com/binary", nil)
+
+	resp2 := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte{0xff, 0xfe})),
+		Request:    req2,
+	}
+
+	req3, _ := http.NewRequest("GET", "http://example.com/failed", nil)
+
+	recorder.Request(req1)
+	recorder.Response(resp1, 1500*time.Microsecond)
+	recorder.Request(req2)
+	recorder.Response(resp2, time.Millisecond)
+	recorder.Request(req3)
+	recorder.Request(nil)
+	recorder.Response(nil, 0)
+
+	doc := harDecode(t, recorder)
+
+	entries := NewValue(t, doc).Path("$.log.entries").Array()
+	entries.Length().Equal(3)
+
+	e1 := entries.Element(0).Object()
+	e1.Value("time").Number().Equal(1.5)
+	e1.Path("$.request.method").String().Equal("POST")
+	e1.Path("$.request.url").String().Equal("http://example.com/path?a=1&b=2")
+	e1.Path("$.request.queryString").Array().Equal([]interface{}{
+		map[string]interface{}{"name": "a", "value": "1"},
+		map[string]interface{}{"name": "b", "value": "2"},
+	})
+	e1.Path("$.request.cookies[0].name").String().Equal("session")
+	e1.Path("$.request.postData.mimeType").String().Equal("text/plain")
+	e1.Path("$.request.postData.text").String().Equal("body1")
+	e1.Path("$.request.bodySize").Number().Equal(5)
+	e1.Path("$.response.status").Number().Equal(http.StatusCreated)
+	e1.Path("$.response.statusText").String().Equal("Created")
+	e1.Path("$.response.content.mimeType").String().Equal("application/json")
+	e1.Path("$.response.content.text").String().Equal(`{"ok":true}`)
+	e1.Path("$.response.cookies[0]").Object().ContainsSubset(map[string]interface{}{
+		"name":     "foo",
+		"value":    "bar",
+		"secure":   true,
+		"httpOnly": true,
+	})
+
+	e2 := entries.Element(1).Object()
+	e2.Path("$.request.url").String().Equal("http://example.com/binary")
+	e2.Path("$.request").Object().NotContainsKey("postData")
+	e2.Path("$.response.content.encoding").String().Equal("base64")
+	e2.Path("$.response.content.text").String().Equal("//4=")
+
+	e3 := entries.Element(2).Object()
+	e3.Path("$.request.url").String().Equal("http://example.com/failed")
+	e3.Path("$.response.status").Number().Equal(0)
+}
+
+func TestHARRecorder_Pairing(t *testing.T) {
+	t.Run("interleaved", func(t *testing.T) {
+		recorder := NewHARRecorder()
+
+		req1, _ := http.NewRequest("GET", "http://example.com/1", nil)
+		req2, _ := http.NewRequest("GET", "http://example.com/2", nil)
+
+		recorder.Request(req1)
+		recorder.Request(req2)
+		recorder.Response(&http.Response{StatusCode: 202, Request: req2}, 0)
+		recorder.Response(&http.Response{StatusCode: 201, Request: req1}, 0)
+
+		entries := NewValue(t, harDecode(t, recorder)).Path("$.log.entries").Array()
+		entries.Length().Equal(2)
+
+		entries.Element(0).Path("$.request.url").String().Equal("http://example.com/2")
+		entries.Element(0).Path("$.response.status").Number().Equal(202)
+		entries.Element(1).Path("$.request.url").String().Equal("http://example.com/1")
+		entries.Element(1).Path("$.response.status").Number().Equal(201)
+	})
+
+	t.Run("redirect", func(t *testing.T) {
+		recorder := NewHARRecorder()
+
+		req1, _ := http.NewRequest("GET", "http://example.com/old", nil)
+		req2, _ := http.NewRequest("GET", "http://example.com/new", nil)
+		req2.Response = &http.Response{StatusCode: 301, Request: req1}
+
+		recorder.Request(req1)
+		recorder.Response(&http.Response{StatusCode: 200, Request: req2}, 0)
+
+		entries := NewValue(t, harDecode(t, recorder)).Path("$.log.entries").Array()
+		entries.Length().Equal(1)
+
+		entries.Element(0).Path("$.request.url").String().Equal("http://example.com/old")
+		entries.Element(0).Path("$.response.status").Number().Equal(200)
+	})
+
+	t.Run("copied request", func(t *testing.T) {
+		recorder := NewHARRecorder()
+
+		req1, _ := http.NewRequest("GET", "http://example.com/1", nil)
+		req2, _ := http.NewRequest("GET", "http://example.com/2", nil)
+
+		recorder.Request(req1)
+		recorder.Request(req2)
+		recorder.Response(&http.Response{StatusCode: 202, Request: req2.Clone(req2.Context())}, 0)
+
+		entries := NewValue(t, harDecode(t, recorder)).Path("$.log.entries").Array()
+		entries.Length().Equal(2)
+
+		entries.Element(0).Path("$.request.url").String().Equal("http://example.com/2")
+		entries.Element(0).Path("$.response.status").Number().Equal(202)
+		entries.Element(1).Path("$.request.url").String().Equal("http://example.com/1")
+		entries.Element(1).Path("$.response.status").Number().Equal(0)
+	})
+}
+
+func TestHARRecorder_Concurrent(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// random-ish delay to make responses arrive out of order
+		time.Sleep(time.Duration(len(r.URL.Path)%3) * time.Millisecond)
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	recorder := NewHARRecorder()
+
+	const numRequests = 20
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			e := WithConfig(Config{
+				BaseURL:  server.URL,
+				Reporter: NewAssertReporter(t),
+				Printers: []Printer{
+					recorder,
+				},
+			})
+
+			e.GET("/path/{i}", strings.Repeat("x", i)).
+				WithTimeout(time.Minute).
+				Expect().
+				Status(http.StatusOK)
+		}(i)
+	}
+
+	wg.Wait()
+
+	doc := harDecode(t, recorder)
+
+	entries := NewValue(t, doc).Path("$.log.entries").Array()
+	entries.Length().Equal(numRequests)
+
+	for _, entry := range entries.Iter() {
+		url := entry.Path("$.request.url").String().Raw()
+		entry.Path("$.response.content.text").String().
+			Equal(strings.TrimPrefix(url, server.URL))
+	}
+}
+
+func TestHARRecorder_WriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recorder := NewHARRecorder()
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+	recorder.Request(req)
+	recorder.Response(&http.Response{StatusCode: http.StatusOK, Request: req}, 0)
+
+	path := filepath.Join(dir, "test.har")
+	require.NoError(t, recorder.WriteFile(path))
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = recorder.WriteTo(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, buf.Bytes(), b)
+
+	assert.Error(t, recorder.WriteFile(filepath.Join(dir, "bad", "test.har")))
+}
+
+func TestHARRecorder_E2E(t *testing.T) {
+	handler := createPrinterHandler()
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	recorder := NewHARRecorder()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+		Printers: []Printer{
+			recorder,
+		},
+	})
+
+	e.POST("/test").
+		WithText("test_request").
+		Expect().
+		Text().
+		Equal("test_response")
+
+	doc := harDecode(t, recorder)
+
+	entries := NewValue(t, doc).Path("$.log.entries").Array()
+	entries.Length().Equal(1)
+
+	entry := entries.Element(0).Object()
+	entry.Path("$.request.method").String().Equal("POST")
+	entry.Path("$.request.url").String().Equal(server.URL + "/test")
+	entry.Path("$.request.postData.text").String().Equal("test_request")
+	entry.Path("$.response.status").Number().Equal(http.StatusOK)
+	entry.Path("$.response.httpVersion").String().Equal("HTTP/1.1")
+	entry.Path("$.response.content.text").String().Equal("test_response")
+}
@@ -0,0 +1,72 @@
+package httpexpect
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// hostResolveTransport configures transport of default client to connect
+// to addresses from Config.HostResolve
+func hostResolveTransport(
+	transport http.RoundTripper, resolve map[string]string,
+) http.RoundTripper {
+	dial := hostResolveDialContext(resolve)
+
+	switch t := transport.(type) {
+	case nil:
+		ret := NewTLSTransport(nil)
+		ret.DialContext = dial
+		return ret
+
+	case *http.Transport:
+		t.DialContext = dial
+		return t
+
+	case *http2Transport:
+		t.setDialContext(dial)
+		return t
+	}
+
+	return transport
+}
+
+func hostResolveDialContext(
+	resolve map[string]string,
+) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	// same settings as in http.DefaultTransport
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, hostResolveAddr(resolve, addr))
+	}
+}
+
+// hostResolveAddr maps "host:port" to address from Config.HostResolve
+//
+// Exact "host:port" key has precedence over "host" key. If mapped address
+// has no port, original port is kept. Unmapped addresses are returned as is.
+func hostResolveAddr(resolve map[string]string, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	target, ok := resolve[addr]
+	if !ok {
+		target, ok = resolve[host]
+	}
+	if !ok {
+		return addr
+	}
+
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+
+	return net.JoinHostPort(target, port)
+}
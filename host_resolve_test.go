@@ -0,0 +1,114 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostResolve_Addr(t *testing.T) {
+	resolve := map[string]string{
+		"example.com":      "127.0.0.1",
+		"example.com:8080": "127.0.0.2",
+		"api.example.com":  "10.0.0.1:9000",
+		"v6.example.com":   "::1",
+	}
+
+	cases := []struct {
+		addr     string
+		expected string
+	}{
+		{"example.com:80", "127.0.0.1:80"},
+		{"example.com:8080", "127.0.0.2:8080"},
+		{"api.example.com:443", "10.0.0.1:9000"},
+		{"v6.example.com:443", "[::1]:443"},
+		{"other.com:80", "other.com:80"},
+		{"example.com", "example.com"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.addr, func(t *testing.T) {
+			assert.Equal(t, tc.expected, hostResolveAddr(resolve, tc.addr))
+		})
+	}
+}
+
+func TestHostResolve_Client(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	})
+
+	t.Run("http", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+		e := WithConfig(Config{
+			BaseURL:  "http://backend.test",
+			Reporter: NewAssertReporter(t),
+			HostResolve: map[string]string{
+				"backend.test": server.Listener.Addr().String(),
+			},
+		})
+
+		e.GET("/").Expect().
+			Status(http.StatusOK).
+			Body().Equal("backend.test")
+
+		e = WithConfig(Config{
+			BaseURL:  "http://backend.test:" + port,
+			Reporter: NewAssertReporter(t),
+			HostResolve: map[string]string{
+				"backend.test": "127.0.0.1",
+			},
+		})
+
+		e.GET("/").Expect().
+			Status(http.StatusOK).
+			Body().Equal("backend.test:" + port)
+	})
+
+	versions := map[string]HTTPVersion{
+		"https default": defaultHTTPVersion,
+		"https http1":   ForceHTTP1,
+		"https http2":   ForceHTTP2,
+	}
+
+	for name, version := range versions {
+		version := version
+
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewUnstartedServer(handler)
+			server.EnableHTTP2 = true
+			server.StartTLS()
+			defer server.Close()
+
+			_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+			pool := x509.NewCertPool()
+			pool.AddCert(server.Certificate())
+
+			// certificate of test server is valid for example.com, so
+			// verification succeeds only if SNI is preserved
+			e := WithConfig(Config{
+				BaseURL:         "https://example.com:" + port,
+				Reporter:        NewAssertReporter(t),
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+				HTTPVersion:     version,
+				HostResolve: map[string]string{
+					"example.com": "127.0.0.1",
+				},
+			})
+
+			e.GET("/").Expect().
+				Status(http.StatusOK).
+				Body().Equal("example.com:" + port)
+		})
+	}
+}
@@ -0,0 +1,599 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTML provides methods to inspect HTML document or set of elements
+// selected from it.
+//
+// Elements are selected using subset of CSS selectors syntax:
+//   - type selector: "div", universal selector: "*"
+//   - id selector: "#main"
+//   - class selector: ".item"
+//   - attribute selectors: "[href]", "[type=submit]", "[title='a b']"
+//   - compound selectors: "a.button[target=_blank]"
+//   - descendant and child combinators: "ul li", "ul > li"
+//   - selector lists: "h1, h2"
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//
+//	doc.Select("title").Text().Equal("Home")
+//	doc.Select("ul.menu > li").Length().Equal(3)
+//	doc.Select("a#login").Attr("href").Equal("/login")
+type HTML struct {
+	noCopy noCopy
+	chain  *chain
+	nodes  []*html.Node
+}
+
+// NewHTML returns a new HTML instance with document parsed from body.
+//
+// If reporter is nil, the function panics.
+// Body may be nil.
+//
+// Example:
+//
+//	doc := NewHTML(t, []byte("<h1>Hello</h1>"))
+//	doc.Select("h1").Text().Equal("Hello")
+func NewHTML(reporter Reporter, body []byte) *HTML {
+	return newHTML(newChainWithDefaults("HTML()", reporter), body)
+}
+
+// NewHTMLC returns a new HTML instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// Body may be nil.
+//
+// Example:
+//
+//	doc := NewHTMLC(config, []byte("<h1>Hello</h1>"))
+//	doc.Select("h1").Text().Equal("Hello")
+func NewHTMLC(config Config, body []byte) *HTML {
+	return newHTML(newChainWithConfig("HTML()", config.withDefaults()), body)
+}
+
+func newHTML(parent *chain, body []byte) *HTML {
+	h := &HTML{chain: parent.clone()}
+
+	opChain := h.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(body),
+			},
+			Errors: []error{
+				errors.New("failed to parse html"),
+				err,
+			},
+		})
+		return h
+	}
+
+	h.nodes = []*html.Node{doc}
+
+	return h
+}
+
+func newHTMLSelection(parent *chain, nodes []*html.Node) *HTML {
+	return &HTML{chain: parent.clone(), nodes: nodes}
+}
+
+// Raw returns underlying nodes attached to HTML.
+//
+// For document returned by NewHTML, this is a single document node.
+// For selection returned by Select, these are selected elements.
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//	nodes := doc.Select("li").Raw()
+func (h *HTML) Raw() []*html.Node {
+	return h.nodes
+}
+
+// Select returns a new HTML instance with elements matching given
+// CSS selector.
+//
+// Elements are searched among descendants of currently selected nodes
+// and are returned in document order. If no elements match, returned
+// selection is empty and no failure is reported.
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//	doc.Select("ul.menu").Select("li > a").Length().Equal(3)
+func (h *HTML) Select(selector string) *HTML {
+	opChain := h.chain.enter("Select(%q)", selector)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHTMLSelection(opChain, nil)
+	}
+
+	sel, err := parseHTMLSelector(selector)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid css selector"),
+				err,
+			},
+		})
+		return newHTMLSelection(opChain, nil)
+	}
+
+	var (
+		nodes []*html.Node
+		seen  = map[*html.Node]bool{}
+	)
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if !seen[c] && sel.match(c) {
+				seen[c] = true
+				nodes = append(nodes, c)
+			}
+			walk(c)
+		}
+	}
+
+	for _, n := range h.nodes {
+		walk(n)
+	}
+
+	return newHTMLSelection(opChain, nodes)
+}
+
+// Length returns a new Number instance with number of selected nodes.
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//	doc.Select("table tr").Length().Equal(10)
+func (h *HTML) Length() *Number {
+	opChain := h.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(h.nodes)))
+}
+
+// Element returns a new HTML instance with selected node with given index.
+//
+// If index is out of bounds, Element reports failure and returns empty
+// (but non-nil) instance.
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//	doc.Select("li").Element(0).Text().Equal("first")
+func (h *HTML) Element(index int) *HTML {
+	opChain := h.chain.enter("Element(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHTMLSelection(opChain, nil)
+	}
+
+	if index < 0 || index >= len(h.nodes) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(h.nodes) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid element index"),
+			},
+		})
+		return newHTMLSelection(opChain, nil)
+	}
+
+	return newHTMLSelection(opChain, []*html.Node{h.nodes[index]})
+}
+
+// Text returns a new String instance with text content of selected
+// nodes, including their descendants.
+//
+// Text of multiple nodes is concatenated.
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//	doc.Select("h1").Text().Equal("Welcome")
+func (h *HTML) Text() *String {
+	opChain := h.chain.enter("Text()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	var buf strings.Builder
+
+	for _, n := range h.nodes {
+		htmlText(&buf, n)
+	}
+
+	return newString(opChain, buf.String())
+}
+
+// Attr returns a new String instance with value of given attribute
+// of first selected element.
+//
+// If selection is empty or element doesn't have such attribute,
+// failure is reported.
+//
+// Example:
+//
+//	doc := NewHTML(t, body)
+//	doc.Select("a.logo").Attr("href").Equal("/")
+func (h *HTML) Attr(name string) *String {
+	opChain := h.chain.enter("Attr(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if len(h.nodes) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{h.nodes},
+			Errors: []error{
+				errors.New("expected: non-empty selection"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	value, ok := htmlAttr(h.nodes[0], name)
+	if !ok {
+		attrs := map[string]interface{}{}
+		for _, a := range h.nodes[0].Attr {
+			attrs[a.Key] = a.Val
+		}
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{attrs},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: element has attribute"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
+
+func htmlText(buf *strings.Builder, n *html.Node) {
+	if n.Type == html.TextNode {
+		buf.WriteString(n.Data)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		htmlText(buf, c)
+	}
+}
+
+func htmlAttr(n *html.Node, name string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Namespace == "" && a.Key == name {
+			return a.Val, true
+		}
+	}
+
+	return "", false
+}
+
+// list of complex selectors, separated by commas
+type htmlSelector []htmlComplexSelector
+
+func (s htmlSelector) match(n *html.Node) bool {
+	for _, cs := range s {
+		if cs.matchAt(n, len(cs.compounds)-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sequence of compound selectors, separated by combinators;
+// combinators[i] is placed between compounds[i] and compounds[i+1]
+// and is either ' ' (descendant) or '>' (child)
+type htmlComplexSelector struct {
+	compounds   []htmlCompoundSelector
+	combinators []byte
+}
+
+func (s *htmlComplexSelector) matchAt(n *html.Node, i int) bool {
+	if !s.compounds[i].match(n) {
+		return false
+	}
+
+	if i == 0 {
+		return true
+	}
+
+	if s.combinators[i-1] == '>' {
+		return n.Parent != nil && s.matchAt(n.Parent, i-1)
+	}
+
+	for p := n.Parent; p != nil; p = p.Parent {
+		if s.matchAt(p, i-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// type, id, class and attribute selectors applied to single element
+type htmlCompoundSelector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []htmlAttrSelector
+}
+
+type htmlAttrSelector struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+func (s *htmlCompoundSelector) match(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+
+	if s.tag != "" && s.tag != n.Data {
+		return false
+	}
+
+	if s.id != "" {
+		if id, _ := htmlAttr(n, "id"); id != s.id {
+			return false
+		}
+	}
+
+	if len(s.classes) != 0 {
+		class, _ := htmlAttr(n, "class")
+		classes := strings.Fields(class)
+
+		for _, want := range s.classes {
+			found := false
+			for _, have := range classes {
+				if have == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	for _, attr := range s.attrs {
+		value, ok := htmlAttr(n, attr.name)
+		if !ok || (attr.hasValue && value != attr.value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseHTMLSelector(selector string) (htmlSelector, error) {
+	p := &htmlSelectorParser{input: selector}
+
+	var sel htmlSelector
+
+	for {
+		cs, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, cs)
+
+		if p.eof() {
+			return sel, nil
+		}
+
+		// parseComplex stops only at end of input or at comma
+		p.pos++
+	}
+}
+
+type htmlSelectorParser struct {
+	input string
+	pos   int
+}
+
+func (p *htmlSelectorParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *htmlSelectorParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *htmlSelectorParser) skipSpace() bool {
+	start := p.pos
+	for !p.eof() && strings.IndexByte(" \t\r\n\f", p.peek()) >= 0 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *htmlSelectorParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at position %d in %q",
+		fmt.Sprintf(format, args...), p.pos, p.input)
+}
+
+func (p *htmlSelectorParser) parseComplex() (htmlComplexSelector, error) {
+	var cs htmlComplexSelector
+
+	p.skipSpace()
+
+	compound, err := p.parseCompound()
+	if err != nil {
+		return cs, err
+	}
+	cs.compounds = append(cs.compounds, compound)
+
+	for {
+		hasSpace := p.skipSpace()
+
+		if p.eof() || p.peek() == ',' {
+			return cs, nil
+		}
+
+		combinator := byte(' ')
+		if p.peek() == '>' {
+			combinator = '>'
+			p.pos++
+			p.skipSpace()
+		} else if !hasSpace {
+			return cs, p.errorf("unexpected character %q", p.peek())
+		}
+
+		compound, err := p.parseCompound()
+		if err != nil {
+			return cs, err
+		}
+
+		cs.compounds = append(cs.compounds, compound)
+		cs.combinators = append(cs.combinators, combinator)
+	}
+}
+
+func (p *htmlSelectorParser) parseCompound() (htmlCompoundSelector, error) {
+	var s htmlCompoundSelector
+
+	start := p.pos
+
+	if p.peek() == '*' {
+		p.pos++
+	} else {
+		s.tag = strings.ToLower(p.parseIdent())
+	}
+
+	for {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			if s.id = p.parseIdent(); s.id == "" {
+				return s, p.errorf("expected id")
+			}
+
+		case '.':
+			p.pos++
+			class := p.parseIdent()
+			if class == "" {
+				return s, p.errorf("expected class name")
+			}
+			s.classes = append(s.classes, class)
+
+		case '[':
+			p.pos++
+			attr, err := p.parseAttr()
+			if err != nil {
+				return s, err
+			}
+			s.attrs = append(s.attrs, attr)
+
+		default:
+			if p.pos == start {
+				if p.eof() {
+					return s, p.errorf("expected selector")
+				}
+				return s, p.errorf("unexpected character %q", p.peek())
+			}
+			return s, nil
+		}
+	}
+}
+
+func (p *htmlSelectorParser) parseAttr() (htmlAttrSelector, error) {
+	var s htmlAttrSelector
+
+	p.skipSpace()
+
+	if s.name = strings.ToLower(p.parseIdent()); s.name == "" {
+		return s, p.errorf("expected attribute name")
+	}
+
+	p.skipSpace()
+
+	if p.peek() == '=' {
+		p.pos++
+		p.skipSpace()
+
+		s.hasValue = true
+
+		if quote := p.peek(); quote == '"' || quote == '\'' {
+			end := strings.IndexByte(p.input[p.pos+1:], quote)
+			if end < 0 {
+				return s, p.errorf("unterminated string")
+			}
+			s.value = p.input[p.pos+1 : p.pos+1+end]
+			p.pos += end + 2
+		} else if s.value = p.parseIdent(); s.value == "" {
+			return s, p.errorf("expected attribute value")
+		}
+
+		p.skipSpace()
+	}
+
+	if p.peek() != ']' {
+		return s, p.errorf("expected ']'")
+	}
+	p.pos++
+
+	return s, nil
+}
+
+func (p *htmlSelectorParser) parseIdent() string {
+	start := p.pos
+
+	for !p.eof() {
+		c := p.peek()
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9') || c == '-' || c == '_' || c >= 0x80 {
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	return p.input[start:p.pos]
+}
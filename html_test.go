@@ -0,0 +1,202 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testHTMLDocument = `<!DOCTYPE html>
+<html>
+<head><title>Home</title></head>
+<body>
+  <div id="main">
+    <ul class="menu top">
+      <li><a href="/" class="active">Home</a></li>
+      <li><a href="/about">About</a></li>
+      <li><span><a href="/nested" title="a b">Nested</a></span></li>
+    </ul>
+    <input type="submit" disabled>
+  </div>
+  <p class="menu">Footer</p>
+</body>
+</html>`
+
+func TestHTML_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newHTML(chain, []byte(testHTMLDocument))
+
+	value.chain.assertFailed(t)
+
+	assert.Nil(t, value.Raw())
+	assert.NotNil(t, value.Select("li"))
+	assert.NotNil(t, value.Length())
+	assert.NotNil(t, value.Element(0))
+	assert.NotNil(t, value.Text())
+	assert.NotNil(t, value.Attr("id"))
+
+	value.Select("li").chain.assertFailed(t)
+	value.Length().chain.assertFailed(t)
+	value.Element(0).chain.assertFailed(t)
+	value.Text().chain.assertFailed(t)
+	value.Attr("id").chain.assertFailed(t)
+}
+
+func TestHTML_Constructors(t *testing.T) {
+	body := []byte("<h1>Hello</h1>")
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewHTML(reporter, body)
+		value.Select("h1").Text().Equal("Hello")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewHTMLC(Config{
+			Reporter: reporter,
+		}, body)
+		value.Select("h1").Text().Equal("Hello")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newHTML(chain, body)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestHTML_Select(t *testing.T) {
+	cases := []struct {
+		selector string
+		texts    []string
+	}{
+		{"title", []string{"Home"}},
+		{"TITLE", []string{"Home"}},
+		{"li a", []string{"Home", "About", "Nested"}},
+		{"li > a", []string{"Home", "About"}},
+		{"ul>li>a", []string{"Home", "About"}},
+		{"#main .menu a.active", []string{"Home"}},
+		{".menu.top > li > a.active", []string{"Home"}},
+		{"ul.menu.top li:first", nil},
+		{"p.menu, a[href='/about']", []string{"About", "Footer"}},
+		{"a[title=\"a b\"]", []string{"Nested"}},
+		{"[disabled]", []string{""}},
+		{"input[type=submit]", []string{""}},
+		{"input[type=button]", []string{}},
+		{"* > span > *", []string{"Nested"}},
+		{"div#other", []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			doc := NewHTML(reporter, []byte(testHTMLDocument))
+			sel := doc.Select(tc.selector)
+
+			if tc.texts == nil {
+				sel.chain.assertFailed(t)
+				return
+			}
+
+			sel.chain.assertNotFailed(t)
+
+			texts := []string{}
+			for i := range sel.Raw() {
+				texts = append(texts, sel.Element(i).Text().Raw())
+			}
+
+			assert.Equal(t, tc.texts, texts)
+		})
+	}
+}
+
+func TestHTML_SelectNested(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	doc := NewHTML(reporter, []byte(testHTMLDocument))
+
+	menu := doc.Select("ul")
+	menu.Length().Equal(1)
+
+	menu.Select("ul").Length().Equal(0)
+	menu.Select("li").Length().Equal(3)
+	menu.Select("li").Select("a").Length().Equal(3)
+
+	doc.Select(".menu").Length().Equal(2)
+
+	doc.chain.assertNotFailed(t)
+}
+
+func TestHTML_InvalidSelector(t *testing.T) {
+	for _, selector := range []string{
+		"",
+		"a,",
+		"a >",
+		"#",
+		".",
+		"a[",
+		"a[href",
+		"a[=x]",
+		"a[href=]",
+		"a[href='x]",
+		"a:hover",
+		"a + b",
+	} {
+		t.Run(selector, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			doc := NewHTML(reporter, []byte(testHTMLDocument))
+			sel := doc.Select(selector)
+
+			sel.chain.assertFailed(t)
+			assert.Nil(t, sel.Raw())
+		})
+	}
+}
+
+func TestHTML_Element(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	items := NewHTML(reporter, []byte(testHTMLDocument)).Select("li")
+
+	items.Element(1).Text().Equal("About")
+	items.chain.assertNotFailed(t)
+
+	items.Element(3).chain.assertFailed(t)
+	items.chain.clearFailed()
+
+	items.Element(-1).chain.assertFailed(t)
+	items.chain.clearFailed()
+}
+
+func TestHTML_Text(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	doc := NewHTML(reporter, []byte(testHTMLDocument))
+
+	doc.Select("a").Text().Equal("HomeAboutNested")
+	doc.Select("span").Text().Equal("Nested")
+	doc.Select("table").Text().Equal("")
+
+	doc.chain.assertNotFailed(t)
+}
+
+func TestHTML_Attr(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	doc := NewHTML(reporter, []byte(testHTMLDocument))
+
+	doc.Select("a").Attr("href").Equal("/")
+	doc.Select("input").Attr("disabled").Equal("")
+	doc.chain.assertNotFailed(t)
+
+	doc.Select("a").Attr("target").chain.assertFailed(t)
+	doc.Select("table").Attr("id").chain.assertFailed(t)
+}
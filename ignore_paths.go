@@ -0,0 +1,151 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ignored paths are used by EqualIgnoring methods of Value, Object and
+// Array to exclude volatile fields from comparison.
+//
+// Path is a sequence of object keys and array indexes, e.g.:
+//   - "created_at" or "$.created_at" - top-level key
+//   - "meta.request_id" - nested key
+//   - "items[0].id" - key of first array element
+//   - "items[*].id" or "items.*.id" - key of every array element
+//   - "*.id" - key of every object value
+type ignorePath []string
+
+const ignorePathWildcard = "*"
+
+func parseIgnorePaths(opChain *chain, paths []string) ([]ignorePath, bool) {
+	result := make([]ignorePath, 0, len(paths))
+
+	for _, path := range paths {
+		p, err := parseIgnorePath(path)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("invalid ignored path %q", path),
+					err,
+				},
+			})
+			return nil, false
+		}
+		result = append(result, p)
+	}
+
+	return result, true
+}
+
+func parseIgnorePath(path string) (ignorePath, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if s == "" {
+		return nil, errors.New("empty path")
+	}
+
+	var segments ignorePath
+
+	for _, part := range strings.Split(s, ".") {
+		key := part
+		rest := ""
+
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key, rest = part[:i], part[i:]
+		}
+
+		if strings.IndexByte(key, ']') >= 0 {
+			return nil, fmt.Errorf("malformed index in %q", part)
+		}
+
+		if key != "" {
+			segments = append(segments, key)
+		} else if rest == "" {
+			return nil, errors.New("empty path segment")
+		}
+
+		for rest != "" {
+			end := strings.IndexByte(rest, ']')
+			if rest[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("malformed index in %q", part)
+			}
+
+			index := rest[1:end]
+			if index != ignorePathWildcard {
+				if _, err := strconv.Atoi(index); err != nil {
+					return nil, fmt.Errorf("malformed index in %q", part)
+				}
+			}
+
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		}
+	}
+
+	return segments, nil
+}
+
+// removeIgnoredPaths returns a copy of canonical value without given paths;
+// original value is not modified
+func removeIgnoredPaths(value interface{}, paths []ignorePath) interface{} {
+	for _, path := range paths {
+		value = removeIgnoredPath(value, path)
+	}
+
+	return value
+}
+
+func removeIgnoredPath(value interface{}, path ignorePath) interface{} {
+	if len(path) == 0 {
+		return value
+	}
+
+	head, tail := path[0], path[1:]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			if head != ignorePathWildcard && head != key {
+				result[key] = elem
+				continue
+			}
+			if len(tail) == 0 {
+				continue
+			}
+			result[key] = removeIgnoredPath(elem, tail)
+		}
+		return result
+
+	case []interface{}:
+		index := -1
+		if head != ignorePathWildcard {
+			n, err := strconv.Atoi(head)
+			if err != nil {
+				return value
+			}
+			index = n
+		}
+
+		// ignored elements are replaced with null instead of being removed,
+		// so that indexes of other elements are preserved
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			switch {
+			case index >= 0 && i != index:
+				result[i] = elem
+			case len(tail) == 0:
+				result[i] = nil
+			default:
+				result[i] = removeIgnoredPath(elem, tail)
+			}
+		}
+		return result
+
+	default:
+		return value
+	}
+}
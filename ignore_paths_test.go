@@ -0,0 +1,144 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnorePaths_Parse(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected ignorePath
+	}{
+		{"foo", ignorePath{"foo"}},
+		{"$.foo", ignorePath{"foo"}},
+		{".foo", ignorePath{"foo"}},
+		{"foo.bar", ignorePath{"foo", "bar"}},
+		{"foo[0]", ignorePath{"foo", "0"}},
+		{"foo[0].bar", ignorePath{"foo", "0", "bar"}},
+		{"foo[*].bar", ignorePath{"foo", "*", "bar"}},
+		{"foo.*.bar", ignorePath{"foo", "*", "bar"}},
+		{"foo[0][1]", ignorePath{"foo", "0", "1"}},
+		{"$[0]", ignorePath{"0"}},
+		{"[*].id", ignorePath{"*", "id"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := parseIgnorePath(tc.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, p)
+		})
+	}
+
+	for _, path := range []string{
+		"", "$", "foo..bar", "foo.", "foo[", "foo[x]", "foo[0]x", "foo]",
+	} {
+		t.Run("invalid "+path, func(t *testing.T) {
+			_, err := parseIgnorePath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIgnorePaths_Remove(t *testing.T) {
+	value := map[string]interface{}{
+		"id": 1.0,
+		"meta": map[string]interface{}{
+			"request_id": "abc",
+			"version":    2.0,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": 1.0, "name": "a"},
+			map[string]interface{}{"id": 2.0, "name": "b"},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		paths    []string
+		expected interface{}
+	}{
+		{
+			name:     "no paths",
+			paths:    nil,
+			expected: value,
+		},
+		{
+			name:  "top-level key",
+			paths: []string{"id"},
+			expected: map[string]interface{}{
+				"meta":  value["meta"],
+				"items": value["items"],
+			},
+		},
+		{
+			name:  "nested key",
+			paths: []string{"meta.request_id"},
+			expected: map[string]interface{}{
+				"id": 1.0,
+				"meta": map[string]interface{}{
+					"version": 2.0,
+				},
+				"items": value["items"],
+			},
+		},
+		{
+			name:  "array element",
+			paths: []string{"items[0]"},
+			expected: map[string]interface{}{
+				"id":   1.0,
+				"meta": value["meta"],
+				"items": []interface{}{
+					nil,
+					map[string]interface{}{"id": 2.0, "name": "b"},
+				},
+			},
+		},
+		{
+			name:  "wildcard index",
+			paths: []string{"items[*].id"},
+			expected: map[string]interface{}{
+				"id":   1.0,
+				"meta": value["meta"],
+				"items": []interface{}{
+					map[string]interface{}{"name": "a"},
+					map[string]interface{}{"name": "b"},
+				},
+			},
+		},
+		{
+			name:  "wildcard key",
+			paths: []string{"*.version", "*.request_id"},
+			expected: map[string]interface{}{
+				"id":    1.0,
+				"meta":  map[string]interface{}{},
+				"items": value["items"],
+			},
+		},
+		{
+			name:     "missing path",
+			paths:    []string{"foo.bar", "id.foo", "items[5]", "meta[0]"},
+			expected: value,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var paths []ignorePath
+			for _, s := range tc.paths {
+				p, err := parseIgnorePath(s)
+				assert.NoError(t, err)
+				paths = append(paths, p)
+			}
+
+			assert.Equal(t, tc.expected, removeIgnoredPaths(value, paths))
+		})
+	}
+
+	t.Run("original not modified", func(t *testing.T) {
+		removeIgnoredPaths(value, []ignorePath{{"meta", "request_id"}})
+		assert.Contains(t, value["meta"], "request_id")
+	})
+}
@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/xeipuuv/gojsonschema"
-	"github.com/yalp/jsonpath"
 )
 
 func jsonPath(chain *chain, value interface{}, path string) *Value {
@@ -15,7 +16,7 @@ func jsonPath(chain *chain, value interface{}, path string) *Value {
 		return newValue(chain, nil)
 	}
 
-	filterFn, err := jsonpath.Prepare(path)
+	filterFn, err := prepareJSONPath(path)
 	if err != nil {
 		chain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -45,6 +46,113 @@ func jsonPath(chain *chain, value interface{}, path string) *Value {
 	return newValue(chain, result)
 }
 
+func jsonPointer(chain *chain, value interface{}, pointer string) *Value {
+	if chain.failed() {
+		return newValue(chain, nil)
+	}
+
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		chain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{pointer},
+			Errors: []error{
+				errors.New("expected: valid json pointer"),
+				err,
+			},
+		})
+		return newValue(chain, nil)
+	}
+
+	result := value
+
+	for i, token := range tokens {
+		next, err := resolvePointerToken(result, token)
+		if err != nil {
+			prefix := "/" + strings.Join(escapePointerTokens(tokens[:i+1]), "/")
+
+			chain.fail(AssertionFailure{
+				Type:     AssertMatchPath,
+				Actual:   &AssertionValue{value},
+				Expected: &AssertionValue{pointer},
+				Errors: []error{
+					errors.New("expected: value matches given json pointer"),
+					fmt.Errorf("can't resolve %q: %s", prefix, err.Error()),
+				},
+			})
+			return newValue(chain, nil)
+		}
+		result = next
+	}
+
+	return newValue(chain, result)
+}
+
+// parse JSON Pointer (RFC 6901) into unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("pointer %q should be empty or start with '/'", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' &&
+				(j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("invalid escape sequence in %q", token)
+			}
+		}
+
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+func escapePointerTokens(tokens []string) []string {
+	escaped := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		escaped = append(escaped,
+			strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+
+	return escaped
+}
+
+func resolvePointerToken(value interface{}, token string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		elem, ok := v[token]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found", token)
+		}
+		return elem, nil
+
+	case []interface{}:
+		if token == "-" {
+			return nil, errors.New("index \"-\" refers to nonexistent element")
+		}
+		if token == "" || (len(token) > 1 && token[0] == '0') ||
+			strings.TrimLeft(token, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid array index %q", token)
+		}
+		index, err := strconv.Atoi(token)
+		if err != nil || index >= len(v) {
+			return nil, fmt.Errorf("index %s out of range [0; %d)", token, len(v))
+		}
+		return v[index], nil
+
+	default:
+		return nil, fmt.Errorf("can't access %q of non-container value", token)
+	}
+}
+
 func jsonSchema(chain *chain, value, schema interface{}) {
 	if chain.failed() {
 		return
@@ -0,0 +1,506 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yalp/jsonpath"
+)
+
+// jsonpath package doesn't support filter expressions, like
+// "$.items[?(@.price > 10)].name", so we handle them here.
+//
+// Path is split at the first filter. The part before filter is evaluated
+// by jsonpath package; filter is applied to children (array elements or
+// object values) of the matched value(s); the part after filter is
+// evaluated for every child that passed the filter, and may contain
+// more filters.
+//
+// Path with filter may match any number of values, so its result is
+// always an array of matches.
+type jsonPathFunc func(value interface{}) (interface{}, error)
+
+func prepareJSONPath(path string) (jsonPathFunc, error) {
+	start, end, err := findJSONPathFilter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if start < 0 {
+		fn, err := jsonpath.Prepare(path)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathFunc(fn), nil
+	}
+
+	prefix, rest := path[:start], path[end:]
+
+	prefixFn, err := jsonpath.Prepare(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseJSONPathFilter(path[start+len("[?(") : end-len(")]")])
+	if err != nil {
+		return nil, err
+	}
+
+	var restFn jsonPathFunc
+	if rest != "" {
+		if restFn, err = prepareJSONPath("$" + rest); err != nil {
+			return nil, err
+		}
+	}
+
+	prefixDefinite := isDefiniteJSONPath(prefix)
+	restDefinite := isDefiniteJSONPath(rest)
+
+	return func(value interface{}) (interface{}, error) {
+		base, err := prefixFn(value)
+		if err != nil {
+			return nil, err
+		}
+
+		parents := []interface{}{base}
+		if !prefixDefinite {
+			parents, _ = base.([]interface{})
+		}
+
+		matches := []interface{}{}
+
+		for _, parent := range parents {
+			for _, child := range jsonPathChildren(parent) {
+				if !filter.match(child) {
+					continue
+				}
+
+				if restFn == nil {
+					matches = append(matches, child)
+					continue
+				}
+
+				// like with wildcards, children that don't have
+				// requested path are skipped
+				result, err := restFn(child)
+				if err != nil {
+					continue
+				}
+
+				if restDefinite {
+					matches = append(matches, result)
+				} else if list, ok := result.([]interface{}); ok {
+					matches = append(matches, list...)
+				}
+			}
+		}
+
+		return matches, nil
+	}, nil
+}
+
+// check if path contains at least one "[?(...)]"
+func hasJSONPathFilter(path string) bool {
+	start, _, err := findJSONPathFilter(path)
+	return err == nil && start >= 0
+}
+
+// find first "[?(...)]" in path, ignoring quoted strings;
+// returns -1 if there are no filters
+func findJSONPathFilter(path string) (start, end int, err error) {
+	start = -1
+
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '"' || path[i] == '\'':
+			i = skipJSONPathQuoted(path, i)
+
+		case strings.HasPrefix(path[i:], "[?("):
+			start = i
+		}
+
+		if start >= 0 {
+			break
+		}
+	}
+
+	if start < 0 {
+		return -1, -1, nil
+	}
+
+	depth := 0
+
+	for i := start + len("[?"); i < len(path); i++ {
+		switch path[i] {
+		case '"', '\'':
+			i = skipJSONPathQuoted(path, i)
+
+		case '(':
+			depth++
+
+		case ')':
+			depth--
+			if depth == 0 {
+				if i+1 == len(path) || path[i+1] != ']' {
+					return 0, 0, fmt.Errorf("expected ']' after filter at %d", i+1)
+				}
+				return start, i + 2, nil
+			}
+		}
+	}
+
+	return 0, 0, fmt.Errorf("unterminated filter at %d", start)
+}
+
+// returns index of closing quote, or len(s) if there is no one
+func skipJSONPathQuoted(s string, i int) int {
+	quote := s[i]
+
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+// definite path refers to at most one value, i.e. has no wildcards,
+// recursive descent, unions, slices, or filters
+func isDefiniteJSONPath(path string) bool {
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '"', '\'':
+			i = skipJSONPathQuoted(path, i)
+		case '*', ',', ':', '?':
+			return false
+		case '.':
+			if i+1 < len(path) && path[i+1] == '.' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func jsonPathChildren(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		children := make([]interface{}, 0, len(v))
+		for _, key := range keys {
+			children = append(children, v[key])
+		}
+		return children
+
+	default:
+		return nil
+	}
+}
+
+// Filter expression is a combination of:
+//   - "@" path relative to current child, e.g. "@.price" or "@.tags[0]"
+//   - number, string ("..." or '...'), true, false, or null literal
+//   - comparisons: ==, !=, <, <=, >, >=
+//   - logical operators: &&, ||, !, and parentheses
+//
+// Path alone checks that it exists in current child.
+type jsonPathFilter struct {
+	op          string
+	left, right *jsonPathFilter
+
+	path    jsonpath.FilterFunc
+	literal interface{}
+}
+
+func (f *jsonPathFilter) match(value interface{}) bool {
+	switch f.op {
+	case "||":
+		return f.left.match(value) || f.right.match(value)
+
+	case "&&":
+		return f.left.match(value) && f.right.match(value)
+
+	case "!":
+		return !f.left.match(value)
+
+	case "==", "!=", "<", "<=", ">", ">=":
+		left, lok := f.left.operand(value)
+		right, rok := f.right.operand(value)
+		return compareJSONPathOperands(f.op, left, lok, right, rok)
+
+	default:
+		if f.path != nil {
+			_, ok := f.operand(value)
+			return ok
+		}
+		return f.literal == true
+	}
+}
+
+func (f *jsonPathFilter) operand(value interface{}) (interface{}, bool) {
+	if f.path == nil {
+		return f.literal, true
+	}
+
+	result, err := f.path(value)
+	if err != nil {
+		return nil, false
+	}
+
+	if num, ok := result.(json.Number); ok {
+		if n, err := num.Float64(); err == nil {
+			return n, true
+		}
+	}
+
+	return result, true
+}
+
+func compareJSONPathOperands(
+	op string, left interface{}, lok bool, right interface{}, rok bool,
+) bool {
+	switch op {
+	case "==":
+		return lok && rok && reflect.DeepEqual(left, right)
+
+	case "!=":
+		return !(lok && rok && reflect.DeepEqual(left, right))
+	}
+
+	if !lok || !rok {
+		return false
+	}
+
+	var cmp int
+
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(l, r)
+
+	default:
+		return false
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+type jsonPathFilterParser struct {
+	expr string
+	pos  int
+}
+
+func parseJSONPathFilter(expr string) (*jsonPathFilter, error) {
+	p := &jsonPathFilterParser{expr: expr}
+
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.skipSpaces(); p.pos != len(p.expr) {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos:])
+	}
+
+	return filter, nil
+}
+
+func (p *jsonPathFilterParser) parseOr() (*jsonPathFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &jsonPathFilter{op: "||", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *jsonPathFilterParser) parseAnd() (*jsonPathFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &jsonPathFilter{op: "&&", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *jsonPathFilterParser) parseUnary() (*jsonPathFilter, error) {
+	if !p.peek("!=") && p.consume("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathFilter{op: "!", left: operand}, nil
+	}
+
+	if p.consume("(") {
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return filter, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			right, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			return &jsonPathFilter{op: op, left: left, right: right}, nil
+		}
+	}
+
+	return left, nil
+}
+
+func (p *jsonPathFilterParser) parseOperand() (*jsonPathFilter, error) {
+	p.skipSpaces()
+
+	if p.pos == len(p.expr) {
+		return nil, p.errorf("expected operand")
+	}
+
+	start := p.pos
+
+	switch c := p.expr[p.pos]; {
+	case c == '@':
+		for p.pos++; p.pos < len(p.expr); p.pos++ {
+			if c := p.expr[p.pos]; c == '"' || c == '\'' {
+				p.pos = skipJSONPathQuoted(p.expr, p.pos)
+			} else if strings.IndexByte(" \t\n=!<>&|()", c) >= 0 {
+				break
+			}
+		}
+		fn, err := jsonpath.Prepare("$" + p.expr[start+1:p.pos])
+		if err != nil {
+			return nil, p.errorf("invalid path %q: %v", p.expr[start:p.pos], err)
+		}
+		return &jsonPathFilter{path: fn}, nil
+
+	case c == '"' || c == '\'':
+		p.pos = skipJSONPathQuoted(p.expr, p.pos)
+		if p.pos == len(p.expr) {
+			return nil, p.errorf("unterminated string")
+		}
+		p.pos++
+		lit := p.expr[start:p.pos]
+		if c == '\'' {
+			lit = `"` + strings.ReplaceAll(
+				strings.ReplaceAll(lit[1:len(lit)-1], `\'`, `'`), `"`, `\"`) + `"`
+		}
+		str, err := strconv.Unquote(lit)
+		if err != nil {
+			return nil, p.errorf("invalid string %s", p.expr[start:p.pos])
+		}
+		return &jsonPathFilter{literal: str}, nil
+
+	default:
+		for p.pos < len(p.expr) &&
+			strings.IndexByte(" \t\n=!<>&|()", p.expr[p.pos]) < 0 {
+			p.pos++
+		}
+		lit := p.expr[start:p.pos]
+		switch lit {
+		case "true":
+			return &jsonPathFilter{literal: true}, nil
+		case "false":
+			return &jsonPathFilter{literal: false}, nil
+		case "null":
+			return &jsonPathFilter{literal: nil}, nil
+		}
+		num, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			return nil, p.errorf("unexpected %q", lit)
+		}
+		return &jsonPathFilter{literal: num}, nil
+	}
+}
+
+func (p *jsonPathFilterParser) skipSpaces() {
+	for p.pos < len(p.expr) && strings.IndexByte(" \t\n", p.expr[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *jsonPathFilterParser) peek(token string) bool {
+	p.skipSpaces()
+	return strings.HasPrefix(p.expr[p.pos:], token)
+}
+
+func (p *jsonPathFilterParser) consume(token string) bool {
+	if p.peek(token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathFilterParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid filter expression at %d: %s",
+		p.pos, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,629 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// JSONAPI provides methods to inspect JSON:API document, i.e. JSON
+// object with "data", "errors", "meta", and "included" keys.
+//
+// See https://jsonapi.org/format/.
+//
+// Example:
+//
+//	doc := e.GET("/articles/1").
+//		WithQuery("include", "author").
+//		Expect().
+//		Status(http.StatusOK).
+//		JSONAPI()
+//
+//	doc.NoErrors()
+//	doc.Data().Object().Path("$.attributes.title").String().Equal("JSON:API")
+//	doc.Related("author").Object().Path("$.attributes.name").String().Equal("Dan")
+type JSONAPI struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+// NewJSONAPI returns a new JSONAPI instance.
+//
+// If reporter is nil, the function panics.
+// If value is not a valid JSON:API document, failure is reported.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": map[string]interface{}{"type": "articles", "id": "1"},
+//	})
+func NewJSONAPI(reporter Reporter, value interface{}) *JSONAPI {
+	return newJSONAPI(newChainWithDefaults("JSONAPI()", reporter), value)
+}
+
+// NewJSONAPIC returns a new JSONAPI instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is not a valid JSON:API document, failure is reported.
+//
+// Example:
+//
+//	doc := NewJSONAPIC(config, map[string]interface{}{
+//		"data": map[string]interface{}{"type": "articles", "id": "1"},
+//	})
+func NewJSONAPIC(config Config, value interface{}) *JSONAPI {
+	return newJSONAPI(newChainWithConfig("JSONAPI()", config.withDefaults()), value)
+}
+
+func newJSONAPI(parent *chain, val interface{}) *JSONAPI {
+	j := &JSONAPI{chain: parent.clone(), value: nil}
+
+	opChain := j.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	canon, ok := canonValue(opChain, val)
+	if !ok {
+		return j
+	}
+
+	object, ok := canon.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: JSON:API document is an object"),
+			},
+		})
+		return j
+	}
+
+	if err := validateJSONAPI(object); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: valid JSON:API document"),
+				err,
+			},
+		})
+		return j
+	}
+
+	j.value = object
+
+	return j
+}
+
+func validateJSONAPI(doc map[string]interface{}) error {
+	data, hasData := doc["data"]
+	errs, hasErrors := doc["errors"]
+	meta, hasMeta := doc["meta"]
+	included, hasIncluded := doc["included"]
+
+	if !hasData && !hasErrors && !hasMeta {
+		return errors.New(
+			`document must contain at least one of "data", "errors", "meta"`)
+	}
+
+	if hasData && hasErrors {
+		return errors.New(`document must not contain both "data" and "errors"`)
+	}
+
+	if hasIncluded && !hasData {
+		return errors.New(`document must not contain "included" without "data"`)
+	}
+
+	if hasMeta {
+		if _, ok := meta.(map[string]interface{}); !ok {
+			return errors.New(`"meta" must be an object`)
+		}
+	}
+
+	if hasData {
+		switch d := data.(type) {
+		case nil:
+		case map[string]interface{}:
+			if err := validateJSONAPIResource(d); err != nil {
+				return fmt.Errorf(`"data": %v`, err)
+			}
+		case []interface{}:
+			for i, r := range d {
+				if err := validateJSONAPIResource(r); err != nil {
+					return fmt.Errorf(`"data[%d]": %v`, i, err)
+				}
+			}
+		default:
+			return errors.New(`"data" must be null, an object, or an array`)
+		}
+	}
+
+	if hasIncluded {
+		arr, ok := included.([]interface{})
+		if !ok {
+			return errors.New(`"included" must be an array`)
+		}
+		for i, r := range arr {
+			if err := validateJSONAPIResource(r); err != nil {
+				return fmt.Errorf(`"included[%d]": %v`, i, err)
+			}
+		}
+	}
+
+	if hasErrors {
+		arr, ok := errs.([]interface{})
+		if !ok {
+			return errors.New(`"errors" must be an array`)
+		}
+		for i, e := range arr {
+			if _, ok := e.(map[string]interface{}); !ok {
+				return fmt.Errorf(`"errors[%d]" must be an object`, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateJSONAPIResource(val interface{}) error {
+	resource, ok := val.(map[string]interface{})
+	if !ok {
+		return errors.New("resource must be an object")
+	}
+
+	if _, ok := resource["type"].(string); !ok {
+		return errors.New(`resource must have string "type"`)
+	}
+
+	_, hasID := resource["id"].(string)
+	_, hasLID := resource["lid"].(string)
+
+	if !hasID && !hasLID {
+		return errors.New(`resource must have string "id" or "lid"`)
+	}
+
+	for _, key := range []string{"attributes", "relationships", "links", "meta"} {
+		if member, ok := resource[key]; ok {
+			if _, ok := member.(map[string]interface{}); !ok {
+				return fmt.Errorf(`resource %q must be an object`, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Raw returns underlying value attached to JSONAPI.
+// This is the value originally passed to NewJSONAPI, converted to
+// canonical form.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{"data": nil})
+//	assert.Equal(t, map[string]interface{}{"data": nil}, doc.Raw())
+func (j *JSONAPI) Raw() map[string]interface{} {
+	return j.value
+}
+
+// Data returns a new Value instance with "data" key of JSON:API document,
+// i.e. primary data.
+//
+// Primary data is either null, a resource object, or an array of resource
+// objects. If there is no "data" key, returned Value contains null.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": map[string]interface{}{"type": "articles", "id": "1"},
+//	})
+//	doc.Data().Object().ValueEqual("type", "articles")
+func (j *JSONAPI) Data() *Value {
+	opChain := j.chain.enter("Data()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, j.value["data"])
+}
+
+// Included returns a new Array instance with "included" key of JSON:API
+// document.
+//
+// If there is no "included" key, returned Array is empty.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": nil,
+//		"included": []interface{}{
+//			map[string]interface{}{"type": "people", "id": "9"},
+//		},
+//	})
+//	doc.Included().Length().Equal(1)
+func (j *JSONAPI) Included() *Array {
+	opChain := j.chain.enter("Included()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, j.included())
+}
+
+// Errors returns a new Array instance with "errors" key of JSON:API
+// document.
+//
+// If there is no "errors" key, returned Array is empty.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"errors": []interface{}{
+//			map[string]interface{}{"status": "404", "title": "Not Found"},
+//		},
+//	})
+//	doc.Errors().Element(0).Object().ValueEqual("status", "404")
+func (j *JSONAPI) Errors() *Array {
+	opChain := j.chain.enter("Errors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	errs, _ := j.value["errors"].([]interface{})
+	if errs == nil {
+		errs = []interface{}{}
+	}
+
+	return newArray(opChain, errs)
+}
+
+// Meta returns a new Object instance with "meta" key of JSON:API
+// document.
+//
+// If there is no "meta" key, returned Object is empty.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"meta": map[string]interface{}{"total": 10},
+//	})
+//	doc.Meta().ValueEqual("total", 10)
+func (j *JSONAPI) Meta() *Object {
+	opChain := j.chain.enter("Meta()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	meta, _ := j.value["meta"].(map[string]interface{})
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+
+	return newObject(opChain, meta)
+}
+
+// NoErrors succeeds if JSON:API document has no errors, i.e. "errors" key
+// is missing or empty array.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{"data": nil})
+//	doc.NoErrors()
+func (j *JSONAPI) NoErrors() *JSONAPI {
+	opChain := j.chain.enter("NoErrors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	if errs, _ := j.value["errors"].([]interface{}); len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertEmpty,
+			Actual: &AssertionValue{errs},
+			Errors: []error{
+				errors.New("expected: JSON:API document has no errors"),
+			},
+		})
+	}
+
+	return j
+}
+
+// HasErrors succeeds if JSON:API document has at least one error.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"errors": []interface{}{
+//			map[string]interface{}{"status": "404"},
+//		},
+//	})
+//	doc.HasErrors()
+func (j *JSONAPI) HasErrors() *JSONAPI {
+	opChain := j.chain.enter("HasErrors()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	if errs, _ := j.value["errors"].([]interface{}); len(errs) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{j.value["errors"]},
+			Errors: []error{
+				errors.New("expected: JSON:API document has errors"),
+			},
+		})
+	}
+
+	return j
+}
+
+// Resource returns a new Object instance with resource object of given
+// type and id, searched in primary data and in included resources.
+//
+// If there is no such resource, failure is reported.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": []interface{}{
+//			map[string]interface{}{"type": "articles", "id": "1"},
+//		},
+//	})
+//	doc.Resource("articles", "1").ContainsKey("type")
+func (j *JSONAPI) Resource(typ, id string) *Object {
+	opChain := j.chain.enter("Resource(%q, %q)", typ, id)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	resource := j.find(typ, id)
+	if resource == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertContainsElement,
+			Actual: &AssertionValue{
+				j.value,
+			},
+			Expected: &AssertionValue{
+				map[string]interface{}{"type": typ, "id": id},
+			},
+			Errors: []error{
+				errors.New("expected: JSON:API document contains resource"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, resource)
+}
+
+// Relationship returns a new Value instance with resource linkage of
+// given relationship of primary data, i.e. "data" key of relationship
+// object.
+//
+// Linkage is either null, a resource identifier object, or an array of
+// resource identifier objects.
+//
+// If primary data is not a single resource object, or resource has no
+// such relationship, or relationship has no linkage, failure is reported.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": map[string]interface{}{
+//			"type": "articles",
+//			"id":   "1",
+//			"relationships": map[string]interface{}{
+//				"author": map[string]interface{}{
+//					"data": map[string]interface{}{"type": "people", "id": "9"},
+//				},
+//			},
+//		},
+//	})
+//	doc.Relationship("author").Object().ValueEqual("id", "9")
+func (j *JSONAPI) Relationship(name string) *Value {
+	opChain := j.chain.enter("Relationship(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	linkage, ok := j.linkage(opChain, name)
+	if !ok {
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, linkage)
+}
+
+// Related returns a new Value instance with resource objects referenced
+// by given relationship of primary data, resolved against primary data
+// and included resources.
+//
+// For to-one relationship, returned Value contains resource object, or
+// null if relationship is empty. For to-many relationship, returned Value
+// contains array of resource objects.
+//
+// If relationship can't be found (see Relationship), or some referenced
+// resource is not present in document, failure is reported.
+//
+// Example:
+//
+//	doc := NewJSONAPI(t, map[string]interface{}{
+//		"data": map[string]interface{}{
+//			"type": "articles",
+//			"id":   "1",
+//			"relationships": map[string]interface{}{
+//				"author": map[string]interface{}{
+//					"data": map[string]interface{}{"type": "people", "id": "9"},
+//				},
+//			},
+//		},
+//		"included": []interface{}{
+//			map[string]interface{}{
+//				"type":       "people",
+//				"id":         "9",
+//				"attributes": map[string]interface{}{"name": "Dan"},
+//			},
+//		},
+//	})
+//	doc.Related("author").Path("$.attributes.name").String().Equal("Dan")
+func (j *JSONAPI) Related(name string) *Value {
+	opChain := j.chain.enter("Related(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	linkage, ok := j.linkage(opChain, name)
+	if !ok {
+		return newValue(opChain, nil)
+	}
+
+	resolve := func(identifier interface{}) (interface{}, bool) {
+		ident, _ := identifier.(map[string]interface{})
+		typ, _ := ident["type"].(string)
+		id, _ := ident["id"].(string)
+
+		resource := j.find(typ, id)
+		if resource == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertContainsElement,
+				Actual: &AssertionValue{
+					j.included(),
+				},
+				Expected: &AssertionValue{
+					identifier,
+				},
+				Errors: []error{
+					fmt.Errorf(
+						"expected: JSON:API document contains resource"+
+							" referenced by relationship %q", name),
+				},
+			})
+			return nil, false
+		}
+
+		return resource, true
+	}
+
+	switch l := linkage.(type) {
+	case nil:
+		return newValue(opChain, nil)
+
+	case []interface{}:
+		resources := make([]interface{}, 0, len(l))
+		for _, identifier := range l {
+			resource, ok := resolve(identifier)
+			if !ok {
+				return newValue(opChain, nil)
+			}
+			resources = append(resources, resource)
+		}
+		return newValue(opChain, resources)
+
+	default:
+		resource, ok := resolve(l)
+		if !ok {
+			return newValue(opChain, nil)
+		}
+		return newValue(opChain, resource)
+	}
+}
+
+func (j *JSONAPI) linkage(opChain *chain, name string) (interface{}, bool) {
+	primary, ok := j.value["data"].(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{j.value["data"]},
+			Errors: []error{
+				errors.New(
+					"expected: JSON:API primary data is a single resource object"),
+			},
+		})
+		return nil, false
+	}
+
+	relationships, _ := primary["relationships"].(map[string]interface{})
+
+	relationship, ok := relationships[name].(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{relationships},
+			Expected: &AssertionValue{
+				name,
+			},
+			Errors: []error{
+				fmt.Errorf("expected: JSON:API resource has relationship %q", name),
+			},
+		})
+		return nil, false
+	}
+
+	linkage, ok := relationship["data"]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{relationship},
+			Expected: &AssertionValue{
+				"data",
+			},
+			Errors: []error{
+				fmt.Errorf(
+					"expected: JSON:API relationship %q has resource linkage", name),
+			},
+		})
+		return nil, false
+	}
+
+	return linkage, true
+}
+
+func (j *JSONAPI) find(typ, id string) map[string]interface{} {
+	candidates := []interface{}{}
+
+	switch d := j.value["data"].(type) {
+	case map[string]interface{}:
+		candidates = append(candidates, d)
+	case []interface{}:
+		candidates = append(candidates, d...)
+	}
+
+	candidates = append(candidates, j.included()...)
+
+	for _, c := range candidates {
+		resource, _ := c.(map[string]interface{})
+		if resource["type"] == typ && resource["id"] == id {
+			return resource
+		}
+	}
+
+	return nil
+}
+
+func (j *JSONAPI) included() []interface{} {
+	if included, ok := j.value["included"].([]interface{}); ok {
+		return included
+	}
+	return []interface{}{}
+}
@@ -0,0 +1,379 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONAPI_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newJSONAPI(chain, map[string]interface{}{"data": nil})
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Data())
+	assert.NotNil(t, value.Included())
+	assert.NotNil(t, value.Errors())
+	assert.NotNil(t, value.Meta())
+	assert.NotNil(t, value.Resource("articles", "1"))
+	assert.NotNil(t, value.Relationship("author"))
+	assert.NotNil(t, value.Related("author"))
+
+	value.Data().chain.assertFailed(t)
+	value.Included().chain.assertFailed(t)
+	value.Errors().chain.assertFailed(t)
+	value.Meta().chain.assertFailed(t)
+	value.Resource("articles", "1").chain.assertFailed(t)
+	value.Relationship("author").chain.assertFailed(t)
+	value.Related("author").chain.assertFailed(t)
+
+	value.NoErrors()
+	value.HasErrors()
+}
+
+func TestJSONAPI_Constructors(t *testing.T) {
+	data := map[string]interface{}{"data": nil}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewJSONAPI(reporter, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewJSONAPIC(Config{
+			Reporter: reporter,
+		}, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newJSONAPI(chain, data)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestJSONAPI_Conformance(t *testing.T) {
+	article := map[string]interface{}{"type": "articles", "id": "1"}
+
+	cases := []struct {
+		name  string
+		value interface{}
+		fail  bool
+	}{
+		{
+			name:  "null data",
+			value: map[string]interface{}{"data": nil},
+		},
+		{
+			name:  "single resource",
+			value: map[string]interface{}{"data": article},
+		},
+		{
+			name:  "resource collection",
+			value: map[string]interface{}{"data": []interface{}{article}},
+		},
+		{
+			name: "local id",
+			value: map[string]interface{}{
+				"data": map[string]interface{}{"type": "articles", "lid": "a"},
+			},
+		},
+		{
+			name: "included",
+			value: map[string]interface{}{
+				"data":     article,
+				"included": []interface{}{article},
+			},
+		},
+		{
+			name:  "errors",
+			value: map[string]interface{}{"errors": []interface{}{}},
+		},
+		{
+			name:  "meta only",
+			value: map[string]interface{}{"meta": map[string]interface{}{}},
+		},
+		{
+			name:  "nil",
+			value: nil,
+			fail:  true,
+		},
+		{
+			name:  "not object",
+			value: []interface{}{article},
+			fail:  true,
+		},
+		{
+			name:  "no top-level members",
+			value: map[string]interface{}{"links": map[string]interface{}{}},
+			fail:  true,
+		},
+		{
+			name: "data and errors",
+			value: map[string]interface{}{
+				"data":   nil,
+				"errors": []interface{}{},
+			},
+			fail: true,
+		},
+		{
+			name: "included without data",
+			value: map[string]interface{}{
+				"meta":     map[string]interface{}{},
+				"included": []interface{}{},
+			},
+			fail: true,
+		},
+		{
+			name:  "meta not object",
+			value: map[string]interface{}{"meta": "bad"},
+			fail:  true,
+		},
+		{
+			name:  "data not resource",
+			value: map[string]interface{}{"data": "bad"},
+			fail:  true,
+		},
+		{
+			name: "resource without type",
+			value: map[string]interface{}{
+				"data": map[string]interface{}{"id": "1"},
+			},
+			fail: true,
+		},
+		{
+			name: "resource without id",
+			value: map[string]interface{}{
+				"data": []interface{}{map[string]interface{}{"type": "articles"}},
+			},
+			fail: true,
+		},
+		{
+			name: "numeric id",
+			value: map[string]interface{}{
+				"data": map[string]interface{}{"type": "articles", "id": 1},
+			},
+			fail: true,
+		},
+		{
+			name: "attributes not object",
+			value: map[string]interface{}{
+				"data": map[string]interface{}{
+					"type":       "articles",
+					"id":         "1",
+					"attributes": []interface{}{},
+				},
+			},
+			fail: true,
+		},
+		{
+			name: "included not array",
+			value: map[string]interface{}{
+				"data":     nil,
+				"included": article,
+			},
+			fail: true,
+		},
+		{
+			name:  "errors not array",
+			value: map[string]interface{}{"errors": "bad"},
+			fail:  true,
+		},
+		{
+			name:  "error not object",
+			value: map[string]interface{}{"errors": []interface{}{"bad"}},
+			fail:  true,
+		},
+		{
+			name:  "not marshalable",
+			value: func() {},
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewJSONAPI(reporter, tc.value)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				assert.Nil(t, value.Raw())
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.NotNil(t, value.Raw())
+			}
+		})
+	}
+}
+
+func TestJSONAPI_Members(t *testing.T) {
+	t.Run("data", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{"type": "articles", "id": "1"},
+			},
+			"included": []interface{}{
+				map[string]interface{}{"type": "people", "id": "9"},
+			},
+			"meta": map[string]interface{}{"total": 1},
+		})
+
+		value.Data().Array().Length().Equal(1)
+		value.Included().Length().Equal(1)
+		value.Errors().Empty()
+		value.Meta().ValueEqual("total", 1)
+
+		value.NoErrors()
+		value.chain.assertNotFailed(t)
+
+		value.HasErrors()
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, map[string]interface{}{
+			"errors": []interface{}{
+				map[string]interface{}{"status": "404", "title": "Not Found"},
+			},
+		})
+
+		value.Data().Null()
+		value.Included().Empty()
+		value.Errors().Element(0).Object().ValueEqual("status", "404")
+		value.Meta().Empty()
+
+		value.HasErrors()
+		value.chain.assertNotFailed(t)
+
+		value.NoErrors()
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestJSONAPI_Resource(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewJSONAPI(reporter, map[string]interface{}{
+		"data": []interface{}{
+			map[string]interface{}{"type": "articles", "id": "1"},
+		},
+		"included": []interface{}{
+			map[string]interface{}{
+				"type":       "people",
+				"id":         "9",
+				"attributes": map[string]interface{}{"name": "Dan"},
+			},
+		},
+	})
+
+	value.Resource("articles", "1").ValueEqual("id", "1")
+	value.Resource("people", "9").Path("$.attributes.name").String().Equal("Dan")
+	value.chain.assertNotFailed(t)
+
+	value.Resource("people", "1").chain.assertFailed(t)
+	value.Resource("articles", "9").chain.assertFailed(t)
+}
+
+func TestJSONAPI_Relationship(t *testing.T) {
+	doc := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "articles",
+			"id":   "1",
+			"relationships": map[string]interface{}{
+				"author": map[string]interface{}{
+					"data": map[string]interface{}{"type": "people", "id": "9"},
+				},
+				"comments": map[string]interface{}{
+					"data": []interface{}{
+						map[string]interface{}{"type": "comments", "id": "5"},
+						map[string]interface{}{"type": "comments", "id": "12"},
+					},
+				},
+				"editor": map[string]interface{}{
+					"data": nil,
+				},
+				"tags": map[string]interface{}{
+					"links": map[string]interface{}{
+						"related": "/articles/1/tags",
+					},
+				},
+				"reviewer": map[string]interface{}{
+					"data": map[string]interface{}{"type": "people", "id": "2"},
+				},
+			},
+		},
+		"included": []interface{}{
+			map[string]interface{}{
+				"type":       "people",
+				"id":         "9",
+				"attributes": map[string]interface{}{"name": "Dan"},
+			},
+			map[string]interface{}{
+				"type":       "comments",
+				"id":         "5",
+				"attributes": map[string]interface{}{"body": "First!"},
+			},
+			map[string]interface{}{
+				"type":       "comments",
+				"id":         "12",
+				"attributes": map[string]interface{}{"body": "I like XML better"},
+			},
+		},
+	}
+
+	t.Run("linkage", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, doc)
+
+		value.Relationship("author").Object().ValueEqual("id", "9")
+		value.Relationship("comments").Array().Length().Equal(2)
+		value.Relationship("editor").Null()
+		value.chain.assertNotFailed(t)
+
+		value.Relationship("tags").chain.assertFailed(t)
+		value.Relationship("missing").chain.assertFailed(t)
+	})
+
+	t.Run("related", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, doc)
+
+		value.Related("author").Path("$.attributes.name").String().Equal("Dan")
+		value.Related("comments").Path("$[*].attributes.body").Array().
+			Elements("First!", "I like XML better")
+		value.Related("editor").Null()
+		value.chain.assertNotFailed(t)
+
+		value.Related("reviewer").chain.assertFailed(t)
+		value.Related("tags").chain.assertFailed(t)
+		value.Related("missing").chain.assertFailed(t)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJSONAPI(reporter, map[string]interface{}{
+			"data": []interface{}{},
+		})
+
+		value.Relationship("author").chain.assertFailed(t)
+		value.Related("author").chain.assertFailed(t)
+	})
+}
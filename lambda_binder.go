@@ -0,0 +1,321 @@
+package httpexpect
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// LambdaBinder implements networkless http.RoundTripper attached directly
+// to AWS Lambda handler for API Gateway proxy integration.
+//
+// LambdaBinder emulates API Gateway by converting http.Request into
+// APIGatewayProxyRequest event (REST API, payload format 1.0), invoking
+// given handler directly, and converting returned APIGatewayProxyResponse
+// into http.Response.
+//
+// Handler is a function in one of the forms supported by AWS Lambda
+// runtime for handlers with input and output:
+//
+//	func(TIn) TOut
+//	func(TIn) (TOut, error)
+//	func(context.Context, TIn) TOut
+//	func(context.Context, TIn) (TOut, error)
+//
+// where TIn and TOut are types compatible with JSON representation of
+// APIGatewayProxyRequest and APIGatewayProxyResponse, for example
+// events.APIGatewayProxyRequest and events.APIGatewayProxyResponse from
+// github.com/aws/aws-lambda-go. Like the real runtime, LambdaBinder passes
+// events to the handler and back via JSON, so it doesn't depend on
+// aws-lambda-go itself.
+//
+// If handler returns error, LambdaBinder responds with 502 status, like
+// API Gateway does.
+type LambdaBinder struct {
+	// Lambda handler invoked for every request.
+	Handler interface{}
+}
+
+// NewLambdaBinder returns a new LambdaBinder given a Lambda handler.
+//
+// Example:
+//
+//	func handler(
+//		ctx context.Context, req events.APIGatewayProxyRequest,
+//	) (events.APIGatewayProxyResponse, error) {
+//		...
+//	}
+//
+//	client := &http.Client{
+//	    Transport: NewLambdaBinder(handler),
+//	}
+func NewLambdaBinder(handler interface{}) LambdaBinder {
+	return LambdaBinder{Handler: handler}
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (binder LambdaBinder) RoundTrip(req *http.Request) (*http.Response, error) {
+	event, err := lambdaMakeEvent(req)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	output, handlerErr, err := lambdaInvoke(req.Context(), binder.Handler, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if handlerErr != nil {
+		return lambdaMakeResponse(req, &lambdaResponse{
+			StatusCode: http.StatusBadGateway,
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			Body: `{"message": "Internal server error"}`,
+		})
+	}
+
+	var resp lambdaResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("lambda: can't decode handler response: %s",
+			err.Error())
+	}
+
+	return lambdaMakeResponse(req, &resp)
+}
+
+type lambdaRequest struct {
+	Resource          string               `json:"resource"`
+	Path              string               `json:"path"`
+	HTTPMethod        string               `json:"httpMethod"`
+	Headers           map[string]string    `json:"headers"`
+	MultiValueHeaders map[string][]string  `json:"multiValueHeaders"`
+	Query             map[string]string    `json:"queryStringParameters"`
+	MultiValueQuery   map[string][]string  `json:"multiValueQueryStringParameters"`
+	RequestContext    lambdaRequestContext `json:"requestContext"`
+	Body              string               `json:"body"`
+	IsBase64Encoded   bool                 `json:"isBase64Encoded"`
+}
+
+type lambdaRequestContext struct {
+	ResourcePath     string `json:"resourcePath"`
+	Path             string `json:"path"`
+	HTTPMethod       string `json:"httpMethod"`
+	Protocol         string `json:"protocol"`
+	RequestTimeEpoch int64  `json:"requestTimeEpoch"`
+}
+
+type lambdaResponse struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded"`
+}
+
+func lambdaMakeEvent(req *http.Request) (*lambdaRequest, error) {
+	event := &lambdaRequest{
+		Resource:          req.URL.Path,
+		Path:              req.URL.Path,
+		HTTPMethod:        req.Method,
+		Headers:           map[string]string{},
+		MultiValueHeaders: map[string][]string{},
+		RequestContext: lambdaRequestContext{
+			ResourcePath:     req.URL.Path,
+			Path:             req.URL.Path,
+			HTTPMethod:       req.Method,
+			Protocol:         req.Proto,
+			RequestTimeEpoch: time.Now().UnixNano() / int64(time.Millisecond),
+		},
+	}
+
+	if event.RequestContext.Protocol == "" {
+		event.RequestContext.Protocol = fmt.Sprintf("HTTP/%d.%d",
+			req.ProtoMajor, req.ProtoMinor)
+	}
+
+	for k, v := range req.Header {
+		if len(v) != 0 {
+			event.Headers[k] = v[len(v)-1]
+			event.MultiValueHeaders[k] = v
+		}
+	}
+
+	if req.Host != "" {
+		event.Headers["Host"] = req.Host
+		event.MultiValueHeaders["Host"] = []string{req.Host}
+	} else if req.URL.Host != "" {
+		event.Headers["Host"] = req.URL.Host
+		event.MultiValueHeaders["Host"] = []string{req.URL.Host}
+	}
+
+	// API Gateway passes null instead of empty query maps
+	if query := req.URL.Query(); len(query) != 0 {
+		event.Query = map[string]string{}
+		event.MultiValueQuery = map[string][]string{}
+
+		for k, v := range query {
+			if len(v) != 0 {
+				event.Query[k] = v[len(v)-1]
+				event.MultiValueQuery[k] = v
+			}
+		}
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if utf8.Valid(body) {
+			event.Body = string(body)
+		} else {
+			event.Body = base64.StdEncoding.EncodeToString(body)
+			event.IsBase64Encoded = true
+		}
+	}
+
+	return event, nil
+}
+
+func lambdaMakeResponse(
+	req *http.Request, lresp *lambdaResponse,
+) (*http.Response, error) {
+	header := http.Header{}
+
+	for k, v := range lresp.MultiValueHeaders {
+		for _, s := range v {
+			header.Add(k, s)
+		}
+	}
+
+	for k, v := range lresp.Headers {
+		if _, ok := header[http.CanonicalHeaderKey(k)]; !ok {
+			header.Set(k, v)
+		}
+	}
+
+	body := []byte(lresp.Body)
+
+	if lresp.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(lresp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("lambda: can't decode base64 body: %s",
+				err.Error())
+		}
+		body = decoded
+	}
+
+	status := lresp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Request:       req,
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, nil
+}
+
+var (
+	lambdaContextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	lambdaErrorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// lambdaInvoke calls handler with payload decoded into handler's input
+// type and returns handler's output encoded into JSON
+//
+// Returns handlerErr if handler returned error, and err if handler
+// can't be invoked.
+func lambdaInvoke(
+	ctx context.Context, handler interface{}, payload []byte,
+) (output []byte, handlerErr error, err error) {
+	if handler == nil {
+		return nil, nil, errors.New("lambda: handler is nil")
+	}
+
+	fn := reflect.ValueOf(handler)
+	typ := fn.Type()
+
+	if typ.Kind() != reflect.Func {
+		return nil, nil, fmt.Errorf("lambda: handler kind %s is not func",
+			typ.Kind())
+	}
+
+	var args []reflect.Value
+
+	switch typ.NumIn() {
+	case 1:
+	case 2:
+		if !typ.In(0).Implements(lambdaContextType) {
+			return nil, nil, errors.New(
+				"lambda: handler takes two arguments, but the first is not Context")
+		}
+		args = append(args, reflect.ValueOf(ctx))
+	default:
+		return nil, nil, fmt.Errorf(
+			"lambda: handler takes %d arguments, expected 1 or 2", typ.NumIn())
+	}
+
+	input := reflect.New(typ.In(typ.NumIn() - 1))
+
+	if err := json.Unmarshal(payload, input.Interface()); err != nil {
+		return nil, nil, fmt.Errorf("lambda: can't decode handler request: %s",
+			err.Error())
+	}
+
+	args = append(args, input.Elem())
+
+	switch typ.NumOut() {
+	case 1:
+		if typ.Out(0) == lambdaErrorType {
+			return nil, nil, errors.New("lambda: handler returns only error")
+		}
+	case 2:
+		if typ.Out(1) != lambdaErrorType {
+			return nil, nil, errors.New(
+				"lambda: handler returns two values, but the second is not error")
+		}
+	default:
+		return nil, nil, fmt.Errorf(
+			"lambda: handler returns %d values, expected 1 or 2", typ.NumOut())
+	}
+
+	results := fn.Call(args)
+
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, results[1].Interface().(error), nil
+	}
+
+	output, err = json.Marshal(results[0].Interface())
+	if err != nil {
+		return nil, nil, fmt.Errorf("lambda: can't encode handler response: %s",
+			err.Error())
+	}
+
+	return output, nil, nil
+}
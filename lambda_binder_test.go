@@ -0,0 +1,289 @@
+package httpexpect
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mimics events.APIGatewayProxyRequest from aws-lambda-go
+type testLambdaRequest struct {
+	Path              string              `json:"path"`
+	HTTPMethod        string              `json:"httpMethod"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Query             map[string]string   `json:"queryStringParameters"`
+	MultiValueQuery   map[string][]string `json:"multiValueQueryStringParameters"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded"`
+}
+
+// mimics events.APIGatewayProxyResponse from aws-lambda-go
+type testLambdaResponse struct {
+	StatusCode        int                 `json:"statusCode"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded"`
+}
+
+func TestLambdaBinder_Request(t *testing.T) {
+	var event testLambdaRequest
+
+	handler := func(
+		ctx context.Context, req testLambdaRequest,
+	) (testLambdaResponse, error) {
+		assert.NotNil(t, ctx)
+		event = req
+		return testLambdaResponse{StatusCode: http.StatusOK}, nil
+	}
+
+	client := &http.Client{
+		Transport: NewLambdaBinder(handler),
+	}
+
+	req, err := http.NewRequest("POST",
+		"http://example.com/path?a=1&b=2&b=3", strings.NewReader("body"))
+	require.NoError(t, err)
+
+	req.Header.Add("X-Foo", "foo1")
+	req.Header.Add("X-Foo", "foo2")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "200 OK", resp.Status)
+
+	assert.Equal(t, "/path", event.Path)
+	assert.Equal(t, "POST", event.HTTPMethod)
+
+	assert.Equal(t, "foo2", event.Headers["X-Foo"])
+	assert.Equal(t, []string{"foo1", "foo2"}, event.MultiValueHeaders["X-Foo"])
+	assert.Equal(t, "example.com", event.Headers["Host"])
+
+	assert.Equal(t, map[string]string{"a": "1", "b": "3"},
+		event.Query)
+	assert.Equal(t, map[string][]string{"a": {"1"}, "b": {"2", "3"}},
+		event.MultiValueQuery)
+
+	assert.Equal(t, "body", event.Body)
+	assert.False(t, event.IsBase64Encoded)
+}
+
+func TestLambdaBinder_RequestBase64(t *testing.T) {
+	var event testLambdaRequest
+
+	handler := func(req testLambdaRequest) testLambdaResponse {
+		event = req
+		return testLambdaResponse{StatusCode: http.StatusOK}
+	}
+
+	client := &http.Client{
+		Transport: NewLambdaBinder(handler),
+	}
+
+	body := []byte{0xff, 0xfe, 0x00}
+
+	req, err := http.NewRequest("PUT", "http://example.com/path",
+		strings.NewReader(string(body)))
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.NoError(t, err)
+
+	assert.Nil(t, event.Query)
+	assert.Nil(t, event.MultiValueQuery)
+
+	assert.True(t, event.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(body), event.Body)
+}
+
+func TestLambdaBinder_Response(t *testing.T) {
+	cases := []struct {
+		name     string
+		response testLambdaResponse
+		status   int
+		header   http.Header
+		body     string
+	}{
+		{
+			name: "headers",
+			response: testLambdaResponse{
+				StatusCode: http.StatusCreated,
+				Headers: map[string]string{
+					"content-type": "text/plain",
+				},
+				Body: "hello",
+			},
+			status: http.StatusCreated,
+			header: http.Header{
+				"Content-Type":   {"text/plain"},
+				"Content-Length": {"5"},
+			},
+			body: "hello",
+		},
+		{
+			name: "multi-value headers",
+			response: testLambdaResponse{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"X-Foo": "ignored",
+					"X-Bar": "bar",
+				},
+				MultiValueHeaders: map[string][]string{
+					"X-Foo": {"foo1", "foo2"},
+				},
+			},
+			status: http.StatusOK,
+			header: http.Header{
+				"X-Foo":          {"foo1", "foo2"},
+				"X-Bar":          {"bar"},
+				"Content-Length": {"0"},
+			},
+			body: "",
+		},
+		{
+			name: "base64 body",
+			response: testLambdaResponse{
+				StatusCode:      http.StatusOK,
+				Body:            base64.StdEncoding.EncodeToString([]byte("hello")),
+				IsBase64Encoded: true,
+			},
+			status: http.StatusOK,
+			header: http.Header{
+				"Content-Length": {"5"},
+			},
+			body: "hello",
+		},
+		{
+			name:     "default status",
+			response: testLambdaResponse{},
+			status:   http.StatusOK,
+			header: http.Header{
+				"Content-Length": {"0"},
+			},
+			body: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := func(req testLambdaRequest) (testLambdaResponse, error) {
+				return tc.response, nil
+			}
+
+			client := &http.Client{
+				Transport: NewLambdaBinder(handler),
+			}
+
+			resp, err := client.Get("http://example.com/path")
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, tc.header, resp.Header)
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestLambdaBinder_HandlerError(t *testing.T) {
+	handler := func(
+		ctx context.Context, req testLambdaRequest,
+	) (*testLambdaResponse, error) {
+		return nil, errors.New("test error")
+	}
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewLambdaBinder(handler),
+		},
+	})
+
+	e.GET("/path").
+		Expect().
+		Status(http.StatusBadGateway).
+		JSON().Object().ValueEqual("message", "Internal server error")
+}
+
+func TestLambdaBinder_InvalidHandler(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler interface{}
+	}{
+		{
+			name:    "nil",
+			handler: nil,
+		},
+		{
+			name:    "not func",
+			handler: "handler",
+		},
+		{
+			name:    "no arguments",
+			handler: func() testLambdaResponse { return testLambdaResponse{} },
+		},
+		{
+			name: "first argument is not context",
+			handler: func(string, testLambdaRequest) testLambdaResponse {
+				return testLambdaResponse{}
+			},
+		},
+		{
+			name: "too many arguments",
+			handler: func(
+				context.Context, testLambdaRequest, int,
+			) testLambdaResponse {
+				return testLambdaResponse{}
+			},
+		},
+		{
+			name:    "no results",
+			handler: func(testLambdaRequest) {},
+		},
+		{
+			name:    "only error",
+			handler: func(testLambdaRequest) error { return nil },
+		},
+		{
+			name: "second result is not error",
+			handler: func(testLambdaRequest) (testLambdaResponse, int) {
+				return testLambdaResponse{}, 0
+			},
+		},
+		{
+			name: "incompatible request",
+			handler: func(int) testLambdaResponse {
+				return testLambdaResponse{}
+			},
+		},
+		{
+			name: "incompatible response",
+			handler: func(testLambdaRequest) int {
+				return 0
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &http.Client{
+				Transport: NewLambdaBinder(tc.handler),
+			}
+
+			_, err := client.Get("http://example.com/path")
+			assert.Error(t, err)
+		})
+	}
+}
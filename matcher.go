@@ -0,0 +1,98 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Matcher is a reusable assertion for a single value, which may be used
+// to package domain-specific checks, e.g. money amounts, ULIDs, or geo
+// coordinates, and apply them using Value.Satisfies.
+//
+// Match receives value in canonical form, the same as returned by
+// Value.Raw: objects are map[string]interface{}, arrays are []interface{},
+// numbers are float64, and so on. If Config.UseJSONNumber is enabled,
+// integers that can't be exactly represented as float64 are json.Number.
+// It returns nil if value matches, or an error describing the mismatch
+// otherwise.
+//
+// If Matcher also implements fmt.Stringer, String is used to describe
+// matcher in failure reports.
+type Matcher interface {
+	// Match checks value and returns non-nil error if it doesn't match.
+	Match(value interface{}) error
+}
+
+// MatcherFunc implements Matcher using given function.
+//
+// Example:
+//
+//	isULID := httpexpect.MatcherFunc(func(value interface{}) error {
+//		s, ok := value.(string)
+//		if !ok || !ulidRegexp.MatchString(s) {
+//			return errors.New("expected: ULID string")
+//		}
+//		return nil
+//	})
+//
+//	object.Value("id").Satisfies(isULID)
+type MatcherFunc func(value interface{}) error
+
+// Match implements Matcher.Match.
+func (f MatcherFunc) Match(value interface{}) error {
+	return f(value)
+}
+
+// describe matcher for failure report
+func matcherName(matcher Matcher) string {
+	if s, ok := matcher.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%T", matcher)
+}
+
+// GomegaMatcher has the same method set as types.GomegaMatcher from
+// github.com/onsi/gomega, so Gomega matchers (and other libraries
+// following the same convention) can be used with Value.Should and
+// FromGomega without importing Gomega into httpexpect.
+type GomegaMatcher interface {
+	Match(actual interface{}) (success bool, err error)
+	FailureMessage(actual interface{}) (message string)
+	NegatedFailureMessage(actual interface{}) (message string)
+}
+
+// FromGomega returns a Matcher that wraps given Gomega matcher, which may
+// be used with Value.Satisfies or combined with other matchers.
+//
+// Gomega matcher receives value in canonical form, e.g. numbers are
+// float64, so use BeNumerically instead of Equal to compare numbers.
+// If Config.UseJSONNumber is enabled, integers that can't be exactly
+// represented as float64 are json.Number instead.
+//
+// Example:
+//
+//	value.Satisfies(httpexpect.FromGomega(gomega.HaveLen(3)))
+func FromGomega(matcher GomegaMatcher) Matcher {
+	return gomegaAdapter{matcher}
+}
+
+type gomegaAdapter struct {
+	matcher GomegaMatcher
+}
+
+// Match implements Matcher.Match.
+func (a gomegaAdapter) Match(value interface{}) error {
+	success, err := a.matcher.Match(value)
+	if err != nil {
+		return err
+	}
+	if !success {
+		return errors.New(a.matcher.FailureMessage(value))
+	}
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (a gomegaAdapter) String() string {
+	return fmt.Sprintf("%T", a.matcher)
+}
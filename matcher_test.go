@@ -0,0 +1,101 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockNamedMatcher struct{}
+
+func (mockNamedMatcher) Match(value interface{}) error {
+	if value != "ok" {
+		return errors.New("not ok")
+	}
+	return nil
+}
+
+func (mockNamedMatcher) String() string {
+	return "ok matcher"
+}
+
+// mimics gomega.Equal
+type mockGomegaMatcher struct {
+	expected interface{}
+}
+
+func (m mockGomegaMatcher) Match(actual interface{}) (bool, error) {
+	if m.expected == nil {
+		return false, errors.New("refusing to compare <nil> to <nil>")
+	}
+	return reflect.DeepEqual(actual, m.expected), nil
+}
+
+func (m mockGomegaMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n    %v\nto equal\n    %v", actual, m.expected)
+}
+
+func (m mockGomegaMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n    %v\nnot to equal\n    %v", actual, m.expected)
+}
+
+func TestMatcher_Func(t *testing.T) {
+	m := MatcherFunc(func(value interface{}) error {
+		if value != 1.0 {
+			return errors.New("not one")
+		}
+		return nil
+	})
+
+	assert.NoError(t, m.Match(1.0))
+	assert.EqualError(t, m.Match(2.0), "not one")
+
+	assert.Equal(t, "httpexpect.MatcherFunc", matcherName(m))
+}
+
+func TestMatcher_Name(t *testing.T) {
+	assert.Equal(t, "ok matcher", matcherName(mockNamedMatcher{}))
+
+	handler := &mockAssertionHandler{}
+
+	config := Config{
+		AssertionHandler: handler,
+	}
+
+	NewValueC(config, "ok").Satisfies(mockNamedMatcher{}).
+		chain.assertNotFailed(t)
+	assert.Nil(t, handler.failure)
+
+	NewValueC(config, "bad").Satisfies(mockNamedMatcher{}).
+		chain.assertFailed(t)
+
+	if assert.NotNil(t, handler.failure) {
+		assert.Equal(t, AssertValid, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors[0].Error(), "ok matcher")
+		assert.EqualError(t, handler.failure.Errors[1], "not ok")
+	}
+}
+
+func TestMatcher_FromGomega(t *testing.T) {
+	m := FromGomega(mockGomegaMatcher{expected: "foo"})
+
+	assert.NoError(t, m.Match("foo"))
+	assert.EqualError(t, m.Match("bar"), "Expected\n    bar\nto equal\n    foo")
+
+	assert.Equal(t, "httpexpect.mockGomegaMatcher", matcherName(m))
+
+	m = FromGomega(mockGomegaMatcher{expected: nil})
+
+	assert.EqualError(t, m.Match("foo"), "refusing to compare <nil> to <nil>")
+
+	reporter := newMockReporter(t)
+
+	NewValue(reporter, "foo").Satisfies(FromGomega(mockGomegaMatcher{"foo"})).
+		chain.assertNotFailed(t)
+
+	NewValue(reporter, "foo").Satisfies(FromGomega(mockGomegaMatcher{"bar"})).
+		chain.assertFailed(t)
+}
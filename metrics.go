@@ -0,0 +1,235 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsRecorder implements Printer.
+// Collects request counts, status code distribution, and latencies per
+// endpoint, identified by method and URL path (without query).
+//
+// Collected metrics may be inspected programmatically via Endpoints, printed
+// as a summary table via WriteSummary, or published via expvar. This is
+// useful for smoke and performance gates over a whole suite run.
+//
+// MetricsRecorder is safe for concurrent use. The same instance may be
+// shared between multiple Expect instances.
+//
+// Example:
+//
+//	metrics := httpexpect.NewMetricsRecorder()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			metrics,
+//		},
+//	})
+//
+//	defer metrics.WriteSummary(os.Stdout)
+type MetricsRecorder struct {
+	mu        sync.Mutex
+	endpoints map[metricsKey]*metricsEntry
+}
+
+// EndpointMetrics contains metrics collected by MetricsRecorder for
+// single endpoint.
+type EndpointMetrics struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Number of sent requests and received responses.
+	// Requests that failed without response are counted only in Requests.
+	Requests  int `json:"requests"`
+	Responses int `json:"responses"`
+
+	// Number of responses per status code.
+	StatusCodes map[int]int `json:"status_codes"`
+
+	// Latencies of received responses.
+	// Zero if there were no responses.
+	MinLatency  time.Duration `json:"min_latency"`
+	MaxLatency  time.Duration `json:"max_latency"`
+	MeanLatency time.Duration `json:"mean_latency"`
+	P50Latency  time.Duration `json:"p50_latency"`
+	P95Latency  time.Duration `json:"p95_latency"`
+	P99Latency  time.Duration `json:"p99_latency"`
+}
+
+type metricsKey struct {
+	method string
+	path   string
+}
+
+type metricsEntry struct {
+	requests    int
+	statusCodes map[int]int
+	latencies   []time.Duration
+}
+
+// NewMetricsRecorder returns a new empty MetricsRecorder.
+func NewMetricsRecorder() *MetricsRecorder {
+	return &MetricsRecorder{
+		endpoints: map[metricsKey]*metricsEntry{},
+	}
+}
+
+// Request implements Printer.Request.
+func (m *MetricsRecorder) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entry(req).requests++
+}
+
+// Response implements Printer.Response.
+func (m *MetricsRecorder) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.entry(resp.Request)
+
+	entry.statusCodes[resp.StatusCode]++
+	entry.latencies = append(entry.latencies, duration)
+}
+
+// must be called with mutex locked
+func (m *MetricsRecorder) entry(req *http.Request) *metricsEntry {
+	key := metricsKey{method: req.Method}
+	if req.URL != nil {
+		key.path = req.URL.Path
+	}
+
+	entry := m.endpoints[key]
+	if entry == nil {
+		entry = &metricsEntry{
+			statusCodes: map[int]int{},
+		}
+		m.endpoints[key] = entry
+	}
+
+	return entry
+}
+
+// Endpoints returns metrics for all endpoints, sorted by path and method.
+func (m *MetricsRecorder) Endpoints() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]EndpointMetrics, 0, len(m.endpoints))
+
+	for key, entry := range m.endpoints {
+		em := EndpointMetrics{
+			Method:      key.method,
+			Path:        key.path,
+			Requests:    entry.requests,
+			Responses:   len(entry.latencies),
+			StatusCodes: make(map[int]int, len(entry.statusCodes)),
+		}
+
+		for code, count := range entry.statusCodes {
+			em.StatusCodes[code] = count
+		}
+
+		if len(entry.latencies) != 0 {
+			latencies := append([]time.Duration(nil), entry.latencies...)
+			sort.Slice(latencies, func(i, j int) bool {
+				return latencies[i] < latencies[j]
+			})
+
+			var total time.Duration
+			for _, l := range latencies {
+				total += l
+			}
+
+			em.MinLatency = latencies[0]
+			em.MaxLatency = latencies[len(latencies)-1]
+			em.MeanLatency = total / time.Duration(len(latencies))
+			em.P50Latency = metricsPercentile(latencies, 50)
+			em.P95Latency = metricsPercentile(latencies, 95)
+			em.P99Latency = metricsPercentile(latencies, 99)
+		}
+
+		result = append(result, em)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Method < result[j].Method
+	})
+
+	return result
+}
+
+// WriteSummary writes human-readable table with metrics of all endpoints
+// to given writer.
+func (m *MetricsRecorder) WriteSummary(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%-7s %-30s %8s %8s %-20s %10s %10s %10s\n",
+		"METHOD", "PATH", "REQUESTS", "ERRORS", "STATUS", "MEAN", "P95", "MAX")
+
+	for _, em := range m.Endpoints() {
+		codes := make([]int, 0, len(em.StatusCodes))
+		for code := range em.StatusCodes {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+
+		statuses := make([]string, 0, len(codes))
+		for _, code := range codes {
+			statuses = append(statuses, fmt.Sprintf("%d:%d", code, em.StatusCodes[code]))
+		}
+
+		fmt.Fprintf(&b, "%-7s %-30s %8d %8d %-20s %10s %10s %10s\n",
+			em.Method, em.Path, em.Requests, em.Requests-em.Responses,
+			strings.Join(statuses, ","),
+			em.MeanLatency, em.P95Latency, em.MaxLatency)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Publish exposes metrics of all endpoints via expvar under given name,
+// e.g. to be scraped from /debug/vars during long suite runs.
+//
+// Like expvar.Publish, panics if the name is already registered.
+func (m *MetricsRecorder) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return m.Endpoints()
+	}))
+}
+
+// MarshalJSON implements json.Marshaler, encoding metrics of all endpoints.
+func (m *MetricsRecorder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Endpoints())
+}
+
+// nearest-rank percentile of sorted slice
+func metricsPercentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,136 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRecorder_Endpoints(t *testing.T) {
+	metrics := NewMetricsRecorder()
+
+	assert.Empty(t, metrics.Endpoints())
+
+	send := func(method, url string, status int, latency time.Duration) {
+		req, _ := http.NewRequest(method, url, nil)
+		metrics.Request(req)
+
+		if status != 0 {
+			metrics.Response(&http.Response{
+				StatusCode: status,
+				Request:    req,
+			}, latency)
+		}
+	}
+
+	send("GET", "http://example.com/users?page=1", 200, 10*time.Millisecond)
+	send("GET", "http://example.com/users?page=2", 200, 30*time.Millisecond)
+	send("GET", "http://example.com/users", 500, 20*time.Millisecond)
+	send("GET", "http://example.com/users", 0, 0)
+	send("POST", "http://example.com/users", 201, 40*time.Millisecond)
+	send("GET", "http://example.com/health", 200, time.Millisecond)
+
+	metrics.Request(nil)
+	metrics.Response(nil, 0)
+
+	endpoints := metrics.Endpoints()
+	require.Equal(t, 3, len(endpoints))
+
+	assert.Equal(t, EndpointMetrics{
+		Method:      "GET",
+		Path:        "/health",
+		Requests:    1,
+		Responses:   1,
+		StatusCodes: map[int]int{200: 1},
+		MinLatency:  time.Millisecond,
+		MaxLatency:  time.Millisecond,
+		MeanLatency: time.Millisecond,
+		P50Latency:  time.Millisecond,
+		P95Latency:  time.Millisecond,
+		P99Latency:  time.Millisecond,
+	}, endpoints[0])
+
+	assert.Equal(t, EndpointMetrics{
+		Method:      "GET",
+		Path:        "/users",
+		Requests:    4,
+		Responses:   3,
+		StatusCodes: map[int]int{200: 2, 500: 1},
+		MinLatency:  10 * time.Millisecond,
+		MaxLatency:  30 * time.Millisecond,
+		MeanLatency: 20 * time.Millisecond,
+		P50Latency:  20 * time.Millisecond,
+		P95Latency:  30 * time.Millisecond,
+		P99Latency:  30 * time.Millisecond,
+	}, endpoints[1])
+
+	assert.Equal(t, "POST", endpoints[2].Method)
+	assert.Equal(t, "/users", endpoints[2].Path)
+	assert.Equal(t, 1, endpoints[2].Requests)
+	assert.Equal(t, map[int]int{201: 1}, endpoints[2].StatusCodes)
+
+	// returned metrics are a copy
+	endpoints[0].StatusCodes[200] = 100
+	assert.Equal(t, 1, metrics.Endpoints()[0].StatusCodes[200])
+}
+
+func TestMetricsRecorder_Summary(t *testing.T) {
+	metrics := NewMetricsRecorder()
+
+	req, _ := http.NewRequest("GET", "http://example.com/users", nil)
+
+	metrics.Request(req)
+	metrics.Response(&http.Response{StatusCode: 200, Request: req}, time.Second)
+	metrics.Request(req)
+
+	var buf bytes.Buffer
+	require.NoError(t, metrics.WriteSummary(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 2, len(lines))
+
+	assert.Equal(t,
+		[]string{"METHOD", "PATH", "REQUESTS", "ERRORS", "STATUS", "MEAN", "P95", "MAX"},
+		strings.Fields(lines[0]))
+	assert.Equal(t,
+		[]string{"GET", "/users", "2", "1", "200:1", "1s", "1s", "1s"},
+		strings.Fields(lines[1]))
+}
+
+func TestMetricsRecorder_Export(t *testing.T) {
+	metrics := NewMetricsRecorder()
+
+	req, _ := http.NewRequest("GET", "http://example.com/users", nil)
+
+	metrics.Request(req)
+	metrics.Response(&http.Response{StatusCode: 200, Request: req}, time.Second)
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(metrics)
+		require.NoError(t, err)
+
+		var decoded []EndpointMetrics
+		require.NoError(t, json.Unmarshal(b, &decoded))
+
+		assert.Equal(t, metrics.Endpoints(), decoded)
+	})
+
+	t.Run("expvar", func(t *testing.T) {
+		metrics.Publish("httpexpect_test_metrics")
+
+		v := expvar.Get("httpexpect_test_metrics")
+		require.NotNil(t, v)
+
+		var decoded []EndpointMetrics
+		require.NoError(t, json.Unmarshal([]byte(v.String()), &decoded))
+
+		assert.Equal(t, metrics.Endpoints(), decoded)
+	})
+}
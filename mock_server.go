@@ -0,0 +1,558 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// MockServer is a local HTTP server that replies with stubbed responses
+// and records received requests.
+//
+// MockServer is useful for testing HTTP clients: client under test is
+// pointed to MockServer.URL(), and then received requests are inspected
+// using the usual httpexpect assertions.
+//
+// Example:
+//
+//	server := NewMockServer(t)
+//	defer server.Close()
+//
+//	server.When("GET", "/users/1").
+//		Reply(http.StatusOK).
+//		JSON(map[string]interface{}{"name": "john"})
+//
+//	client := NewUsersClient(server.URL())
+//	client.GetUser(1)
+//
+//	server.Requests().Length().Equal(1)
+//	server.Unmatched().Empty()
+type MockServer struct {
+	noCopy noCopy
+	chain  *chain
+	server *httptest.Server
+
+	mu        sync.Mutex
+	stubs     []*MockStub
+	requests  []interface{}
+	unmatched []interface{}
+}
+
+// MockStub defines which requests are matched by stub and what is
+// replied to them.
+//
+// MockStub is created by MockServer.When.
+type MockStub struct {
+	server *MockServer
+	chain  *chain
+	method string
+	path   string
+	query  map[string]string
+	header map[string]string
+	reply  *MockReply
+
+	requests []interface{}
+}
+
+// MockReply defines response returned for requests matched by stub.
+//
+// MockReply is created by MockStub.Reply.
+type MockReply struct {
+	stub   *MockStub
+	status int
+	header http.Header
+	body   []byte
+}
+
+// NewMockServer starts a new MockServer.
+//
+// MockServer should be closed using Close method when it's not needed
+// anymore.
+//
+// If reporter is nil, the function panics.
+//
+// Example:
+//
+//	server := NewMockServer(t)
+//	defer server.Close()
+func NewMockServer(reporter Reporter) *MockServer {
+	return newMockServer(newChainWithDefaults("MockServer()", reporter))
+}
+
+// NewMockServerC starts a new MockServer with config.
+//
+// Requirements for config are same as for WithConfig function.
+//
+// Example:
+//
+//	server := NewMockServerC(config)
+//	defer server.Close()
+func NewMockServerC(config Config) *MockServer {
+	return newMockServer(newChainWithConfig("MockServer()", config.withDefaults()))
+}
+
+func newMockServer(parent *chain) *MockServer {
+	m := &MockServer{
+		chain: parent.clone(),
+	}
+
+	m.server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+
+	return m
+}
+
+// URL returns base URL of the server, e.g. "http://127.0.0.1:12345".
+//
+// Example:
+//
+//	e := httpexpect.Default(t, server.URL())
+func (m *MockServer) URL() string {
+	return m.server.URL
+}
+
+// Close shuts down the server and blocks until all outstanding
+// requests on this server have completed.
+func (m *MockServer) Close() {
+	m.server.Close()
+}
+
+// When adds a new stub for requests with given method and path.
+//
+// Path is compared with request URL path, without query string.
+// Stub can be further narrowed using MockStub.WithQuery and
+// MockStub.WithHeader.
+//
+// If multiple stubs match a request, the most recently added one is used,
+// so stubs added in a test can override stubs added in common setup code.
+// If no stubs match a request, server replies with 404 status.
+//
+// Example:
+//
+//	server.When("POST", "/users").
+//		Reply(http.StatusCreated).
+//		JSON(map[string]interface{}{"id": 1})
+func (m *MockServer) When(method, path string) *MockStub {
+	opChain := m.chain.enter("When(%q, %q)", method, path)
+	defer opChain.leave()
+
+	stub := &MockStub{
+		server:   m,
+		chain:    opChain.clone(),
+		method:   method,
+		path:     path,
+		query:    map[string]string{},
+		header:   map[string]string{},
+		requests: []interface{}{},
+	}
+
+	stub.reply = &MockReply{
+		stub:   stub,
+		status: http.StatusOK,
+		header: http.Header{},
+	}
+
+	if opChain.failed() {
+		return stub
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stubs = append(m.stubs, stub)
+
+	return stub
+}
+
+// Requests returns a new Array instance with all requests received by
+// server, in the order they were received.
+//
+// Every request is represented as an object with the following keys:
+//   - "method" - request method
+//   - "path" - request URL path
+//   - "query" - object with query parameters, every value is an array
+//   - "header" - object with headers, every value is an array
+//   - "body" - request body as string
+//   - "json" - decoded request body; present only if body is valid JSON
+//
+// Example:
+//
+//	req := server.Requests().Element(0).Object()
+//
+//	req.ValueEqual("method", "POST")
+//	req.ValueEqual("path", "/users")
+//	req.Value("header").Object().Value("Content-Type").Array().
+//		ContainsOnly("application/json")
+//	req.Value("json").Object().ValueEqual("name", "john")
+func (m *MockServer) Requests() *Array {
+	opChain := m.chain.enter("Requests()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	m.mu.Lock()
+	requests := append([]interface{}{}, m.requests...)
+	m.mu.Unlock()
+
+	return newArray(opChain, requests)
+}
+
+// Unmatched returns a new Array instance with requests received by
+// server that were not matched by any stub.
+//
+// Requests are represented in the same way as in Requests.
+//
+// Example:
+//
+//	server.Unmatched().Empty()
+func (m *MockServer) Unmatched() *Array {
+	opChain := m.chain.enter("Unmatched()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	m.mu.Lock()
+	unmatched := append([]interface{}{}, m.unmatched...)
+	m.mu.Unlock()
+
+	return newArray(opChain, unmatched)
+}
+
+func (m *MockServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	request := mockServerRequest(req, body)
+
+	m.mu.Lock()
+
+	m.requests = append(m.requests, request)
+
+	var stub *MockStub
+	for i := len(m.stubs) - 1; i >= 0; i-- {
+		if m.stubs[i].matches(req) {
+			stub = m.stubs[i]
+			break
+		}
+	}
+
+	if stub == nil {
+		m.unmatched = append(m.unmatched, request)
+		m.mu.Unlock()
+
+		http.Error(w, fmt.Sprintf("no stub for %s %s", req.Method, req.URL.Path),
+			http.StatusNotFound)
+		return
+	}
+
+	stub.requests = append(stub.requests, request)
+
+	status := stub.reply.status
+	header := stub.reply.header.Clone()
+	replyBody := append([]byte{}, stub.reply.body...)
+
+	m.mu.Unlock()
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(status)
+
+	_, _ = w.Write(replyBody)
+}
+
+// WithQuery narrows stub to requests that have query parameter with
+// given key and value.
+//
+// Example:
+//
+//	server.When("GET", "/users").
+//		WithQuery("page", "2").
+//		Reply(http.StatusOK)
+func (s *MockStub) WithQuery(key, value string) *MockStub {
+	opChain := s.chain.enter("WithQuery(%q, %q)", key, value)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	s.query[key] = value
+
+	return s
+}
+
+// WithHeader narrows stub to requests that have header with given key
+// and value. Header key is case-insensitive.
+//
+// Example:
+//
+//	server.When("GET", "/users").
+//		WithHeader("Authorization", "Bearer token").
+//		Reply(http.StatusOK)
+func (s *MockStub) WithHeader(key, value string) *MockStub {
+	opChain := s.chain.enter("WithHeader(%q, %q)", key, value)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	s.header[http.CanonicalHeaderKey(key)] = value
+
+	return s
+}
+
+// Reply sets status code of response and returns MockReply that can be
+// used to set response headers and body.
+//
+// If Reply is not called, stub replies with 200 status and empty body.
+// If status is not a valid three-digit code, failure is reported.
+//
+// Example:
+//
+//	server.When("DELETE", "/users/1").
+//		Reply(http.StatusNoContent)
+func (s *MockStub) Reply(status int) *MockReply {
+	opChain := s.chain.enter("Reply(%d)", status)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s.reply
+	}
+
+	// http.ResponseWriter.WriteHeader panics on such codes
+	if status < 100 || status > 999 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected invalid status code argument: %d", status),
+			},
+		})
+		return s.reply
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	s.reply.status = status
+
+	return s.reply
+}
+
+// Requests returns a new Array instance with requests matched by stub,
+// in the order they were received.
+//
+// Requests are represented in the same way as in MockServer.Requests.
+//
+// Example:
+//
+//	stub := server.When("POST", "/users").
+//		Reply(http.StatusCreated)
+//
+//	client.CreateUser("john")
+//
+//	stub.Requests().Length().Equal(1)
+func (s *MockStub) Requests() *Array {
+	opChain := s.chain.enter("Requests()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	s.server.mu.Lock()
+	requests := append([]interface{}{}, s.requests...)
+	s.server.mu.Unlock()
+
+	return newArray(opChain, requests)
+}
+
+func (s *MockStub) matches(req *http.Request) bool {
+	if !strings.EqualFold(s.method, req.Method) || s.path != req.URL.Path {
+		return false
+	}
+
+	query := req.URL.Query()
+	for k, v := range s.query {
+		if !mockServerContains(query[k], v) {
+			return false
+		}
+	}
+
+	for k, v := range s.header {
+		if !mockServerContains(req.Header[k], v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Header adds given header to response.
+//
+// Example:
+//
+//	server.When("GET", "/").
+//		Reply(http.StatusFound).
+//		Header("Location", "/login")
+func (r *MockReply) Header(key, value string) *MockReply {
+	opChain := r.stub.chain.enter("Header(%q, %q)", key, value)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.stub.server.mu.Lock()
+	defer r.stub.server.mu.Unlock()
+
+	r.header.Add(key, value)
+
+	return r
+}
+
+// Bytes sets response body.
+//
+// Example:
+//
+//	server.When("GET", "/file").
+//		Reply(http.StatusOK).
+//		Bytes([]byte("content"))
+func (r *MockReply) Bytes(body []byte) *MockReply {
+	opChain := r.stub.chain.enter("Bytes()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.setBody("", body)
+
+	return r
+}
+
+// Text sets response body to given string.
+// Sets Content-Type to "text/plain; charset=utf-8", unless it's already set.
+//
+// Example:
+//
+//	server.When("GET", "/ping").
+//		Reply(http.StatusOK).
+//		Text("pong")
+func (r *MockReply) Text(body string) *MockReply {
+	opChain := r.stub.chain.enter("Text()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.setBody("text/plain; charset=utf-8", []byte(body))
+
+	return r
+}
+
+// JSON sets response body to given value marshaled to JSON.
+// Sets Content-Type to "application/json; charset=utf-8", unless it's
+// already set.
+//
+// If value can't be marshaled, failure is reported.
+//
+// Example:
+//
+//	server.When("GET", "/users/1").
+//		Reply(http.StatusOK).
+//		JSON(map[string]interface{}{"name": "john"})
+func (r *MockReply) JSON(value interface{}) *MockReply {
+	opChain := r.stub.chain.enter("JSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	body, err := json.Marshal(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				value,
+			},
+			Errors: []error{
+				errors.New("invalid json value"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setBody("application/json; charset=utf-8", body)
+
+	return r
+}
+
+func (r *MockReply) setBody(contentType string, body []byte) {
+	r.stub.server.mu.Lock()
+	defer r.stub.server.mu.Unlock()
+
+	if contentType != "" && r.header.Get("Content-Type") == "" {
+		r.header.Set("Content-Type", contentType)
+	}
+
+	r.body = append([]byte{}, body...)
+}
+
+func mockServerRequest(req *http.Request, body []byte) map[string]interface{} {
+	request := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+		"query":  mockServerValues(req.URL.Query()),
+		"header": mockServerValues(req.Header),
+		"body":   string(body),
+	}
+
+	var value interface{}
+	if len(body) != 0 && json.Unmarshal(body, &value) == nil {
+		request["json"] = value
+	}
+
+	return request
+}
+
+func mockServerValues(values map[string][]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+
+	for k, v := range values {
+		elems := make([]interface{}, len(v))
+		for i := range v {
+			elems[i] = v[i]
+		}
+		result[k] = elems
+	}
+
+	return result
+}
+
+func mockServerContains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
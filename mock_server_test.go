@@ -0,0 +1,283 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockServer_Constructors(t *testing.T) {
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		server := NewMockServer(reporter)
+		defer server.Close()
+		server.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		server := NewMockServerC(Config{
+			Reporter: reporter,
+		})
+		defer server.Close()
+		server.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		server := newMockServer(chain)
+		defer server.Close()
+		assert.NotSame(t, server.chain, chain)
+		assert.Equal(t, server.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestMockServer_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	server := newMockServer(chain)
+	defer server.Close()
+
+	server.Requests().chain.assertFailed(t)
+	server.Unmatched().chain.assertFailed(t)
+
+	stub := server.When("GET", "/")
+	stub.Requests().chain.assertFailed(t)
+
+	stub.WithQuery("a", "b").
+		WithHeader("a", "b").
+		Reply(http.StatusOK).
+		Header("a", "b").
+		Text("text").
+		JSON(nil).
+		Bytes(nil)
+
+	assert.Empty(t, server.stubs)
+	assert.Empty(t, stub.query)
+	assert.Empty(t, stub.header)
+	assert.Empty(t, stub.reply.header)
+	assert.Nil(t, stub.reply.body)
+}
+
+func TestMockServer_Reply(t *testing.T) {
+	server := NewMockServer(t)
+	defer server.Close()
+
+	server.When("GET", "/text").
+		Reply(http.StatusOK).
+		Text("hello")
+
+	server.When("GET", "/json").
+		Reply(http.StatusCreated).
+		Header("X-Test", "1").
+		JSON(map[string]interface{}{"name": "john"})
+
+	server.When("GET", "/bytes").
+		Reply(http.StatusAccepted).
+		Header("Content-Type", "application/octet-stream").
+		Bytes([]byte("\xff\x00"))
+
+	server.When("DELETE", "/empty")
+
+	e := Default(t, server.URL())
+
+	e.GET("/text").
+		Expect().
+		Status(http.StatusOK).
+		ContentType("text/plain").
+		Body().Equal("hello")
+
+	e.GET("/json").
+		Expect().
+		Status(http.StatusCreated).
+		Header("X-Test").Equal("1")
+
+	e.GET("/json").
+		Expect().
+		JSON().Object().ValueEqual("name", "john")
+
+	e.GET("/bytes").
+		Expect().
+		Status(http.StatusAccepted).
+		ContentType("application/octet-stream").
+		Body().Equal("\xff\x00")
+
+	e.DELETE("/empty").
+		Expect().
+		Status(http.StatusOK).
+		NoContent()
+
+	e.GET("/missing").
+		Expect().
+		Status(http.StatusNotFound)
+
+	e.POST("/text").
+		Expect().
+		Status(http.StatusNotFound)
+
+	server.Requests().Length().Equal(7)
+	server.Unmatched().Length().Equal(2)
+	server.chain.assertNotFailed(t)
+}
+
+func TestMockServer_Match(t *testing.T) {
+	server := NewMockServer(t)
+	defer server.Close()
+
+	server.When("GET", "/users").
+		Reply(http.StatusOK).
+		Text("all")
+
+	server.When("GET", "/users").
+		WithQuery("page", "2").
+		Reply(http.StatusOK).
+		Text("page 2")
+
+	server.When("get", "/users").
+		WithHeader("authorization", "token").
+		Reply(http.StatusOK).
+		Text("private")
+
+	e := Default(t, server.URL())
+
+	e.GET("/users").
+		Expect().
+		Body().Equal("all")
+
+	e.GET("/users").WithQuery("page", 2).
+		Expect().
+		Body().Equal("page 2")
+
+	e.GET("/users").WithQuery("page", 3).
+		Expect().
+		Body().Equal("all")
+
+	e.GET("/users").WithHeader("Authorization", "token").
+		Expect().
+		Body().Equal("private")
+
+	e.GET("/users").WithHeader("Authorization", "token").WithQuery("page", 2).
+		Expect().
+		Body().Equal("private")
+
+	server.Unmatched().Empty()
+}
+
+func TestMockServer_Override(t *testing.T) {
+	server := NewMockServer(t)
+	defer server.Close()
+
+	server.When("GET", "/status").
+		Reply(http.StatusOK)
+
+	e := Default(t, server.URL())
+
+	e.GET("/status").
+		Expect().
+		Status(http.StatusOK)
+
+	server.When("GET", "/status").
+		Reply(http.StatusServiceUnavailable)
+
+	e.GET("/status").
+		Expect().
+		Status(http.StatusServiceUnavailable)
+}
+
+func TestMockServer_Requests(t *testing.T) {
+	server := NewMockServer(t)
+	defer server.Close()
+
+	stub := server.When("POST", "/users")
+	stub.Reply(http.StatusCreated)
+
+	textStub := server.When("PUT", "/users/1")
+
+	resp, err := http.Post(server.URL()+"/users?notify=true",
+		"application/json", bytes.NewBufferString(`{"name":"john"}`))
+	require.NoError(t, err)
+	_, _ = ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	req, err := http.NewRequest("PUT", server.URL()+"/users/1",
+		bytes.NewBufferString("text"))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	resp, err = http.Get(server.URL() + "/other")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	server.Requests().Length().Equal(3)
+
+	stub.Requests().Length().Equal(1)
+
+	obj := stub.Requests().Element(0).Object()
+	obj.ValueEqual("method", "POST")
+	obj.ValueEqual("path", "/users")
+	obj.ValueEqual("body", `{"name":"john"}`)
+	obj.Value("query").Object().
+		ValueEqual("notify", []interface{}{"true"})
+	obj.Value("header").Object().
+		ValueEqual("Content-Type", []interface{}{"application/json"})
+	obj.Value("json").Object().
+		ValueEqual("name", "john")
+
+	textStub.Requests().Length().Equal(1)
+
+	obj = textStub.Requests().Element(0).Object()
+	obj.ValueEqual("method", "PUT")
+	obj.ValueEqual("body", "text")
+	obj.NotContainsKey("json")
+
+	obj = server.Unmatched().Element(0).Object()
+	obj.ValueEqual("method", "GET")
+	obj.ValueEqual("path", "/other")
+	obj.ValueEqual("body", "")
+}
+
+func TestMockServer_InvalidJSON(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	server := NewMockServer(reporter)
+	defer server.Close()
+
+	reply := server.When("GET", "/").
+		Reply(http.StatusOK).
+		JSON(func() {})
+
+	reply.stub.chain.assertFailed(t)
+}
+
+func TestMockServer_InvalidStatus(t *testing.T) {
+	for _, status := range []int{-1, 0, 99, 1000} {
+		reporter := newMockReporter(t)
+
+		server := NewMockServer(reporter)
+		defer server.Close()
+
+		reply := server.When("GET", "/").
+			Reply(status)
+
+		reply.stub.chain.assertFailed(t)
+		assert.Equal(t, http.StatusOK, reply.status)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL(),
+			Reporter: newMockReporter(t),
+		})
+
+		// stub keeps default status, so server doesn't panic
+		resp := e.GET("/").Expect()
+		resp.Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+	}
+}
@@ -33,11 +33,20 @@ func (c *mockClient) Do(req *http.Request) (*http.Response, error) {
 	return nil, c.err
 }
 
+type mockTransport struct {
+	err error
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
 type mockBody struct {
 	reader   io.Reader
 	closed   bool
 	readErr  error
 	closeErr error
+	eofErr   error
 }
 
 func newMockBody(body string) *mockBody {
@@ -50,7 +59,11 @@ func (b *mockBody) Read(p []byte) (int, error) {
 	if b.readErr != nil {
 		return 0, b.readErr
 	}
-	return b.reader.Read(p)
+	n, err := b.reader.Read(p)
+	if err == io.EOF && b.eofErr != nil {
+		err = b.eofErr
+	}
+	return n, err
 }
 
 func (b *mockBody) Close() error {
@@ -166,6 +179,25 @@ func (p *mockPrinter) Response(resp *http.Response, rtt time.Duration) {
 	p.rtt = rtt
 }
 
+type mockTestingCleanup struct {
+	failed   bool
+	cleanups []func()
+}
+
+func (c *mockTestingCleanup) Cleanup(fn func()) {
+	c.cleanups = append(c.cleanups, fn)
+}
+
+func (c *mockTestingCleanup) Failed() bool {
+	return c.failed
+}
+
+func (c *mockTestingCleanup) run() {
+	for i := len(c.cleanups) - 1; i >= 0; i-- {
+		c.cleanups[i]()
+	}
+}
+
 type mockWebsocketPrinter struct {
 	isWrittenTo bool
 	isReadFrom  bool
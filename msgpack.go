@@ -0,0 +1,427 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Encode object into MessagePack document.
+//
+// Object is first converted to generic Go value using json.Marshal,
+// so that struct tags and custom marshalers are respected in the same
+// way as for JSON. Integral numbers are encoded as MessagePack integers,
+// other numbers as 64-bit floats; map keys are sorted.
+func encodeMsgPack(object interface{}) ([]byte, error) {
+	b, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := encodeMsgPackValue(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeMsgPackValue(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+
+	case bool:
+		if v {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			encodeMsgPackInt(buf, i)
+		} else if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			buf.WriteByte(0xcf)
+			writeUint(buf, u, 8)
+		} else if f, err := v.Float64(); err == nil {
+			buf.WriteByte(0xcb)
+			writeUint(buf, math.Float64bits(f), 8)
+		} else {
+			return err
+		}
+
+	case string:
+		n := len(v)
+		switch {
+		case n < 32:
+			buf.WriteByte(0xa0 | byte(n))
+		case n <= math.MaxUint8:
+			buf.WriteByte(0xd9)
+			writeUint(buf, uint64(n), 1)
+		case n <= math.MaxUint16:
+			buf.WriteByte(0xda)
+			writeUint(buf, uint64(n), 2)
+		default:
+			buf.WriteByte(0xdb)
+			writeUint(buf, uint64(n), 4)
+		}
+		buf.WriteString(v)
+
+	case []interface{}:
+		writeMsgPackHeader(buf, len(v), 0x90, 0xdc, 0xdd)
+		for _, elem := range v {
+			if err := encodeMsgPackValue(buf, elem); err != nil {
+				return err
+			}
+		}
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		writeMsgPackHeader(buf, len(v), 0x80, 0xde, 0xdf)
+		for _, key := range keys {
+			if err := encodeMsgPackValue(buf, key); err != nil {
+				return err
+			}
+			if err := encodeMsgPackValue(buf, v[key]); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unsupported value type %T", value)
+	}
+
+	return nil
+}
+
+func encodeMsgPackInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= math.MaxInt8:
+		buf.WriteByte(byte(i))
+	case i >= 0 && i <= math.MaxUint8:
+		buf.WriteByte(0xcc)
+		writeUint(buf, uint64(i), 1)
+	case i >= 0 && i <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		writeUint(buf, uint64(i), 2)
+	case i >= 0 && i <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		writeUint(buf, uint64(i), 4)
+	case i >= 0:
+		buf.WriteByte(0xcf)
+		writeUint(buf, uint64(i), 8)
+	case i >= -32:
+		buf.WriteByte(byte(i))
+	case i >= math.MinInt8:
+		buf.WriteByte(0xd0)
+		writeUint(buf, uint64(i), 1)
+	case i >= math.MinInt16:
+		buf.WriteByte(0xd1)
+		writeUint(buf, uint64(i), 2)
+	case i >= math.MinInt32:
+		buf.WriteByte(0xd2)
+		writeUint(buf, uint64(i), 4)
+	default:
+		buf.WriteByte(0xd3)
+		writeUint(buf, uint64(i), 8)
+	}
+}
+
+func writeMsgPackHeader(buf *bytes.Buffer, n int, fix, code16, code32 byte) {
+	switch {
+	case n < 16:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(code16)
+		writeUint(buf, uint64(n), 2)
+	default:
+		buf.WriteByte(code32)
+		writeUint(buf, uint64(n), 4)
+	}
+}
+
+// writes low size bytes of v in big-endian order
+func writeUint(buf *bytes.Buffer, v uint64, size int) {
+	for i := size - 1; i >= 0; i-- {
+		buf.WriteByte(byte(v >> (8 * uint(i))))
+	}
+}
+
+// Decode MessagePack document into generic Go value.
+//
+// Conversion rules are chosen so that result is the same as if
+// equivalent JSON document was decoded:
+//   - maps are converted to map[string]interface{}; keys must be strings
+//   - arrays are converted to []interface{}
+//   - integers and floats are converted to float64
+//   - strings and binary data are converted to string
+//   - timestamp extension is converted to RFC 3339 string
+//
+// Other extension types are not supported.
+func decodeMsgPack(data []byte) (interface{}, error) {
+	d := &msgPackDecoder{data: data}
+
+	value, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("unexpected trailing data at offset %d", d.pos)
+	}
+
+	return value, nil
+}
+
+type msgPackDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *msgPackDecoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errors.New("unexpected end of data")
+	}
+
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+
+	return b, nil
+}
+
+func (d *msgPackDecoder) readUint(size int) (uint64, error) {
+	b, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+
+	return v, nil
+}
+
+func (d *msgPackDecoder) decode() (interface{}, error) {
+	offset := d.pos
+
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	code := b[0]
+
+	switch {
+	case code <= 0x7f:
+		return float64(code), nil
+	case code >= 0xe0:
+		return float64(int8(code)), nil
+	case code >= 0x80 && code <= 0x8f:
+		return d.decodeMap(int(code & 0x0f))
+	case code >= 0x90 && code <= 0x9f:
+		return d.decodeArray(int(code & 0x0f))
+	case code >= 0xa0 && code <= 0xbf:
+		return d.decodeString(int(code & 0x1f))
+	}
+
+	switch code {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+
+	case 0xc4, 0xc5, 0xc6:
+		// bin 8, bin 16, bin 32
+		n, err := d.readUint(1 << (code - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+
+	case 0xd9, 0xda, 0xdb:
+		// str 8, str 16, str 32
+		n, err := d.readUint(1 << (code - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(int(n))
+
+	case 0xca:
+		v, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(v))), nil
+	case 0xcb:
+		v, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(v), nil
+
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := d.readUint(1 << (code - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return float64(v), nil
+
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (code - 0xd0)
+		v, err := d.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		// sign-extend
+		shift := uint(64 - 8*size)
+		return float64(int64(v<<shift) >> shift), nil
+
+	case 0xdc, 0xdd:
+		n, err := d.readUint(2 << (code - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(int(n))
+
+	case 0xde, 0xdf:
+		n, err := d.readUint(2 << (code - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(int(n))
+
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.decodeExt(1 << (code - 0xd4))
+
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.readUint(1 << (code - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(int(n))
+	}
+
+	return nil, fmt.Errorf("unknown type code 0x%02x at offset %d", code, offset)
+}
+
+func (d *msgPackDecoder) decodeString(n int) (interface{}, error) {
+	b, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (d *msgPackDecoder) decodeArray(n int) (interface{}, error) {
+	arr := make([]interface{}, 0, minInt(n, len(d.data)-d.pos))
+
+	for i := 0; i < n; i++ {
+		elem, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, elem)
+	}
+
+	return arr, nil
+}
+
+func (d *msgPackDecoder) decodeMap(n int) (interface{}, error) {
+	m := make(map[string]interface{}, minInt(n, len(d.data)-d.pos))
+
+	for i := 0; i < n; i++ {
+		offset := d.pos
+
+		key, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		keyStr, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("unsupported map key type %T at offset %d",
+				key, offset)
+		}
+
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+
+		m[keyStr] = value
+	}
+
+	return m, nil
+}
+
+func (d *msgPackDecoder) decodeExt(n int) (interface{}, error) {
+	offset := d.pos
+
+	b, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	extType := int8(b[0])
+
+	data, err := d.read(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if extType != -1 {
+		return nil, fmt.Errorf("unsupported extension type %d at offset %d",
+			extType, offset)
+	}
+
+	var (
+		sec  int64
+		nsec int64
+	)
+
+	switch len(data) {
+	case 4:
+		sec = int64(binary.BigEndian.Uint32(data))
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		nsec, sec = int64(v>>34), int64(v&0x3ffffffff)
+	case 12:
+		nsec = int64(binary.BigEndian.Uint32(data[:4]))
+		sec = int64(binary.BigEndian.Uint64(data[4:]))
+	default:
+		return nil, fmt.Errorf("invalid timestamp length %d at offset %d",
+			len(data), offset)
+	}
+
+	return time.Unix(sec, nsec).UTC().Format(time.RFC3339Nano), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,155 @@
+package httpexpect
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgPack_Encode(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"nil", nil, "\xc0"},
+		{"false", false, "\xc2"},
+		{"true", true, "\xc3"},
+		{"positive fixint", 127, "\x7f"},
+		{"negative fixint", -32, "\xe0"},
+		{"uint8", 200, "\xcc\xc8"},
+		{"uint16", 1000, "\xcd\x03\xe8"},
+		{"uint32", 70000, "\xce\x00\x01\x11\x70"},
+		{"uint64", uint64(math.MaxUint64),
+			"\xcf\xff\xff\xff\xff\xff\xff\xff\xff"},
+		{"int8", -100, "\xd0\x9c"},
+		{"int16", -1000, "\xd1\xfc\x18"},
+		{"int32", -70000, "\xd2\xff\xfe\xee\x90"},
+		{"int64", int64(math.MinInt64),
+			"\xd3\x80\x00\x00\x00\x00\x00\x00\x00"},
+		{"float64", 1.5, "\xcb\x3f\xf8\x00\x00\x00\x00\x00\x00"},
+		{"fixstr", "abc", "\xa3abc"},
+		{"str8", strings.Repeat("a", 32), "\xd9\x20" + strings.Repeat("a", 32)},
+		{"fixarray", []interface{}{1, "a"}, "\x92\x01\xa1a"},
+		{"fixmap", map[string]interface{}{"b": 2, "a": 1}, "\x82\xa1a\x01\xa1b\x02"},
+		{"struct", struct {
+			Foo int `json:"foo"`
+		}{Foo: 1}, "\x81\xa3foo\x01"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := encodeMsgPack(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(tc.expected), b)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := encodeMsgPack(func() {})
+		assert.Error(t, err)
+	})
+}
+
+func TestMsgPack_Decode(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{"nil", "\xc0", nil},
+		{"false", "\xc2", false},
+		{"true", "\xc3", true},
+		{"positive fixint", "\x7f", 127.0},
+		{"negative fixint", "\xe0", -32.0},
+		{"uint8", "\xcc\xc8", 200.0},
+		{"uint16", "\xcd\x03\xe8", 1000.0},
+		{"uint32", "\xce\x00\x01\x11\x70", 70000.0},
+		{"int8", "\xd0\x9c", -100.0},
+		{"int16", "\xd1\xfc\x18", -1000.0},
+		{"int32", "\xd2\xff\xfe\xee\x90", -70000.0},
+		{"int64", "\xd3\xff\xff\xff\xff\xff\xff\xff\xfe", -2.0},
+		{"float32", "\xca\x3f\xc0\x00\x00", 1.5},
+		{"float64", "\xcb\x3f\xf8\x00\x00\x00\x00\x00\x00", 1.5},
+		{"fixstr", "\xa3abc", "abc"},
+		{"str8", "\xd9\x03abc", "abc"},
+		{"str16", "\xda\x00\x03abc", "abc"},
+		{"bin8", "\xc4\x02\x00\xff", "\x00\xff"},
+		{"fixarray", "\x92\x01\xa1a", []interface{}{1.0, "a"}},
+		{"array16", "\xdc\x00\x01\xc0", []interface{}{nil}},
+		{"fixmap", "\x81\xa1a\x91\x01",
+			map[string]interface{}{"a": []interface{}{1.0}}},
+		{"map16", "\xde\x00\x01\xa1a\xc3", map[string]interface{}{"a": true}},
+		{"timestamp32", "\xd6\xff\x00\x00\x00\x3c", "1970-01-01T00:01:00Z"},
+		{"timestamp64", "\xd7\xff\x00\x00\x00\x04\x00\x00\x00\x3c",
+			"1970-01-01T00:01:00.000000001Z"},
+		{"timestamp96", "\xc7\x0c\xff\x00\x00\x00\x01" +
+			"\x00\x00\x00\x00\x00\x00\x00\x3c", "1970-01-01T00:01:00.000000001Z"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := decodeMsgPack([]byte(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestMsgPack_DecodeErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"truncated string", "\xa3ab"},
+		{"truncated array", "\x92\x01"},
+		{"truncated length", "\xda\x00"},
+		{"trailing data", "\x01\x02"},
+		{"unused code", "\xc1"},
+		{"non-string key", "\x81\x01\x02"},
+		{"unknown extension", "\xd4\x01\x00"},
+		{"bad timestamp", "\xd5\xff\x00\x00"},
+		{"huge array", "\xdd\xff\xff\xff\xff"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := decodeMsgPack([]byte(tc.input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMsgPack_RoundTrip(t *testing.T) {
+	input := map[string]interface{}{
+		"name":  strings.Repeat("x", 300),
+		"count": 123456789,
+		"ratio": -0.25,
+		"tags":  make([]interface{}, 20),
+		"nested": map[string]interface{}{
+			"ok": true,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"name":  strings.Repeat("x", 300),
+		"count": 123456789.0,
+		"ratio": -0.25,
+		"tags":  make([]interface{}, 20),
+		"nested": map[string]interface{}{
+			"ok": true,
+		},
+	}
+
+	b, err := encodeMsgPack(input)
+	require.NoError(t, err)
+
+	value, err := decodeMsgPack(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, value)
+}
@@ -1,8 +1,24 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
 	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// Byte size units, may be used with Number.AsBytes and in Number
+// comparisons, e.g. Lt(2*MiB).
+const (
+	KB = 1000
+	MB = 1000 * KB
+	GB = 1000 * MB
+
+	KiB = 1 << 10
+	MiB = 1 << 20
+	GiB = 1 << 30
 )
 
 // Number provides methods to inspect attached float64 value
@@ -11,6 +27,7 @@ type Number struct {
 	noCopy noCopy
 	chain  *chain
 	value  float64
+	exact  *big.Int
 }
 
 // NewNumber returns a new Number instance.
@@ -39,6 +56,17 @@ func newNumber(parent *chain, val float64) *Number {
 	return &Number{chain: parent.clone(), value: val}
 }
 
+// newNumberExact creates number from json.Number holding integer
+// that can't be represented exactly as float64
+func newNumberExact(parent *chain, val json.Number) *Number {
+	n := &Number{chain: parent.clone()}
+
+	n.value, _ = val.Float64()
+	n.exact, _ = new(big.Int).SetString(string(val), 10)
+
+	return n
+}
+
 // Raw returns underlying value attached to Number.
 // This is the value originally passed to NewNumber.
 //
@@ -50,6 +78,20 @@ func (n *Number) Raw() float64 {
 	return n.value
 }
 
+// Alias is similar to Value.Alias.
+//
+// Example:
+//
+//	number := NewNumber(t, 123)
+//	number.Alias("user.age").Gt(18)
+func (n *Number) Alias(name string) *Number {
+	opChain := n.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	n.chain.setAlias(name)
+	return n
+}
+
 // Decode unmarshals the underlying value attached to the Number to a target variable.
 // target should be one of these:
 //
@@ -72,7 +114,11 @@ func (n *Number) Decode(target interface{}) *Number {
 		return n
 	}
 
-	canonDecode(opChain, n.value, target)
+	if n.exact != nil {
+		canonDecode(opChain, json.Number(n.exact.String()), target)
+	} else {
+		canonDecode(opChain, n.value, target)
+	}
 	return n
 }
 
@@ -96,7 +142,9 @@ func (n *Number) Schema(schema interface{}) *Number {
 // Equal succeeds if number is equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If value has integer type (or is json.Number
+// holding integer) and number is integer, they are compared exactly instead,
+// which is important for integers exceeding 2^53 (see Config.UseJSONNumber).
 //
 // Example:
 //
@@ -111,6 +159,20 @@ func (n *Number) Equal(value interface{}) *Number {
 		return n
 	}
 
+	if cmp, ok := n.compareExact(value); ok {
+		if !(cmp == 0) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{n.rawExact()},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: numbers are equal"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(opChain, value)
 	if !ok {
 		return n
@@ -133,7 +195,9 @@ func (n *Number) Equal(value interface{}) *Number {
 // NotEqual succeeds if number is not equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If value has integer type (or is json.Number
+// holding integer) and number is integer, they are compared exactly instead,
+// which is important for integers exceeding 2^53 (see Config.UseJSONNumber).
 //
 // Example:
 //
@@ -148,6 +212,20 @@ func (n *Number) NotEqual(value interface{}) *Number {
 		return n
 	}
 
+	if cmp, ok := n.compareExact(value); ok {
+		if !(cmp != 0) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertNotEqual,
+				Actual:   &AssertionValue{n.rawExact()},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: numbers are non-equal"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(opChain, value)
 	if !ok {
 		return n
@@ -267,6 +345,63 @@ func (n *Number) NotEqualDelta(value, delta float64) *Number {
 	return n.NotInDelta(value, delta)
 }
 
+// IsInt succeeds if number is an integer, i.e. has no fractional part.
+//
+// NaN and infinite values are not considered integers.
+//
+// Example:
+//
+//	number := NewNumber(t, 123.0)
+//	number.IsInt()
+func (n *Number) IsInt() *Number {
+	opChain := n.chain.enter("IsInt()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return n
+	}
+
+	if !isInteger(n.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number is an integer"),
+			},
+		})
+	}
+
+	return n
+}
+
+// NotInt succeeds if number is not an integer, i.e. has fractional part,
+// or is NaN or infinite.
+//
+// Example:
+//
+//	number := NewNumber(t, 123.5)
+//	number.NotInt()
+func (n *Number) NotInt() *Number {
+	opChain := n.chain.enter("NotInt()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return n
+	}
+
+	if isInteger(n.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number is not an integer"),
+			},
+		})
+	}
+
+	return n
+}
+
 // InRange succeeds if number is within given range [min; max].
 //
 // min and max should have numeric type convertible to float64. Before comparison,
@@ -355,7 +490,9 @@ func (n *Number) NotInRange(min, max interface{}) *Number {
 // Gt succeeds if number is greater than given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If value has integer type (or is json.Number
+// holding integer) and number is integer, they are compared exactly instead,
+// which is important for integers exceeding 2^53 (see Config.UseJSONNumber).
 //
 // Example:
 //
@@ -370,6 +507,20 @@ func (n *Number) Gt(value interface{}) *Number {
 		return n
 	}
 
+	if cmp, ok := n.compareExact(value); ok {
+		if !(cmp > 0) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertGt,
+				Actual:   &AssertionValue{n.rawExact()},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: number is larger than given value"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(opChain, value)
 	if !ok {
 		return n
@@ -392,7 +543,9 @@ func (n *Number) Gt(value interface{}) *Number {
 // Ge succeeds if number is greater than or equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If value has integer type (or is json.Number
+// holding integer) and number is integer, they are compared exactly instead,
+// which is important for integers exceeding 2^53 (see Config.UseJSONNumber).
 //
 // Example:
 //
@@ -407,6 +560,20 @@ func (n *Number) Ge(value interface{}) *Number {
 		return n
 	}
 
+	if cmp, ok := n.compareExact(value); ok {
+		if !(cmp >= 0) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertGe,
+				Actual:   &AssertionValue{n.rawExact()},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: number is larger than or equal to given value"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(opChain, value)
 	if !ok {
 		return n
@@ -429,7 +596,9 @@ func (n *Number) Ge(value interface{}) *Number {
 // Lt succeeds if number is lesser than given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If value has integer type (or is json.Number
+// holding integer) and number is integer, they are compared exactly instead,
+// which is important for integers exceeding 2^53 (see Config.UseJSONNumber).
 //
 // Example:
 //
@@ -444,6 +613,20 @@ func (n *Number) Lt(value interface{}) *Number {
 		return n
 	}
 
+	if cmp, ok := n.compareExact(value); ok {
+		if !(cmp < 0) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertLt,
+				Actual:   &AssertionValue{n.rawExact()},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: number is less than given value"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(opChain, value)
 	if !ok {
 		return n
@@ -466,7 +649,9 @@ func (n *Number) Lt(value interface{}) *Number {
 // Le succeeds if number is lesser than or equal to given value.
 //
 // value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// it is converted to float64. If value has integer type (or is json.Number
+// holding integer) and number is integer, they are compared exactly instead,
+// which is important for integers exceeding 2^53 (see Config.UseJSONNumber).
 //
 // Example:
 //
@@ -481,6 +666,20 @@ func (n *Number) Le(value interface{}) *Number {
 		return n
 	}
 
+	if cmp, ok := n.compareExact(value); ok {
+		if !(cmp <= 0) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertLe,
+				Actual:   &AssertionValue{n.rawExact()},
+				Expected: &AssertionValue{value},
+				Errors: []error{
+					errors.New("expected: number is less than or equal to given value"),
+				},
+			})
+		}
+		return n
+	}
+
 	num, ok := canonNumber(opChain, value)
 	if !ok {
 		return n
@@ -499,3 +698,161 @@ func (n *Number) Le(value interface{}) *Number {
 
 	return n
 }
+
+// AsDuration converts number expressed in given units to time.Duration
+// and returns a new Duration instance with result. Fractional part is
+// rounded to the nearest nanosecond.
+//
+// unit should be positive, e.g. time.Millisecond or time.Second.
+//
+// Example:
+//
+//	number := NewNumber(t, 1500) // milliseconds
+//	number.AsDuration(time.Millisecond).Lt(2 * time.Second)
+func (n *Number) AsDuration(unit time.Duration) *Duration {
+	opChain := n.chain.enter("AsDuration()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	if unit <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive unit argument"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	value := math.Round(n.value * float64(unit))
+
+	if math.IsNaN(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number can be converted to duration"),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	d := time.Duration(value)
+
+	return newDuration(opChain, &d)
+}
+
+// AsBytes converts non-negative number expressed in given units (e.g. KiB)
+// to bytes and returns a new Number instance with result. If unit is
+// omitted, number is already in bytes and is only checked to be
+// a non-negative size.
+//
+// Byte size unit constants (KB, MiB, etc.) may be used for human-friendly
+// thresholds in subsequent comparisons.
+//
+// Example:
+//
+//	number := NewNumber(t, 1536) // kibibytes
+//	number.AsBytes(KiB).InRange(1*MiB, 2*MiB)
+func (n *Number) AsBytes(unit ...int64) *Number {
+	opChain := n.chain.enter("AsBytes()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if len(unit) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple unit arguments"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	mult := int64(1)
+	if len(unit) != 0 {
+		mult = unit[0]
+	}
+
+	if mult <= 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected non-positive unit argument"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	if math.IsNaN(n.value) || math.IsInf(n.value, 0) || n.value < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number is a non-negative finite size"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, n.value*float64(mult))
+}
+
+func isInteger(value float64) bool {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return false
+	}
+
+	return math.Trunc(value) == value
+}
+
+// compareExact compares number with given integer value exactly;
+// returns false in second value if number or value is not an integer
+func (n *Number) compareExact(value interface{}) (int, bool) {
+	var expected *big.Int
+
+	switch v := value.(type) {
+	case json.Number:
+		i, ok := new(big.Int).SetString(string(v), 10)
+		if !ok {
+			return 0, false
+		}
+		expected = i
+
+	default:
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			expected = big.NewInt(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			expected = new(big.Int).SetUint64(rv.Uint())
+		default:
+			return 0, false
+		}
+	}
+
+	actual := n.exact
+	if actual == nil {
+		if math.IsInf(n.value, 0) || math.IsNaN(n.value) || math.Trunc(n.value) != n.value {
+			return 0, false
+		}
+		actual, _ = big.NewFloat(n.value).Int(nil)
+	}
+
+	return actual.Cmp(expected), true
+}
+
+// rawExact returns number value for reporting
+func (n *Number) rawExact() interface{} {
+	if n.exact != nil {
+		return json.Number(n.exact.String())
+	}
+	return n.value
+}
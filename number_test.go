@@ -1,8 +1,10 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,6 +15,7 @@ func TestNumber_Failed(t *testing.T) {
 
 	value := newNumber(chain, 0)
 
+	value.Alias("foo")
 	value.Path("$")
 	value.Schema("")
 
@@ -23,12 +26,17 @@ func TestNumber_Failed(t *testing.T) {
 	value.NotEqual(0)
 	value.InDelta(0, 0)
 	value.NotInDelta(0, 0)
+	value.IsInt()
+	value.NotInt()
 	value.Gt(0)
 	value.Ge(0)
 	value.Lt(0)
 	value.Le(0)
 	value.InRange(0, 0)
 	value.NotInRange(0, 0)
+
+	value.AsDuration(time.Second).chain.assertFailed(t)
+	value.AsBytes().chain.assertFailed(t)
 }
 
 func TestNumber_Constructors(t *testing.T) {
@@ -233,6 +241,45 @@ func TestNumber_InDelta(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestNumber_IsInt(t *testing.T) {
+	cases := []struct {
+		value float64
+		isInt bool
+	}{
+		{0, true},
+		{123, true},
+		{-123, true},
+		{1e15, true},
+		{123.5, false},
+		{-0.1, false},
+		{math.NaN(), false},
+		{math.Inf(1), false},
+		{math.Inf(-1), false},
+	}
+
+	for _, tc := range cases {
+		reporter := newMockReporter(t)
+
+		value := NewNumber(reporter, tc.value)
+
+		value.IsInt()
+		if tc.isInt {
+			value.chain.assertNotFailed(t)
+		} else {
+			value.chain.assertFailed(t)
+		}
+		value.chain.clearFailed()
+
+		value.NotInt()
+		if tc.isInt {
+			value.chain.assertFailed(t)
+		} else {
+			value.chain.assertNotFailed(t)
+		}
+		value.chain.clearFailed()
+	}
+}
+
 func TestNumber_InRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -377,6 +424,96 @@ func TestNumber_ConvertEqual(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestNumber_ExactInt(t *testing.T) {
+	t.Run("float", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		// 2^53 + 1 is rounded to 2^53 when converted to float64
+		value := NewNumber(reporter, 9007199254740992)
+
+		value.Equal(int64(9007199254740992))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Equal(int64(9007199254740993))
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.NotEqual(int64(9007199254740993))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Lt(uint64(9007199254740993))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+	})
+
+	t.Run("json number", func(t *testing.T) {
+		value := newNumberExact(newMockChain(t), json.Number("9007199254740993"))
+
+		assert.Equal(t, float64(9007199254740992), value.Raw())
+
+		value.Equal(int64(9007199254740993))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Equal(json.Number("9007199254740993"))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Equal(int64(9007199254740992))
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.NotEqual(int64(9007199254740992))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Gt(int64(9007199254740992))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Ge(int64(9007199254740993))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Lt(uint64(9007199254740994))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Le(uint64(9007199254740993))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Gt(int64(9007199254740993))
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.Equal(float64(9007199254740992))
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		var target int64
+		value.Decode(&target)
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, int64(9007199254740993), target)
+	})
+
+	t.Run("not integer", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewNumber(reporter, 123.5)
+
+		value.Equal(123)
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.Gt(123)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+	})
+}
+
 func TestNumber_ConvertInRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -454,3 +591,108 @@ func TestNumber_ConvertLesser(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.clearFailed()
 }
+
+func TestNumber_AsDuration(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    float64
+		unit     time.Duration
+		expected time.Duration
+		fail     bool
+	}{
+		{"millis", 1500, time.Millisecond, 1500 * time.Millisecond, false},
+		{"seconds", 2, time.Second, 2 * time.Second, false},
+		{"fractional", 1.5, time.Second, 1500 * time.Millisecond, false},
+		{"negative", -3, time.Minute, -3 * time.Minute, false},
+		{"rounded", 0.6, time.Nanosecond, time.Nanosecond, false},
+		{"zero unit", 1, 0, 0, true},
+		{"negative unit", 1, -time.Second, 0, true},
+		{"overflow", 1e12, time.Hour, 0, true},
+		{"nan", math.NaN(), time.Second, 0, true},
+		{"inf", math.Inf(1), time.Second, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewNumber(reporter, tc.value)
+			d := value.AsDuration(tc.unit)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				d.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+				d.chain.assertNotFailed(t)
+				assert.Equal(t, tc.expected, d.Raw())
+			}
+		})
+	}
+
+	t.Run("comparison", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewNumber(reporter, 1500)
+
+		d := value.AsDuration(time.Millisecond)
+
+		d.Lt(2 * time.Second)
+		d.chain.assertNotFailed(t)
+
+		d.Gt(2 * time.Second)
+		d.chain.assertFailed(t)
+	})
+}
+
+func TestNumber_AsBytes(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    float64
+		unit     []int64
+		expected float64
+		fail     bool
+	}{
+		{"bytes", 100, nil, 100, false},
+		{"kib", 1.5, []int64{KiB}, 1536, false},
+		{"mb", 2, []int64{MB}, 2000000, false},
+		{"zero", 0, []int64{GiB}, 0, false},
+		{"negative", -1, nil, 0, true},
+		{"nan", math.NaN(), nil, 0, true},
+		{"inf", math.Inf(1), nil, 0, true},
+		{"zero unit", 1, []int64{0}, 0, true},
+		{"multiple units", 1, []int64{KiB, MiB}, 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewNumber(reporter, tc.value)
+			b := value.AsBytes(tc.unit...)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				b.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+				b.chain.assertNotFailed(t)
+				assert.Equal(t, tc.expected, b.Raw())
+			}
+		})
+	}
+
+	t.Run("comparison", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewNumber(reporter, 1536)
+
+		b := value.AsBytes(KiB)
+
+		b.InRange(1*MiB, 2*MiB)
+		b.chain.assertNotFailed(t)
+
+		b.Lt(1 * MB)
+		b.chain.assertFailed(t)
+	})
+}
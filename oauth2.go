@@ -0,0 +1,331 @@
+package httpexpect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2Config defines OAuth 2.0 client credentials grant parameters.
+//
+// See https://www.rfc-editor.org/rfc/rfc6749#section-4.4.
+type OAuth2Config struct {
+	// Token endpoint URL.
+	// Should not be empty.
+	TokenURL string
+
+	// Client credentials.
+	// Sent to token endpoint using HTTP Basic authentication.
+	ClientID     string
+	ClientSecret string
+
+	// Optional requested scopes.
+	Scopes []string
+
+	// Optional additional parameters sent to token endpoint,
+	// e.g. "audience".
+	EndpointParams url.Values
+
+	// Client used to send token requests.
+	// If nil, http.DefaultClient is used.
+	Client Client
+}
+
+// OAuth2TokenSource fetches and caches access tokens using OAuth 2.0
+// client credentials grant.
+//
+// Token is fetched on first use and reused until it expires or is
+// rejected by server with 401 status.
+//
+// OAuth2TokenSource is safe for concurrent use and is intended to be
+// shared between requests, so that a token is fetched once per test
+// suite instead of once per request.
+type OAuth2TokenSource struct {
+	config OAuth2Config
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+
+	now func() time.Time
+}
+
+// oauth2ExpiryDelta defines how early token is considered expired,
+// to avoid using token that expires while request is in flight.
+const oauth2ExpiryDelta = 10 * time.Second
+
+// NewOAuth2TokenSource returns a new OAuth2TokenSource with given config.
+//
+// Example:
+//
+//	source := NewOAuth2TokenSource(OAuth2Config{
+//		TokenURL:     "https://auth.example.com/oauth/token",
+//		ClientID:     "client",
+//		ClientSecret: "secret",
+//		Scopes:       []string{"read", "write"},
+//	})
+func NewOAuth2TokenSource(config OAuth2Config) *OAuth2TokenSource {
+	return &OAuth2TokenSource{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// Token returns cached access token, or fetches a new one from token
+// endpoint if there is no token yet or it has expired.
+func (s *OAuth2TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+
+	if expiresIn > 0 {
+		s.expiry = s.now().Add(expiresIn - oauth2ExpiryDelta)
+	} else {
+		s.expiry = time.Time{}
+	}
+
+	return s.token, nil
+}
+
+// Invalidate drops cached token, so that next call to Token will fetch
+// a new one.
+func (s *OAuth2TokenSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = ""
+	s.expiry = time.Time{}
+}
+
+// invalidate drops cached token only if it's equal to given one, so that
+// a token fetched concurrently by another request is not dropped
+func (s *OAuth2TokenSource) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+		s.expiry = time.Time{}
+	}
+}
+
+func (s *OAuth2TokenSource) fetch(ctx context.Context) (string, time.Duration, error) {
+	if s.config.TokenURL == "" {
+		return "", 0, errors.New("oauth2: empty token url")
+	}
+
+	params := url.Values{}
+	for k, v := range s.config.EndpointParams {
+		params[k] = v
+	}
+
+	params.Set("grant_type", "client_credentials")
+
+	if len(s.config.Scopes) != 0 {
+		params.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost, s.config.TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("oauth2: %s", err.Error())
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	req.SetBasicAuth(
+		url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	client := s.config.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("oauth2: can't fetch token: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("oauth2: can't read token response: %s",
+			err.Error())
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", 0, fmt.Errorf("oauth2: token endpoint returned %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", 0, fmt.Errorf("oauth2: can't decode token response: %s",
+			err.Error())
+	}
+
+	if tokenResp.AccessToken == "" {
+		return "", 0, errors.New("oauth2: token response has no access_token")
+	}
+
+	return tokenResp.AccessToken,
+		time.Duration(tokenResp.ExpiresIn) * time.Second, nil
+}
+
+// OAuth2Transport implements http.RoundTripper that attaches access token
+// from OAuth2TokenSource to every request as "Authorization: Bearer" header.
+//
+// If server responds with 401 status, OAuth2Transport drops cached token,
+// fetches a new one, and repeats request once.
+type OAuth2Transport struct {
+	// Source of access tokens.
+	// Should not be nil.
+	Source *OAuth2TokenSource
+
+	// Underlying transport.
+	// If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+}
+
+// NewOAuth2Transport returns a new OAuth2Transport given a token source
+// and underlying transport.
+//
+// If base is nil, http.DefaultTransport is used.
+//
+// Example:
+//
+//	e := WithConfig(Config{
+//		BaseURL:  "https://api.example.com",
+//		Reporter: NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: NewOAuth2Transport(source, nil),
+//			Jar:       NewCookieJar(),
+//		},
+//	})
+func NewOAuth2Transport(
+	source *OAuth2TokenSource, base http.RoundTripper,
+) *OAuth2Transport {
+	return &OAuth2Transport{
+		Source: source,
+		Base:   base,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *OAuth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return oauth2Do(t.Source, req, base.RoundTrip)
+}
+
+// oauth2Client is used to attach tokens when Config.Client is not
+// http.Client, so we can't replace its transport
+type oauth2Client struct {
+	source *OAuth2TokenSource
+	client Client
+}
+
+func (c *oauth2Client) Do(req *http.Request) (*http.Response, error) {
+	return oauth2Do(c.source, req, c.client.Do)
+}
+
+func oauth2Do(
+	source *OAuth2TokenSource,
+	req *http.Request,
+	send func(*http.Request) (*http.Response, error),
+) (*http.Response, error) {
+	if source == nil {
+		return nil, errors.New("oauth2: token source is nil")
+	}
+
+	getBody := req.GetBody
+	if getBody == nil {
+		if bw, ok := req.Body.(*bodyWrapper); ok {
+			getBody = bw.GetBody
+		}
+	}
+
+	token, err := source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := send(oauth2Authorize(req, token, nil))
+	if err != nil {
+		return resp, err
+	}
+
+	// request can be repeated only if its body can be re-read
+	canRepeat := req.Body == nil || req.Body == http.NoBody || getBody != nil
+
+	if resp.StatusCode != http.StatusUnauthorized || !canRepeat {
+		return resp, nil
+	}
+
+	var body io.ReadCloser
+	if req.Body != nil && req.Body != http.NoBody {
+		if body, err = getBody(); err != nil {
+			return resp, nil
+		}
+	}
+
+	source.invalidate(token)
+
+	newToken, err := source.Token(req.Context())
+	if err != nil || newToken == token {
+		if body != nil {
+			body.Close()
+		}
+		return resp, nil
+	}
+
+	if resp.Body != nil {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	return send(oauth2Authorize(req, newToken, body))
+}
+
+func oauth2Authorize(req *http.Request, token string, body io.ReadCloser) *http.Request {
+	authReq := req.Clone(req.Context())
+
+	if body != nil {
+		authReq.Body = body
+	}
+
+	authReq.Header.Set("Authorization", "Bearer "+token)
+
+	return authReq
+}
@@ -0,0 +1,381 @@
+package httpexpect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type oauth2TestServer struct {
+	mu        sync.Mutex
+	fetches   int
+	expiresIn int
+	valid     map[string]bool
+	params    []map[string]string
+}
+
+func (s *oauth2TestServer) tokenHandler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != "client" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+		return
+	}
+
+	_ = r.ParseForm()
+
+	params := map[string]string{}
+	for k := range r.PostForm {
+		params[k] = r.PostForm.Get(k)
+	}
+	s.params = append(s.params, params)
+
+	s.fetches++
+	token := fmt.Sprintf("token-%d", s.fetches)
+
+	if s.valid == nil {
+		s.valid = map[string]bool{}
+	}
+	s.valid[token] = true
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprintf(w,
+		`{"access_token":%q,"token_type":"bearer","expires_in":%d}`,
+		token, s.expiresIn)
+}
+
+func (s *oauth2TestServer) apiHandler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	auth := r.Header.Get("Authorization")
+
+	if len(auth) < 7 || !s.valid[auth[7:]] {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+
+	_, _ = w.Write([]byte(auth[7:] + " " + string(body)))
+}
+
+func (s *oauth2TestServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.valid = nil
+}
+
+func createOAuth2Server() (*oauth2TestServer, *httptest.Server) {
+	state := &oauth2TestServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", state.tokenHandler)
+	mux.HandleFunc("/api", state.apiHandler)
+
+	return state, httptest.NewServer(mux)
+}
+
+func TestOAuth2TokenSource_Token(t *testing.T) {
+	state, server := createOAuth2Server()
+	defer server.Close()
+
+	state.expiresIn = 60
+
+	source := NewOAuth2TokenSource(OAuth2Config{
+		TokenURL:     server.URL + "/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+		EndpointParams: map[string][]string{
+			"audience": {"api"},
+		},
+	})
+
+	now := time.Now()
+	source.now = func() time.Time {
+		return now
+	}
+
+	t.Run("fetch", func(t *testing.T) {
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+
+		assert.Equal(t, []map[string]string{
+			{
+				"grant_type": "client_credentials",
+				"scope":      "read write",
+				"audience":   "api",
+			},
+		}, state.params)
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+		assert.Equal(t, 1, state.fetches)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		now = now.Add(60*time.Second - oauth2ExpiryDelta)
+
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-2", token)
+		assert.Equal(t, 2, state.fetches)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		source.Invalidate()
+
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-3", token)
+		assert.Equal(t, 3, state.fetches)
+	})
+
+	t.Run("invalidate other", func(t *testing.T) {
+		source.invalidate("token-1")
+
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-3", token)
+		assert.Equal(t, 3, state.fetches)
+	})
+}
+
+func TestOAuth2TokenSource_NoExpiry(t *testing.T) {
+	state, server := createOAuth2Server()
+	defer server.Close()
+
+	source := NewOAuth2TokenSource(OAuth2Config{
+		TokenURL:     server.URL + "/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+	})
+
+	for i := 0; i < 3; i++ {
+		token, err := source.Token(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+	}
+
+	assert.Equal(t, 1, state.fetches)
+	assert.Equal(t, []map[string]string{
+		{
+			"grant_type": "client_credentials",
+		},
+	}, state.params)
+}
+
+func TestOAuth2TokenSource_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":`))
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"token_type":"bearer"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cases := []struct {
+		name   string
+		config OAuth2Config
+	}{
+		{
+			name:   "empty url",
+			config: OAuth2Config{},
+		},
+		{
+			name: "bad url",
+			config: OAuth2Config{
+				TokenURL: "::",
+			},
+		},
+		{
+			name: "client error",
+			config: OAuth2Config{
+				TokenURL: server.URL + "/status",
+				Client:   &mockClient{err: errors.New("test error")},
+			},
+		},
+		{
+			name: "bad status",
+			config: OAuth2Config{
+				TokenURL: server.URL + "/status",
+			},
+		},
+		{
+			name: "bad json",
+			config: OAuth2Config{
+				TokenURL: server.URL + "/json",
+			},
+		},
+		{
+			name: "no access token",
+			config: OAuth2Config{
+				TokenURL: server.URL + "/token",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := NewOAuth2TokenSource(tc.config)
+
+			token, err := source.Token(context.Background())
+			assert.Error(t, err)
+			assert.Equal(t, "", token)
+		})
+	}
+}
+
+func TestOAuth2Transport_Refresh(t *testing.T) {
+	state, server := createOAuth2Server()
+	defer server.Close()
+
+	source := NewOAuth2TokenSource(OAuth2Config{
+		TokenURL:     server.URL + "/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+	})
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewOAuth2Transport(source, nil),
+		},
+	})
+
+	e.POST("/api").WithText("foo").
+		Expect().
+		Status(http.StatusOK).
+		Body().Equal("token-1 foo")
+
+	state.revoke()
+
+	e.POST("/api").WithText("bar").
+		Expect().
+		Status(http.StatusOK).
+		Body().Equal("token-2 bar")
+
+	assert.Equal(t, 2, state.fetches)
+}
+
+func TestOAuth2Transport_Unauthorized(t *testing.T) {
+	state, server := createOAuth2Server()
+	defer server.Close()
+
+	source := NewOAuth2TokenSource(OAuth2Config{
+		TokenURL:     server.URL + "/token",
+		ClientID:     "client",
+		ClientSecret: "wrong",
+	})
+
+	client := &http.Client{
+		Transport: NewOAuth2Transport(source, nil),
+	}
+
+	_, err := client.Get(server.URL + "/api")
+	assert.Error(t, err)
+
+	assert.Equal(t, 0, state.fetches)
+}
+
+func TestOAuth2Transport_NilSource(t *testing.T) {
+	client := &http.Client{
+		Transport: NewOAuth2Transport(nil, nil),
+	}
+
+	_, err := client.Get("http://example.com")
+	assert.Error(t, err)
+}
+
+func TestOAuth2_Request(t *testing.T) {
+	state, server := createOAuth2Server()
+	defer server.Close()
+
+	source := NewOAuth2TokenSource(OAuth2Config{
+		TokenURL:     server.URL + "/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+	})
+
+	t.Run("http client", func(t *testing.T) {
+		client := &http.Client{
+			Jar: NewCookieJar(),
+		}
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+			Client:   client,
+		})
+
+		e.GET("/api").
+			Expect().
+			Status(http.StatusUnauthorized)
+
+		for i := 0; i < 2; i++ {
+			e.PUT("/api").WithOAuth2(source).WithText("foo").
+				Expect().
+				Status(http.StatusOK).
+				Body().Equal("token-1 foo")
+		}
+
+		assert.Nil(t, client.Transport)
+		assert.Equal(t, 1, state.fetches)
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		client := &mockClient{}
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+			Client:   client,
+		})
+
+		e.GET("/api").WithOAuth2(source).
+			Expect()
+
+		assert.Equal(t, "Bearer token-1", client.req.Header.Get("Authorization"))
+		assert.Equal(t, 1, state.fetches)
+	})
+
+	t.Run("builder", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+		}).Builder(func(req *Request) {
+			req.WithOAuth2(source)
+		})
+
+		e.DELETE("/api").
+			Expect().
+			Status(http.StatusOK).
+			Body().Equal("token-1 ")
+	})
+}
@@ -71,6 +71,22 @@ func (o *Object) Raw() map[string]interface{} {
 	return o.value
 }
 
+// Alias is similar to Value.Alias.
+//
+// Example:
+//
+//	for _, id := range ids {
+//		user := e.GET("/users/{id}", id).Expect().JSON().Object()
+//		user.Alias(fmt.Sprintf("user %d", id)).Value("active").Boolean().True()
+//	}
+func (o *Object) Alias(name string) *Object {
+	opChain := o.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	o.chain.setAlias(name)
+	return o
+}
+
 // Decode unmarshals the underlying value attached to the Object to a target variable
 // target should be one of this:
 //
@@ -124,6 +140,14 @@ func (o *Object) Path(path string) *Value {
 	return jsonPath(opChain, o.value, path)
 }
 
+// Pointer is similar to Value.Pointer.
+func (o *Object) Pointer(pointer string) *Value {
+	opChain := o.chain.enter("Pointer(%q)", pointer)
+	defer opChain.leave()
+
+	return jsonPointer(opChain, o.value, pointer)
+}
+
 // Schema is similar to Value.Schema.
 func (o *Object) Schema(schema interface{}) *Object {
 	opChain := o.chain.enter("Schema()")
@@ -210,6 +234,35 @@ func (o *Object) Value(key string) *Value {
 	return newValue(opChain, value)
 }
 
+// Lookup returns a new Value instance with element for given key.
+//
+// Unlike Value, Lookup doesn't report failure if there is no such key.
+// Instead, it returns missing value, which may be checked using
+// Value.Exists and Value.NotExists. This allows to distinguish absent
+// key from key with null value.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//
+//	object.Lookup("foo").Exists().IsNull()
+//	object.Lookup("bar").NotExists()
+func (o *Object) Lookup(key string) *Value {
+	opChain := o.chain.enter("Lookup(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	value, ok := o.value[key]
+
+	v := newValue(opChain, value)
+	v.missing = !ok
+
+	return v
+}
+
 // Iter returns a new map of Values attached to object elements.
 //
 // Example:
@@ -658,6 +711,9 @@ func (o *Object) NotEmpty() *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// Expected value may contain placeholders, like "$any" or "$uuid";
+// see PlaceholderAny for details.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{"foo": 123})
@@ -675,7 +731,11 @@ func (o *Object) Equal(value interface{}) *Object {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if !equalValues(opChain, expected, o.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{o.value},
@@ -711,7 +771,11 @@ func (o *Object) NotEqual(value interface{}) *Object {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if equalValues(opChain, expected, o.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{o.value},
@@ -725,6 +789,174 @@ func (o *Object) NotEqual(value interface{}) *Object {
 	return o
 }
 
+// EqualIgnoring succeeds if object is equal to given value, ignoring
+// given paths in both object and value.
+// Before comparison, both object and value are converted to canonical form.
+//
+// value should be map[string]interface{} or struct.
+//
+// Each path is a dot-separated sequence of keys with optional array indexes,
+// like "created_at", "meta.request_id", or "items[0].id". Wildcard "*"
+// matches any key or index, e.g. "items[*].id".
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"id":         123,
+//		"created_at": "2023-01-02T15:04:05Z",
+//	})
+//	object.EqualIgnoring(map[string]interface{}{"id": 123}, "created_at")
+func (o *Object) EqualIgnoring(value interface{}, paths ...string) *Object {
+	opChain := o.chain.enter("EqualIgnoring()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	expected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	ignored, ok := parseIgnorePaths(opChain, paths)
+	if !ok {
+		return o
+	}
+
+	expectedStripped := removeIgnoredPaths(expected, ignored)
+	actualStripped := removeIgnoredPaths(o.value, ignored)
+
+	if !equalValues(opChain, expectedStripped, actualStripped) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actualStripped},
+			Expected: &AssertionValue{expectedStripped},
+			Errors: []error{
+				errors.New("expected: maps are equal, ignoring given paths"),
+			},
+		})
+	}
+
+	return o
+}
+
+// EqualStrict succeeds if object is equal to given value, treating
+// unexpected keys (present in object but not in value) as failures at
+// any nesting level.
+// Before comparison, both object and value are converted to canonical form.
+//
+// EqualStrict matches same objects as Equal, but on failure it reports
+// every missing, unexpected, and mismatched key with its path, like
+// "meta.request_id" or "items[0].id".
+//
+// Use EqualStrict to forbid unknown fields in responses and EqualSubset
+// to allow them.
+//
+// value should be map[string]interface{} or struct.
+//
+// Expected value may contain placeholders, like "$any" or "$uuid";
+// see PlaceholderAny for details.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": 123, "bar": 456})
+//	object.EqualStrict(map[string]interface{}{"foo": 123}) // failure
+func (o *Object) EqualStrict(value interface{}) *Object {
+	opChain := o.chain.enter("EqualStrict()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	expected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if diffs := diffObjects(opChain, expected, o.value, "", true); len(diffs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{expected},
+			Errors: append([]error{
+				errors.New("expected: maps are equal, without unexpected keys"),
+			}, diffs...),
+		})
+	}
+
+	return o
+}
+
+// EqualSubset succeeds if object is equal to given value, ignoring
+// unexpected keys (present in object but not in value) at any nesting
+// level.
+// Before comparison, both object and value are converted to canonical form.
+//
+// Unlike ContainsSubset, EqualSubset applies to objects nested in arrays
+// too: arrays should have same length, and their elements are compared
+// pairwise using same rules. This allows to check responses while staying
+// forward-compatible with fields added later.
+//
+// value should be map[string]interface{} or struct.
+//
+// Expected value may contain placeholders, like "$any" or "$uuid";
+// see PlaceholderAny for details.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"items": []interface{}{
+//			map[string]interface{}{"id": 1, "added_later": true},
+//		},
+//		"added_later": true,
+//	})
+//
+//	object.EqualSubset(map[string]interface{}{
+//		"items": []interface{}{
+//			map[string]interface{}{"id": 1},
+//		},
+//	})
+func (o *Object) EqualSubset(value interface{}) *Object {
+	opChain := o.chain.enter("EqualSubset()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	expected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if diffs := diffObjects(opChain, expected, o.value, "", false); len(diffs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{expected},
+			Errors: append([]error{
+				errors.New("expected: maps are equal, ignoring unexpected keys"),
+			}, diffs...),
+		})
+	}
+
+	return o
+}
+
 // ContainsKey succeeds if object contains given key.
 //
 // Example:
@@ -845,6 +1077,13 @@ func (o *Object) NotContainsValue(value interface{}) *Object {
 //
 // value should be map[string]interface{} or struct.
 //
+// Nested objects are matched recursively, i.e. only keys present in value
+// are checked at every level. On failure, the reported diff includes only
+// those keys, so mismatches are easy to find even in large objects.
+//
+// Expected value may contain placeholders, like "$any" or "$uuid";
+// see PlaceholderAny for details.
+//
 // Example:
 //
 //	object := NewObject(t, map[string]interface{}{
@@ -879,11 +1118,20 @@ func (o *Object) ContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if !containsSubset(opChain, o.value, value) {
+	expected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if !isSubset(opChain, o.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertContainsSubset,
 			Actual:   &AssertionValue{o.value},
-			Expected: &AssertionValue{value},
+			Expected: &AssertionValue{expected},
 			Errors: []error{
 				errors.New("expected: map contains sub-map"),
 			},
@@ -968,7 +1216,11 @@ func (o *Object) ValueEqual(key string, value interface{}) *Object {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value[key]) {
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if !equalValues(opChain, expected, o.value[key]) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{o.value[key]},
@@ -1022,7 +1274,11 @@ func (o *Object) NotValueEqual(key string, value interface{}) *Object {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value[key]) {
+	if !checkPlaceholders(opChain, expected) {
+		return o
+	}
+
+	if equalValues(opChain, expected, o.value[key]) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{o.value[key]},
@@ -1099,10 +1355,92 @@ func containsSubset(
 		return false
 	}
 
-	return isSubset(obj, canonVal)
+	if !checkPlaceholders(opChain, canonVal) {
+		return false
+	}
+
+	return isSubset(opChain, obj, canonVal)
+}
+
+// diffObjects compares canonical values and returns an error for every
+// missing key and mismatched value; if strict is true, keys not present
+// in expected value are reported as well
+func diffObjects(opChain *chain, expected, actual interface{}, path string, strict bool) []error {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return []error{fmt.Errorf("value mismatch at %q: expected object", path)}
+		}
+
+		var diffs []error
+
+		for _, k := range sortedKeys(e) {
+			av, ok := a[k]
+			if !ok {
+				diffs = append(diffs,
+					fmt.Errorf("missing key %q", joinObjectPath(path, k)))
+				continue
+			}
+			diffs = append(diffs, diffObjects(opChain, e[k], av, joinObjectPath(path, k), strict)...)
+		}
+
+		if strict {
+			for _, k := range sortedKeys(a) {
+				if _, ok := e[k]; !ok {
+					diffs = append(diffs,
+						fmt.Errorf("unexpected key %q", joinObjectPath(path, k)))
+				}
+			}
+		}
+
+		return diffs
+
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return []error{fmt.Errorf("value mismatch at %q: expected array", path)}
+		}
+
+		if len(a) != len(e) {
+			return []error{fmt.Errorf("array length mismatch at %q: expected %d, got %d",
+				path, len(e), len(a))}
+		}
+
+		var diffs []error
+
+		for i := range e {
+			diffs = append(diffs,
+				diffObjects(opChain, e[i], a[i], fmt.Sprintf("%s[%d]", path, i), strict)...)
+		}
+
+		return diffs
+	}
+
+	if !equalValues(opChain, expected, actual) {
+		return []error{fmt.Errorf("value mismatch at %q", path)}
+	}
+
+	return nil
+}
+
+func joinObjectPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
-func isSubset(outer, inner map[string]interface{}) bool {
+func isSubset(opChain *chain, outer, inner map[string]interface{}) bool {
 	for k, iv := range inner {
 		ov, ok := outer[k]
 		if !ok {
@@ -1111,14 +1449,14 @@ func isSubset(outer, inner map[string]interface{}) bool {
 
 		if ovm, ok := ov.(map[string]interface{}); ok {
 			if ivm, ok := iv.(map[string]interface{}); ok {
-				if !isSubset(ovm, ivm) {
+				if !isSubset(opChain, ovm, ivm) {
 					return false
 				}
 				continue
 			}
 		}
 
-		if !reflect.DeepEqual(ov, iv) {
+		if !equalValues(opChain, iv, ov) {
 			return false
 		}
 	}
@@ -10,12 +10,15 @@ func TestObject_Failed(t *testing.T) {
 	check := func(value *Object) {
 		value.chain.assertFailed(t)
 
+		value.Alias("foo")
 		value.Path("$")
+		value.Pointer("")
 		value.Schema("")
 
 		assert.NotNil(t, value.Keys())
 		assert.NotNil(t, value.Values())
 		assert.NotNil(t, value.Value("foo"))
+		assert.NotNil(t, value.Lookup("foo"))
 		assert.NotNil(t, value.Iter())
 
 		var target interface{}
@@ -25,6 +28,9 @@ func TestObject_Failed(t *testing.T) {
 		value.NotEmpty()
 		value.Equal(nil)
 		value.NotEqual(nil)
+		value.EqualIgnoring(nil)
+		value.EqualStrict(nil)
+		value.EqualSubset(nil)
 		value.ContainsKey("foo")
 		value.NotContainsKey("foo")
 		value.ContainsValue("foo")
@@ -285,6 +291,14 @@ func TestObject_Getters(t *testing.T) {
 	value.chain.assertFailed(t)
 	value.chain.clearFailed()
 
+	assert.Equal(t, m["foo"], value.Lookup("foo").Raw())
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	assert.Equal(t, nil, value.Lookup("BAZ").Raw())
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
 	it := value.Iter()
 	assert.Equal(t, 3, len(it))
 	assert.Equal(t, it["foo"].value, value.Value("foo").Raw())
@@ -397,6 +411,250 @@ func TestObject_Equal(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestObject_EqualIgnoring(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObjectC(Config{
+		Reporter:        reporter,
+		UsePlaceholders: true,
+	}, map[string]interface{}{
+		"id":         123.0,
+		"created_at": "2023-01-02T15:04:05Z",
+		"meta": map[string]interface{}{
+			"request_id": "abc",
+			"version":    1.0,
+		},
+	})
+
+	value.EqualIgnoring(map[string]interface{}{
+		"id":   123.0,
+		"meta": map[string]interface{}{"version": 1.0},
+	}, "created_at", "meta.request_id")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(map[string]interface{}{
+		"id":         123.0,
+		"created_at": "2000-01-01T00:00:00Z",
+		"meta": map[string]interface{}{
+			"request_id": "xyz",
+			"version":    1.0,
+		},
+	}, "$.created_at", "meta.request_id")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(map[string]interface{}{
+		"id":   "$any",
+		"meta": map[string]interface{}{"version": 1.0},
+	}, "created_at", "meta.request_id")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(map[string]interface{}{
+		"id":   456.0,
+		"meta": map[string]interface{}{"version": 1.0},
+	}, "created_at", "meta.request_id")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(map[string]interface{}{
+		"id":   123.0,
+		"meta": map[string]interface{}{"version": 1.0},
+	}, "created_at")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(map[string]interface{}{
+		"id":   123.0,
+		"meta": map[string]interface{}{"version": 1.0},
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(map[string]interface{}{}, "foo..bar")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring(nil, "created_at")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestObject_EqualStrict(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObjectC(Config{
+		Reporter:        reporter,
+		UsePlaceholders: true,
+	}, map[string]interface{}{
+		"id": 123.0,
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo", "extra": true},
+		},
+	})
+
+	value.EqualStrict(map[string]interface{}{
+		"id": 123.0,
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo", "extra": true},
+		},
+	})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualStrict(map[string]interface{}{
+		"id": "$any",
+		"items": []interface{}{
+			map[string]interface{}{"name": "$any", "extra": "$any"},
+		},
+	})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualStrict(map[string]interface{}{
+		"id": 123.0,
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+		},
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualStrict(map[string]interface{}{
+		"id": 123.0,
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualStrict(map[string]interface{}{
+		"id":    123.0,
+		"items": []interface{}{},
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualStrict(nil)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestObject_EqualSubset(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObjectC(Config{
+		Reporter:        reporter,
+		UsePlaceholders: true,
+	}, map[string]interface{}{
+		"id": 123.0,
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo", "extra": true},
+			map[string]interface{}{"name": "bar"},
+		},
+		"extra": map[string]interface{}{"a": 1.0},
+	})
+
+	value.EqualSubset(map[string]interface{}{
+		"id": 123.0,
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"name": "bar"},
+		},
+	})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"name": "$any"},
+		},
+	})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(map[string]interface{}{})
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+		},
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"name": "baz"},
+		},
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(map[string]interface{}{
+		"missing": 1.0,
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(map[string]interface{}{
+		"id": map[string]interface{}{},
+	})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualSubset(nil)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
+func TestObject_DiffObjects(t *testing.T) {
+	expected := map[string]interface{}{
+		"id": 1.0,
+		"meta": map[string]interface{}{
+			"version": 1.0,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+		},
+	}
+
+	actual := map[string]interface{}{
+		"id": 2.0,
+		"meta": map[string]interface{}{
+			"debug": true,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo", "extra": true},
+		},
+		"extra": true,
+	}
+
+	diffs := func(strict bool) []string {
+		var msgs []string
+		for _, err := range diffObjects(newMockChain(t), expected, actual, "", strict) {
+			msgs = append(msgs, err.Error())
+		}
+		return msgs
+	}
+
+	assert.Equal(t, []string{
+		`value mismatch at "id"`,
+		`missing key "meta.version"`,
+	}, diffs(false))
+
+	assert.Equal(t, []string{
+		`value mismatch at "id"`,
+		`unexpected key "items[0].extra"`,
+		`missing key "meta.version"`,
+		`unexpected key "meta.debug"`,
+		`unexpected key "extra"`,
+	}, diffs(true))
+}
+
 func TestObject_EqualStruct(t *testing.T) {
 	reporter := newMockReporter(t)
 
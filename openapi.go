@@ -0,0 +1,28 @@
+package httpexpect
+
+import (
+	"github.com/gavv/httpexpect/v2/openapi"
+)
+
+// OpenAPIMatcher returns a function that checks every response against
+// given OpenAPI specification, see Response.MatchOpenAPI.
+// It can be passed to Expect.Matcher or Request.WithMatcher.
+//
+// Example:
+//
+//	spec, err := openapi.Load("openapi.yaml")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		Matcher(httpexpect.OpenAPIMatcher(spec))
+//
+//	e.GET("/users/{id}", 123).
+//		Expect().
+//		Status(http.StatusOK)
+func OpenAPIMatcher(spec *openapi.Spec) func(*Response) {
+	return func(resp *Response) {
+		resp.MatchOpenAPI(spec)
+	}
+}
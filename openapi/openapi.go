@@ -0,0 +1,391 @@
+// Package openapi parses OpenAPI 3.x specifications, so that responses
+// can be checked against them.
+//
+// Specification is parsed once and then can be shared between tests.
+// Use httpexpect.Response.MatchOpenAPI to check single response, or
+// httpexpect.OpenAPIMatcher to check every response.
+//
+// Example:
+//
+//	spec, err := openapi.Load("openapi.yaml")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	e := httpexpect.Default(t, "http://example.com").
+//		Matcher(httpexpect.OpenAPIMatcher(spec))
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Spec holds parsed OpenAPI 3.x specification.
+type Spec struct {
+	doc        map[string]interface{}
+	prefixes   []string
+	operations []Operation
+}
+
+// Operation is an operation defined in specification, i.e. a method
+// of a path item.
+type Operation struct {
+	// Method is upper-case HTTP method, e.g. "GET".
+	Method string
+	// Path is path template from specification, e.g. "/users/{id}".
+	Path string
+
+	spec      *Spec
+	pattern   *regexp.Regexp
+	numParams int
+	responses map[string]interface{}
+}
+
+// Response is a response documented for an operation.
+type Response struct {
+	spec    *Spec
+	content map[string]interface{}
+}
+
+// MediaType is a media type documented for a response.
+type MediaType struct {
+	spec  *Spec
+	value map[string]interface{}
+}
+
+// New parses OpenAPI 3.x specification in JSON or YAML format.
+//
+// Example:
+//
+//	spec, err := openapi.New([]byte(`
+//	openapi: 3.0.0
+//	info:
+//	  title: example
+//	  version: 1.0.0
+//	paths:
+//	  /users/{id}:
+//	    get:
+//	      responses:
+//	        "200":
+//	          description: user
+//	          content:
+//	            application/json:
+//	              schema:
+//	                type: object
+//	                required: [name]
+//	`))
+func New(spec []byte) (*Spec, error) {
+	var raw interface{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(spec), []byte("{")) {
+		if err := json.Unmarshal(spec, &raw); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := yaml.Unmarshal(spec, &raw); err != nil {
+			return nil, err
+		}
+		raw = canonYAML(raw)
+	}
+
+	doc, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid OpenAPI spec: expected object")
+	}
+
+	version, _ := doc["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, expected 3.x", version)
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid OpenAPI spec: missing paths")
+	}
+
+	s := &Spec{
+		doc: doc,
+	}
+
+	if servers, ok := doc["servers"].([]interface{}); ok {
+		for _, server := range servers {
+			serverMap, _ := server.(map[string]interface{})
+			serverURL, _ := serverMap["url"].(string)
+			if u, err := url.Parse(serverURL); err == nil {
+				if prefix := strings.TrimSuffix(u.Path, "/"); prefix != "" {
+					s.prefixes = append(s.prefixes, prefix)
+				}
+			}
+		}
+	}
+
+	for path, item := range paths {
+		methods, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		pattern, numParams := pathPattern(path)
+
+		for method, op := range methods {
+			opMap, ok := op.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			responses, ok := opMap["responses"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			s.operations = append(s.operations, Operation{
+				Method:    strings.ToUpper(method),
+				Path:      path,
+				spec:      s,
+				pattern:   pattern,
+				numParams: numParams,
+				responses: responses,
+			})
+		}
+	}
+
+	// prefer most specific paths, e.g. "/users/me" over "/users/{id}"
+	sort.Slice(s.operations, func(i, j int) bool {
+		if s.operations[i].numParams != s.operations[j].numParams {
+			return s.operations[i].numParams < s.operations[j].numParams
+		}
+		if s.operations[i].Path != s.operations[j].Path {
+			return s.operations[i].Path < s.operations[j].Path
+		}
+		return s.operations[i].Method < s.operations[j].Method
+	})
+
+	return s, nil
+}
+
+// Load reads OpenAPI 3.x specification in JSON or YAML format from
+// given file.
+//
+// Example:
+//
+//	spec, err := openapi.Load("openapi.yaml")
+func Load(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(data)
+}
+
+// FindOperation returns operation matching given method and request path.
+// Path may include prefix defined in "servers" section of specification.
+// If there is no such operation, returns nil.
+func (s *Spec) FindOperation(method, path string) *Operation {
+	candidates := []string{path}
+
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(path, prefix+"/") {
+			candidates = append(candidates, strings.TrimPrefix(path, prefix))
+		}
+	}
+
+	for _, p := range candidates {
+		for i := range s.operations {
+			op := &s.operations[i]
+			if op.Method == method && op.pattern.MatchString(p) {
+				return op
+			}
+		}
+	}
+
+	return nil
+}
+
+// Statuses returns sorted list of documented status codes, as written in
+// specification, e.g. "200", "4XX", "default".
+func (op *Operation) Statuses() []string {
+	keys := make([]string, 0, len(op.responses))
+	for key := range op.responses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// FindResponse returns response documented for given status code, either
+// exactly, by range like "4XX", or as "default". If there is no such
+// response, returns nil.
+func (op *Operation) FindResponse(status int) *Response {
+	keys := []string{
+		fmt.Sprint(status),
+		fmt.Sprintf("%dXX", status/100),
+		fmt.Sprintf("%dxx", status/100),
+		"default",
+	}
+
+	for _, key := range keys {
+		if resp, ok := op.responses[key].(map[string]interface{}); ok {
+			content, _ := resp["content"].(map[string]interface{})
+
+			return &Response{
+				spec:    op.spec,
+				content: content,
+			}
+		}
+	}
+
+	return nil
+}
+
+// MediaTypes returns sorted list of documented media types, as written in
+// specification, e.g. "application/json", "text/*". Empty list means
+// that response content is not documented.
+func (r *Response) MediaTypes() []string {
+	keys := make([]string, 0, len(r.content))
+	for key := range r.content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// FindMediaType returns documented media type matching given one. Exact
+// matches are preferred over wildcards like "application/*" and "*/*".
+// If there is no such media type, returns nil.
+func (r *Response) FindMediaType(mediaType string) *MediaType {
+	keys := r.MediaTypes()
+
+	for _, exact := range []bool{true, false} {
+		for _, key := range keys {
+			pattern, _, err := mime.ParseMediaType(key)
+			if err != nil {
+				pattern = key
+			}
+
+			if exact && !strings.EqualFold(pattern, mediaType) {
+				continue
+			}
+
+			if matchMediaType(pattern, mediaType) {
+				value, _ := r.content[key].(map[string]interface{})
+
+				return &MediaType{
+					spec:  r.spec,
+					value: value,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Schema returns JSON schema documented for media type, if any.
+//
+// Returned schema has attached components of specification, so that
+// references like "#/components/schemas/User" are resolved against it.
+// OpenAPI-specific keywords like "nullable" are kept as is.
+func (m *MediaType) Schema() (interface{}, bool) {
+	schema, ok := m.value["schema"]
+	if !ok {
+		return nil, false
+	}
+
+	schemaMap, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema, true
+	}
+
+	components, ok := m.spec.doc["components"]
+	if !ok {
+		return schema, true
+	}
+
+	result := make(map[string]interface{}, len(schemaMap)+1)
+	for k, v := range schemaMap {
+		result[k] = v
+	}
+	result["components"] = components
+
+	return result, true
+}
+
+func pathPattern(path string) (*regexp.Regexp, int) {
+	var (
+		buf       strings.Builder
+		numParams int
+	)
+
+	buf.WriteString("^")
+
+	for len(path) != 0 {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+
+		if start < 0 || end < start {
+			buf.WriteString(regexp.QuoteMeta(path))
+			break
+		}
+
+		buf.WriteString(regexp.QuoteMeta(path[:start]))
+		buf.WriteString("[^/]+")
+
+		path = path[end+1:]
+		numParams++
+	}
+
+	buf.WriteString("/?$")
+
+	return regexp.MustCompile(buf.String()), numParams
+}
+
+func matchMediaType(pattern, mediaType string) bool {
+	if pattern == "*/*" || strings.EqualFold(pattern, mediaType) {
+		return true
+	}
+
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(
+			strings.ToLower(mediaType), strings.ToLower(strings.TrimSuffix(pattern, "*")))
+	}
+
+	return false
+}
+
+// canonYAML converts value decoded by yaml.v2 to a value that would be
+// decoded from equivalent JSON, i.e. replaces map[interface{}]interface{}
+// with map[string]interface{}
+func canonYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			result[fmt.Sprint(key)] = canonYAML(elem)
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = canonYAML(elem)
+		}
+		return result
+
+	default:
+		return value
+	}
+}
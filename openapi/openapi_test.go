@@ -0,0 +1,257 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOpenAPIYAML = `
+openapi: 3.0.3
+info:
+  title: test
+  version: 1.0.0
+servers:
+  - url: http://example.com/api/v1
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: list of users
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/User"
+    post:
+      responses:
+        "201":
+          description: created
+        4XX:
+          description: error
+          content:
+            text/*:
+              schema:
+                type: string
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: user
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+        default:
+          description: error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+  /users/me:
+    get:
+      responses:
+        "204":
+          description: no content
+components:
+  schemas:
+    User:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+`
+
+const testOpenAPIJSON = `{
+  "openapi": "3.1.0",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/ping": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "pong",
+            "content": {
+              "text/plain": {}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestSpec_Parse(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		spec, err := New([]byte(testOpenAPIYAML))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/api/v1"}, spec.prefixes)
+		assert.Equal(t, 4, len(spec.operations))
+
+		schemas := spec.doc["components"].(map[string]interface{})["schemas"]
+		assert.Contains(t, schemas, "User")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		spec, err := New([]byte(testOpenAPIJSON))
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, len(spec.operations))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		inputs := []string{
+			``,
+			`[]`,
+			`{`,
+			`foo: [`,
+			`{"swagger": "2.0", "paths": {}}`,
+			`{"openapi": "3.0.0"}`,
+			`{"openapi": "3.0.0", "paths": []}`,
+		}
+
+		for _, input := range inputs {
+			_, err := New([]byte(input))
+			assert.Error(t, err, input)
+		}
+	})
+}
+
+func TestSpec_Load(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "openapi.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testOpenAPIYAML), 0644))
+
+	spec, err := Load(path)
+	require.NoError(t, err)
+	assert.NotNil(t, spec)
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestSpec_FindOperation(t *testing.T) {
+	spec, err := New([]byte(testOpenAPIYAML))
+	require.NoError(t, err)
+
+	cases := []struct {
+		method string
+		path   string
+		result string
+	}{
+		{"GET", "/users", "/users"},
+		{"GET", "/users/", "/users"},
+		{"POST", "/users", "/users"},
+		{"GET", "/users/123", "/users/{id}"},
+		{"GET", "/users/me", "/users/me"},
+		{"GET", "/api/v1/users/123", "/users/{id}"},
+		{"DELETE", "/users", ""},
+		{"GET", "/users/123/posts", ""},
+		{"GET", "/api/v2/users", ""},
+	}
+
+	for _, tc := range cases {
+		op := spec.FindOperation(tc.method, tc.path)
+		if tc.result == "" {
+			assert.Nil(t, op, tc.path)
+		} else {
+			require.NotNil(t, op, tc.path)
+			assert.Equal(t, tc.method, op.Method)
+			assert.Equal(t, tc.result, op.Path)
+		}
+	}
+}
+
+func TestSpec_FindResponse(t *testing.T) {
+	spec, err := New([]byte(testOpenAPIYAML))
+	require.NoError(t, err)
+
+	t.Run("exact status", func(t *testing.T) {
+		op := spec.FindOperation("GET", "/users/123")
+		require.NotNil(t, op)
+
+		assert.Equal(t, []string{"200", "default"}, op.Statuses())
+
+		resp := op.FindResponse(200)
+		require.NotNil(t, resp)
+		assert.Equal(t, []string{"application/json"}, resp.MediaTypes())
+
+		mediaType := resp.FindMediaType("application/json")
+		require.NotNil(t, mediaType)
+
+		schema, ok := mediaType.Schema()
+		require.True(t, ok)
+		assert.Equal(t, "#/components/schemas/User",
+			schema.(map[string]interface{})["$ref"])
+		assert.Contains(t, schema, "components")
+
+		assert.Nil(t, resp.FindMediaType("text/plain"))
+	})
+
+	t.Run("default status", func(t *testing.T) {
+		op := spec.FindOperation("GET", "/users/123")
+		require.NotNil(t, op)
+
+		resp := op.FindResponse(404)
+		require.NotNil(t, resp)
+		assert.Equal(t, []string{"application/problem+json"}, resp.MediaTypes())
+	})
+
+	t.Run("status range", func(t *testing.T) {
+		op := spec.FindOperation("POST", "/users")
+		require.NotNil(t, op)
+
+		assert.Equal(t, []string{"201", "4XX"}, op.Statuses())
+
+		resp := op.FindResponse(422)
+		require.NotNil(t, resp)
+
+		mediaType := resp.FindMediaType("text/plain")
+		require.NotNil(t, mediaType)
+
+		_, ok := mediaType.Schema()
+		assert.True(t, ok)
+
+		resp = op.FindResponse(201)
+		require.NotNil(t, resp)
+		assert.Empty(t, resp.MediaTypes())
+
+		assert.Nil(t, op.FindResponse(500))
+	})
+
+	t.Run("no schema", func(t *testing.T) {
+		spec, err := New([]byte(testOpenAPIJSON))
+		require.NoError(t, err)
+
+		op := spec.FindOperation("GET", "/ping")
+		require.NotNil(t, op)
+
+		mediaType := op.FindResponse(200).FindMediaType("text/plain")
+		require.NotNil(t, mediaType)
+
+		_, ok := mediaType.Schema()
+		assert.False(t, ok)
+	})
+}
+
+func TestSpec_MatchMediaType(t *testing.T) {
+	assert.True(t, matchMediaType("*/*", "text/plain"))
+	assert.True(t, matchMediaType("text/*", "text/plain"))
+	assert.True(t, matchMediaType("Text/Plain", "text/plain"))
+	assert.False(t, matchMediaType("text/*", "application/json"))
+	assert.False(t, matchMediaType("text/html", "text/plain"))
+}
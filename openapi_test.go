@@ -0,0 +1,100 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+const testOpenAPIYAML = `
+openapi: 3.0.3
+info:
+  title: test
+  version: 1.0.0
+servers:
+  - url: http://example.com/api/v1
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: list of users
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/User"
+    post:
+      responses:
+        "201":
+          description: created
+        4XX:
+          description: error
+          content:
+            text/*:
+              schema:
+                type: string
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: user
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+        default:
+          description: error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+  /users/me:
+    get:
+      responses:
+        "204":
+          description: no content
+components:
+  schemas:
+    User:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+`
+
+func TestOpenAPIMatcher(t *testing.T) {
+	spec, err := openapi.New([]byte(testOpenAPIYAML))
+	require.NoError(t, err)
+
+	matcher := OpenAPIMatcher(spec)
+
+	newResp := func(body string) *Response {
+		httpReq, err := http.NewRequest("GET", "http://example.com/users/1", nil)
+		require.NoError(t, err)
+
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Request:    httpReq,
+		})
+	}
+
+	resp := newResp(`{"name": "john"}`)
+	matcher(resp)
+	resp.chain.assertNotFailed(t)
+
+	resp = newResp(`{"age": 42}`)
+	matcher(resp)
+	resp.chain.assertFailed(t)
+}
@@ -0,0 +1,220 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageOpts define how the next page of paginated response is found.
+//
+// By default, the next page is defined by Link header with rel="next",
+// as described in RFC 8288 (formerly RFC 5988), e.g.:
+//
+//	Link: <https://example.com/items?page=2>; rel="next"
+//
+// If CursorPath is set, the next page is defined by cursor from response
+// body instead, which is passed to the next request as query parameter.
+type PageOpts struct {
+	// JSON path of the next page cursor in response body,
+	// e.g. "$.meta.next_cursor".
+	// Path may contain filters, e.g. "$.links[?(@.rel == 'next')].cursor";
+	// in this case, it should match at most one value.
+	// If cursor is missing, null, or empty string, there is no next page.
+	CursorPath string
+
+	// Name of query parameter for the next page cursor.
+	// If empty, "cursor" is used.
+	CursorParam string
+
+	// Maximum number of pages fetched by AllPages, including the first one.
+	// If zero, 100 is used.
+	MaxPages int
+}
+
+const (
+	defaultPageCursorParam = "cursor"
+	defaultPageMaxPages    = 100
+)
+
+func (opts PageOpts) cursorParam() string {
+	if opts.CursorParam == "" {
+		return defaultPageCursorParam
+	}
+	return opts.CursorParam
+}
+
+func (opts PageOpts) maxPages() int {
+	if opts.MaxPages <= 0 {
+		return defaultPageMaxPages
+	}
+	return opts.MaxPages
+}
+
+// nextPageURL returns URL of the next page, or nil if there is no next page
+func (r *Response) nextPageURL(opChain *chain, opts PageOpts) (*url.URL, bool) {
+	base := r.requestURL()
+
+	if opts.CursorPath == "" {
+		link := parseLinkHeader(r.httpResp.Header.Values("Link"))["next"]
+		if link == "" {
+			return nil, true
+		}
+
+		u, err := url.Parse(link)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{link},
+				Errors: []error{
+					errors.New(`invalid url in "Link" response header`),
+					err,
+				},
+			})
+			return nil, false
+		}
+
+		if base != nil {
+			u = base.ResolveReference(u)
+		}
+
+		return u, true
+	}
+
+	value := r.getJSON(opChain)
+	if opChain.failed() {
+		return nil, false
+	}
+
+	filterFn, err := prepareJSONPath(opts.CursorPath)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{opts.CursorPath},
+			Errors: []error{
+				errors.New("expected: valid json path"),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	var cursor string
+
+	// missing cursor means last page
+	if result, err := filterFn(value); err == nil {
+		// path with filter always yields array of matches
+		matches, isArray := result.([]interface{})
+		if isArray && hasJSONPathFilter(opts.CursorPath) {
+			switch len(matches) {
+			case 0:
+				result = nil
+			case 1:
+				result = matches[0]
+			}
+		}
+
+		switch c := result.(type) {
+		case nil:
+		case string:
+			cursor = c
+		case float64:
+			cursor = strconv.FormatFloat(c, 'f', -1, 64)
+		case json.Number:
+			cursor = c.String()
+		default:
+			opChain.fail(AssertionFailure{
+				Type:   AssertType,
+				Actual: &AssertionValue{result},
+				Errors: []error{
+					errors.New("expected: page cursor is string or number"),
+				},
+			})
+			return nil, false
+		}
+	}
+
+	if cursor == "" {
+		return nil, true
+	}
+
+	if base == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("can't construct next page url: response has no request"),
+			},
+		})
+		return nil, false
+	}
+
+	u := *base
+
+	query := u.Query()
+	query.Set(opts.cursorParam(), cursor)
+	u.RawQuery = query.Encode()
+
+	return &u, true
+}
+
+// URL of request that produced response, if known
+func (r *Response) requestURL() *url.URL {
+	if r.httpResp.Request != nil && r.httpResp.Request.URL != nil {
+		return r.httpResp.Request.URL
+	}
+	if u, err := url.Parse(r.config.BaseURL); err == nil && r.config.BaseURL != "" {
+		return u
+	}
+	return nil
+}
+
+// parseLinkHeader parses values of Link header and returns map of URLs
+// by relation type; only the first URL of each relation type is kept
+func parseLinkHeader(values []string) map[string]string {
+	links := map[string]string{}
+
+	for _, value := range values {
+		for value != "" {
+			value = strings.TrimLeft(value, " \t,")
+
+			if !strings.HasPrefix(value, "<") {
+				break
+			}
+
+			end := strings.IndexByte(value, '>')
+			if end < 0 {
+				break
+			}
+
+			target := value[1:end]
+			value = value[end+1:]
+
+			var params string
+			if next := strings.IndexByte(value, '<'); next >= 0 {
+				params, value = value[:next], value[next:]
+			} else {
+				params, value = value, ""
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+				if len(kv) != 2 || !strings.EqualFold(strings.TrimSpace(kv[0]), "rel") {
+					continue
+				}
+
+				rels := strings.Trim(strings.TrimSpace(kv[1]), `",`)
+
+				for _, rel := range strings.Fields(rels) {
+					rel = strings.ToLower(rel)
+					if _, ok := links[rel]; !ok {
+						links[rel] = target
+					}
+				}
+			}
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,421 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination_ParseLinkHeader(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []string
+		expected map[string]string
+	}{
+		{
+			name:     "empty",
+			values:   nil,
+			expected: map[string]string{},
+		},
+		{
+			name:   "single",
+			values: []string{`<https://example.com/items?page=2>; rel="next"`},
+			expected: map[string]string{
+				"next": "https://example.com/items?page=2",
+			},
+		},
+		{
+			name: "multiple links",
+			values: []string{
+				`<https://example.com/items?page=2>; rel="next", ` +
+					`<https://example.com/items?page=5>; rel="last"`,
+			},
+			expected: map[string]string{
+				"next": "https://example.com/items?page=2",
+				"last": "https://example.com/items?page=5",
+			},
+		},
+		{
+			name: "multiple values",
+			values: []string{
+				`</items?page=1>; rel="prev"`,
+				`</items?page=3>; rel=next`,
+			},
+			expected: map[string]string{
+				"prev": "/items?page=1",
+				"next": "/items?page=3",
+			},
+		},
+		{
+			name:   "multiple rels",
+			values: []string{`</items?page=2>; rel="next last"`},
+			expected: map[string]string{
+				"next": "/items?page=2",
+				"last": "/items?page=2",
+			},
+		},
+		{
+			name:   "comma in url",
+			values: []string{`</items?ids=1,2,3>; title="a;b"; REL="Next"`},
+			expected: map[string]string{
+				"next": "/items?ids=1,2,3",
+			},
+		},
+		{
+			name: "first wins",
+			values: []string{
+				`</items?page=2>; rel="next", </items?page=3>; rel="next"`,
+			},
+			expected: map[string]string{
+				"next": "/items?page=2",
+			},
+		},
+		{
+			name:     "malformed",
+			values:   []string{`/items?page=2; rel="next"`},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseLinkHeader(tc.values))
+		})
+	}
+}
+
+func paginationLinkHandler(pages int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if page < pages {
+			w.Header().Set("Link",
+				`</items?page=`+strconv.Itoa(page+1)+`>; rel="next"`)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]int{page * 10, page*10 + 1})
+	})
+}
+
+func paginationCursorHandler(pages int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("after"))
+
+		body := map[string]interface{}{
+			"items": []int{page},
+			"meta":  map[string]interface{}{},
+		}
+
+		if page+1 < pages {
+			body["meta"] = map[string]interface{}{
+				"next": page + 1,
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
+
+func TestPagination_Link(t *testing.T) {
+	server := httptest.NewServer(paginationLinkHandler(3))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		resp := e.GET("/items").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
+
+		resp.HasNextPage().True()
+
+		page2 := resp.NextPage()
+		page2.Status(http.StatusOK)
+		page2.JSON().Array().ContainsOnly(20, 21)
+		page2.HasNextPage().True()
+
+		page3 := page2.NextPage()
+		page3.Status(http.StatusOK)
+		page3.JSON().Array().ContainsOnly(30, 31)
+		page3.HasNextPage().False()
+	})
+
+	t.Run("all pages", func(t *testing.T) {
+		pages := e.GET("/items").
+			WithHeader("Authorization", "Bearer token").
+			Expect().
+			AllPages()
+
+		assert.Equal(t, 3, len(pages))
+
+		for i, page := range pages {
+			page.Status(http.StatusOK)
+			page.JSON().Array().ContainsOnly((i+1)*10, (i+1)*10+1)
+		}
+	})
+}
+
+func TestPagination_Cursor(t *testing.T) {
+	server := httptest.NewServer(paginationCursorHandler(4))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	opts := PageOpts{
+		CursorPath:  "$.meta.next",
+		CursorParam: "after",
+	}
+
+	resp := e.GET("/items").Expect()
+
+	resp.HasNextPage(opts).True()
+	resp.NextPage(opts).JSON().Path("$.items").Array().ContainsOnly(1)
+
+	pages := resp.AllPages(opts)
+
+	assert.Equal(t, 4, len(pages))
+
+	for i, page := range pages {
+		page.JSON().Path("$.items").Array().ContainsOnly(i)
+	}
+
+	pages[3].HasNextPage(opts).False()
+}
+
+func TestPagination_CursorJSONNumber(t *testing.T) {
+	const cursor = "9007199254740993"
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("after") == cursor {
+			_, _ = w.Write([]byte(`{"items": [2], "meta": {}}`))
+		} else {
+			_, _ = w.Write([]byte(`{"items": [1], "meta": {"next": ` + cursor + `}}`))
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:       server.URL,
+		Reporter:      NewAssertReporter(t),
+		UseJSONNumber: true,
+	})
+
+	opts := PageOpts{
+		CursorPath:  "$.meta.next",
+		CursorParam: "after",
+	}
+
+	e.GET("/items").Expect().
+		NextPage(opts).JSON().Path("$.items").Array().ContainsOnly(2)
+}
+
+func TestPagination_Failures(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("no next page", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		resp.HasNextPage().False()
+		resp.chain.assertNotFailed(t)
+
+		resp.NextPage().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		pages := resp.AllPages()
+
+		assert.Equal(t, []*Response{resp}, pages)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("max pages", func(t *testing.T) {
+		server := httptest.NewServer(paginationCursorHandler(10))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		resp := e.GET("/items").Expect()
+
+		pages := resp.AllPages(PageOpts{
+			CursorPath:  "$.meta.next",
+			CursorParam: "after",
+			MaxPages:    3,
+		})
+
+		assert.Equal(t, 3, len(pages))
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("loop", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Link", `</items>; rel="next"`)
+			}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		resp := e.GET("/items").Expect()
+
+		pages := resp.AllPages()
+
+		assert.Equal(t, 1, len(pages))
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: newMockBody(`{"next": {"page": 2}}`),
+		})
+
+		resp.HasNextPage(PageOpts{CursorPath: "$.next"})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: newMockBody(`{}`),
+		})
+
+		resp.HasNextPage(PageOpts{CursorPath: "!!!"})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		resp.NextPage(PageOpts{}, PageOpts{})
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestPagination_CursorFilter(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "abc" {
+			_, _ = w.Write([]byte(`{"items": [2], "links": []}`))
+		} else {
+			_, _ = w.Write([]byte(`{"items": [1], "links": [` +
+				`{"rel": "prev", "cursor": "xyz"}, {"rel": "next", "cursor": "abc"}]}`))
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	opts := PageOpts{
+		CursorPath: `$.links[?(@.rel == "next")].cursor`,
+	}
+
+	pages := e.GET("/items").Expect().AllPages(opts)
+
+	assert.Equal(t, 2, len(pages))
+
+	pages[0].JSON().Path("$.items").Array().ContainsOnly(1)
+	pages[1].JSON().Path("$.items").Array().ContainsOnly(2)
+}
+
+func TestPagination_OtherHost(t *testing.T) {
+	var header http.Header
+
+	other := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Clone()
+		}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", `<`+other.URL+`/items?page=2>; rel="next"`)
+		}))
+	defer server.Close()
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+	})
+
+	e.GET("/items").
+		WithHeader("Authorization", "Bearer token").
+		WithHeader("X-Custom", "value").
+		Expect().
+		NextPage().
+		Status(http.StatusOK)
+
+	// credentials are not sent to another host
+	assert.Empty(t, header.Get("Authorization"))
+	assert.Equal(t, "value", header.Get("X-Custom"))
+}
+
+func TestPagination_IsSameOrSubdomain(t *testing.T) {
+	cases := []struct {
+		host     string
+		parent   string
+		expected bool
+	}{
+		{"example.com", "example.com", true},
+		{"EXAMPLE.com", "example.COM", true},
+		{"api.example.com", "example.com", true},
+		{"example.com:8080", "example.com:8080", true},
+		{"example.com:8081", "example.com:8080", false},
+		{"example.com", "api.example.com", false},
+		{"badexample.com", "example.com", false},
+		{"other.com", "example.com", false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, isSameOrSubdomain(tc.host, tc.parent),
+			"%s, %s", tc.host, tc.parent)
+	}
+}
@@ -0,0 +1,167 @@
+package httpexpect
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Placeholders may be used in expected values passed to Equal, NotEqual,
+// ContainsSubset and similar methods of Value, Object and Array, to match
+// dynamic fields like identifiers and timestamps.
+//
+// Placeholders are disabled by default and should be enabled using
+// Config.UsePlaceholders. When disabled, such strings are compared literally.
+//
+// Placeholder is a string value of one of the following forms:
+//   - "$any" matches any value, including null
+//   - "$uuid" matches string with UUID in canonical form
+//   - "$timestamp" matches string with RFC 3339 date and time
+//   - "$regex:<pattern>" matches string matching regular expression
+//
+// Placeholders are recognized only in expected values, at any depth.
+//
+// Example:
+//
+//	object.Equal(map[string]interface{}{
+//		"id":         "$uuid",
+//		"name":       "john",
+//		"created_at": "$timestamp",
+//		"etag":       `$regex:^"[0-9a-f]+"$`,
+//		"extra":      "$any",
+//	})
+const (
+	PlaceholderAny       = "$any"
+	PlaceholderUUID      = "$uuid"
+	PlaceholderTimestamp = "$timestamp"
+	PlaceholderRegex     = "$regex:"
+)
+
+var placeholderUUIDRegexp = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkPlaceholders reports failure if expected value contains
+// malformed placeholder, e.g. invalid regular expression
+func checkPlaceholders(opChain *chain, expected interface{}) bool {
+	if !opChain.usePlaceholders() {
+		return true
+	}
+
+	switch e := expected.(type) {
+	case string:
+		if strings.HasPrefix(e, PlaceholderRegex) {
+			pattern := strings.TrimPrefix(e, PlaceholderRegex)
+
+			if _, err := regexp.Compile(pattern); err != nil {
+				opChain.fail(AssertionFailure{
+					Type: AssertUsage,
+					Errors: []error{
+						fmt.Errorf("invalid regular expression in %q placeholder", e),
+						err,
+					},
+				})
+				return false
+			}
+		}
+
+	case map[string]interface{}:
+		for _, v := range e {
+			if !checkPlaceholders(opChain, v) {
+				return false
+			}
+		}
+
+	case []interface{}:
+		for _, v := range e {
+			if !checkPlaceholders(opChain, v) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// equalValues checks deep equality of canonical values; if placeholders
+// are enabled in chain, expected value may contain placeholders
+func equalValues(opChain *chain, expected, actual interface{}) bool {
+	if !opChain.usePlaceholders() {
+		return reflect.DeepEqual(expected, actual)
+	}
+
+	return equalPlaceholders(expected, actual)
+}
+
+// equalPlaceholders checks deep equality of canonical values, where
+// expected value may contain placeholders
+func equalPlaceholders(expected, actual interface{}) bool {
+	switch e := expected.(type) {
+	case string:
+		if matched, ok := matchPlaceholder(e, actual); ok {
+			return matched
+		}
+
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok || len(a) != len(e) {
+			return false
+		}
+		for k, ev := range e {
+			av, ok := a[k]
+			if !ok || !equalPlaceholders(ev, av) {
+				return false
+			}
+		}
+		return true
+
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(e) {
+			return false
+		}
+		for i := range e {
+			if !equalPlaceholders(e[i], a[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(expected, actual)
+}
+
+// matchPlaceholder returns false in second value if given string
+// is not a placeholder
+func matchPlaceholder(placeholder string, actual interface{}) (bool, bool) {
+	if placeholder == PlaceholderAny {
+		return true, true
+	}
+
+	str, isStr := actual.(string)
+
+	switch {
+	case placeholder == PlaceholderUUID:
+		return isStr && placeholderUUIDRegexp.MatchString(str), true
+
+	case placeholder == PlaceholderTimestamp:
+		if !isStr {
+			return false, true
+		}
+		_, err := time.Parse(time.RFC3339Nano, str)
+		return err == nil, true
+
+	case strings.HasPrefix(placeholder, PlaceholderRegex):
+		if !isStr {
+			return false, true
+		}
+		re, err := regexp.Compile(strings.TrimPrefix(placeholder, PlaceholderRegex))
+		if err != nil {
+			return false, true
+		}
+		return re.MatchString(str), true
+	}
+
+	return false, false
+}
@@ -0,0 +1,205 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceholder_Equal(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected interface{}
+		actual   interface{}
+		result   bool
+	}{
+		{"any string", "$any", "foo", true},
+		{"any null", "$any", nil, true},
+		{"any object", "$any", map[string]interface{}{"a": 1.0}, true},
+		{"uuid", "$uuid", "123e4567-e89b-12d3-a456-426614174000", true},
+		{"uuid upper", "$uuid", "123E4567-E89B-12D3-A456-426614174000", true},
+		{"uuid bad", "$uuid", "123e4567-e89b-12d3-a456", false},
+		{"uuid number", "$uuid", 123.0, false},
+		{"timestamp", "$timestamp", "2023-01-02T03:04:05Z", true},
+		{"timestamp nanos", "$timestamp", "2023-01-02T03:04:05.123+02:00", true},
+		{"timestamp bad", "$timestamp", "2023-01-02", false},
+		{"timestamp number", "$timestamp", 1672628645.0, false},
+		{"regex", "$regex:^a+$", "aaa", true},
+		{"regex bad", "$regex:^a+$", "aab", false},
+		{"regex number", "$regex:1", 1.0, false},
+		{"plain string", "foo", "foo", true},
+		{"plain string bad", "foo", "bar", false},
+		{"other dollar string", "$other", "$other", true},
+		{"other dollar string bad", "$other", "foo", false},
+		{
+			"nested object",
+			map[string]interface{}{
+				"id":   "$uuid",
+				"tags": []interface{}{"$any", "b"},
+			},
+			map[string]interface{}{
+				"id":   "123e4567-e89b-12d3-a456-426614174000",
+				"tags": []interface{}{"a", "b"},
+			},
+			true,
+		},
+		{
+			"object extra key",
+			map[string]interface{}{"a": "$any"},
+			map[string]interface{}{"a": 1.0, "b": 2.0},
+			false,
+		},
+		{
+			"object missing key",
+			map[string]interface{}{"a": "$any", "b": "$any"},
+			map[string]interface{}{"a": 1.0, "c": 2.0},
+			false,
+		},
+		{
+			"array length",
+			[]interface{}{"$any"},
+			[]interface{}{1.0, 2.0},
+			false,
+		},
+		{
+			"type mismatch",
+			[]interface{}{"$any"},
+			map[string]interface{}{"0": 1.0},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.result, equalPlaceholders(tc.expected, tc.actual))
+		})
+	}
+}
+
+func TestPlaceholder_Check(t *testing.T) {
+	chain := newChainWithConfig("test", Config{
+		Reporter:        newMockReporter(t),
+		UsePlaceholders: true,
+	}.withDefaults())
+
+	opChain := chain.enter("test")
+	assert.True(t, checkPlaceholders(opChain, map[string]interface{}{
+		"a": []interface{}{"$regex:^a+$", "$any"},
+	}))
+	opChain.leave()
+
+	chain.assertNotFailed(t)
+
+	opChain = chain.enter("test")
+	assert.False(t, checkPlaceholders(opChain, map[string]interface{}{
+		"a": []interface{}{"$regex:(a"},
+	}))
+	opChain.leave()
+
+	chain.assertFailed(t)
+}
+
+func TestPlaceholder_Assertions(t *testing.T) {
+	data := map[string]interface{}{
+		"id":         "123e4567-e89b-12d3-a456-426614174000",
+		"name":       "john",
+		"created_at": "2023-01-02T03:04:05Z",
+	}
+
+	expected := map[string]interface{}{
+		"id":         PlaceholderUUID,
+		"name":       "john",
+		"created_at": PlaceholderTimestamp,
+	}
+
+	newConfig := func(reporter Reporter) Config {
+		return Config{
+			Reporter:        reporter,
+			UsePlaceholders: true,
+		}
+	}
+
+	t.Run("value", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValueC(newConfig(reporter), data).Equal(expected).chain.assertNotFailed(t)
+		NewValueC(newConfig(reporter), data).NotEqual(expected).chain.assertFailed(t)
+	})
+
+	t.Run("object", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewObjectC(newConfig(reporter), data).Equal(expected).chain.assertNotFailed(t)
+		NewObjectC(newConfig(reporter), data).NotEqual(expected).chain.assertFailed(t)
+
+		NewObjectC(newConfig(reporter), data).
+			ContainsSubset(map[string]interface{}{"id": "$uuid"}).
+			chain.assertNotFailed(t)
+		NewObjectC(newConfig(reporter), data).
+			NotContainsSubset(map[string]interface{}{"id": "$uuid"}).
+			chain.assertFailed(t)
+		NewObjectC(newConfig(reporter), data).
+			ContainsSubset(map[string]interface{}{"name": "$uuid"}).
+			chain.assertFailed(t)
+
+		NewObjectC(newConfig(reporter), data).
+			ValueEqual("name", "$regex:^jo").
+			chain.assertNotFailed(t)
+		NewObjectC(newConfig(reporter), data).
+			NotValueEqual("name", "$regex:^jo").
+			chain.assertFailed(t)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		array := []interface{}{data, "foo"}
+
+		NewArrayC(newConfig(reporter), array).
+			Equal([]interface{}{expected, "$any"}).
+			chain.assertNotFailed(t)
+		NewArrayC(newConfig(reporter), array).
+			NotEqual([]interface{}{expected, "$any"}).
+			chain.assertFailed(t)
+		NewArrayC(newConfig(reporter), array).
+			Elements(expected, "$regex:o+").
+			chain.assertNotFailed(t)
+		NewArrayC(newConfig(reporter), array).
+			NotElements(expected, "$regex:o+").
+			chain.assertFailed(t)
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewObjectC(newConfig(reporter), data).
+			Equal(map[string]interface{}{"name": "$regex:("}).
+			chain.assertFailed(t)
+		NewObjectC(newConfig(reporter), data).
+			NotEqual(map[string]interface{}{"name": "$regex:("}).
+			chain.assertFailed(t)
+		NewObjectC(newConfig(reporter), data).
+			NotContainsSubset(map[string]interface{}{"name": "$regex:("}).
+			chain.assertFailed(t)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, data).Equal(expected).chain.assertFailed(t)
+		NewValue(reporter, data).NotEqual(expected).chain.assertNotFailed(t)
+
+		NewObject(reporter, map[string]interface{}{"id": "$any"}).
+			ValueEqual("id", "$any").
+			chain.assertNotFailed(t)
+		NewObject(reporter, map[string]interface{}{"id": "foo"}).
+			ValueEqual("id", "$any").
+			chain.assertFailed(t)
+		NewArray(reporter, []interface{}{"$regex:("}).
+			Equal([]interface{}{"$regex:("}).
+			chain.assertNotFailed(t)
+		NewObject(reporter, data).
+			ContainsSubset(map[string]interface{}{"id": "$uuid"}).
+			chain.assertFailed(t)
+	})
+}
@@ -2,10 +2,13 @@ package httpexpect
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -13,7 +16,8 @@ import (
 )
 
 // Printer is used to print requests and responses.
-// CompactPrinter, DebugPrinter, and CurlPrinter implement this interface.
+// CompactPrinter, DebugPrinter, CurlPrinter, JSONPrinter, BufferedPrinter,
+// HARRecorder, AllureRecorder, and MetricsRecorder implement this interface.
 type Printer interface {
 	// Request is called before request is sent.
 	// It is allowed to read and close request body, or ignore it.
@@ -29,7 +33,7 @@ type Printer interface {
 // If WebSocket connection is used, all Printers that also implement WebsocketPrinter
 // are invoked on every WebSocket message read or written.
 //
-// DebugPrinter implements this interface.
+// DebugPrinter and BufferedPrinter implement this interface.
 type WebsocketPrinter interface {
 	Printer
 
@@ -40,6 +44,81 @@ type WebsocketPrinter interface {
 	WebsocketRead(typ int, content []byte, closeCode int)
 }
 
+// PrinterVerbosity defines how much of requests and responses is printed.
+type PrinterVerbosity int
+
+const (
+	// indicates that Verbosity was not set in PrinterOptions
+	defaultPrinterVerbosity PrinterVerbosity = iota
+
+	// VerbosityLine prints only request line and status line.
+	VerbosityLine
+
+	// VerbosityHeaders prints request and status lines and headers.
+	VerbosityHeaders
+
+	// VerbosityFull prints request and status lines, headers, and body.
+	VerbosityFull
+)
+
+// PrinterOptions defines what is printed by DebugPrinter and JSONPrinter.
+// See NewDebugPrinterWithOptions and NewJSONPrinterWithOptions.
+type PrinterOptions struct {
+	// What parts of requests and responses are printed.
+	// If zero, VerbosityFull is used.
+	Verbosity PrinterVerbosity
+
+	// Maximum number of printed body bytes, longer bodies are truncated.
+	// Use zero for printer's default, and negative value to disable
+	// truncation. DebugPrinter doesn't truncate by default, and JSONPrinter
+	// truncates to 1024 bytes.
+	MaxBodySize int
+
+	// If non-empty, only headers with given names are printed.
+	// Names are case-insensitive.
+	Headers []string
+}
+
+func (o PrinterOptions) verbosity() PrinterVerbosity {
+	if o.Verbosity == defaultPrinterVerbosity {
+		return VerbosityFull
+	}
+	return o.Verbosity
+}
+
+func (o PrinterOptions) maxBodySize(defaultSize int) int {
+	if o.MaxBodySize == 0 {
+		return defaultSize
+	}
+	return o.MaxBodySize
+}
+
+func (o PrinterOptions) filterHeader(header http.Header) http.Header {
+	if len(o.Headers) == 0 {
+		return header
+	}
+
+	result := http.Header{}
+
+	for _, name := range o.Headers {
+		key := http.CanonicalHeaderKey(name)
+		if values, ok := header[key]; ok {
+			result[key] = values
+		}
+	}
+
+	return result
+}
+
+// truncateBody returns body truncated to given size and a flag indicating
+// whether it was truncated; negative size means no limit
+func truncateBody(body []byte, size int) ([]byte, bool) {
+	if size < 0 || len(body) <= size {
+		return body, false
+	}
+	return body[:size], true
+}
+
 // CompactPrinter implements Printer.
 // Prints requests in compact form. Does not print responses.
 type CompactPrinter struct {
@@ -93,14 +172,35 @@ func (CurlPrinter) Response(*http.Response, time.Duration) {
 // Uses net/http/httputil to dump both requests and responses.
 // Also prints all websocket messages.
 type DebugPrinter struct {
-	logger Logger
-	body   bool
+	logger  Logger
+	options PrinterOptions
 }
 
 // NewDebugPrinter returns a new DebugPrinter given a logger and body
 // flag. If body is true, request and response body is also printed.
 func NewDebugPrinter(logger Logger, body bool) DebugPrinter {
-	return DebugPrinter{logger, body}
+	options := PrinterOptions{
+		Verbosity: VerbosityHeaders,
+	}
+	if body {
+		options.Verbosity = VerbosityFull
+	}
+
+	return DebugPrinter{logger, options}
+}
+
+// NewDebugPrinterWithOptions returns a new DebugPrinter given a logger
+// and options, which allow to limit printed body size and headers.
+//
+// Example:
+//
+//	printer := NewDebugPrinterWithOptions(t, PrinterOptions{
+//		Verbosity:   VerbosityFull,
+//		MaxBodySize: 4096,
+//		Headers:     []string{"Content-Type", "Location"},
+//	})
+func NewDebugPrinterWithOptions(logger Logger, options PrinterOptions) DebugPrinter {
+	return DebugPrinter{logger, options}
 }
 
 // Request implements Printer.Request.
@@ -109,10 +209,27 @@ func (p DebugPrinter) Request(req *http.Request) {
 		return
 	}
 
-	dump, err := httputil.DumpRequest(req, p.body)
+	if p.options.verbosity() == VerbosityLine {
+		p.logger.Logf("%s %s %s", req.Method, req.URL, req.Proto)
+		return
+	}
+
+	reqCopy := *req
+	reqCopy.Header = p.options.filterHeader(req.Header)
+
+	maxBodySize := p.options.maxBodySize(-1)
+	withBody := p.options.verbosity() == VerbosityFull
+
+	dump, err := httputil.DumpRequest(&reqCopy, withBody && maxBodySize < 0)
 	if err != nil {
 		panic(err)
 	}
+
+	if withBody && maxBodySize >= 0 && req.Body != nil && req.Body != http.NoBody {
+		body, _ := ioutil.ReadAll(req.Body)
+		dump = append(dump, p.formatBody(body, maxBodySize)...)
+	}
+
 	p.logger.Logf("%s", dump)
 }
 
@@ -122,17 +239,47 @@ func (p DebugPrinter) Response(resp *http.Response, duration time.Duration) {
 		return
 	}
 
-	dump, err := httputil.DumpResponse(resp, p.body)
+	if p.options.verbosity() == VerbosityLine {
+		p.logger.Logf("%s %s %s", resp.Proto, resp.Status, duration)
+		return
+	}
+
+	respCopy := *resp
+	respCopy.Header = p.options.filterHeader(resp.Header)
+
+	maxBodySize := p.options.maxBodySize(-1)
+	withBody := p.options.verbosity() == VerbosityFull
+
+	dump, err := httputil.DumpResponse(&respCopy, withBody && maxBodySize < 0)
+	if err != nil && withBody && isTimeoutError(err) && isStreamingContent(resp) {
+		// endless event or NDJSON stream was interrupted by timeout
+		dump, err = httputil.DumpResponse(&respCopy, false)
+	}
 	if err != nil {
 		panic(err)
 	}
 
+	if withBody && maxBodySize >= 0 && resp.Body != nil && !isEventStream(resp) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		dump = append(dump, p.formatBody(body, maxBodySize)...)
+	}
+
 	text := strings.Replace(string(dump), "\r\n", "\n", -1)
 	lines := strings.SplitN(text, "\n", 2)
 
 	p.logger.Logf("%s %s\n%s", lines[0], duration, lines[1])
 }
 
+func (p DebugPrinter) formatBody(body []byte, maxBodySize int) []byte {
+	truncBody, truncated := truncateBody(body, maxBodySize)
+	if !truncated {
+		return body
+	}
+
+	return append(append([]byte(nil), truncBody...),
+		fmt.Sprintf("... (%d bytes truncated)", len(body)-maxBodySize)...)
+}
+
 // WebsocketWrite implements WebsocketPrinter.WebsocketWrite.
 func (p DebugPrinter) WebsocketWrite(typ int, content []byte, closeCode int) {
 	b := &bytes.Buffer{}
@@ -170,3 +317,308 @@ func (p DebugPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
 	fmt.Fprintf(b, "\n")
 	p.logger.Logf(b.String())
 }
+
+// JSONPrinter implements Printer.
+// Prints one JSON object per line for every request and response, so that
+// test traffic can be indexed by log aggregators.
+//
+// Request line includes method, URL, body size, and optionally headers
+// and body. Response line additionally includes status code and latency
+// in milliseconds. By default, printed bodies are truncated to 1024 bytes.
+//
+// Example output:
+//
+//	{"type":"request","method":"GET","url":"http://example.com/users","size":0}
+//	{"type":"response","method":"GET","url":"http://example.com/users",
+//	 "status":200,"latency_ms":12.5,"size":2,"body":"[]"}
+type JSONPrinter struct {
+	logger  Logger
+	options PrinterOptions
+}
+
+// NewJSONPrinter returns a new JSONPrinter given a logger and body
+// flag. If body is true, request and response body is also printed.
+func NewJSONPrinter(logger Logger, body bool) JSONPrinter {
+	options := PrinterOptions{
+		Verbosity: VerbosityLine,
+	}
+	if body {
+		options.Verbosity = VerbosityFull
+	}
+
+	return JSONPrinter{logger, options}
+}
+
+// NewJSONPrinterWithOptions returns a new JSONPrinter given a logger
+// and options, which allow to include headers and limit printed body size.
+func NewJSONPrinterWithOptions(logger Logger, options PrinterOptions) JSONPrinter {
+	return JSONPrinter{logger, options}
+}
+
+const jsonPrinterBodyLimit = 1024
+
+type jsonPrinterEntry struct {
+	Type          string      `json:"type"`
+	Method        string      `json:"method"`
+	URL           string      `json:"url"`
+	Status        int         `json:"status,omitempty"`
+	Latency       *float64    `json:"latency_ms,omitempty"`
+	Headers       http.Header `json:"headers,omitempty"`
+	Size          int         `json:"size"`
+	Body          *string     `json:"body,omitempty"`
+	BodyTruncated bool        `json:"body_truncated,omitempty"`
+}
+
+// Request implements Printer.Request.
+func (p JSONPrinter) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	entry := jsonPrinterEntry{
+		Type:   "request",
+		Method: req.Method,
+	}
+
+	if req.URL != nil {
+		entry.URL = req.URL.String()
+	}
+
+	if p.options.verbosity() >= VerbosityHeaders {
+		entry.Headers = p.options.filterHeader(req.Header)
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := ioutil.ReadAll(req.Body)
+		p.setBody(&entry, body)
+	}
+
+	p.print(entry)
+}
+
+// Response implements Printer.Response.
+func (p JSONPrinter) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		return
+	}
+
+	latency := float64(duration) / float64(time.Millisecond)
+
+	entry := jsonPrinterEntry{
+		Type:    "response",
+		Status:  resp.StatusCode,
+		Latency: &latency,
+	}
+
+	if resp.Request != nil {
+		entry.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			entry.URL = resp.Request.URL.String()
+		}
+	}
+
+	if p.options.verbosity() >= VerbosityHeaders {
+		entry.Headers = p.options.filterHeader(resp.Header)
+	}
+
+	// endless event stream can't be read until the end
+	if resp.Body != nil && !isEventStream(resp) {
+		body, _ := ioutil.ReadAll(resp.Body)
+		p.setBody(&entry, body)
+	}
+
+	p.print(entry)
+}
+
+func (p JSONPrinter) setBody(entry *jsonPrinterEntry, body []byte) {
+	entry.Size = len(body)
+
+	if p.options.verbosity() != VerbosityFull || len(body) == 0 {
+		return
+	}
+
+	body, entry.BodyTruncated = truncateBody(body,
+		p.options.maxBodySize(jsonPrinterBodyLimit))
+
+	s := strings.ToValidUTF8(string(body), "�")
+	entry.Body = &s
+}
+
+func (p JSONPrinter) print(entry jsonPrinterEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		panic(err)
+	}
+
+	p.logger.Logf("%s", b)
+}
+
+// BufferedPrinter implements Printer and WebsocketPrinter.
+// Records requests and responses and forwards them to another printer
+// only when Flush or FlushIfFailed is called, so that traffic is printed
+// only for failed tests, and passing tests produce no noise.
+//
+// Use FlushOnCleanup to call FlushIfFailed automatically when test
+// finishes, or call FlushIfFailed manually, e.g. from a deferred function.
+//
+// Bodies are copied into memory when recorded. Bodies of event streams
+// are not recorded, since they may be endless.
+//
+// BufferedPrinter is safe for concurrent use.
+//
+// Example:
+//
+//	printer := httpexpect.NewBufferedPrinter(httpexpect.NewDebugPrinter(t, true))
+//	printer.FlushOnCleanup(t)
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Printers: []httpexpect.Printer{
+//			printer,
+//		},
+//	})
+type BufferedPrinter struct {
+	mu      sync.Mutex
+	printer Printer
+	entries []func()
+}
+
+// NewBufferedPrinter returns a new BufferedPrinter given a printer to
+// which recorded traffic is forwarded.
+//
+// If printer is nil, the function panics.
+func NewBufferedPrinter(printer Printer) *BufferedPrinter {
+	if printer == nil {
+		panic("Printer is nil")
+	}
+
+	return &BufferedPrinter{printer: printer}
+}
+
+// Request implements Printer.Request.
+func (p *BufferedPrinter) Request(req *http.Request) {
+	if req == nil {
+		return
+	}
+
+	reqCopy := req.Clone(req.Context())
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ = ioutil.ReadAll(req.Body)
+	}
+
+	p.record(func() {
+		if body != nil {
+			reqCopy.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		p.printer.Request(reqCopy)
+	})
+}
+
+// Response implements Printer.Response.
+func (p *BufferedPrinter) Response(resp *http.Response, duration time.Duration) {
+	if resp == nil {
+		return
+	}
+
+	respCopy := *resp
+	respCopy.Header = resp.Header.Clone()
+
+	var body []byte
+	if resp.Body != nil && !isEventStream(resp) {
+		body, _ = ioutil.ReadAll(resp.Body)
+	}
+
+	p.record(func() {
+		if body != nil {
+			respCopy.Body = ioutil.NopCloser(bytes.NewReader(body))
+		} else {
+			respCopy.Body = http.NoBody
+		}
+		p.printer.Response(&respCopy, duration)
+	})
+}
+
+// WebsocketWrite implements WebsocketPrinter.WebsocketWrite.
+// Does nothing if underlying printer doesn't implement WebsocketPrinter.
+func (p *BufferedPrinter) WebsocketWrite(typ int, content []byte, closeCode int) {
+	wsPrinter, ok := p.printer.(WebsocketPrinter)
+	if !ok {
+		return
+	}
+
+	content = append([]byte(nil), content...)
+
+	p.record(func() {
+		wsPrinter.WebsocketWrite(typ, content, closeCode)
+	})
+}
+
+// WebsocketRead implements WebsocketPrinter.WebsocketRead.
+// Does nothing if underlying printer doesn't implement WebsocketPrinter.
+func (p *BufferedPrinter) WebsocketRead(typ int, content []byte, closeCode int) {
+	wsPrinter, ok := p.printer.(WebsocketPrinter)
+	if !ok {
+		return
+	}
+
+	content = append([]byte(nil), content...)
+
+	p.record(func() {
+		wsPrinter.WebsocketRead(typ, content, closeCode)
+	})
+}
+
+func (p *BufferedPrinter) record(entry func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = append(p.entries, entry)
+}
+
+// Flush forwards all recorded traffic to underlying printer, in order
+// of recording, and clears the buffer.
+func (p *BufferedPrinter) Flush() {
+	p.mu.Lock()
+	entries := p.entries
+	p.entries = nil
+	p.mu.Unlock()
+
+	for _, entry := range entries {
+		entry()
+	}
+}
+
+// FlushIfFailed calls Flush if failed is true, and Reset otherwise.
+// Usually failed comes from t.Failed().
+func (p *BufferedPrinter) FlushIfFailed(failed bool) {
+	if failed {
+		p.Flush()
+	} else {
+		p.Reset()
+	}
+}
+
+// TestingCleanup is a subset of testing.TB interface used by
+// BufferedPrinter.FlushOnCleanup. You can use *testing.T.
+type TestingCleanup interface {
+	Cleanup(func())
+	Failed() bool
+}
+
+// FlushOnCleanup registers cleanup function of t which calls FlushIfFailed
+// with t.Failed() when test and its subtests finish.
+func (p *BufferedPrinter) FlushOnCleanup(t TestingCleanup) {
+	t.Cleanup(func() {
+		p.FlushIfFailed(t.Failed())
+	})
+}
+
+// Reset drops all recorded traffic.
+func (p *BufferedPrinter) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = nil
+}
@@ -2,9 +2,16 @@ package httpexpect
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPrinter_Compact(t *testing.T) {
@@ -42,3 +49,337 @@ func TestPrinter_Debug(t *testing.T) {
 	printer.Response(&http.Response{}, 0)
 	printer.Response(nil, 0)
 }
+
+func TestPrinter_JSON(t *testing.T) {
+	t.Run("with body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewJSONPrinter(logger, true)
+
+		req, _ := http.NewRequest("POST", "http://example.com/users",
+			bytes.NewBufferString(`{"name":"john"}`))
+
+		printer.Request(req)
+		assert.JSONEq(t, `{
+			"type": "request",
+			"method": "POST",
+			"url": "http://example.com/users",
+			"size": 15,
+			"body": "{\"name\":\"john\"}"
+		}`, logger.lastMessage)
+
+		printer.Response(&http.Response{
+			StatusCode: http.StatusCreated,
+			Request:    req,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":1}`)),
+		}, 1500*time.Microsecond)
+		assert.JSONEq(t, `{
+			"type": "response",
+			"method": "POST",
+			"url": "http://example.com/users",
+			"status": 201,
+			"latency_ms": 1.5,
+			"size": 8,
+			"body": "{\"id\":1}"
+		}`, logger.lastMessage)
+	})
+
+	t.Run("without body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewJSONPrinter(logger, false)
+
+		req, _ := http.NewRequest("GET", "http://example.com", nil)
+
+		printer.Request(req)
+		assert.JSONEq(t, `{
+			"type": "request",
+			"method": "GET",
+			"url": "http://example.com",
+			"size": 0
+		}`, logger.lastMessage)
+
+		printer.Response(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("hello")),
+		}, 0)
+		assert.JSONEq(t, `{
+			"type": "response",
+			"method": "",
+			"url": "",
+			"status": 200,
+			"latency_ms": 0,
+			"size": 5
+		}`, logger.lastMessage)
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewJSONPrinter(logger, true)
+
+		printer.Response(&http.Response{
+			StatusCode: http.StatusOK,
+			Body: ioutil.NopCloser(
+				bytes.NewBufferString(strings.Repeat("x", jsonPrinterBodyLimit+1))),
+		}, 0)
+
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(logger.lastMessage), &entry))
+
+		assert.Equal(t, float64(jsonPrinterBodyLimit+1), entry["size"])
+		assert.Equal(t, strings.Repeat("x", jsonPrinterBodyLimit), entry["body"])
+		assert.Equal(t, true, entry["body_truncated"])
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewJSONPrinter(logger, true)
+
+		printer.Request(nil)
+		printer.Response(nil, 0)
+		assert.False(t, logger.logged)
+	})
+}
+
+func TestPrinter_Options(t *testing.T) {
+	newReq := func() *http.Request {
+		req, _ := http.NewRequest("POST", "http://example.com/path",
+			bytes.NewBufferString("0123456789"))
+		req.Header.Set("Content-Type", "text/plain")
+		req.Header.Set("Authorization", "Bearer token")
+		return req
+	}
+
+	newResp := func() *http.Response {
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header: http.Header{
+				"Content-Type": {"text/plain"},
+				"Set-Cookie":   {"session=secret"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("abcdefghij")),
+		}
+	}
+
+	t.Run("debug line", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterWithOptions(logger, PrinterOptions{
+			Verbosity: VerbosityLine,
+		})
+
+		printer.Request(newReq())
+		assert.Equal(t, "POST http://example.com/path HTTP/1.1", logger.lastMessage)
+
+		printer.Response(newResp(), time.Second)
+		assert.Equal(t, "HTTP/1.1 200 OK 1s", logger.lastMessage)
+	})
+
+	t.Run("debug headers", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterWithOptions(logger, PrinterOptions{
+			Verbosity: VerbosityHeaders,
+			Headers:   []string{"content-type"},
+		})
+
+		printer.Request(newReq())
+		assert.Contains(t, logger.lastMessage, "Content-Type: text/plain")
+		assert.NotContains(t, logger.lastMessage, "Authorization")
+		assert.NotContains(t, logger.lastMessage, "0123456789")
+
+		printer.Response(newResp(), time.Second)
+		assert.Contains(t, logger.lastMessage, "HTTP/1.1 200 OK 1s")
+		assert.Contains(t, logger.lastMessage, "Content-Type: text/plain")
+		assert.NotContains(t, logger.lastMessage, "Set-Cookie")
+		assert.NotContains(t, logger.lastMessage, "abcdefghij")
+	})
+
+	t.Run("debug truncated body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterWithOptions(logger, PrinterOptions{
+			MaxBodySize: 4,
+		})
+
+		printer.Request(newReq())
+		assert.Contains(t, logger.lastMessage, "Authorization: Bearer token")
+		assert.True(t,
+			strings.HasSuffix(logger.lastMessage, "\r\n0123... (6 bytes truncated)"))
+
+		printer.Response(newResp(), time.Second)
+		assert.True(t,
+			strings.HasSuffix(logger.lastMessage, "\nabcd... (6 bytes truncated)"))
+	})
+
+	t.Run("debug full body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewDebugPrinterWithOptions(logger, PrinterOptions{
+			MaxBodySize: 100,
+		})
+
+		printer.Request(newReq())
+		assert.True(t, strings.HasSuffix(logger.lastMessage, "\r\n0123456789"))
+
+		printer.Response(newResp(), time.Second)
+		assert.True(t, strings.HasSuffix(logger.lastMessage, "\nabcdefghij"))
+	})
+
+	t.Run("json headers", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewJSONPrinterWithOptions(logger, PrinterOptions{
+			Verbosity: VerbosityHeaders,
+			Headers:   []string{"Content-Type"},
+		})
+
+		printer.Request(newReq())
+		assert.JSONEq(t, `{
+			"type": "request",
+			"method": "POST",
+			"url": "http://example.com/path",
+			"headers": {"Content-Type": ["text/plain"]},
+			"size": 10
+		}`, logger.lastMessage)
+	})
+
+	t.Run("json truncated body", func(t *testing.T) {
+		logger := newMockLogger(t)
+		printer := NewJSONPrinterWithOptions(logger, PrinterOptions{
+			MaxBodySize: 4,
+			Headers:     []string{"X-Missing"},
+		})
+
+		printer.Response(newResp(), 0)
+		assert.JSONEq(t, `{
+			"type": "response",
+			"method": "",
+			"url": "",
+			"status": 200,
+			"latency_ms": 0,
+			"size": 10,
+			"body": "abcd",
+			"body_truncated": true
+		}`, logger.lastMessage)
+	})
+}
+
+func TestPrinter_Buffered(t *testing.T) {
+	assert.Panics(t, func() {
+		NewBufferedPrinter(nil)
+	})
+
+	record := func(printer *BufferedPrinter) {
+		req, _ := http.NewRequest("POST", "http://example.com",
+			bytes.NewBufferString("request"))
+
+		printer.Request(req)
+		printer.Request(nil)
+
+		// original bodies are drained after recording
+		body, _ := ioutil.ReadAll(req.Body)
+		assert.Empty(t, body)
+
+		printer.Response(&http.Response{
+			Request: req,
+			Header:  http.Header{},
+			Body:    ioutil.NopCloser(bytes.NewBufferString("response")),
+		}, time.Second)
+		printer.Response(nil, 0)
+
+		printer.WebsocketWrite(websocket.TextMessage, []byte("write"), 0)
+		printer.WebsocketRead(websocket.TextMessage, []byte("read"), 0)
+	}
+
+	t.Run("flush", func(t *testing.T) {
+		backend := &mockPrinter{}
+		printer := NewBufferedPrinter(backend)
+
+		record(printer)
+
+		assert.Nil(t, backend.reqBody)
+		assert.Nil(t, backend.respBody)
+
+		printer.Flush()
+
+		assert.Equal(t, "request", string(backend.reqBody))
+		assert.Equal(t, "response", string(backend.respBody))
+		assert.Equal(t, time.Second, backend.rtt)
+
+		// buffer is cleared
+		backend.reqBody = nil
+		printer.Flush()
+		assert.Nil(t, backend.reqBody)
+	})
+
+	t.Run("flush if failed", func(t *testing.T) {
+		backend := &mockPrinter{}
+		printer := NewBufferedPrinter(backend)
+
+		record(printer)
+		printer.FlushIfFailed(false)
+
+		assert.Nil(t, backend.reqBody)
+
+		printer.Flush()
+		assert.Nil(t, backend.reqBody)
+
+		record(printer)
+		printer.FlushIfFailed(true)
+
+		assert.Equal(t, "request", string(backend.reqBody))
+	})
+
+	t.Run("flush on cleanup", func(t *testing.T) {
+		var _ TestingCleanup = t
+
+		for _, failed := range []bool{false, true} {
+			backend := &mockPrinter{}
+			printer := NewBufferedPrinter(backend)
+
+			cleanup := &mockTestingCleanup{failed: failed}
+			printer.FlushOnCleanup(cleanup)
+
+			record(printer)
+			assert.Nil(t, backend.reqBody)
+
+			cleanup.run()
+
+			if failed {
+				assert.Equal(t, "request", string(backend.reqBody))
+			} else {
+				assert.Nil(t, backend.reqBody)
+			}
+
+			// buffer is cleared
+			backend.reqBody = nil
+			printer.Flush()
+			assert.Nil(t, backend.reqBody)
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		backend := &mockPrinter{}
+		printer := NewBufferedPrinter(backend)
+
+		record(printer)
+		printer.Reset()
+		printer.Flush()
+
+		assert.Nil(t, backend.reqBody)
+		assert.Nil(t, backend.respBody)
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		backend := newMockWsPrinter()
+		printer := NewBufferedPrinter(backend)
+
+		record(printer)
+
+		assert.False(t, backend.isWrittenTo)
+		assert.False(t, backend.isReadFrom)
+
+		printer.Flush()
+
+		assert.True(t, backend.isWrittenTo)
+		assert.True(t, backend.isReadFrom)
+	})
+}
@@ -0,0 +1,307 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Problem provides methods to inspect "problem details" object, i.e.
+// JSON object describing an error in HTTP API.
+//
+// See https://www.rfc-editor.org/rfc/rfc7807.
+//
+// Example:
+//
+//	problem := e.GET("/users/123").
+//		Expect().
+//		Status(http.StatusNotFound).
+//		Problem()
+//
+//	problem.Type().Equal("https://example.com/probs/not-found")
+//	problem.Title().Equal("User not found")
+//	problem.Status().Equal(http.StatusNotFound)
+type Problem struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+// NewProblem returns a new Problem instance.
+//
+// If reporter is nil, the function panics.
+// If value is not a valid problem details object, failure is reported.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"title":  "User not found",
+//		"status": 404,
+//	})
+func NewProblem(reporter Reporter, value interface{}) *Problem {
+	return newProblem(newChainWithDefaults("Problem()", reporter), value)
+}
+
+// NewProblemC returns a new Problem instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is not a valid problem details object, failure is reported.
+//
+// Example:
+//
+//	problem := NewProblemC(config, map[string]interface{}{
+//		"title":  "User not found",
+//		"status": 404,
+//	})
+func NewProblemC(config Config, value interface{}) *Problem {
+	return newProblem(newChainWithConfig("Problem()", config.withDefaults()), value)
+}
+
+func newProblem(parent *chain, val interface{}) *Problem {
+	p := &Problem{chain: parent.clone(), value: nil}
+
+	opChain := p.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return p
+	}
+
+	canon, ok := canonValue(opChain, val)
+	if !ok {
+		return p
+	}
+
+	object, ok := canon.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: problem details is an object"),
+			},
+		})
+		return p
+	}
+
+	for _, key := range []string{"type", "title", "detail", "instance"} {
+		if member, ok := object[key]; ok {
+			if _, ok := member.(string); !ok {
+				opChain.fail(AssertionFailure{
+					Type:   AssertValid,
+					Actual: &AssertionValue{val},
+					Errors: []error{
+						fmt.Errorf("expected: problem details %q member is string", key),
+					},
+				})
+				return p
+			}
+		}
+	}
+
+	if member, ok := object["status"]; ok {
+		if !isCanonNumber(member) {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{val},
+				Errors: []error{
+					errors.New(`expected: problem details "status" member is number`),
+				},
+			})
+			return p
+		}
+	}
+
+	p.value = object
+
+	return p
+}
+
+// Raw returns underlying value attached to Problem.
+// This is the value originally passed to NewProblem, converted to
+// canonical form.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{"title": "oops"})
+//	assert.Equal(t, map[string]interface{}{"title": "oops"}, problem.Raw())
+func (p *Problem) Raw() map[string]interface{} {
+	return p.value
+}
+
+// Type returns a new String instance with "type" member of problem
+// details.
+//
+// If there is no "type" member, returned String contains "about:blank",
+// as defined by RFC 7807.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"type": "https://example.com/probs/out-of-credit",
+//	})
+//	problem.Type().Equal("https://example.com/probs/out-of-credit")
+func (p *Problem) Type() *String {
+	opChain := p.chain.enter("Type()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if typ, ok := p.value["type"].(string); ok {
+		return newString(opChain, typ)
+	}
+
+	return newString(opChain, "about:blank")
+}
+
+// Title returns a new String instance with "title" member of problem
+// details.
+//
+// If there is no "title" member, returned String is empty.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"title": "You do not have enough credit.",
+//	})
+//	problem.Title().Equal("You do not have enough credit.")
+func (p *Problem) Title() *String {
+	opChain := p.chain.enter("Title()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	title, _ := p.value["title"].(string)
+
+	return newString(opChain, title)
+}
+
+// Status returns a new Number instance with "status" member of problem
+// details.
+//
+// If there is no "status" member, failure is reported.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"status": 403,
+//	})
+//	problem.Status().Equal(http.StatusForbidden)
+func (p *Problem) Status() *Number {
+	opChain := p.chain.enter("Status()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	status, ok := p.value["status"]
+	if !ok || !isCanonNumber(status) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{p.value},
+			Expected: &AssertionValue{
+				"status",
+			},
+			Errors: []error{
+				errors.New(`expected: problem details has "status" member`),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	if num, ok := status.(json.Number); ok {
+		return newNumberExact(opChain, num)
+	}
+
+	return newNumber(opChain, status.(float64))
+}
+
+// Detail returns a new String instance with "detail" member of problem
+// details.
+//
+// If there is no "detail" member, returned String is empty.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"detail": "Your current balance is 30, but that costs 50.",
+//	})
+//	problem.Detail().Contains("balance")
+func (p *Problem) Detail() *String {
+	opChain := p.chain.enter("Detail()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	detail, _ := p.value["detail"].(string)
+
+	return newString(opChain, detail)
+}
+
+// Instance returns a new String instance with "instance" member of
+// problem details.
+//
+// If there is no "instance" member, returned String is empty.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"instance": "/account/12345/msgs/abc",
+//	})
+//	problem.Instance().Equal("/account/12345/msgs/abc")
+func (p *Problem) Instance() *String {
+	opChain := p.chain.enter("Instance()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	instance, _ := p.value["instance"].(string)
+
+	return newString(opChain, instance)
+}
+
+// Extension returns a new Value instance with extension member of
+// problem details, i.e. any member besides the standard ones.
+//
+// If there is no such member, failure is reported.
+//
+// Example:
+//
+//	problem := NewProblem(t, map[string]interface{}{
+//		"balance": 30,
+//	})
+//	problem.Extension("balance").Number().Equal(30)
+func (p *Problem) Extension(name string) *Value {
+	opChain := p.chain.enter("Extension(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	member, ok := p.value[name]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{p.value},
+			Expected: &AssertionValue{
+				name,
+			},
+			Errors: []error{
+				fmt.Errorf("expected: problem details has %q member", name),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, member)
+}
@@ -0,0 +1,208 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblem_Failed(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newProblem(chain, map[string]interface{}{"title": "oops"})
+
+	value.chain.assertFailed(t)
+
+	assert.NotNil(t, value.Type())
+	assert.NotNil(t, value.Title())
+	assert.NotNil(t, value.Status())
+	assert.NotNil(t, value.Detail())
+	assert.NotNil(t, value.Instance())
+	assert.NotNil(t, value.Extension("foo"))
+
+	value.Type().chain.assertFailed(t)
+	value.Title().chain.assertFailed(t)
+	value.Status().chain.assertFailed(t)
+	value.Detail().chain.assertFailed(t)
+	value.Instance().chain.assertFailed(t)
+	value.Extension("foo").chain.assertFailed(t)
+}
+
+func TestProblem_Constructors(t *testing.T) {
+	data := map[string]interface{}{"title": "oops"}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewProblem(reporter, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewProblemC(Config{
+			Reporter: reporter,
+		}, data)
+		assert.Equal(t, data, value.Raw())
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newProblem(chain, data)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestProblem_Validation(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		fail  bool
+	}{
+		{
+			name:  "empty",
+			value: map[string]interface{}{},
+		},
+		{
+			name: "all members",
+			value: map[string]interface{}{
+				"type":     "https://example.com/probs/out-of-credit",
+				"title":    "You do not have enough credit.",
+				"status":   403,
+				"detail":   "Your current balance is 30, but that costs 50.",
+				"instance": "/account/12345/msgs/abc",
+				"balance":  30,
+			},
+		},
+		{
+			name:  "nil",
+			value: nil,
+			fail:  true,
+		},
+		{
+			name:  "not object",
+			value: []interface{}{"title"},
+			fail:  true,
+		},
+		{
+			name:  "type not string",
+			value: map[string]interface{}{"type": 123},
+			fail:  true,
+		},
+		{
+			name:  "title not string",
+			value: map[string]interface{}{"title": nil},
+			fail:  true,
+		},
+		{
+			name:  "status not number",
+			value: map[string]interface{}{"status": "404"},
+			fail:  true,
+		},
+		{
+			name:  "not marshalable",
+			value: func() {},
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewProblem(reporter, tc.value)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				assert.Nil(t, value.Raw())
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.NotNil(t, value.Raw())
+			}
+		})
+	}
+}
+
+func TestProblem_Members(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewProblem(reporter, map[string]interface{}{
+			"type":     "https://example.com/probs/out-of-credit",
+			"title":    "You do not have enough credit.",
+			"status":   403,
+			"detail":   "Your current balance is 30, but that costs 50.",
+			"instance": "/account/12345/msgs/abc",
+			"balance":  30,
+		})
+
+		value.Type().Equal("https://example.com/probs/out-of-credit")
+		value.Title().Equal("You do not have enough credit.")
+		value.Status().Equal(http.StatusForbidden)
+		value.Detail().Contains("balance")
+		value.Instance().Equal("/account/12345/msgs/abc")
+		value.Extension("balance").Number().Equal(30)
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewProblem(reporter, map[string]interface{}{})
+
+		value.Type().Equal("about:blank")
+		value.Title().Empty()
+		value.Detail().Empty()
+		value.Instance().Empty()
+
+		value.chain.assertNotFailed(t)
+
+		value.Status().chain.assertFailed(t)
+		value.Extension("balance").chain.assertFailed(t)
+	})
+}
+
+func TestProblem_JSONNumber(t *testing.T) {
+	config := newMockConfig(newMockReporter(t))
+	config.UseJSONNumber = true
+
+	value := NewProblemC(config, map[string]interface{}{
+		"status": uint64(9007199254740993),
+	})
+	value.chain.assertNotFailed(t)
+
+	value.Status().Equal(uint64(9007199254740993)).
+		chain.assertNotFailed(t)
+	value.Status().Equal(uint64(9007199254740992)).
+		chain.assertFailed(t)
+
+	t.Run("response", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.UseJSONNumber = true
+
+		newResp := func(status int, body string) *Response {
+			return newResponse(responseOpts{
+				config: config,
+				chain:  newChainWithConfig("test", config),
+				httpResp: &http.Response{
+					StatusCode: status,
+					Header: http.Header{
+						"Content-Type": {"application/problem+json"},
+					},
+					Body: newMockBody(body),
+				},
+			})
+		}
+
+		resp := newResp(http.StatusNotFound, `{"status": 404}`)
+		resp.Problem().chain.assertNotFailed(t)
+
+		resp = newResp(http.StatusNotFound, `{"status": 9007199254740993}`)
+		resp.Problem().chain.assertFailed(t)
+	})
+}
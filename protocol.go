@@ -0,0 +1,126 @@
+package httpexpect
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// HTTPVersion defines which HTTP protocol version is used by default client.
+//
+// See Config.HTTPVersion.
+type HTTPVersion int
+
+const (
+	// indicates that Config.HTTPVersion was not set
+	defaultHTTPVersion HTTPVersion = iota
+
+	// ForceHTTP1 forces HTTP/1.1 for both "http" and "https" URLs.
+	// HTTP/2 is not negotiated via TLS ALPN.
+	ForceHTTP1
+
+	// ForceHTTP2 forces HTTP/2 for both "http" and "https" URLs.
+	// For "https", HTTP/2 is negotiated via TLS ALPN, and request fails if
+	// server doesn't support it. For "http", HTTP/2 over cleartext (h2c)
+	// is used with prior knowledge, without HTTP/1.1 upgrade.
+	ForceHTTP2
+)
+
+// NewHTTP1Transport returns a new http.Transport which uses only HTTP/1.1,
+// with given TLS config.
+//
+// Other transport settings are copied from http.DefaultTransport.
+//
+// Example:
+//
+//	client := &http.Client{
+//		Transport: NewHTTP1Transport(nil),
+//	}
+func NewHTTP1Transport(config *tls.Config) *http.Transport {
+	transport := NewTLSTransport(config)
+
+	// non-nil empty map disables HTTP/2
+	transport.ForceAttemptHTTP2 = false
+	transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+
+	return transport
+}
+
+// NewHTTP2Transport returns a new http.RoundTripper which uses only HTTP/2,
+// with given TLS config.
+//
+// For "https" URLs, HTTP/2 is negotiated via TLS ALPN. For "http" URLs,
+// HTTP/2 over cleartext (h2c) is used with prior knowledge.
+//
+// Example:
+//
+//	client := &http.Client{
+//		Transport: NewHTTP2Transport(nil),
+//	}
+func NewHTTP2Transport(config *tls.Config) http.RoundTripper {
+	return &http2Transport{
+		tls: &http2.Transport{
+			TLSClientConfig: config,
+		},
+		h2c: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+}
+
+type http2Transport struct {
+	tls *http2.Transport
+	h2c *http2.Transport
+}
+
+func (t *http2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL != nil && req.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
+
+// replaces function used to establish TCP connections, both for
+// cleartext and TLS connections
+func (t *http2Transport) setDialContext(
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+) {
+	t.h2c.DialTLS = func(network, addr string, _ *tls.Config) (net.Conn, error) {
+		return dial(context.Background(), network, addr)
+	}
+
+	t.tls.DialTLS = func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+		conn, err := dial(context.Background(), network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConn := tls.Client(conn, cfg)
+
+		if err := tlsConn.Handshake(); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+
+		proto := tlsConn.ConnectionState().NegotiatedProtocol
+		if proto != http2.NextProtoTLS {
+			_ = tlsConn.Close()
+			return nil, fmt.Errorf("unexpected ALPN protocol %q, want %q",
+				proto, http2.NextProtoTLS)
+		}
+
+		return tlsConn, nil
+	}
+}
+
+func (t *http2Transport) CloseIdleConnections() {
+	t.tls.CloseIdleConnections()
+	t.h2c.CloseIdleConnections()
+}
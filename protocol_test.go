@@ -0,0 +1,142 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func createProtoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+}
+
+func TestProtocol_Transports(t *testing.T) {
+	t.Run("http1", func(t *testing.T) {
+		transport := NewHTTP1Transport(&tls.Config{ServerName: "example.com"})
+
+		require.NotNil(t, transport.TLSClientConfig)
+		assert.Equal(t, "example.com", transport.TLSClientConfig.ServerName)
+
+		assert.False(t, transport.ForceAttemptHTTP2)
+		assert.NotNil(t, transport.TLSNextProto)
+		assert.Empty(t, transport.TLSNextProto)
+	})
+
+	t.Run("http2", func(t *testing.T) {
+		transport := NewHTTP2Transport(&tls.Config{ServerName: "example.com"})
+
+		h2, ok := transport.(*http2Transport)
+		require.True(t, ok)
+
+		require.NotNil(t, h2.tls.TLSClientConfig)
+		assert.Equal(t, "example.com", h2.tls.TLSClientConfig.ServerName)
+
+		assert.True(t, h2.h2c.AllowHTTP)
+	})
+}
+
+func TestProtocol_TLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(createProtoHandler())
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	cases := []struct {
+		name    string
+		version HTTPVersion
+		proto   string
+	}{
+		{"default", defaultHTTPVersion, "HTTP/2.0"},
+		{"http1", ForceHTTP1, "HTTP/1.1"},
+		{"http2", ForceHTTP2, "HTTP/2.0"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := WithConfig(Config{
+				BaseURL:         server.URL,
+				Reporter:        newMockReporter(t),
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+				HTTPVersion:     tc.version,
+			})
+
+			resp := e.GET("/").Expect()
+
+			resp.Status(http.StatusOK)
+			resp.Proto().Equal(tc.proto)
+			resp.Body().Equal(tc.proto)
+
+			resp.chain.assertNotFailed(t)
+		})
+	}
+}
+
+func TestProtocol_Cleartext(t *testing.T) {
+	t.Run("h2c", func(t *testing.T) {
+		server := httptest.NewServer(
+			h2c.NewHandler(createProtoHandler(), &http2.Server{}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:     server.URL,
+			Reporter:    newMockReporter(t),
+			HTTPVersion: ForceHTTP2,
+		})
+
+		resp := e.GET("/").Expect()
+
+		resp.Status(http.StatusOK)
+		resp.Proto().Equal("HTTP/2.0")
+		resp.ProtoAtLeast(2, 0)
+		resp.Body().Equal("HTTP/2.0")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("http1", func(t *testing.T) {
+		server := httptest.NewServer(
+			h2c.NewHandler(createProtoHandler(), &http2.Server{}))
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:     server.URL,
+			Reporter:    newMockReporter(t),
+			HTTPVersion: ForceHTTP1,
+		})
+
+		resp := e.GET("/").Expect()
+
+		resp.Status(http.StatusOK)
+		resp.Proto().Equal("HTTP/1.1")
+		resp.Body().Equal("HTTP/1.1")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("h2c not supported", func(t *testing.T) {
+		server := httptest.NewServer(createProtoHandler())
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:     server.URL,
+			Reporter:    newMockReporter(t),
+			HTTPVersion: ForceHTTP2,
+		})
+
+		resp := e.GET("/").WithMaxRetries(0).Expect()
+
+		resp.chain.assertFailed(t)
+	})
+}
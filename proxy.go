@@ -0,0 +1,155 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// proxySelector selects proxy for requests, taking into account NO_PROXY list
+type proxySelector struct {
+	url     *url.URL
+	noProxy []noProxyEntry
+}
+
+type noProxyEntry struct {
+	all    bool
+	ipNet  *net.IPNet
+	ip     net.IP
+	domain string
+	port   string
+}
+
+// newProxySelector parses proxy URL and NO_PROXY list
+//
+// Proxy URL scheme should be "http", "https", or "socks5". NO_PROXY entries
+// have the same format as in NO_PROXY environment variable: "*", IP address,
+// CIDR, or domain name, optionally with port. Domain name matches itself
+// and its subdomains; leading dot is allowed.
+func newProxySelector(proxyURL string, noProxy []string) (*proxySelector, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf(
+			"unsupported proxy scheme %q, want http, https, or socks5", u.Scheme)
+	}
+
+	if u.Host == "" {
+		return nil, errors.New("proxy URL should contain host")
+	}
+
+	p := &proxySelector{
+		url: u,
+	}
+
+	for _, s := range noProxy {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+
+		p.noProxy = append(p.noProxy, parseNoProxyEntry(s))
+	}
+
+	return p, nil
+}
+
+func parseNoProxyEntry(s string) noProxyEntry {
+	if s == "*" {
+		return noProxyEntry{all: true}
+	}
+
+	if _, ipNet, err := net.ParseCIDR(s); err == nil {
+		return noProxyEntry{ipNet: ipNet}
+	}
+
+	host, port := s, ""
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		host, port = h, p
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return noProxyEntry{ip: ip, port: port}
+	}
+
+	return noProxyEntry{
+		domain: strings.TrimPrefix(host, "."),
+		port:   port,
+	}
+}
+
+// proxyFunc is used as http.Transport.Proxy and websocket.Dialer.Proxy
+func (p *proxySelector) proxyFunc(req *http.Request) (*url.URL, error) {
+	if p.bypass(req.URL) {
+		return nil, nil
+	}
+
+	return p.url, nil
+}
+
+func (p *proxySelector) bypass(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+
+	if port == "" {
+		switch u.Scheme {
+		case "http", "ws":
+			port = "80"
+		case "https", "wss":
+			port = "443"
+		}
+	}
+
+	ip := net.ParseIP(host)
+
+	for _, entry := range p.noProxy {
+		switch {
+		case entry.all:
+			return true
+
+		case entry.ipNet != nil:
+			if ip != nil && entry.ipNet.Contains(ip) {
+				return true
+			}
+
+		case entry.port != "" && entry.port != port:
+			continue
+
+		case entry.ip != nil:
+			if ip != nil && entry.ip.Equal(ip) {
+				return true
+			}
+
+		default:
+			if host == entry.domain || strings.HasSuffix(host, "."+entry.domain) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// proxyTransport returns a copy of client's http.Transport, which proxy
+// may be then changed without affecting the original client
+func proxyTransport(client *http.Client) (*http.Transport, error) {
+	switch transport := client.Transport.(type) {
+	case nil:
+		return NewTLSTransport(nil), nil
+
+	case *http.Transport:
+		return transport.Clone(), nil
+
+	default:
+		return nil, errors.New(
+			"client transport should be http.Transport to configure proxy")
+	}
+}
@@ -0,0 +1,185 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"strconv"
+	"strings"
+)
+
+// contentRange is a parsed value of Content-Range header
+type contentRange struct {
+	unit string
+
+	// false for unsatisfied range, e.g. "bytes */1000"
+	hasRange bool
+	start    int64
+	end      int64
+
+	// false for unknown size, e.g. "bytes 0-99/*"
+	hasSize bool
+	size    int64
+}
+
+// parseContentRange parses Content-Range header value, as defined in
+// RFC 9110, e.g. "bytes 0-99/1000", "bytes 0-99/*", or "bytes */1000"
+func parseContentRange(value string) (contentRange, error) {
+	var cr contentRange
+
+	sp := strings.IndexByte(value, ' ')
+	if sp <= 0 {
+		return cr, errors.New("missing range unit")
+	}
+
+	cr.unit = value[:sp]
+
+	slash := strings.IndexByte(value, '/')
+	if slash < sp {
+		return cr, errors.New("missing complete length")
+	}
+
+	rng, size := strings.TrimSpace(value[sp+1:slash]), value[slash+1:]
+
+	if size != "*" {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || n < 0 {
+			return cr, fmt.Errorf("invalid complete length %q", size)
+		}
+		cr.hasSize = true
+		cr.size = n
+	}
+
+	if rng == "*" {
+		if !cr.hasSize {
+			return cr, errors.New("unsatisfied range requires complete length")
+		}
+		return cr, nil
+	}
+
+	bounds := strings.SplitN(rng, "-", 2)
+	if len(bounds) != 2 {
+		return cr, fmt.Errorf("invalid range %q", rng)
+	}
+
+	start, err := strconv.ParseInt(bounds[0], 10, 64)
+	if err != nil || start < 0 {
+		return cr, fmt.Errorf("invalid range start %q", bounds[0])
+	}
+
+	end, err := strconv.ParseInt(bounds[1], 10, 64)
+	if err != nil || end < start {
+		return cr, fmt.Errorf("invalid range end %q", bounds[1])
+	}
+
+	if cr.hasSize && end >= cr.size {
+		return cr, fmt.Errorf("range end %d exceeds complete length %d",
+			end, cr.size)
+	}
+
+	cr.hasRange = true
+	cr.start = start
+	cr.end = end
+
+	return cr, nil
+}
+
+func (cr contentRange) object() map[string]interface{} {
+	obj := map[string]interface{}{
+		"unit": cr.unit,
+	}
+
+	if cr.hasRange {
+		obj["start"] = float64(cr.start)
+		obj["end"] = float64(cr.end)
+	}
+
+	if cr.hasSize {
+		obj["size"] = float64(cr.size)
+	}
+
+	return obj
+}
+
+// byteRange is a single part of partial content response
+type byteRange struct {
+	contentRange contentRange
+	contentType  string
+	body         []byte
+}
+
+func (br byteRange) object() map[string]interface{} {
+	obj := br.contentRange.object()
+
+	if br.contentType != "" {
+		obj["content_type"] = br.contentType
+	}
+
+	obj["body"] = string(br.body)
+
+	return obj
+}
+
+// parseByteRanges parses body of "multipart/byteranges" response
+func parseByteRanges(contentType string, body []byte) ([]byteRange, error) {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, errors.New("missing multipart boundary")
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+
+	var ranges []byteRange
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cr, err := parseContentRange(part.Header.Get("Content-Range"))
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %s", len(ranges), err)
+		}
+
+		if !cr.hasRange {
+			return nil, fmt.Errorf("part %d: unexpected unsatisfied range",
+				len(ranges))
+		}
+
+		data, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if int64(len(data)) != cr.end-cr.start+1 {
+			return nil, fmt.Errorf(
+				"part %d: body length %d doesn't match range length %d",
+				len(ranges), len(data), cr.end-cr.start+1)
+		}
+
+		ranges = append(ranges, byteRange{
+			contentRange: cr,
+			contentType:  part.Header.Get("Content-Type"),
+			body:         data,
+		})
+	}
+
+	if len(ranges) == 0 {
+		return nil, errors.New("no parts found")
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,141 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange_ParseContentRange(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected map[string]interface{}
+		isValid  bool
+	}{
+		{
+			value: "bytes 0-99/1000",
+			expected: map[string]interface{}{
+				"unit": "bytes", "start": 0.0, "end": 99.0, "size": 1000.0,
+			},
+			isValid: true,
+		},
+		{
+			value: "bytes 0-99/*",
+			expected: map[string]interface{}{
+				"unit": "bytes", "start": 0.0, "end": 99.0,
+			},
+			isValid: true,
+		},
+		{
+			value: "bytes */1000",
+			expected: map[string]interface{}{
+				"unit": "bytes", "size": 1000.0,
+			},
+			isValid: true,
+		},
+		{
+			value: "items 5-5/10",
+			expected: map[string]interface{}{
+				"unit": "items", "start": 5.0, "end": 5.0, "size": 10.0,
+			},
+			isValid: true,
+		},
+		{value: "", isValid: false},
+		{value: "bytes", isValid: false},
+		{value: "bytes 0-99", isValid: false},
+		{value: "bytes */*", isValid: false},
+		{value: "bytes 0-99/abc", isValid: false},
+		{value: "bytes 0-/1000", isValid: false},
+		{value: "bytes -1-5/1000", isValid: false},
+		{value: "bytes 10-5/1000", isValid: false},
+		{value: "bytes 0-1000/1000", isValid: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			cr, err := parseContentRange(tc.value)
+			if !tc.isValid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cr.object())
+		})
+	}
+}
+
+func TestRange_ParseByteRanges(t *testing.T) {
+	const contentType = "multipart/byteranges; boundary=XYZ"
+
+	t.Run("valid", func(t *testing.T) {
+		body := "--XYZ\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"Content-Range: bytes 0-4/12\r\n" +
+			"\r\n" +
+			"hello\r\n" +
+			"--XYZ\r\n" +
+			"Content-Range: bytes 7-11/12\r\n" +
+			"\r\n" +
+			"world\r\n" +
+			"--XYZ--\r\n"
+
+		ranges, err := parseByteRanges(contentType, []byte(body))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(ranges))
+
+		assert.Equal(t, map[string]interface{}{
+			"unit":         "bytes",
+			"start":        0.0,
+			"end":          4.0,
+			"size":         12.0,
+			"content_type": "text/plain",
+			"body":         "hello",
+		}, ranges[0].object())
+
+		assert.Equal(t, map[string]interface{}{
+			"unit":  "bytes",
+			"start": 7.0,
+			"end":   11.0,
+			"size":  12.0,
+			"body":  "world",
+		}, ranges[1].object())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			contentType string
+			body        string
+		}{
+			{
+				name:        "no boundary",
+				contentType: "multipart/byteranges",
+				body:        "",
+			},
+			{
+				name:        "no parts",
+				contentType: contentType,
+				body:        "--XYZ--\r\n",
+			},
+			{
+				name:        "no content range",
+				contentType: contentType,
+				body:        "--XYZ\r\n\r\nhello\r\n--XYZ--\r\n",
+			},
+			{
+				name:        "length mismatch",
+				contentType: contentType,
+				body: "--XYZ\r\nContent-Range: bytes 0-9/12\r\n\r\n" +
+					"hello\r\n--XYZ--\r\n",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := parseByteRanges(tc.contentType, []byte(tc.body))
+				assert.Error(t, err)
+			})
+		}
+	})
+}
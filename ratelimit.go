@@ -0,0 +1,80 @@
+package httpexpect
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// values of X-RateLimit-Reset larger than this are treated as unix time
+// rather than delay in seconds (roughly 3 years in seconds)
+const rateLimitEpochThreshold = 100000000
+
+// rateLimitHeader returns value of standard RateLimit-* header, or, if
+// it's missing, value of legacy X-RateLimit-* header
+func rateLimitHeader(header http.Header, name string) (string, string) {
+	for _, key := range []string{"RateLimit-" + name, "X-RateLimit-" + name} {
+		if value := header.Get(key); value != "" {
+			return key, value
+		}
+	}
+	return "", ""
+}
+
+// parseRateLimitValue parses first integer from RateLimit-* header value,
+// e.g. "100" or "100, 100;w=60"
+func parseRateLimitValue(value string) (int64, bool) {
+	if i := strings.IndexAny(value, ",;"); i >= 0 {
+		value = value[:i]
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// parseRateLimitReset parses RateLimit-Reset header value, which is delay
+// in seconds, or, for some legacy X-RateLimit-Reset implementations,
+// unix time in seconds
+func parseRateLimitReset(value string, now time.Time) (time.Duration, bool) {
+	n, ok := parseRateLimitValue(value)
+	if !ok {
+		return 0, false
+	}
+
+	if n > rateLimitEpochThreshold {
+		return nonNegative(time.Unix(n, 0).Sub(now)), true
+	}
+
+	return time.Duration(n) * time.Second, true
+}
+
+// parseRetryAfter parses Retry-After header value, which is either delay
+// in seconds or HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n < 0 {
+			return 0, false
+		}
+		return time.Duration(n) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return nonNegative(t.Sub(now)), true
+	}
+
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,103 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_Header(t *testing.T) {
+	header := http.Header{}
+
+	key, value := rateLimitHeader(header, "Limit")
+	assert.Equal(t, "", key)
+	assert.Equal(t, "", value)
+
+	header.Set("X-RateLimit-Limit", "10")
+
+	key, value = rateLimitHeader(header, "Limit")
+	assert.Equal(t, "X-RateLimit-Limit", key)
+	assert.Equal(t, "10", value)
+
+	header.Set("RateLimit-Limit", "20")
+
+	key, value = rateLimitHeader(header, "Limit")
+	assert.Equal(t, "RateLimit-Limit", key)
+	assert.Equal(t, "20", value)
+}
+
+func TestRateLimit_ParseValue(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected int64
+		isValid  bool
+	}{
+		{"100", 100, true},
+		{" 100 ", 100, true},
+		{"0", 0, true},
+		{"100, 100;w=60", 100, true},
+		{"100;w=60", 100, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"abc", 0, false},
+		{"1.5", 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			n, ok := parseRateLimitValue(tc.value)
+			assert.Equal(t, tc.isValid, ok)
+			assert.Equal(t, tc.expected, n)
+		})
+	}
+}
+
+func TestRateLimit_ParseReset(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	cases := []struct {
+		value    string
+		expected time.Duration
+		isValid  bool
+	}{
+		{"30", 30 * time.Second, true},
+		{"1600000060", time.Minute, true},
+		{"1500000000", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			d, ok := parseRateLimitReset(tc.value, now)
+			assert.Equal(t, tc.isValid, ok)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
+
+func TestRateLimit_ParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value    string
+		expected time.Duration
+		isValid  bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"0", 0, true},
+		{"Wed, 01 Jan 2020 00:01:00 GMT", time.Minute, true},
+		{"Tue, 31 Dec 2019 23:59:00 GMT", 0, true},
+		{"-1", 0, false},
+		{"tomorrow", 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			d, ok := parseRetryAfter(tc.value, now)
+			assert.Equal(t, tc.isValid, ok)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Redactor defines rules for hiding sensitive data, like authorization
+// tokens, passwords, and API keys, so that it doesn't end up in logs.
+//
+// Redactor may be used in two places:
+//   - Config.Redactor is applied to requests, responses, and websocket
+//     messages before they are passed to printers
+//   - DefaultFormatter.Redactor is applied to failure messages
+//
+// Redactor never modifies requests and responses that are actually sent
+// and received; it modifies only copies that are printed or reported.
+//
+// Example:
+//
+//	redactor := &httpexpect.Redactor{
+//		Headers:   []string{"Authorization", "Cookie", "Set-Cookie"},
+//		JSONKeys:  []string{"password"},
+//		JSONPaths: []string{"$.user.token"},
+//		Patterns:  []*regexp.Regexp{
+//			regexp.MustCompile(`api_key=[^&\s]+`),
+//		},
+//	}
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Formatter: &httpexpect.DefaultFormatter{
+//			Redactor: redactor,
+//		},
+//		Printers: []httpexpect.Printer{
+//			httpexpect.NewDebugPrinter(t, true),
+//		},
+//		Redactor: redactor,
+//	})
+type Redactor struct {
+	// Names of headers which values are replaced.
+	// Names are case-insensitive.
+	Headers []string
+
+	// Names of JSON object keys which values are replaced.
+	// Keys are matched at any depth and are case-insensitive.
+	// Applied to bodies that are valid JSON and to JSON values
+	// in failure messages.
+	JSONKeys []string
+
+	// JSON paths which values are replaced, e.g. "user.token" or
+	// "$.items[*].secret". Unlike JSONKeys, paths are case-sensitive
+	// and select values only at given location. Paths have the same
+	// syntax as in Object.EqualIgnoring. Applied to bodies that are
+	// valid JSON and to JSON values in failure messages; in the latter
+	// case, paths are relative to the reported value.
+	// Invalid paths cause panic.
+	JSONPaths []string
+
+	// Regular expressions which matches are replaced.
+	// Applied to URLs, header values, bodies, websocket messages,
+	// and failure messages.
+	Patterns []*regexp.Regexp
+
+	// String used instead of hidden data.
+	// If empty, "[REDACTED]" is used.
+	Replacement string
+}
+
+const defaultRedactorReplacement = "[REDACTED]"
+
+// RedactString replaces all matches of Patterns in given string.
+func (r *Redactor) RedactString(s string) string {
+	for _, re := range r.Patterns {
+		s = re.ReplaceAllLiteralString(s, r.replacement())
+	}
+	return s
+}
+
+// RedactHeader returns a copy of header with values of Headers replaced,
+// and all matches of Patterns replaced in other values.
+func (r *Redactor) RedactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	result := make(http.Header, len(header))
+
+	for name, values := range header {
+		redacted := make([]string, len(values))
+
+		for i, value := range values {
+			if r.isHeader(name) {
+				redacted[i] = r.replacement()
+			} else {
+				redacted[i] = r.RedactString(value)
+			}
+		}
+
+		result[name] = redacted
+	}
+
+	return result
+}
+
+// RedactBody returns a copy of body with values of JSONKeys and JSONPaths
+// replaced (if body is a valid JSON), and all matches of Patterns replaced.
+//
+// If none of JSONKeys and JSONPaths matched, JSON body is kept as is;
+// otherwise it is re-encoded, preserving numbers and without escaping
+// HTML characters, but without original formatting.
+func (r *Redactor) RedactBody(body []byte) []byte {
+	if (len(r.JSONKeys) != 0 || len(r.JSONPaths) != 0) && json.Valid(body) {
+		if b, ok := r.redactJSONBody(body); ok {
+			body = b
+		}
+	}
+
+	if len(r.Patterns) != 0 {
+		s := r.RedactString(string(body))
+		body = []byte(s)
+	}
+
+	return body
+}
+
+func (r *Redactor) redactJSONBody(body []byte) ([]byte, bool) {
+	var value interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	if err := dec.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	value, matched := r.redactJSON(value)
+	if !matched {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(value); err != nil {
+		return nil, false
+	}
+
+	// Encode appends newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), true
+}
+
+func (r *Redactor) redactRequest(req *http.Request) *http.Request {
+	if req == nil {
+		return nil
+	}
+
+	result := new(http.Request)
+	*result = *req
+
+	result.Header = r.RedactHeader(req.Header)
+
+	if req.URL != nil {
+		if u, err := url.Parse(r.RedactString(req.URL.String())); err == nil {
+			result.URL = u
+		}
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := ioutil.ReadAll(req.Body)
+		body = r.RedactBody(body)
+
+		result.Body = ioutil.NopCloser(bytes.NewReader(body))
+		result.ContentLength = int64(len(body))
+		result.GetBody = nil
+	}
+
+	return result
+}
+
+func (r *Redactor) redactResponse(resp *http.Response) *http.Response {
+	if resp == nil {
+		return nil
+	}
+
+	result := new(http.Response)
+	*result = *resp
+
+	result.Header = r.RedactHeader(resp.Header)
+
+	if resp.Request != nil {
+		// request body was already consumed when the request was sent
+		req := *resp.Request
+		req.Body = nil
+
+		result.Request = r.redactRequest(&req)
+	}
+
+	if resp.Body != nil {
+		body, _ := ioutil.ReadAll(resp.Body)
+		body = r.RedactBody(body)
+
+		result.Body = ioutil.NopCloser(bytes.NewReader(body))
+		result.ContentLength = int64(len(body))
+	}
+
+	return result
+}
+
+func (r *Redactor) redactValue(value interface{}) interface{} {
+	value, _ = r.redactJSON(value)
+	return value
+}
+
+// redactJSON returns redacted copy of value and reports whether any
+// of JSONKeys or JSONPaths matched
+func (r *Redactor) redactJSON(value interface{}) (interface{}, bool) {
+	matched := false
+
+	for _, path := range r.jsonPaths() {
+		value = r.redactPath(value, path, &matched)
+	}
+
+	return r.redactKeys(value, &matched), matched
+}
+
+func (r *Redactor) redactPath(
+	value interface{}, path ignorePath, matched *bool,
+) interface{} {
+	if len(path) == 0 {
+		*matched = true
+		return r.replacement()
+	}
+
+	head, tail := path[0], path[1:]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			if head == ignorePathWildcard || head == key {
+				result[key] = r.redactPath(elem, tail, matched)
+			} else {
+				result[key] = elem
+			}
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			if head == ignorePathWildcard || head == strconv.Itoa(i) {
+				result[i] = r.redactPath(elem, tail, matched)
+			} else {
+				result[i] = elem
+			}
+		}
+		return result
+
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) redactKeys(value interface{}, matched *bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			if r.isJSONKey(key) {
+				*matched = true
+				result[key] = r.replacement()
+			} else {
+				result[key] = r.redactKeys(elem, matched)
+			}
+		}
+		return result
+
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = r.redactKeys(elem, matched)
+		}
+		return result
+
+	case string:
+		return r.RedactString(v)
+
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) isHeader(name string) bool {
+	for _, h := range r.Headers {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) isJSONKey(key string) bool {
+	for _, k := range r.JSONKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) jsonPaths() []ignorePath {
+	paths := make([]ignorePath, 0, len(r.JSONPaths))
+
+	for _, path := range r.JSONPaths {
+		p, err := parseIgnorePath(path)
+		if err != nil {
+			panic(fmt.Sprintf("Redactor.JSONPaths: invalid path %q: %v", path, err))
+		}
+		paths = append(paths, p)
+	}
+
+	return paths
+}
+
+func (r *Redactor) replacement() string {
+	if r.Replacement != "" {
+		return r.Replacement
+	}
+	return defaultRedactorReplacement
+}
@@ -0,0 +1,300 @@
+package httpexpect
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_String(t *testing.T) {
+	redactor := &Redactor{
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`Bearer \S+`),
+			regexp.MustCompile(`api_key=[^&\s]+`),
+		},
+	}
+
+	assert.Equal(t, "foo", redactor.RedactString("foo"))
+	assert.Equal(t, "[REDACTED]", redactor.RedactString("Bearer secret"))
+	assert.Equal(t, "/path?[REDACTED]&a=b",
+		redactor.RedactString("/path?api_key=secret&a=b"))
+
+	redactor.Replacement = "***"
+	assert.Equal(t, "token: ***", redactor.RedactString("token: Bearer secret"))
+}
+
+func TestRedactor_Header(t *testing.T) {
+	redactor := &Redactor{
+		Headers: []string{"authorization", "X-Api-Key"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`session=\w+`),
+		},
+	}
+
+	header := http.Header{
+		"Authorization": {"Bearer secret"},
+		"X-Api-Key":     {"secret1", "secret2"},
+		"Cookie":        {"session=secret; lang=en"},
+		"Accept":        {"text/plain"},
+	}
+
+	redacted := redactor.RedactHeader(header)
+
+	assert.Equal(t, http.Header{
+		"Authorization": {"[REDACTED]"},
+		"X-Api-Key":     {"[REDACTED]", "[REDACTED]"},
+		"Cookie":        {"[REDACTED]; lang=en"},
+		"Accept":        {"text/plain"},
+	}, redacted)
+
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+
+	assert.Nil(t, redactor.RedactHeader(nil))
+}
+
+func TestRedactor_Body(t *testing.T) {
+	redactor := &Redactor{
+		JSONKeys: []string{"password", "Token"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`secret\d+`),
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		body := `{
+			"user": "john",
+			"password": "qwerty",
+			"nested": [{"token": 123}, {"note": "my secret1"}]
+		}`
+
+		assert.JSONEq(t, `{
+			"user": "john",
+			"password": "[REDACTED]",
+			"nested": [{"token": "[REDACTED]"}, {"note": "my [REDACTED]"}]
+		}`, string(redactor.RedactBody([]byte(body))))
+	})
+
+	t.Run("json not matched", func(t *testing.T) {
+		body := `{"id": 9007199254740993, "html": "<b>"}`
+
+		assert.Equal(t, body, string(redactor.RedactBody([]byte(body))))
+	})
+
+	t.Run("json numbers and html", func(t *testing.T) {
+		body := `{"id": 9007199254740993, "html": "<b>", "password": "qwerty"}`
+
+		assert.Equal(t,
+			`{"html":"<b>","id":9007199254740993,"password":"[REDACTED]"}`,
+			string(redactor.RedactBody([]byte(body))))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		assert.Equal(t, "password=qwerty&key=[REDACTED]",
+			string(redactor.RedactBody([]byte("password=qwerty&key=secret2"))))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", string(redactor.RedactBody(nil)))
+	})
+}
+
+func TestRedactor_JSONPaths(t *testing.T) {
+	redactor := &Redactor{
+		JSONPaths: []string{"$.user.token", "sessions[*].id", "keys[1]"},
+	}
+
+	t.Run("body", func(t *testing.T) {
+		body := `{
+			"token": "public",
+			"user": {"name": "john", "token": "secret", "Token": "other"},
+			"sessions": [{"id": "s1", "ip": "1.2.3.4"}, {"id": "s2"}],
+			"keys": ["k0", "k1", "k2"],
+			"nested": {"user": {"token": "public"}}
+		}`
+
+		assert.JSONEq(t, `{
+			"token": "public",
+			"user": {"name": "john", "token": "[REDACTED]", "Token": "other"},
+			"sessions": [{"id": "[REDACTED]", "ip": "1.2.3.4"}, {"id": "[REDACTED]"}],
+			"keys": ["k0", "[REDACTED]", "k2"],
+			"nested": {"user": {"token": "public"}}
+		}`, string(redactor.RedactBody([]byte(body))))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		assert.JSONEq(t, `{"user": "john", "keys": []}`,
+			string(redactor.RedactBody([]byte(`{"user": "john", "keys": []}`))))
+	})
+
+	t.Run("with keys", func(t *testing.T) {
+		redactor := &Redactor{
+			JSONKeys:    []string{"password"},
+			JSONPaths:   []string{"user.token"},
+			Replacement: "***",
+		}
+
+		assert.JSONEq(t,
+			`{"password": "***", "user": {"token": "***", "password": "***"}}`,
+			string(redactor.RedactBody([]byte(
+				`{"password": "a", "user": {"token": "b", "password": "c"}}`))))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		redactor := &Redactor{
+			JSONPaths: []string{"items[x]"},
+		}
+
+		assert.Panics(t, func() {
+			redactor.RedactBody([]byte(`{}`))
+		})
+
+		assert.Panics(t, func() {
+			WithConfig(Config{
+				Reporter: newMockReporter(t),
+				Redactor: redactor,
+			})
+		})
+	})
+}
+
+func TestRedactor_Request(t *testing.T) {
+	redactor := &Redactor{
+		Headers:  []string{"Authorization"},
+		JSONKeys: []string{"password"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`api_key=[^&]+`),
+		},
+	}
+
+	req, err := http.NewRequest("POST", "http://example.com/path?api_key=secret",
+		bytes.NewBufferString(`{"password":"qwerty"}`))
+	require.NoError(t, err)
+
+	req.Header.Set("Authorization", "Bearer secret")
+
+	redacted := redactor.redactRequest(req)
+
+	assert.Equal(t, "http://example.com/path?[REDACTED]", redacted.URL.String())
+	assert.Equal(t, "[REDACTED]", redacted.Header.Get("Authorization"))
+
+	body, _ := ioutil.ReadAll(redacted.Body)
+	assert.Equal(t, `{"password":"[REDACTED]"}`, string(body))
+	assert.Equal(t, int64(len(body)), redacted.ContentLength)
+
+	assert.Equal(t, "http://example.com/path?api_key=secret", req.URL.String())
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+	assert.Nil(t, redactor.redactRequest(nil))
+}
+
+func TestRedactor_Response(t *testing.T) {
+	redactor := &Redactor{
+		Headers:  []string{"Set-Cookie"},
+		JSONKeys: []string{"token"},
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Set-Cookie":   {"session=secret"},
+			"Content-Type": {"application/json"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString(`{"token":"secret"}`)),
+	}
+
+	redacted := redactor.redactResponse(resp)
+
+	assert.Equal(t, http.StatusOK, redacted.StatusCode)
+	assert.Equal(t, "[REDACTED]", redacted.Header.Get("Set-Cookie"))
+	assert.Equal(t, "application/json", redacted.Header.Get("Content-Type"))
+
+	body, _ := ioutil.ReadAll(redacted.Body)
+	assert.Equal(t, `{"token":"[REDACTED]"}`, string(body))
+
+	assert.Equal(t, "session=secret", resp.Header.Get("Set-Cookie"))
+
+	assert.Nil(t, redactor.redactResponse(nil))
+}
+
+func TestRedactor_Printers(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"password":"qwerty","user":"john"}`, string(b))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token":"secret"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	printer := &mockPrinter{}
+
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
+		Printers: []Printer{
+			printer,
+		},
+		Redactor: &Redactor{
+			Headers:  []string{"Authorization"},
+			JSONKeys: []string{"password", "token"},
+		},
+	})
+
+	e.POST("/login").
+		WithHeader("Authorization", "Bearer secret").
+		WithJSON(map[string]string{"user": "john", "password": "qwerty"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().ValueEqual("token", "secret")
+
+	assert.Equal(t, `{"password":"[REDACTED]","user":"john"}`, string(printer.reqBody))
+	assert.Equal(t, `{"token":"[REDACTED]"}`, string(printer.respBody))
+}
+
+func TestRedactor_Formatter(t *testing.T) {
+	formatter := &DefaultFormatter{
+		Redactor: &Redactor{
+			JSONKeys: []string{"token"},
+			Patterns: []*regexp.Regexp{
+				regexp.MustCompile(`Bearer \S+`),
+			},
+		},
+	}
+
+	ctx := &AssertionContext{}
+
+	failure := &AssertionFailure{
+		Type: AssertEqual,
+		Actual: &AssertionValue{
+			map[string]interface{}{"token": "secret1", "auth": "Bearer secret2"},
+		},
+		Expected: &AssertionValue{
+			map[string]interface{}{"token": "secret3", "auth": "Bearer secret4"},
+		},
+		Errors: []error{
+			assert.AnError,
+		},
+	}
+
+	msg := formatter.FormatFailure(ctx, failure)
+
+	assert.Contains(t, msg, "[REDACTED]")
+	assert.NotContains(t, msg, "secret1")
+	assert.NotContains(t, msg, "secret2")
+	assert.NotContains(t, msg, "secret3")
+	assert.NotContains(t, msg, "secret4")
+
+	assert.Equal(t, "secret1",
+		failure.Actual.Value.(map[string]interface{})["token"])
+}
@@ -0,0 +1,279 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"sync"
+)
+
+// AssertionReport implements AssertionHandler interface by recording every
+// executed assertion and forwarding it to another AssertionHandler.
+//
+// Recorded assertions may be exported as JUnit-style XML or as JSON, e.g.
+// for dashboards that track API contract coverage separately from go test
+// output. Assertions are grouped by test name (see Config.TestName).
+//
+// Failures with SeverityLog (e.g. from predicates of Array.Filter) are only
+// forwarded and are not recorded.
+//
+// Example:
+//
+//	report := httpexpect.NewAssertionReport(&httpexpect.DefaultAssertionHandler{
+//		Formatter: &httpexpect.DefaultFormatter{},
+//		Reporter:  t,
+//	})
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		TestName:         t.Name(),
+//		BaseURL:          "http://example.com",
+//		AssertionHandler: report,
+//	})
+//
+//	e.GET("/users").Expect().Status(http.StatusOK)
+//
+//	f, _ := os.Create("report.xml")
+//	defer f.Close()
+//
+//	report.WriteJUnit(f)
+type AssertionReport struct {
+	mu        sync.Mutex
+	backend   AssertionHandler
+	formatter Formatter
+	records   []AssertionRecord
+}
+
+// AssertionRecord describes single assertion recorded by AssertionReport.
+type AssertionRecord struct {
+	// Name of the running test, empty if not set
+	TestName string `json:"test_name,omitempty"`
+
+	// Name of request, empty if not set
+	RequestName string `json:"request_name,omitempty"`
+
+	// Assertion path joined with dots
+	// Example value:
+	//   `Request("GET", "/path").Expect().JSON().NotNull()`
+	Path string `json:"path"`
+
+	// Whether assertion failed
+	Failed bool `json:"failed"`
+
+	// Type of failed assertion, empty for succeeded assertion
+	FailureType string `json:"failure_type,omitempty"`
+
+	// Formatted failure message, empty for succeeded assertion
+	FailureMessage string `json:"failure_message,omitempty"`
+}
+
+// NewAssertionReport returns a new AssertionReport object.
+//
+// If backend is nil, the function panics.
+func NewAssertionReport(backend AssertionHandler) *AssertionReport {
+	if backend == nil {
+		panic("AssertionHandler is nil")
+	}
+
+	return &AssertionReport{
+		backend:   backend,
+		formatter: &DefaultFormatter{DisableNames: true, LineWidth: -1},
+	}
+}
+
+// Success implements AssertionHandler.Success.
+func (r *AssertionReport) Success(ctx *AssertionContext) {
+	r.record(AssertionRecord{
+		TestName:    ctx.TestName,
+		RequestName: ctx.RequestName,
+		Path:        strings.Join(ctx.Path, "."),
+	})
+
+	r.backend.Success(ctx)
+}
+
+// Failure implements AssertionHandler.Failure.
+func (r *AssertionReport) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	if failure.Severity == SeverityError {
+		msg := r.formatter.FormatFailure(ctx, failure)
+
+		r.record(AssertionRecord{
+			TestName:       ctx.TestName,
+			RequestName:    ctx.RequestName,
+			Path:           strings.Join(ctx.Path, "."),
+			Failed:         true,
+			FailureType:    failure.Type.String(),
+			FailureMessage: strings.TrimSpace(msg),
+		})
+	}
+
+	r.backend.Failure(ctx, failure)
+}
+
+func (r *AssertionReport) record(rec AssertionRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, rec)
+}
+
+// Records returns all recorded assertions, in order of execution.
+func (r *AssertionReport) Records() []AssertionRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]AssertionRecord(nil), r.records...)
+}
+
+type reportTest struct {
+	name    string
+	records []AssertionRecord
+}
+
+// group records by test name, preserving order of first appearance
+func (r *AssertionReport) tests() []reportTest {
+	var tests []reportTest
+
+	index := map[string]int{}
+
+	for _, rec := range r.Records() {
+		n, ok := index[rec.TestName]
+		if !ok {
+			n = len(tests)
+			index[rec.TestName] = n
+			tests = append(tests, reportTest{name: rec.TestName})
+		}
+		tests[n].records = append(tests[n].records, rec)
+	}
+
+	return tests
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes recorded assertions to w in JUnit XML format.
+//
+// Every test becomes a test suite, and every assertion becomes a test case,
+// named by assertion path. Request name, if set, is used as class name.
+func (r *AssertionReport) WriteJUnit(w io.Writer) error {
+	report := junitTestSuites{}
+
+	for _, test := range r.tests() {
+		suite := junitTestSuite{
+			Name:  test.name,
+			Tests: len(test.records),
+		}
+
+		for _, rec := range test.records {
+			tc := junitTestCase{
+				Name:      rec.Path,
+				ClassName: rec.RequestName,
+			}
+
+			if rec.Failed {
+				message := rec.FailureMessage
+				if n := strings.IndexByte(message, '\n'); n >= 0 {
+					message = message[:n]
+				}
+
+				tc.Failure = &junitFailure{
+					Type:    rec.FailureType,
+					Message: message,
+					Text:    rec.FailureMessage,
+				}
+				suite.Failures++
+			}
+
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type jsonReport struct {
+	Tests    int              `json:"tests"`
+	Failures int              `json:"failures"`
+	Suites   []jsonReportTest `json:"suites"`
+}
+
+type jsonReportTest struct {
+	Name       string            `json:"name"`
+	Tests      int               `json:"tests"`
+	Failures   int               `json:"failures"`
+	Assertions []AssertionRecord `json:"assertions"`
+}
+
+// WriteJSON writes recorded assertions to w in JSON format.
+//
+// Assertions are grouped by test name, same way as in WriteJUnit.
+func (r *AssertionReport) WriteJSON(w io.Writer) error {
+	report := jsonReport{
+		Suites: []jsonReportTest{},
+	}
+
+	for _, test := range r.tests() {
+		suite := jsonReportTest{
+			Name:       test.name,
+			Tests:      len(test.records),
+			Assertions: test.records,
+		}
+
+		for _, rec := range test.records {
+			if rec.Failed {
+				suite.Failures++
+			}
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(report)
+}
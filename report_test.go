@@ -0,0 +1,161 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertionReport_Constructor(t *testing.T) {
+	assert.Panics(t, func() {
+		NewAssertionReport(nil)
+	})
+}
+
+func TestAssertionReport_Record(t *testing.T) {
+	backend := &mockAssertionHandler{}
+	report := NewAssertionReport(backend)
+
+	successCtx := &AssertionContext{
+		TestName: "TestFoo",
+		Path:     []string{"Request()", "Expect()"},
+	}
+
+	report.Success(successCtx)
+	assert.Same(t, successCtx, backend.ctx)
+
+	failureCtx := &AssertionContext{
+		TestName:    "TestFoo",
+		RequestName: "get users",
+		Path:        []string{"Request()", "Expect()", "Status()"},
+	}
+	failure := &AssertionFailure{
+		Type:     AssertEqual,
+		Severity: SeverityError,
+		Actual:   &AssertionValue{404},
+		Expected: &AssertionValue{200},
+		Errors:   []error{errors.New("expected: status codes are equal")},
+	}
+
+	report.Failure(failureCtx, failure)
+	assert.Same(t, failureCtx, backend.ctx)
+	assert.Same(t, failure, backend.failure)
+
+	logFailure := &AssertionFailure{
+		Type:     AssertValid,
+		Severity: SeverityLog,
+		Actual:   &AssertionValue{nil},
+	}
+
+	report.Failure(failureCtx, logFailure)
+	assert.Same(t, logFailure, backend.failure)
+
+	records := report.Records()
+	require.Equal(t, 2, len(records))
+
+	assert.Equal(t, AssertionRecord{
+		TestName: "TestFoo",
+		Path:     "Request().Expect()",
+	}, records[0])
+
+	assert.Equal(t, "TestFoo", records[1].TestName)
+	assert.Equal(t, "get users", records[1].RequestName)
+	assert.Equal(t, "Request().Expect().Status()", records[1].Path)
+	assert.True(t, records[1].Failed)
+	assert.Equal(t, "AssertEqual", records[1].FailureType)
+	assert.Contains(t, records[1].FailureMessage,
+		"expected: status codes are equal")
+}
+
+func TestAssertionReport_Write(t *testing.T) {
+	report := NewAssertionReport(&mockAssertionHandler{})
+
+	report.Success(&AssertionContext{
+		TestName: "TestFoo",
+		Path:     []string{"foo"},
+	})
+	report.Success(&AssertionContext{
+		TestName: "TestBar",
+		Path:     []string{"bar"},
+	})
+	report.Failure(&AssertionContext{
+		TestName:    "TestFoo",
+		RequestName: "req",
+		Path:        []string{"foo", "baz"},
+	}, &AssertionFailure{
+		Type:     AssertOperation,
+		Severity: SeverityError,
+		Errors:   []error{errors.New("failed <baz>")},
+	})
+
+	t.Run("junit", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := report.WriteJUnit(&buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1">
+  <testsuite name="TestFoo" tests="2" failures="1">
+    <testcase name="foo"></testcase>
+    <testcase name="foo.baz" classname="req">
+      <failure type="AssertOperation" message="failed &lt;baz&gt;">`+
+			`failed &lt;baz&gt;&#xA;&#xA;assertion:&#xA;  foo.baz</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="TestBar" tests="1" failures="0">
+    <testcase name="bar"></testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := report.WriteJSON(&buf)
+		require.NoError(t, err)
+
+		var decoded struct {
+			Tests    int
+			Failures int
+			Suites   []struct {
+				Name       string
+				Tests      int
+				Failures   int
+				Assertions []AssertionRecord
+			}
+		}
+
+		err = json.Unmarshal(buf.Bytes(), &decoded)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, decoded.Tests)
+		assert.Equal(t, 1, decoded.Failures)
+		require.Equal(t, 2, len(decoded.Suites))
+
+		assert.Equal(t, "TestFoo", decoded.Suites[0].Name)
+		assert.Equal(t, 2, decoded.Suites[0].Tests)
+		assert.Equal(t, 1, decoded.Suites[0].Failures)
+		assert.Equal(t, "foo.baz", decoded.Suites[0].Assertions[1].Path)
+		assert.True(t, decoded.Suites[0].Assertions[1].Failed)
+
+		assert.Equal(t, "TestBar", decoded.Suites[1].Name)
+		assert.Equal(t, 1, decoded.Suites[1].Tests)
+		assert.Equal(t, 0, decoded.Suites[1].Failures)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := NewAssertionReport(&mockAssertionHandler{}).WriteJSON(&buf)
+		require.NoError(t, err)
+
+		assert.JSONEq(t,
+			`{"tests": 0, "failures": 0, "suites": []}`, buf.String())
+	})
+}
@@ -2,6 +2,8 @@ package httpexpect
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,3 +40,107 @@ func NewRequireReporter(t require.TestingT) *RequireReporter {
 func (r *RequireReporter) Errorf(message string, args ...interface{}) {
 	r.backend.FailNow(fmt.Sprintf(message, args...))
 }
+
+// FatalReporter implements Reporter interface using Errorf and FailNow
+// methods of *testing.T or similar type, like t.Fatalf does. Failures are
+// fatal with this reporter.
+//
+// Unlike RequireReporter, FatalReporter doesn't add testify error trace to
+// failure message. To get non-fatal failures without testify formatting,
+// *testing.T may be used as Reporter directly.
+type FatalReporter struct {
+	backend require.TestingT
+}
+
+// NewFatalReporter returns a new FatalReporter object.
+func NewFatalReporter(t require.TestingT) *FatalReporter {
+	return &FatalReporter{t}
+}
+
+// Errorf implements Reporter.Errorf.
+func (r *FatalReporter) Errorf(message string, args ...interface{}) {
+	r.backend.Errorf(message, args...)
+	r.backend.FailNow()
+}
+
+// BufferedReporter implements Reporter interface by collecting failures
+// and forwarding them to another reporter all at once, when Flush is called.
+//
+// It can be used to see all mismatches in a large response in a single
+// run, even if underlying reporter is fatal, like RequireReporter.
+//
+// Note that BufferedReporter doesn't change how chains work: once an
+// assertion fails, following assertions on the same object are skipped.
+// To get all mismatches, check each field using its own chain, e.g.
+// object.Value("a").Equal(1) and object.Value("b").Equal(2) instead
+// of object.ValueEqual("a", 1).ValueEqual("b", 2).
+//
+// Example:
+//
+//	reporter := httpexpect.NewBufferedReporter(httpexpect.NewRequireReporter(t))
+//	defer reporter.Flush()
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://example.com",
+//		Reporter: reporter,
+//	})
+type BufferedReporter struct {
+	mu       sync.Mutex
+	backend  Reporter
+	messages []string
+}
+
+// NewBufferedReporter returns a new BufferedReporter object.
+//
+// If backend is nil, the function panics.
+func NewBufferedReporter(backend Reporter) *BufferedReporter {
+	if backend == nil {
+		panic("Reporter is nil")
+	}
+
+	return &BufferedReporter{backend: backend}
+}
+
+// Errorf implements Reporter.Errorf.
+// It saves the message until next Flush call.
+func (r *BufferedReporter) Errorf(message string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, fmt.Sprintf(message, args...))
+}
+
+// Failures returns messages collected since last Flush call.
+func (r *BufferedReporter) Failures() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.messages...)
+}
+
+// Flush reports all collected messages to backend reporter as a single
+// failure, and clears the buffer. Does nothing if there were no failures.
+func (r *BufferedReporter) Flush() {
+	r.mu.Lock()
+	messages := r.messages
+	r.messages = nil
+	r.mu.Unlock()
+
+	if len(messages) == 0 {
+		return
+	}
+
+	if len(messages) == 1 {
+		r.backend.Errorf("%s", messages[0])
+		return
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d assertions failed:", len(messages))
+	for n, msg := range messages {
+		fmt.Fprintf(&b, "\n\n[%d/%d]\n%s", n+1, len(messages), msg)
+	}
+
+	r.backend.Errorf("%s", b.String())
+}
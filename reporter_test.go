@@ -0,0 +1,126 @@
+package httpexpect
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTestingT struct {
+	errors []string
+	failed bool
+}
+
+func (t *mockTestingT) Errorf(message string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(message, args...))
+}
+
+func (t *mockTestingT) FailNow() {
+	t.failed = true
+}
+
+func TestReporter_Fatal(t *testing.T) {
+	backend := &mockTestingT{}
+	reporter := NewFatalReporter(backend)
+
+	reporter.Errorf("foo %d", 1)
+
+	assert.Equal(t, []string{"foo 1"}, backend.errors)
+	assert.True(t, backend.failed)
+}
+
+func TestReporter_Buffered(t *testing.T) {
+	t.Run("no failures", func(t *testing.T) {
+		backend := &recordingReporter{}
+		reporter := NewBufferedReporter(backend)
+
+		reporter.Flush()
+
+		assert.Empty(t, reporter.Failures())
+		assert.Equal(t, "", backend.reported)
+	})
+
+	t.Run("single failure", func(t *testing.T) {
+		backend := &recordingReporter{}
+		reporter := NewBufferedReporter(backend)
+
+		reporter.Errorf("foo %d", 1)
+
+		assert.Equal(t, []string{"foo 1"}, reporter.Failures())
+		assert.Equal(t, "", backend.reported)
+
+		reporter.Flush()
+
+		assert.Empty(t, reporter.Failures())
+		assert.Equal(t, "foo 1", backend.reported)
+	})
+
+	t.Run("multiple failures", func(t *testing.T) {
+		backend := &recordingReporter{}
+		reporter := NewBufferedReporter(backend)
+
+		reporter.Errorf("foo")
+		reporter.Errorf("bar %s", "%d")
+
+		assert.Equal(t, "", backend.reported)
+
+		reporter.Flush()
+
+		assert.Equal(t,
+			"2 assertions failed:\n\n[1/2]\nfoo\n\n[2/2]\nbar %d",
+			backend.reported)
+
+		backend.reported = ""
+		reporter.Flush()
+
+		assert.Equal(t, "", backend.reported)
+	})
+
+	t.Run("all assertions are run", func(t *testing.T) {
+		backend := &recordingReporter{}
+		reporter := NewBufferedReporter(backend)
+
+		object := NewObject(reporter, map[string]interface{}{
+			"a": 1,
+			"b": "foo",
+			"c": true,
+		})
+
+		object.Value("a").Number().Equal(2)
+		object.Value("b").String().Equal("bar")
+		object.Value("c").Boolean().True()
+
+		assert.Equal(t, 2, len(reporter.Failures()))
+
+		reporter.Flush()
+
+		assert.Contains(t, backend.reported, "2 assertions failed")
+		assert.Contains(t, backend.reported, "Value(\"a\")")
+		assert.Contains(t, backend.reported, "Value(\"b\")")
+	})
+
+	t.Run("chained assertions stop at first failure", func(t *testing.T) {
+		backend := &recordingReporter{}
+		reporter := NewBufferedReporter(backend)
+
+		object := NewObject(reporter, map[string]interface{}{
+			"a": 1,
+			"b": 2,
+			"c": 3,
+		})
+
+		object.
+			ValueEqual("a", 10).
+			ValueEqual("b", 20).
+			ValueEqual("c", 30)
+
+		assert.Equal(t, 1, len(reporter.Failures()))
+	})
+
+	t.Run("nil backend", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewBufferedReporter(nil)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,10 +12,12 @@ import (
 	"mime/multipart"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"os"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +26,7 @@ import (
 	"github.com/google/go-querystring/query"
 	"github.com/gorilla/websocket"
 	"github.com/imkira/go-interpol"
+	"gopkg.in/yaml.v2"
 )
 
 // Request provides methods to incrementally build http.Request object,
@@ -34,15 +38,21 @@ type Request struct {
 
 	redirectPolicy RedirectPolicy
 	maxRedirects   int
+	redirects      []*http.Response
 
 	retryPolicy   RetryPolicy
 	maxRetries    int
 	minRetryDelay time.Duration
 	maxRetryDelay time.Duration
+	maxRetryAfter time.Duration
 	sleepFn       func(d time.Duration) <-chan time.Time
 
 	timeout time.Duration
 
+	// transport created for this request by WithUnixSocket,
+	// WithTLSConfig, WithClientCert, or WithProxy
+	transport *http.Transport
+
 	httpReq *http.Request
 	path    string
 	query   url.Values
@@ -58,6 +68,14 @@ type Request struct {
 
 	wsUpgrade bool
 
+	awsSigner *awsSigner
+
+	trace *responseTrace
+
+	traceCtx *traceContext
+
+	download *responseDownload
+
 	transforms []func(*http.Request)
 	matchers   []func(*Response)
 }
@@ -94,11 +112,15 @@ func NewRequest(config Config, method, path string, pathargs ...interface{}) *Re
 // After interpolation, path is urlencoded and appended to Config.BaseURL,
 // separated by slash. If BaseURL ends with a slash and path (after interpolation)
 // starts with a slash, only single slash is inserted.
+//
+// Failure messages refer to the request using the original path template,
+// e.g. Request("POST", "/repos/{user}/{repo}"), so that the same template used
+// with different arguments is reported uniformly.
 func NewRequestC(config Config, method, path string, pathargs ...interface{}) *Request {
 	config = config.withDefaults()
 
 	return newRequest(
-		newChainWithConfig(fmt.Sprintf("Request(%q)", method), config),
+		newChainWithConfig(fmt.Sprintf("Request(%q, %q)", method, path), config),
 		config,
 		method,
 		path,
@@ -374,6 +396,277 @@ func (r *Request) WithHandler(handler http.Handler) *Request {
 	return r
 }
 
+// WithUnixSocket configures client and websocket dialer to connect to the
+// given unix domain socket instead of host from request URL.
+//
+// If Config.Client is http.Client, then only its Transport field is overwritten
+// because the client may contain some state shared among requests like a cookie
+// jar. Otherwise, the whole client is overwritten with a new client.
+// Same applies to Config.WebsocketDialer and websocket.Dialer.
+//
+// Host part of the URL is still sent in Host header, so it may be set to
+// any value expected by server.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://localhost/containers/json")
+//	req.WithUnixSocket("/var/run/docker.sock")
+func (r *Request) WithUnixSocket(path string) *Request {
+	opChain := r.chain.enter("WithUnixSocket()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithUnixSocket()") {
+		return r
+	}
+
+	if path == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty socket path"),
+			},
+		})
+		return r
+	}
+
+	r.transport = NewUnixSocketTransport(path)
+
+	if client, ok := r.config.Client.(*http.Client); ok {
+		clientCopy := *client
+		clientCopy.Transport = r.transport
+		r.config.Client = &clientCopy
+	} else {
+		r.config.Client = &http.Client{
+			Transport: r.transport,
+			Jar:       NewCookieJar(),
+		}
+	}
+
+	if dialer, ok := r.config.WebsocketDialer.(*websocket.Dialer); ok {
+		dialerCopy := *dialer
+		dialerCopy.NetDial = nil
+		dialerCopy.NetDialContext = unixSocketDialContext(path)
+		r.config.WebsocketDialer = &dialerCopy
+	} else {
+		r.config.WebsocketDialer = NewUnixSocketDialer(path)
+	}
+
+	return r
+}
+
+// WithTLSConfig sets TLS config used by client and websocket dialer.
+//
+// Config.Client should be http.Client with nil Transport or http.Transport.
+// Only its Transport is overwritten with a copy that uses given TLS config,
+// because the client may contain some state shared among requests like
+// a cookie jar. If Config.WebsocketDialer is websocket.Dialer, it's copied
+// and updated in the same way.
+//
+// Example:
+//
+//	pool, _ := LoadCertPool("testdata/ca.pem")
+//
+//	req := NewRequestC(config, "GET", "https://example.com/path")
+//	req.WithTLSConfig(&tls.Config{RootCAs: pool})
+func (r *Request) WithTLSConfig(config *tls.Config) *Request {
+	opChain := r.chain.enter("WithTLSConfig()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithTLSConfig()") {
+		return r
+	}
+
+	if config == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	r.setTLSConfig(opChain, func(*tls.Config) *tls.Config {
+		return config.Clone()
+	})
+
+	return r
+}
+
+// WithClientCert loads client certificate and private key from given
+// PEM files and adds it to TLS config used by client and websocket dialer,
+// to be presented to server requiring mutual TLS authentication.
+//
+// Requirements for Config.Client are same as for WithTLSConfig. Other
+// settings of client's TLS config, if any, are preserved.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "https://example.com/path")
+//	req.WithClientCert("testdata/client.pem", "testdata/client-key.pem")
+func (r *Request) WithClientCert(certFile, keyFile string) *Request {
+	opChain := r.chain.enter("WithClientCert()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithClientCert()") {
+		return r
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to load client certificate"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setTLSConfig(opChain, func(config *tls.Config) *tls.Config {
+		if config == nil {
+			config = &tls.Config{}
+		} else {
+			config = config.Clone()
+		}
+		config.Certificates = append(config.Certificates, cert)
+		return config
+	})
+
+	return r
+}
+
+func (r *Request) setTLSConfig(
+	opChain *chain, update func(*tls.Config) *tls.Config,
+) {
+	client, ok := r.config.Client.(*http.Client)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("client should be http.Client to configure TLS"),
+			},
+		})
+		return
+	}
+
+	transport, err := tlsTransport(client)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				err,
+			},
+		})
+		return
+	}
+
+	transport.TLSClientConfig = update(transport.TLSClientConfig)
+
+	r.transport = transport
+
+	clientCopy := *client
+	clientCopy.Transport = transport
+	r.config.Client = &clientCopy
+
+	if dialer, ok := r.config.WebsocketDialer.(*websocket.Dialer); ok {
+		dialerCopy := *dialer
+		dialerCopy.TLSClientConfig = update(dialer.TLSClientConfig)
+		r.config.WebsocketDialer = &dialerCopy
+	}
+}
+
+// WithProxy sets proxy used by client and websocket dialer.
+//
+// Proxy URL scheme should be "http", "https", or "socks5". Unlike
+// Config.Proxy, NO_PROXY list is not applied: proxy is used for the
+// request regardless of its host.
+//
+// Requirements for Config.Client are same as for WithTLSConfig. Only its
+// Transport is overwritten with a copy that uses given proxy. If
+// Config.WebsocketDialer is websocket.Dialer, it's copied and updated in
+// the same way.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithProxy("http://localhost:8080")
+func (r *Request) WithProxy(proxyURL string) *Request {
+	opChain := r.chain.enter("WithProxy()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithProxy()") {
+		return r
+	}
+
+	proxy, err := newProxySelector(proxyURL, nil)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid proxy URL"),
+				err,
+			},
+		})
+		return r
+	}
+
+	client, ok := r.config.Client.(*http.Client)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("client should be http.Client to configure proxy"),
+			},
+		})
+		return r
+	}
+
+	transport, err := proxyTransport(client)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				err,
+			},
+		})
+		return r
+	}
+
+	transport.Proxy = proxy.proxyFunc
+
+	r.transport = transport
+
+	clientCopy := *client
+	clientCopy.Transport = transport
+	r.config.Client = &clientCopy
+
+	if dialer, ok := r.config.WebsocketDialer.(*websocket.Dialer); ok {
+		dialerCopy := *dialer
+		dialerCopy.Proxy = proxy.proxyFunc
+		r.config.WebsocketDialer = &dialerCopy
+	}
+
+	return r
+}
+
 // WithContext sets the context.
 //
 // Config.Context will be overwritten.
@@ -446,6 +739,58 @@ func (r *Request) WithTimeout(timeout time.Duration) *Request {
 	return r
 }
 
+// WithDownload enables streaming of response body to file with given path.
+//
+// Body is written to file while it's being received, and SHA-256 checksum
+// is computed on the fly, without reading the whole body into memory.
+// This is useful for large artifacts. Use Response.DownloadedLength and
+// Response.DownloadedSHA256 to check written data.
+//
+// Since body is not kept in memory, response body is empty, and printers
+// don't see it. For the same reason, Config.Redactor is not applied to it:
+// file contains body exactly as received. If server sends Content-Encoding
+// that was not requested automatically by http.Transport, body is not
+// decoded.
+//
+// Body is first written to a temporary file in the same directory. If
+// request is retried (see WithRetryPolicy), file of the retried attempt is
+// removed, and only body of the last attempt is moved to given path. If
+// file already exists, it is replaced.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/artifacts/build.tar.gz")
+//	req.WithDownload("/tmp/build.tar.gz")
+//	resp := req.Expect()
+//	resp.DownloadedLength().Gt(0)
+//	resp.DownloadedSHA256().Equal(expectedChecksum)
+func (r *Request) WithDownload(path string) *Request {
+	opChain := r.chain.enter("WithDownload()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithDownload()") {
+		return r
+	}
+
+	if path == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty download path"),
+			},
+		})
+		return r
+	}
+
+	r.download = newResponseDownload(path)
+
+	return r
+}
+
 // RedirectPolicy defines how redirection responses are handled.
 //
 // Status codes 307, 308 require resending body. They are followed only if
@@ -579,6 +924,15 @@ const (
 
 	// RetryAllErrors enables retrying of any error or 4xx/5xx status code.
 	RetryAllErrors
+
+	// RetryTimeoutErrors enables retrying only timeout errors.
+	// Retry happens if Client returns net.Error and its Timeout() method
+	// returns true, e.g. when request timeout set by WithTimeout() expires.
+	RetryTimeoutErrors
+
+	// RetryTimeoutAndServerErrors enables retrying of timeout errors,
+	// as well as 5xx and 429 (Too Many Requests) status codes.
+	RetryTimeoutAndServerErrors
 )
 
 // WithRetryPolicy sets policy for retries.
@@ -701,6 +1055,53 @@ func (r *Request) WithRetryDelay(minDelay, maxDelay time.Duration) *Request {
 	return r
 }
 
+// WithRetryAfter enables retrying after delay requested by server via
+// Retry-After header.
+//
+// If response has 429 (Too Many Requests) or 503 (Service Unavailable)
+// status code and valid Retry-After header, request is retried after the
+// requested delay, regardless of retry policy and WithRetryDelay(). If the
+// requested delay exceeds maxWait, request is not retried and response
+// is returned as is.
+//
+// How much retry attempts happens is still defined by WithMaxRetries().
+//
+// By default, Retry-After header is ignored.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "/path")
+//	req.WithMaxRetries(3)
+//	req.WithRetryAfter(10 * time.Second)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithRetryAfter(maxWait time.Duration) *Request {
+	opChain := r.chain.enter("WithRetryAfter()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRetryAfter()") {
+		return r
+	}
+
+	if maxWait <= 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{maxWait},
+			Errors: []error{
+				errors.New("invalid non-positive argument"),
+			},
+		})
+		return r
+	}
+
+	r.maxRetryAfter = maxWait
+
+	return r
+}
+
 // WithWebsocketUpgrade enables upgrades the connection to websocket.
 //
 // At least the following fields are added to the request header:
@@ -1186,16 +1587,146 @@ func (r *Request) WithHeader(k, v string) *Request {
 	return r
 }
 
-func (r *Request) withHeader(k, v string) {
-	switch http.CanonicalHeaderKey(k) {
-	case "Host":
-		r.httpReq.Host = v
+// WithHeaderFromEnv adds given single header to request, with value
+// built from template, where "{key}" placeholders are replaced with
+// values stored in environment (see Expect.Env and Value.Store).
+//
+// If environment doesn't contain a key, failure is reported.
+//
+// Example:
+//
+//	e.POST("/login").
+//		Expect().
+//		JSON().Path("$.token").Store("token")
+//
+//	e.GET("/profile").
+//		WithHeaderFromEnv("Authorization", "Bearer {token}").
+//		Expect().
+//		Status(http.StatusOK)
+func (r *Request) WithHeaderFromEnv(k, template string) *Request {
+	opChain := r.chain.enter("WithHeaderFromEnv()")
+	defer opChain.leave()
 
-	case "Content-Type":
-		if !r.forceType {
-			delete(r.httpReq.Header, "Content-Type")
-		}
-		r.forceType = true
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithHeaderFromEnv()") {
+		return r
+	}
+
+	v, ok := envExpand(opChain, opChain.env(), template)
+	if !ok {
+		return r
+	}
+
+	r.withHeader(k, v)
+
+	return r
+}
+
+// WithTraceContext adds W3C Trace Context "traceparent" header with newly
+// generated trace identifier to request.
+//
+// Backends instrumented with OpenTelemetry or similar tools continue the
+// trace from this header, so a failing request can be correlated with
+// backend traces. The header is shown by printers and, when
+// DefaultFormatter.EnableDumps is set, in failure messages.
+//
+// Every attempt of request gets its own span identifier. If Config.Tracer
+// is set, it receives span of every attempt, which allows to export spans
+// of test requests to the same tracing system.
+//
+// If "traceparent" header is already set, it is sent as is, and (if header
+// is valid) its trace and span identifiers are used for reported spans.
+// To continue a trace of the test itself, use WithTraceParent instead.
+//
+// To add trace context to every request, use a builder (see Expect.Builder).
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithTraceContext()
+func (r *Request) WithTraceContext() *Request {
+	opChain := r.chain.enter("WithTraceContext()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithTraceContext()") {
+		return r
+	}
+
+	if r.traceCtx != nil {
+		return r
+	}
+
+	if header := r.httpReq.Header.Get(traceparentHeader); header != "" {
+		if tc, err := parseTraceparent(header); err == nil {
+			tc.spanID, tc.parentSpanID = tc.parentSpanID, ""
+			r.traceCtx = tc
+		}
+		return r
+	}
+
+	r.traceCtx = newTraceContext()
+
+	return r
+}
+
+// WithTraceParent is like WithTraceContext, but request continues trace
+// of given parent span instead of starting a new trace.
+//
+// traceparent should be a valid W3C Trace Context "traceparent" header
+// value, e.g. obtained from the span of the test. Request is sent with
+// the same trace identifier and flags, and with a new span identifier;
+// spans reported to Config.Tracer have ParentSpanID set to parent span.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	req.WithTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+func (r *Request) WithTraceParent(traceparent string) *Request {
+	opChain := r.chain.enter("WithTraceParent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithTraceParent()") {
+		return r
+	}
+
+	tc, err := parseTraceparent(traceparent)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected invalid traceparent argument"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.traceCtx = tc
+
+	return r
+}
+
+func (r *Request) withHeader(k, v string) {
+	switch http.CanonicalHeaderKey(k) {
+	case "Host":
+		r.httpReq.Host = v
+
+	case "Content-Type":
+		if !r.forceType {
+			delete(r.httpReq.Header, "Content-Type")
+		}
+		r.forceType = true
 		r.typeSetter = "WithHeader()"
 		r.httpReq.Header.Add(k, v)
 
@@ -1204,6 +1735,167 @@ func (r *Request) withHeader(k, v string) {
 	}
 }
 
+// WithIfNoneMatch adds "If-None-Match" header with given entity tag
+// to request.
+//
+// If etag is not quoted, quotes are added. Weak tags (W/"...") and
+// "*" are passed as is.
+//
+// Example:
+//
+//	etag := e.GET("/fruits/apple").Expect().ETag().Raw()
+//
+//	e.GET("/fruits/apple").
+//		WithIfNoneMatch(etag).
+//		Expect().
+//		Status(http.StatusNotModified)
+func (r *Request) WithIfNoneMatch(etag string) *Request {
+	opChain := r.chain.enter("WithIfNoneMatch()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithIfNoneMatch()") {
+		return r
+	}
+
+	switch {
+	case etag == "*":
+	case strings.HasPrefix(etag, `"`), strings.HasPrefix(etag, `W/"`):
+	default:
+		etag = `"` + etag + `"`
+	}
+
+	r.httpReq.Header.Set("If-None-Match", etag)
+
+	return r
+}
+
+// WithIfModifiedSince adds "If-Modified-Since" header with given time
+// to request. Time is formatted as HTTP date in UTC.
+//
+// Example:
+//
+//	e.GET("/fruits/apple").
+//		WithIfModifiedSince(time.Now()).
+//		Expect().
+//		Status(http.StatusNotModified)
+func (r *Request) WithIfModifiedSince(t time.Time) *Request {
+	opChain := r.chain.enter("WithIfModifiedSince()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithIfModifiedSince()") {
+		return r
+	}
+
+	r.httpReq.Header.Set("If-Modified-Since", t.UTC().Format(http.TimeFormat))
+
+	return r
+}
+
+// WithCORSPreflight adds headers of CORS preflight request: "Origin",
+// "Access-Control-Request-Method", and, if headers are given,
+// "Access-Control-Request-Headers".
+//
+// Preflight request should use OPTIONS method. Use Response.CORS to
+// inspect the response.
+//
+// Example:
+//
+//	e.OPTIONS("/fruits").
+//		WithCORSPreflight("https://example.com", "PUT", "Content-Type").
+//		Expect().
+//		Status(http.StatusNoContent).
+//		CORS().AllowsOrigin("https://example.com").AllowsMethod("PUT")
+func (r *Request) WithCORSPreflight(
+	origin, method string, headers ...string,
+) *Request {
+	opChain := r.chain.enter("WithCORSPreflight()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCORSPreflight()") {
+		return r
+	}
+
+	if origin == "" || method == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty origin or method"),
+			},
+		})
+		return r
+	}
+
+	r.httpReq.Header.Set("Origin", origin)
+	r.httpReq.Header.Set("Access-Control-Request-Method", method)
+
+	if len(headers) != 0 {
+		r.httpReq.Header.Set("Access-Control-Request-Headers",
+			strings.ToLower(strings.Join(headers, ",")))
+	}
+
+	return r
+}
+
+// WithRange adds byte range to "Range" header of request.
+//
+// Range includes bytes from start to end, inclusive. If end is negative,
+// range includes all bytes from start to the end of representation.
+// If called multiple times, ranges are accumulated, and server is asked
+// to return multipart/byteranges response.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/file")
+//	req.WithRange(0, 99)   // Range: bytes=0-99
+//	req.WithRange(200, -1) // Range: bytes=0-99,200-
+func (r *Request) WithRange(start, end int64) *Request {
+	opChain := r.chain.enter("WithRange()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRange()") {
+		return r
+	}
+
+	if start < 0 || (end >= 0 && end < start) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected range %d-%d", start, end),
+			},
+		})
+		return r
+	}
+
+	rng := strconv.FormatInt(start, 10) + "-"
+	if end >= 0 {
+		rng += strconv.FormatInt(end, 10)
+	}
+
+	if prev := r.httpReq.Header.Get("Range"); strings.HasPrefix(prev, "bytes=") {
+		r.httpReq.Header.Set("Range", prev+","+rng)
+	} else {
+		r.httpReq.Header.Set("Range", "bytes="+rng)
+	}
+
+	return r
+}
+
 // WithCookies adds given cookies to request.
 //
 // Example:
@@ -1288,6 +1980,121 @@ func (r *Request) WithBasicAuth(username, password string) *Request {
 	return r
 }
 
+// WithOAuth2 attaches access token from given OAuth2TokenSource to request
+// as "Authorization: Bearer" header.
+//
+// Token is fetched using OAuth 2.0 client credentials grant on first use
+// and cached in token source. If server responds with 401 status, token
+// is dropped, a new one is fetched, and request is repeated once.
+//
+// Token source is intended to be shared between requests. To attach
+// token to all requests, use Expect.Builder, or set Config.Client to
+// http.Client with OAuth2Transport.
+//
+// If Config.Client is http.Client, then only its Transport field is overwritten
+// because the client may contain some state shared among requests like a cookie
+// jar. Otherwise, the whole client is wrapped.
+//
+// Example:
+//
+//	source := NewOAuth2TokenSource(OAuth2Config{
+//		TokenURL:     "https://auth.example.com/oauth/token",
+//		ClientID:     "client",
+//		ClientSecret: "secret",
+//	})
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithOAuth2(source)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) WithOAuth2(source *OAuth2TokenSource) *Request {
+	opChain := r.chain.enter("WithOAuth2()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithOAuth2()") {
+		return r
+	}
+
+	if source == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
+
+	if client, ok := r.config.Client.(*http.Client); ok {
+		clientCopy := *client
+		clientCopy.Transport = NewOAuth2Transport(source, client.Transport)
+		r.config.Client = &clientCopy
+	} else {
+		r.config.Client = &oauth2Client{
+			source: source,
+			client: r.config.Client,
+		}
+	}
+
+	return r
+}
+
+// WithAWSSigV4 signs request using AWS Signature Version 4 with given
+// credentials, region, and service name, e.g. "execute-api" for
+// API Gateway or "lambda" for Lambda function URLs.
+//
+// Request is signed right before sending, after all other builder methods
+// and transformers are applied, so the signature covers final URL, headers,
+// and body. "X-Amz-Date", "Authorization", and (for temporary credentials)
+// "X-Amz-Security-Token" headers are added to request.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "https://api.example.com/prod/items")
+//	req.WithAWSSigV4(AWSCredentials{
+//		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+//		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+//	}, "us-east-1", "execute-api")
+func (r *Request) WithAWSSigV4(creds AWSCredentials, region, service string) *Request {
+	opChain := r.chain.enter("WithAWSSigV4()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithAWSSigV4()") {
+		return r
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty credentials"),
+			},
+		})
+		return r
+	}
+
+	if region == "" || service == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty region or service"),
+			},
+		})
+		return r
+	}
+
+	r.awsSigner = newAWSSigner(creds, region, service)
+
+	return r
+}
+
 // WithHost sets request host to given string.
 //
 // Example:
@@ -1494,6 +2301,250 @@ func (r *Request) WithJSON(object interface{}) *Request {
 	return r
 }
 
+// WithMsgPack sets Content-Type header to "application/msgpack"
+// and sets body to object, encoded in MessagePack format.
+//
+// Object is converted to MessagePack via its JSON representation, so
+// "json" struct tags are respected. Integral numbers are encoded as
+// MessagePack integers.
+//
+// Example:
+//
+//	type MyJSON struct {
+//	    Foo int `json:"foo"`
+//	}
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithMsgPack(MyJSON{Foo: 123})
+func (r *Request) WithMsgPack(object interface{}) *Request {
+	opChain := r.chain.enter("WithMsgPack()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMsgPack()") {
+		return r
+	}
+
+	b, err := encodeMsgPack(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid msgpack object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithMsgPack()", "application/msgpack", false)
+	r.setBody(opChain, "WithMsgPack()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// WithYAML sets Content-Type header to "application/yaml; charset=utf-8"
+// and sets body to object, marshaled using yaml.Marshal() from
+// gopkg.in/yaml.v2.
+//
+// Example:
+//
+//	type MyYAML struct {
+//	    Foo int `yaml:"foo"`
+//	}
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithYAML(MyYAML{Foo: 123})
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithYAML(map[string]interface{}{"foo": 123})
+func (r *Request) WithYAML(object interface{}) *Request {
+	opChain := r.chain.enter("WithYAML()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithYAML()") {
+		return r
+	}
+
+	b, err := marshalYAML(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid yaml object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithYAML()", "application/yaml; charset=utf-8", false)
+	r.setBody(opChain, "WithYAML()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// yaml.Marshal panics on some values, e.g. functions or channels
+func marshalYAML(object interface{}) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return yaml.Marshal(object)
+}
+
+// WithEncoded sets Content-Type header to given media type and sets body
+// to object, encoded using codec registered for this media type in
+// Config.Codecs.
+//
+// If there is no such codec, failure is reported.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithEncoded("application/cbor", map[string]interface{}{"foo": 123})
+func (r *Request) WithEncoded(mediaType string, object interface{}) *Request {
+	opChain := r.chain.enter("WithEncoded()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithEncoded()") {
+		return r
+	}
+
+	codec, ok := findCodec(r.config.Codecs, mediaType)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("no codec registered in Config.Codecs for %q", mediaType),
+			},
+		})
+		return r
+	}
+
+	b, err := codec.Marshal(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				fmt.Errorf("failed to encode %q object", mediaType),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithEncoded()", mediaType, false)
+	r.setBody(opChain, "WithEncoded()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// WithGraphQL sets Content-Type header to "application/json; charset=utf-8"
+// and sets body to GraphQL request with given query and variables,
+// marshaled using json.Marshal().
+//
+// Variables may be nil, a map, or a struct; if nil, "variables" key
+// is omitted.
+//
+// Example:
+//
+//	req := NewRequestC(config, "POST", "http://example.com/graphql")
+//	req.WithGraphQL(`query ($id: ID!) { user(id: $id) { name } }`,
+//	    map[string]interface{}{"id": 123})
+func (r *Request) WithGraphQL(query string, variables interface{}) *Request {
+	opChain := r.chain.enter("WithGraphQL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithGraphQL()") {
+		return r
+	}
+
+	object := map[string]interface{}{
+		"query": query,
+	}
+	if variables != nil {
+		object["variables"] = variables
+	}
+
+	b, err := json.Marshal(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{variables},
+			Errors: []error{
+				errors.New("invalid graphql variables"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithGraphQL()", "application/json; charset=utf-8", false)
+	r.setBody(opChain, "WithGraphQL()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// WithGRPCWeb sets Content-Type header to "application/grpc-web+proto",
+// adds "X-Grpc-Web: 1" header, and sets body to given messages encoded
+// as gRPC-Web length-prefixed frames.
+//
+// Messages are raw serialized protobuf messages, e.g. returned by
+// proto.Marshal(). Usually there is exactly one message.
+//
+// Example:
+//
+//	msg, _ := proto.Marshal(&pb.Request{Id: 123})
+//
+//	req := NewRequestC(config, "POST", "http://example.com/pkg.Service/Method")
+//	req.WithGRPCWeb(msg)
+func (r *Request) WithGRPCWeb(messages ...[]byte) *Request {
+	opChain := r.chain.enter("WithGRPCWeb()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithGRPCWeb()") {
+		return r
+	}
+
+	b := grpcWebEncode(messages)
+
+	r.httpReq.Header.Set("X-Grpc-Web", "1")
+
+	r.setType(opChain, "WithGRPCWeb()", "application/grpc-web+proto", false)
+	r.setBody(opChain, "WithGRPCWeb()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
 // WithForm sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
 // object to url.Values using github.com/ajg/form, and adds it to request body.
@@ -1852,6 +2903,12 @@ func (r *Request) Expect() *Response {
 }
 
 func (r *Request) roundTrip(opChain *chain) *Response {
+	// transport created for this request is not used by other requests;
+	// close its pooled connections when response body is read
+	if r.transport != nil {
+		defer r.transport.CloseIdleConnections()
+	}
+
 	if !r.encodeRequest(opChain) {
 		return nil
 	}
@@ -1866,6 +2923,19 @@ func (r *Request) roundTrip(opChain *chain) *Response {
 		transform(r.httpReq)
 	}
 
+	if r.awsSigner != nil {
+		if err := r.awsSigner.sign(r.httpReq); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to sign request"),
+					err,
+				},
+			})
+			return nil
+		}
+	}
+
 	var (
 		httpResp *http.Response
 		websock  *websocket.Conn
@@ -1886,7 +2956,10 @@ func (r *Request) roundTrip(opChain *chain) *Response {
 		chain:     opChain,
 		httpResp:  httpResp,
 		websocket: websock,
+		redirects: r.redirects,
 		rtt:       []time.Duration{elapsed},
+		trace:     r.trace,
+		download:  r.download,
 	})
 }
 
@@ -1968,17 +3041,20 @@ func (r *Request) sendRequest(opChain *chain) (*http.Response, time.Duration) {
 		return nil, 0
 	}
 
+	r.trace = newResponseTrace()
+	r.httpReq = r.httpReq.WithContext(
+		httptrace.WithClientTrace(r.httpReq.Context(), r.trace.clientTrace()))
+
 	resp, elapsed, err := r.retryRequest(func() (*http.Response, error) {
+		r.redirects = nil
+		r.trace.reset()
 		return r.config.Client.Do(r.httpReq)
 	})
 
 	if err != nil {
 		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				errors.New("failed to send http request"),
-				err,
-			},
+			Type:   AssertOperation,
+			Errors: sendErrors("failed to send http request", err),
 		})
 		return nil, 0
 	}
@@ -2002,11 +3078,8 @@ func (r *Request) sendWebsocketRequest(opChain *chain) (
 
 	if err != nil && err != websocket.ErrBadHandshake {
 		opChain.fail(AssertionFailure{
-			Type: AssertOperation,
-			Errors: []error{
-				errors.New("failed to send websocket request"),
-				err,
-			},
+			Type:   AssertOperation,
+			Errors: sendErrors("failed to send websocket request", err),
 		})
 		return nil, nil, 0
 	}
@@ -2024,6 +3097,21 @@ func (r *Request) sendWebsocketRequest(opChain *chain) (
 	return resp, conn, elapsed
 }
 
+func sendErrors(message string, err error) []error {
+	errs := []error{
+		errors.New(message),
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		errs = append(errs, errors.New("request timed out"))
+	case errors.Is(err, context.Canceled):
+		errs = append(errs, errors.New("request was cancelled"))
+	}
+
+	return append(errs, err)
+}
+
 func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 	*http.Response, time.Duration, error,
 ) {
@@ -2035,37 +3123,71 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 
 	reqBody, _ := r.httpReq.Body.(*bodyWrapper)
 
+	if r.download != nil {
+		// only response of the last attempt is kept, see responseDownload
+		defer r.download.commit()
+	}
+
+	// request context already includes Config.Context and client trace;
+	// timeout of every attempt is derived from it, so that they're kept
+	baseCtx := r.httpReq.Context()
+
 	delay := r.minRetryDelay
 	i := 0
 
 	for {
+		// context is set before printing, so that printers get the same
+		// request that is sent and can match response.Request with it
+		var cancelFn context.CancelFunc
+
+		if r.timeout > 0 {
+			var ctx context.Context
+			ctx, cancelFn = context.WithTimeout(baseCtx, r.timeout)
+
+			r.httpReq = r.httpReq.WithContext(ctx)
+		}
+
+		var span TraceSpan
+		if r.traceCtx != nil {
+			span = r.traceCtx.startSpan(r.httpReq)
+		}
+
 		for _, printer := range r.config.Printers {
 			if reqBody != nil {
+				// printers are allowed to read or replace body
+				r.httpReq.Body = reqBody
 				reqBody.Rewind()
 			}
-			printer.Request(r.httpReq)
+			if r.config.Redactor != nil {
+				printer.Request(r.config.Redactor.redactRequest(r.httpReq))
+			} else {
+				printer.Request(r.httpReq)
+			}
 		}
 
 		if reqBody != nil {
+			r.httpReq.Body = reqBody
 			reqBody.Rewind()
 		}
 
-		var cancelFn context.CancelFunc
+		start := time.Now()
+		resp, err := reqFunc()
+		elapsed := time.Since(start)
 
-		if r.timeout > 0 {
-			var ctx context.Context
-			if r.config.Context != nil {
-				ctx, cancelFn = context.WithTimeout(r.config.Context, r.timeout)
-			} else {
-				ctx, cancelFn = context.WithTimeout(context.Background(), r.timeout)
-			}
+		if r.traceCtx != nil && r.config.Tracer != nil {
+			span.Start = start
+			span.End = start.Add(elapsed)
+			span.Response = resp
+			span.Error = err
 
-			r.httpReq = r.httpReq.WithContext(ctx)
+			r.config.Tracer.Span(span)
 		}
 
-		start := time.Now()
-		resp, err := reqFunc()
-		elapsed := time.Since(start)
+		if resp != nil && resp.Body != nil && r.download != nil {
+			// body is streamed to file instead of reading it into memory
+			r.download.write(resp.Body)
+			resp.Body = http.NoBody
+		}
 
 		if resp != nil && resp.Body != nil {
 			resp.Body = newBodyWrapper(resp.Body, cancelFn)
@@ -2074,11 +3196,24 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 		}
 
 		if resp != nil {
+			respBody, _ := resp.Body.(*bodyWrapper)
+
 			for _, printer := range r.config.Printers {
-				if resp.Body != nil {
-					resp.Body.(*bodyWrapper).Rewind()
+				if respBody != nil {
+					// printers are allowed to read or replace body
+					resp.Body = respBody
+					respBody.Rewind()
+				}
+				if r.config.Redactor != nil {
+					printer.Response(r.config.Redactor.redactResponse(resp), elapsed)
+				} else {
+					printer.Response(resp, elapsed)
 				}
-				printer.Response(resp, elapsed)
+			}
+
+			if respBody != nil {
+				resp.Body = respBody
+				respBody.Rewind()
 			}
 		}
 
@@ -2087,22 +3222,37 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 			return resp, elapsed, err
 		}
 
-		if !r.shouldRetry(resp, err) {
+		retryAfter, hasRetryAfter := r.retryAfter(resp)
+
+		if hasRetryAfter {
+			if retryAfter > r.maxRetryAfter {
+				return resp, elapsed, err
+			}
+		} else if !r.shouldRetry(resp, err) {
 			return resp, elapsed, err
 		}
 
+		sleepDelay := delay
+		if hasRetryAfter {
+			sleepDelay = retryAfter
+		}
+
 		if resp != nil && resp.Body != nil {
 			resp.Body.Close()
 		}
 
+		if r.download != nil {
+			r.download.discard()
+		}
+
 		if configCtx := r.config.Context; configCtx != nil {
 			select {
 			case <-configCtx.Done():
 				return nil, elapsed, configCtx.Err()
-			case <-r.sleepFn(delay):
+			case <-r.sleepFn(sleepDelay):
 			}
 		} else {
-			<-r.sleepFn(delay)
+			<-r.sleepFn(sleepDelay)
 		}
 
 		delay *= 2
@@ -2115,17 +3265,21 @@ func (r *Request) retryRequest(reqFunc func() (*http.Response, error)) (
 func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 	var (
 		isTemporaryNetworkError bool
+		isTimeoutError          bool
 		isTemporaryServerError  bool
+		isTooManyRequests       bool
 		isHTTPError             bool
 	)
 
 	if netErr, ok := err.(net.Error); ok {
 		//nolint
 		isTemporaryNetworkError = netErr.Temporary()
+		isTimeoutError = netErr.Timeout()
 	}
 
 	if resp != nil {
 		isTemporaryServerError = resp.StatusCode >= 500 && resp.StatusCode <= 599
+		isTooManyRequests = resp.StatusCode == http.StatusTooManyRequests
 		isHTTPError = resp.StatusCode >= 400 && resp.StatusCode <= 599
 	}
 
@@ -2141,11 +3295,36 @@ func (r *Request) shouldRetry(resp *http.Response, err error) bool {
 
 	case RetryAllErrors:
 		return err != nil || isHTTPError
+
+	case RetryTimeoutErrors:
+		return isTimeoutError
+
+	case RetryTimeoutAndServerErrors:
+		return isTimeoutError || isTemporaryServerError || isTooManyRequests
 	}
 
 	return false
 }
 
+// returns delay from Retry-After header, if it should be respected
+func (r *Request) retryAfter(resp *http.Response) (time.Duration, bool) {
+	if r.maxRetryAfter == 0 || resp == nil {
+		return 0, false
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	return parseRetryAfter(value, time.Now())
+}
+
 func (r *Request) setupRedirects(opChain *chain) {
 	httpClient, _ := r.config.Client.(*http.Client)
 
@@ -2171,27 +3350,44 @@ func (r *Request) setupRedirects(opChain *chain) {
 			})
 			return
 		}
-	} else {
-		if r.redirectPolicy != defaultRedirectPolicy || r.maxRedirects != -1 {
-			clientCopy := *httpClient
-			httpClient = &clientCopy
-			r.config.Client = &clientCopy
-		}
+
+		return
 	}
 
+	clientCopy := *httpClient
+	httpClient = &clientCopy
+	r.config.Client = &clientCopy
+
+	checkRedirect := httpClient.CheckRedirect
+
 	if r.redirectPolicy == DontFollowRedirects {
-		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		checkRedirect = func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		}
 	} else if r.maxRedirects >= 0 {
-		httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		checkRedirect = func(req *http.Request, via []*http.Request) error {
 			if len(via) > r.maxRedirects {
 				return fmt.Errorf("stopped after %d redirects", r.maxRedirects)
 			}
 			return nil
 		}
 	} else if r.redirectPolicy != defaultRedirectPolicy {
-		httpClient.CheckRedirect = nil
+		checkRedirect = nil
+	}
+
+	// wrap redirect policy to remember intermediate responses
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		var err error
+		if checkRedirect != nil {
+			err = checkRedirect(req, via)
+		} else if len(via) >= 10 {
+			// same as default policy of http.Client
+			err = errors.New("stopped after 10 redirects")
+		}
+		if err == nil && req.Response != nil {
+			r.redirects = append(r.redirects, saveRedirect(req.Response))
+		}
+		return err
 	}
 
 	if r.redirectPolicy == FollowAllRedirects {
@@ -2210,6 +3406,23 @@ func (r *Request) setupRedirects(opChain *chain) {
 	}
 }
 
+// Make a copy of redirection response that remains readable after
+// http.Client drains and closes its body.
+func saveRedirect(resp *http.Response) *http.Response {
+	respCopy := *resp
+
+	if resp.Body != nil {
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			b = nil
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+		respCopy.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	return &respCopy
+}
+
 var typeErr = `ambiguous request "Content-Type" header values:
   first set by %s:
     %q
@@ -3,6 +3,7 @@ package httpexpect
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -10,9 +11,11 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -36,13 +39,19 @@ func TestRequest_Failed(t *testing.T) {
 	})
 	req.WithClient(&http.Client{})
 	req.WithHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	req.WithUnixSocket("/tmp/test.sock")
+	req.WithTLSConfig(&tls.Config{})
+	req.WithClientCert("cert.pem", "key.pem")
+	req.WithProxy("http://localhost:8080")
 	req.WithContext(context.TODO())
 	req.WithTimeout(0)
+	req.WithDownload("file")
 	req.WithRedirectPolicy(FollowAllRedirects)
 	req.WithMaxRedirects(1)
 	req.WithRetryPolicy(RetryAllErrors)
 	req.WithMaxRetries(1)
 	req.WithRetryDelay(time.Millisecond, time.Millisecond)
+	req.WithRetryAfter(time.Second)
 	req.WithWebsocketUpgrade()
 	req.WithWebsocketDialer(
 		NewWebsocketDialer(
@@ -55,15 +64,29 @@ func TestRequest_Failed(t *testing.T) {
 	req.WithURL("http://example.com")
 	req.WithHeaders(map[string]string{"foo": "bar"})
 	req.WithHeader("foo", "bar")
+	req.WithHeaderFromEnv("foo", "bar")
+	req.WithTraceContext()
+	req.WithTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.WithIfNoneMatch("foo")
+	req.WithIfModifiedSince(time.Now())
+	req.WithCORSPreflight("http://example.com", "PUT")
+	req.WithRange(0, 99)
 	req.WithCookies(map[string]string{"foo": "bar"})
 	req.WithCookie("foo", "bar")
 	req.WithBasicAuth("foo", "bar")
+	req.WithOAuth2(NewOAuth2TokenSource(OAuth2Config{}))
+	req.WithAWSSigV4(AWSCredentials{"id", "secret", ""}, "us-east-1", "s3")
 	req.WithHost("127.0.0.1")
 	req.WithProto("HTTP/1.1")
 	req.WithChunked(strings.NewReader("foo"))
 	req.WithBytes([]byte("foo"))
 	req.WithText("foo")
 	req.WithJSON(map[string]string{"foo": "bar"})
+	req.WithMsgPack(map[string]string{"foo": "bar"})
+	req.WithYAML(map[string]string{"foo": "bar"})
+	req.WithEncoded("application/cbor", map[string]string{"foo": "bar"})
+	req.WithGraphQL("query { foo }", nil)
+	req.WithGRPCWeb([]byte("foo"))
 	req.WithForm(map[string]string{"foo": "bar"})
 	req.WithFormField("foo", "bar")
 	req.WithFile("foo", "bar", strings.NewReader("baz"))
@@ -334,6 +357,125 @@ func TestRequest_HandlerResueClient(t *testing.T) {
 	assert.True(t, req.config.Client.(*http.Client).Jar == client.Jar)
 }
 
+func TestRequest_UnixSocketReuseClient(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &http.Client{
+		Jar: NewCookieJar(),
+	}
+
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{"test"},
+	}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory:  factory,
+		Reporter:        reporter,
+		Client:          client,
+		WebsocketDialer: dialer,
+	}
+
+	req := NewRequestC(config, "METHOD", "/")
+	req.WithUnixSocket("/tmp/test.sock")
+
+	assert.True(t, req.config.Client.(*http.Client).Jar == client.Jar)
+	assert.NotNil(t, req.config.Client.(*http.Client).Transport)
+	assert.Nil(t, client.Transport)
+
+	assert.Equal(t, []string{"test"},
+		req.config.WebsocketDialer.(*websocket.Dialer).Subprotocols)
+	assert.NotNil(t, req.config.WebsocketDialer.(*websocket.Dialer).NetDialContext)
+	assert.Nil(t, dialer.NetDialContext)
+}
+
+func TestRequest_ProxyReuseClient(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &http.Client{
+		Jar: NewCookieJar(),
+	}
+
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{"test"},
+	}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory:  factory,
+		Reporter:        reporter,
+		Client:          client,
+		WebsocketDialer: dialer,
+	}
+
+	req := NewRequestC(config, "METHOD", "/")
+	req.WithProxy("http://localhost:8080")
+	req.chain.assertNotFailed(t)
+
+	assert.True(t, req.config.Client.(*http.Client).Jar == client.Jar)
+	assert.NotNil(t, req.config.Client.(*http.Client).Transport)
+	assert.Nil(t, client.Transport)
+
+	assert.Equal(t, []string{"test"},
+		req.config.WebsocketDialer.(*websocket.Dialer).Subprotocols)
+	assert.NotNil(t, req.config.WebsocketDialer.(*websocket.Dialer).Proxy)
+	assert.Nil(t, dialer.Proxy)
+}
+
+func TestRequest_TLSConfigReuseClient(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &http.Client{
+		Jar: NewCookieJar(),
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				ServerName: "example.com",
+			},
+		},
+	}
+
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{"test"},
+	}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory:  factory,
+		Reporter:        reporter,
+		Client:          client,
+		WebsocketDialer: dialer,
+	}
+
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certPEM, keyPEM := createTestCert(t, "client")
+	certFile, keyFile := writeTestCert(t, dir, certPEM, keyPEM)
+
+	req := NewRequestC(config, "METHOD", "/")
+	req.WithClientCert(certFile, keyFile)
+	req.chain.assertNotFailed(t)
+
+	reqClient := req.config.Client.(*http.Client)
+	assert.True(t, reqClient.Jar == client.Jar)
+
+	reqTLS := reqClient.Transport.(*http.Transport).TLSClientConfig
+	assert.Equal(t, "example.com", reqTLS.ServerName)
+	assert.Equal(t, 1, len(reqTLS.Certificates))
+
+	assert.Equal(t, 0,
+		len(client.Transport.(*http.Transport).TLSClientConfig.Certificates))
+
+	reqDialer := req.config.WebsocketDialer.(*websocket.Dialer)
+	assert.Equal(t, []string{"test"}, reqDialer.Subprotocols)
+	assert.Equal(t, 1, len(reqDialer.TLSClientConfig.Certificates))
+	assert.Nil(t, dialer.TLSClientConfig)
+}
+
 func TestRequest_Proto(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -656,6 +798,309 @@ func TestRequest_Headers(t *testing.T) {
 	assert.Same(t, &client.resp, resp.Raw())
 }
 
+func TestRequest_HeaderFromEnv(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	env := NewEnvironment(reporter)
+	env.Put("token", "abc")
+	env.Put("user.id", 123.0)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+		Environment:    env,
+	}
+
+	t.Run("expanded", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "url")
+
+		req.WithHeaderFromEnv("Authorization", "Bearer {token}")
+		req.WithHeaderFromEnv("X-User", "user-{user.id}")
+		req.WithHeaderFromEnv("X-Plain", "plain")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.Header{
+			"Authorization": {"Bearer abc"},
+			"X-User":        {"user-123"},
+			"X-Plain":       {"plain"},
+		}, client.req.Header)
+	})
+
+	t.Run("stored json number", func(t *testing.T) {
+		NewValueC(config, map[string]interface{}{"id": 12345678}).
+			Path("$.id").Store("order.id")
+
+		req := NewRequestC(config, "METHOD", "url")
+
+		req.WithHeaderFromEnv("X-Order", "{order.id}")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.Header{
+			"X-Order": {"12345678"},
+		}, client.req.Header)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "url")
+
+		req.WithHeaderFromEnv("Authorization", "Bearer {missing}")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		for _, template := range []string{"{token", "token}", "{}", "{{token}}"} {
+			req := NewRequestC(config, "METHOD", "url")
+
+			req.WithHeaderFromEnv("Authorization", template)
+			req.chain.assertFailed(t)
+		}
+	})
+}
+
+func TestRequest_TraceContext(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	t.Run("generated", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "url")
+
+		req.WithTraceContext()
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		traceparent := client.req.Header.Get("traceparent")
+		assert.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`, traceparent)
+
+		req2 := NewRequestC(config, "METHOD", "url")
+
+		req2.WithTraceContext()
+		req2.Expect()
+
+		assert.NotEqual(t, traceparent, client.req.Header.Get("traceparent"))
+	})
+
+	t.Run("preserved", func(t *testing.T) {
+		traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+		req := NewRequestC(config, "METHOD", "url")
+
+		req.WithHeader("traceparent", traceparent)
+		req.WithTraceContext()
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t,
+			[]string{traceparent}, client.req.Header.Values("traceparent"))
+	})
+
+	t.Run("parent", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "url")
+
+		req.WithTraceParent(
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Regexp(t, `^00-4bf92f3577b34da6a3ce929d0e0e4736-[0-9a-f]{16}-00$`,
+			client.req.Header.Get("traceparent"))
+		assert.NotContains(t,
+			client.req.Header.Get("traceparent"), "00f067aa0ba902b7")
+	})
+
+	t.Run("invalid parent", func(t *testing.T) {
+		for _, traceparent := range []string{
+			"",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+			"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+			"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01",
+		} {
+			req := NewRequestC(config, "METHOD", "url")
+
+			req.WithTraceParent(traceparent)
+			req.chain.assertFailed(t)
+		}
+	})
+}
+
+func TestRequest_Tracer(t *testing.T) {
+	var spans []TraceSpan
+
+	tracer := TracerFunc(func(span TraceSpan) {
+		spans = append(spans, span)
+	})
+
+	t.Run("new trace", func(t *testing.T) {
+		spans = nil
+
+		client := &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Tracer:   tracer,
+		}
+
+		NewRequestC(config, "GET", "/url").
+			WithTraceContext().
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.Equal(t, 1, len(spans))
+
+		assert.Regexp(t, `^[0-9a-f]{32}$`, spans[0].TraceID)
+		assert.Regexp(t, `^[0-9a-f]{16}$`, spans[0].SpanID)
+		assert.Equal(t, "", spans[0].ParentSpanID)
+		assert.Equal(t, "GET", spans[0].Name)
+		assert.False(t, spans[0].Start.IsZero())
+		assert.False(t, spans[0].End.Before(spans[0].Start))
+		assert.Same(t, client.req, spans[0].Request)
+		assert.Equal(t, http.StatusOK, spans[0].Response.StatusCode)
+		assert.NoError(t, spans[0].Error)
+
+		assert.Equal(t,
+			"00-"+spans[0].TraceID+"-"+spans[0].SpanID+"-01",
+			client.req.Header.Get("traceparent"))
+	})
+
+	t.Run("parent", func(t *testing.T) {
+		spans = nil
+
+		client := &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Tracer:   tracer,
+		}
+
+		NewRequestC(config, "GET", "/url").
+			WithTraceParent(
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.Equal(t, 1, len(spans))
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].ParentSpanID)
+		assert.NotEqual(t, "00f067aa0ba902b7", spans[0].SpanID)
+	})
+
+	t.Run("preserved header", func(t *testing.T) {
+		spans = nil
+
+		client := &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Tracer:   tracer,
+		}
+
+		NewRequestC(config, "GET", "/url").
+			WithHeader("traceparent",
+				"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").
+			WithTraceContext().
+			Expect().
+			chain.assertNotFailed(t)
+
+		require.Equal(t, 1, len(spans))
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceID)
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].SpanID)
+		assert.Equal(t, "", spans[0].ParentSpanID)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		spans = nil
+
+		client := &mockClient{
+			err: &mockNetError{
+				isTemporary: true,
+			},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Tracer:   tracer,
+		}
+
+		req := NewRequestC(config, "GET", "/url").
+			WithTraceContext().
+			WithMaxRetries(2).
+			WithRetryPolicy(RetryTemporaryNetworkErrors)
+		req.sleepFn = func(time.Duration) <-chan time.Time {
+			return time.After(0)
+		}
+
+		req.Expect().chain.assertFailed(t)
+
+		require.Equal(t, 3, len(spans))
+
+		for _, span := range spans {
+			assert.Equal(t, spans[0].TraceID, span.TraceID)
+			assert.Nil(t, span.Response)
+			assert.Error(t, span.Error)
+		}
+
+		assert.NotEqual(t, spans[0].SpanID, spans[1].SpanID)
+		assert.NotEqual(t, spans[1].SpanID, spans[2].SpanID)
+	})
+
+	t.Run("no trace context", func(t *testing.T) {
+		spans = nil
+
+		client := &mockClient{
+			resp: http.Response{StatusCode: http.StatusOK},
+		}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+			Tracer:   tracer,
+		}
+
+		NewRequestC(config, "GET", "/url").
+			Expect().
+			chain.assertNotFailed(t)
+
+		assert.Equal(t, 0, len(spans))
+		assert.Equal(t, "", client.req.Header.Get("traceparent"))
+	})
+}
+
 func TestRequest_Cookies(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -714,6 +1159,134 @@ func TestRequest_BasicAuth(t *testing.T) {
 		req.httpReq.Header.Get("Authorization"))
 }
 
+func TestRequest_Conditional(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	t.Run("if-none-match", func(t *testing.T) {
+		cases := []struct {
+			etag     string
+			expected string
+		}{
+			{`abc`, `"abc"`},
+			{`"abc"`, `"abc"`},
+			{`W/"abc"`, `W/"abc"`},
+			{`*`, `*`},
+		}
+
+		for _, tc := range cases {
+			req := NewRequestC(config, "GET", "url")
+
+			req.WithIfNoneMatch(tc.etag)
+			req.chain.assertNotFailed(t)
+
+			assert.Equal(t, tc.expected, req.httpReq.Header.Get("If-None-Match"))
+		}
+	})
+
+	t.Run("if-modified-since", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		loc := time.FixedZone("UTC+3", 3*60*60)
+
+		req.WithIfModifiedSince(time.Date(2015, 10, 21, 10, 28, 0, 0, loc))
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT",
+			req.httpReq.Header.Get("If-Modified-Since"))
+	})
+}
+
+func TestRequest_CORSPreflight(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	t.Run("without headers", func(t *testing.T) {
+		req := NewRequestC(config, "OPTIONS", "url")
+
+		req.WithCORSPreflight("https://example.com", "PUT")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.Header{
+			"Origin":                        {"https://example.com"},
+			"Access-Control-Request-Method": {"PUT"},
+		}, req.httpReq.Header)
+	})
+
+	t.Run("with headers", func(t *testing.T) {
+		req := NewRequestC(config, "OPTIONS", "url")
+
+		req.WithCORSPreflight("https://example.com", "PUT",
+			"Content-Type", "X-Token")
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, http.Header{
+			"Origin":                         {"https://example.com"},
+			"Access-Control-Request-Method":  {"PUT"},
+			"Access-Control-Request-Headers": {"content-type,x-token"},
+		}, req.httpReq.Header)
+	})
+}
+
+func TestRequest_Range(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	t.Run("single", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithRange(0, 99)
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "bytes=0-99", req.httpReq.Header.Get("Range"))
+	})
+
+	t.Run("open-ended", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithRange(100, -1)
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "bytes=100-", req.httpReq.Header.Get("Range"))
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "url")
+
+		req.WithRange(0, 0).WithRange(10, 19).WithRange(100, -1)
+		req.chain.assertNotFailed(t)
+
+		assert.Equal(t, "bytes=0-0,10-19,100-", req.httpReq.Header.Get("Range"))
+	})
+}
+
 func TestRequest_WithHost(t *testing.T) {
 	factory1 := DefaultRequestFactory{}
 	client1 := &mockClient{}
@@ -1272,6 +1845,172 @@ func TestRequest_BodyJSON(t *testing.T) {
 	assert.Same(t, &client.resp, resp.Raw())
 }
 
+func TestRequest_BodyMsgPack(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	expectedHeaders := map[string][]string{
+		"Content-Type": {"application/msgpack"},
+	}
+
+	req := NewRequestC(config, "METHOD", "url")
+
+	req.WithMsgPack(map[string]interface{}{"key": "value", "n": 1})
+
+	resp := req.Expect()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+	assert.Equal(t, []byte("\x82\xa3key\xa5value\xa1n\x01"), resp.content)
+
+	assert.Same(t, &client.resp, resp.Raw())
+}
+
+func TestRequest_BodyYAML(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	expectedHeaders := map[string][]string{
+		"Content-Type": {"application/yaml; charset=utf-8"},
+	}
+
+	req := NewRequestC(config, "METHOD", "url")
+
+	req.WithYAML(map[string]interface{}{"key": "value", "num": 1})
+
+	resp := req.Expect()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, http.Header(expectedHeaders), client.req.Header)
+	assert.Equal(t, "key: value\nnum: 1\n", string(resp.content))
+
+	assert.Same(t, &client.resp, resp.Raw())
+}
+
+func TestRequest_BodyGraphQL(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	cases := []struct {
+		name      string
+		variables interface{}
+		body      string
+	}{
+		{
+			name:      "nil variables",
+			variables: nil,
+			body:      `{"query":"query { user { name } }"}`,
+		},
+		{
+			name:      "map variables",
+			variables: map[string]interface{}{"id": 123},
+			body:      `{"query":"query { user { name } }","variables":{"id":123}}`,
+		},
+		{
+			name: "struct variables",
+			variables: struct {
+				ID string `json:"id"`
+			}{"abc"},
+			body: `{"query":"query { user { name } }","variables":{"id":"abc"}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			reporter := newMockReporter(t)
+
+			config := Config{
+				RequestFactory: factory,
+				Client:         client,
+				Reporter:       reporter,
+			}
+
+			req := NewRequestC(config, "POST", "url")
+
+			req.WithGraphQL("query { user { name } }", tc.variables)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			assert.Equal(t, "POST", client.req.Method)
+			assert.Equal(t,
+				http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+				client.req.Header)
+			assert.Equal(t, tc.body, string(resp.content))
+		})
+	}
+
+	t.Run("invalid variables", func(t *testing.T) {
+		client := &mockClient{}
+
+		reporter := newMockReporter(t)
+
+		config := Config{
+			RequestFactory: factory,
+			Client:         client,
+			Reporter:       reporter,
+		}
+
+		req := NewRequestC(config, "POST", "url")
+
+		req.WithGraphQL("query { user { name } }", func() {})
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestRequest_BodyGRPCWeb(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req := NewRequestC(config, "POST", "url")
+
+	req.WithGRPCWeb([]byte("foo"), []byte("ab"))
+
+	resp := req.Expect()
+	resp.chain.assertNotFailed(t)
+
+	assert.Equal(t, "POST", client.req.Method)
+	assert.Equal(t,
+		http.Header{
+			"Content-Type": {"application/grpc-web+proto"},
+			"X-Grpc-Web":   {"1"},
+		},
+		client.req.Header)
+	assert.Equal(t,
+		[]byte{0, 0, 0, 0, 3, 'f', 'o', 'o', 0, 0, 0, 0, 2, 'a', 'b'},
+		resp.content)
+}
+
 func TestRequest_ContentLength(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -1418,6 +2157,52 @@ func TestRequest_ErrorMarshalJSON(t *testing.T) {
 	assert.True(t, resp.Raw() == nil)
 }
 
+func TestRequest_ErrorMarshalMsgPack(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req := NewRequestC(config, "METHOD", "url")
+
+	req.WithMsgPack(func() {})
+
+	resp := req.Expect()
+	resp.chain.assertFailed(t)
+
+	assert.True(t, resp.Raw() == nil)
+}
+
+func TestRequest_ErrorMarshalYAML(t *testing.T) {
+	factory := DefaultRequestFactory{}
+
+	client := &mockClient{}
+
+	reporter := newMockReporter(t)
+
+	config := Config{
+		RequestFactory: factory,
+		Client:         client,
+		Reporter:       reporter,
+	}
+
+	req := NewRequestC(config, "METHOD", "url")
+
+	req.WithYAML(func() {})
+
+	resp := req.Expect()
+	resp.chain.assertFailed(t)
+
+	assert.True(t, resp.Raw() == nil)
+}
+
 func TestRequest_ErrorReadFile(t *testing.T) {
 	factory := DefaultRequestFactory{}
 
@@ -2118,38 +2903,138 @@ func TestRequest_Redirect(t *testing.T) {
 					httpClient.CheckRedirect(req.httpReq, make([]*http.Request, 2)),
 				)
 
-				// Should do round trip
-				assert.Equal(t, 2, tp.tripCount)
-			})
+				// Should do round trip
+				assert.Equal(t, 2, tp.tripCount)
+			})
+	})
+}
+
+func TestRequest_UsageChecks(t *testing.T) {
+	config := Config{
+		Reporter: newMockReporter(t),
+	}
+
+	t.Run("WithMatcher", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithMatcher(nil)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTransformer", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithTransformer(nil)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithClient", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithClient(nil)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithHandler", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithHandler(nil)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithUnixSocket", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithUnixSocket("")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTLSConfig", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithTLSConfig(nil)
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTLSConfig custom client", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithClient(&mockClient{})
+		req.WithTLSConfig(&tls.Config{})
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTLSConfig custom transport", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithHandler(http.NotFoundHandler())
+		req.WithTLSConfig(&tls.Config{})
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithClientCert", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithClientCert("missing.pem", "missing-key.pem")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithProxy", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithProxy("localhost:8080")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithProxy custom client", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithClient(&mockClient{})
+		req.WithProxy("http://localhost:8080")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithProxy custom transport", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithHandler(http.NotFoundHandler())
+		req.WithProxy("http://localhost:8080")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithCORSPreflight empty origin", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithCORSPreflight("", "PUT")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithCORSPreflight empty method", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithCORSPreflight("https://example.com", "")
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithRange negative start", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithRange(-1, 10)
+		req.chain.assertFailed(t)
 	})
-}
 
-func TestRequest_UsageChecks(t *testing.T) {
-	config := Config{
-		Reporter: newMockReporter(t),
-	}
+	t.Run("WithRange end before start", func(t *testing.T) {
+		req := NewRequestC(config, "METHOD", "/")
+		req.WithRange(10, 9)
+		req.chain.assertFailed(t)
+	})
 
-	t.Run("WithMatcher", func(t *testing.T) {
+	t.Run("WithOAuth2", func(t *testing.T) {
 		req := NewRequestC(config, "METHOD", "/")
-		req.WithMatcher(nil)
+		req.WithOAuth2(nil)
 		req.chain.assertFailed(t)
 	})
 
-	t.Run("WithTransformer", func(t *testing.T) {
+	t.Run("WithAWSSigV4 empty credentials", func(t *testing.T) {
 		req := NewRequestC(config, "METHOD", "/")
-		req.WithTransformer(nil)
+		req.WithAWSSigV4(AWSCredentials{}, "us-east-1", "s3")
 		req.chain.assertFailed(t)
 	})
 
-	t.Run("WithClient", func(t *testing.T) {
+	t.Run("WithAWSSigV4 empty region", func(t *testing.T) {
 		req := NewRequestC(config, "METHOD", "/")
-		req.WithClient(nil)
+		req.WithAWSSigV4(AWSCredentials{"id", "secret", ""}, "", "s3")
 		req.chain.assertFailed(t)
 	})
 
-	t.Run("WithHandler", func(t *testing.T) {
+	t.Run("WithAWSSigV4 empty service", func(t *testing.T) {
 		req := NewRequestC(config, "METHOD", "/")
-		req.WithHandler(nil)
+		req.WithAWSSigV4(AWSCredentials{"id", "secret", ""}, "us-east-1", "")
 		req.chain.assertFailed(t)
 	})
 
@@ -2287,6 +3172,57 @@ func TestRequest_OrderChecks(t *testing.T) {
 		req.chain.assertFailed(t)
 	})
 
+	t.Run("WithUnixSocket after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithUnixSocket("/tmp/test.sock"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTLSConfig after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithTLSConfig(&tls.Config{}))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithProxy after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithProxy("http://localhost:8080"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithRetryAfter after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithRetryAfter(time.Second))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithClientCert after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithClientCert("cert.pem", "key.pem"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithOAuth2 after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req,
+			req.WithOAuth2(NewOAuth2TokenSource(OAuth2Config{})))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithAWSSigV4 after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithAWSSigV4(
+			AWSCredentials{"id", "secret", ""}, "us-east-1", "s3"))
+		req.chain.assertFailed(t)
+	})
+
 	t.Run("WithContext after an Expect", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "/")
 		req.Expect()
@@ -2407,6 +3343,35 @@ func TestRequest_OrderChecks(t *testing.T) {
 		req.chain.assertFailed(t)
 	})
 
+	t.Run("WithHeaderFromEnv after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithHeaderFromEnv("Authorization", "Bearer"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTraceContext after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithTraceContext())
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithTraceParent after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithTraceParent(
+			"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithDownload after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithDownload("file"))
+		req.chain.assertFailed(t)
+	})
+
 	t.Run("WithCookies after an Expect", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "/")
 		req.Expect()
@@ -2428,6 +3393,34 @@ func TestRequest_OrderChecks(t *testing.T) {
 		req.chain.assertFailed(t)
 	})
 
+	t.Run("WithIfNoneMatch after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithIfNoneMatch("abc"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithIfModifiedSince after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithIfModifiedSince(time.Now()))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithCORSPreflight after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "OPTIONS", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithCORSPreflight("https://example.com", "PUT"))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithRange after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithRange(0, 99))
+		req.chain.assertFailed(t)
+	})
+
 	t.Run("WithHost after an Expect", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "/")
 		req.Expect()
@@ -2470,6 +3463,34 @@ func TestRequest_OrderChecks(t *testing.T) {
 		req.chain.assertFailed(t)
 	})
 
+	t.Run("WithMsgPack after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithMsgPack(map[string]string{"key1": "val1"}))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithYAML after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithYAML(map[string]string{"key1": "val1"}))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithGraphQL after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithGraphQL("query { foo }", nil))
+		req.chain.assertFailed(t)
+	})
+
+	t.Run("WithGRPCWeb after an Expect", func(t *testing.T) {
+		req := NewRequestC(config, "GET", "/")
+		req.Expect()
+		assert.Same(t, req, req.WithGRPCWeb([]byte("foo")))
+		req.chain.assertFailed(t)
+	})
+
 	t.Run("WithForm after an Expect", func(t *testing.T) {
 		req := NewRequestC(config, "GET", "/")
 		req.Expect()
@@ -2656,6 +3677,24 @@ func TestRequest_Retry(t *testing.T) {
 		}
 	}
 
+	newTimeoutErrClient := func(cb func(req *http.Request)) *mockClient {
+		return &mockClient{
+			err: &mockNetError{
+				isTimeout: true,
+			},
+			cb: cb,
+		}
+	}
+
+	newTooManyRequestsClient := func(cb func(req *http.Request)) *mockClient {
+		return &mockClient{
+			resp: http.Response{
+				StatusCode: http.StatusTooManyRequests,
+			},
+			cb: cb,
+		}
+	}
+
 	newHTTPErrClient := func(cb func(req *http.Request)) *mockClient {
 		return &mockClient{
 			resp: http.Response{
@@ -2717,16 +3756,141 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(DontRetry).
-				WithMaxRetries(1)
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("temporary server error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTempServerErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("http error", func(t *testing.T) {
+			callCount := 0
+
+			client := newHTTPErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(DontRetry).
+				WithMaxRetries(1)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusBadRequest)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+	})
+
+	t.Run("retry temporary network errors policy", func(t *testing.T) {
+		t.Run("no error", func(t *testing.T) {
+			callCount := 0
+
+			client := newNoErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTemporaryNetworkErrors)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+
+		t.Run("temporary network error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTempNetErrClient(func(req *http.Request) {
+				callCount++
+
+				b, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "test body", string(b))
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTemporaryNetworkErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
 			resp := req.Expect()
 			resp.chain.assertFailed(t)
 
-			// Should not retry
-			assert.Equal(t, 1, callCount)
+			// Should retry
+			assert.Equal(t, 2, callCount)
 		})
 
 		t.Run("temporary server error", func(t *testing.T) {
@@ -2747,7 +3911,7 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(DontRetry).
+				WithRetryPolicy(RetryTemporaryNetworkErrors).
 				WithMaxRetries(1)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
@@ -2778,7 +3942,7 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(DontRetry).
+				WithRetryPolicy(RetryTemporaryNetworkErrors).
 				WithMaxRetries(1)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
@@ -2790,10 +3954,9 @@ func TestRequest_Retry(t *testing.T) {
 			// Should not retry
 			assert.Equal(t, 1, callCount)
 		})
-
 	})
 
-	t.Run("retry temporary network errors policy", func(t *testing.T) {
+	t.Run("retry temporary network and server errors policy", func(t *testing.T) {
 		t.Run("no error", func(t *testing.T) {
 			callCount := 0
 
@@ -2812,7 +3975,7 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkErrors)
+				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
@@ -2841,7 +4004,7 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkErrors).
+				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
 				WithMaxRetries(1).
 				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
@@ -2872,8 +4035,9 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkErrors).
-				WithMaxRetries(1)
+				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
@@ -2881,6 +4045,34 @@ func TestRequest_Retry(t *testing.T) {
 				Status(http.StatusInternalServerError)
 			resp.chain.assertNotFailed(t)
 
+			// Should retry
+			assert.Equal(t, 2, callCount)
+		})
+
+		t.Run("too many requests", func(t *testing.T) {
+			callCount := 0
+
+			client := newTooManyRequestsClient(func(req *http.Request) {
+				callCount++
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
+				WithMaxRetries(1).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusTooManyRequests)
+			resp.chain.assertNotFailed(t)
+
 			// Should not retry
 			assert.Equal(t, 1, callCount)
 		})
@@ -2903,7 +4095,7 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkErrors).
+				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
 				WithMaxRetries(1)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
@@ -2917,16 +4109,39 @@ func TestRequest_Retry(t *testing.T) {
 		})
 	})
 
-	t.Run("retry temporary network and server errors policy", func(t *testing.T) {
-		t.Run("no error", func(t *testing.T) {
+	t.Run("retry timeout errors policy", func(t *testing.T) {
+		t.Run("timeout error", func(t *testing.T) {
 			callCount := 0
 
-			client := newNoErrClient(func(req *http.Request) {
+			client := newTimeoutErrClient(func(req *http.Request) {
 				callCount++
+			})
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutErrors).
+				WithMaxRetries(2).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect()
+			resp.chain.assertFailed(t)
+
+			// Should retry
+			assert.Equal(t, 3, callCount)
+		})
+
+		t.Run("temporary network error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTempNetErrClient(func(req *http.Request) {
+				callCount++
 			})
 
 			config := Config{
@@ -2936,26 +4151,54 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors)
+				WithRetryPolicy(RetryTimeoutErrors).
+				WithMaxRetries(2).
+				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
 			resp := req.Expect()
-			resp.chain.assertNotFailed(t)
+			resp.chain.assertFailed(t)
 
 			// Should not retry
 			assert.Equal(t, 1, callCount)
 		})
 
-		t.Run("temporary network error", func(t *testing.T) {
+		t.Run("temporary server error", func(t *testing.T) {
 			callCount := 0
 
-			client := newTempNetErrClient(func(req *http.Request) {
+			client := newTempServerErrClient(func(req *http.Request) {
 				callCount++
+			})
 
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutErrors).
+				WithMaxRetries(2).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusInternalServerError)
+			resp.chain.assertNotFailed(t)
+
+			// Should not retry
+			assert.Equal(t, 1, callCount)
+		})
+	})
+
+	t.Run("retry timeout and server errors policy", func(t *testing.T) {
+		t.Run("timeout error", func(t *testing.T) {
+			callCount := 0
+
+			client := newTimeoutErrClient(func(req *http.Request) {
+				callCount++
 			})
 
 			config := Config{
@@ -2965,8 +4208,8 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
-				WithMaxRetries(1).
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(2).
 				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
@@ -2975,7 +4218,7 @@ func TestRequest_Retry(t *testing.T) {
 			resp.chain.assertFailed(t)
 
 			// Should retry
-			assert.Equal(t, 2, callCount)
+			assert.Equal(t, 3, callCount)
 		})
 
 		t.Run("temporary server error", func(t *testing.T) {
@@ -2996,8 +4239,8 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
-				WithMaxRetries(1).
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(2).
 				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
@@ -3007,7 +4250,35 @@ func TestRequest_Retry(t *testing.T) {
 			resp.chain.assertNotFailed(t)
 
 			// Should retry
-			assert.Equal(t, 2, callCount)
+			assert.Equal(t, 3, callCount)
+		})
+
+		t.Run("too many requests", func(t *testing.T) {
+			callCount := 0
+
+			client := newTooManyRequestsClient(func(req *http.Request) {
+				callCount++
+			})
+
+			config := Config{
+				Client:   client,
+				Reporter: reporter,
+			}
+
+			req := NewRequestC(config, http.MethodPost, "/url").
+				WithText("test body").
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(2).
+				WithRetryDelay(0, 0)
+			req.sleepFn = noopSleepFn
+			req.chain.assertNotFailed(t)
+
+			resp := req.Expect().
+				Status(http.StatusTooManyRequests)
+			resp.chain.assertNotFailed(t)
+
+			// Should retry
+			assert.Equal(t, 3, callCount)
 		})
 
 		t.Run("http error", func(t *testing.T) {
@@ -3015,10 +4286,6 @@ func TestRequest_Retry(t *testing.T) {
 
 			client := newHTTPErrClient(func(req *http.Request) {
 				callCount++
-
-				b, err := ioutil.ReadAll(req.Body)
-				assert.NoError(t, err)
-				assert.Equal(t, "test body", string(b))
 			})
 
 			config := Config{
@@ -3028,8 +4295,9 @@ func TestRequest_Retry(t *testing.T) {
 
 			req := NewRequestC(config, http.MethodPost, "/url").
 				WithText("test body").
-				WithRetryPolicy(RetryTemporaryNetworkAndServerErrors).
-				WithMaxRetries(1)
+				WithRetryPolicy(RetryTimeoutAndServerErrors).
+				WithMaxRetries(2).
+				WithRetryDelay(0, 0)
 			req.sleepFn = noopSleepFn
 			req.chain.assertNotFailed(t)
 
@@ -3313,3 +4581,242 @@ func TestRequest_Retry(t *testing.T) {
 		assert.Equal(t, 1, callCount)
 	})
 }
+
+func TestRequest_RetryAfter(t *testing.T) {
+	type step struct {
+		status     int
+		retryAfter string
+	}
+
+	run := func(
+		t *testing.T, steps []step, configure func(*Request),
+	) (*Response, int, []time.Duration) {
+		var (
+			mu    sync.Mutex
+			calls int
+		)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			st := step{status: http.StatusOK}
+			if calls < len(steps) {
+				st = steps[calls]
+			}
+			calls++
+
+			if st.retryAfter != "" {
+				w.Header().Set("Retry-After", st.retryAfter)
+			}
+			w.WriteHeader(st.status)
+		})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		config := Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		}
+
+		var delays []time.Duration
+
+		req := NewRequestC(config, http.MethodGet, "/")
+		req.sleepFn = func(d time.Duration) <-chan time.Time {
+			delays = append(delays, d)
+			return time.After(0)
+		}
+
+		configure(req)
+		req.chain.assertNotFailed(t)
+
+		resp := req.Expect()
+
+		return resp, calls, delays
+	}
+
+	t.Run("retry after delay", func(t *testing.T) {
+		resp, calls, delays := run(t,
+			[]step{
+				{http.StatusTooManyRequests, "3"},
+				{http.StatusServiceUnavailable, "1"},
+			},
+			func(req *Request) {
+				req.WithMaxRetries(5).
+					WithRetryPolicy(DontRetry).
+					WithRetryAfter(time.Minute)
+			})
+
+		resp.Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{3 * time.Second, time.Second}, delays)
+	})
+
+	t.Run("retry after date", func(t *testing.T) {
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+		resp, calls, delays := run(t,
+			[]step{
+				{http.StatusTooManyRequests, date},
+			},
+			func(req *Request) {
+				req.WithMaxRetries(1).
+					WithRetryAfter(2 * time.Hour)
+			})
+
+		resp.Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 2, calls)
+		if assert.Equal(t, 1, len(delays)) {
+			assert.InDelta(t, float64(time.Hour), float64(delays[0]),
+				float64(time.Minute))
+		}
+	})
+
+	t.Run("delay too long", func(t *testing.T) {
+		resp, calls, delays := run(t,
+			[]step{
+				{http.StatusTooManyRequests, "120"},
+			},
+			func(req *Request) {
+				req.WithMaxRetries(1).
+					WithRetryAfter(time.Minute)
+			})
+
+		resp.Status(http.StatusTooManyRequests)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, delays)
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		resp, calls, delays := run(t,
+			[]step{
+				{http.StatusTooManyRequests, "1"},
+				{http.StatusTooManyRequests, "1"},
+			},
+			func(req *Request) {
+				req.WithMaxRetries(1).
+					WithRetryAfter(time.Minute)
+			})
+
+		resp.Status(http.StatusTooManyRequests)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, []time.Duration{time.Second}, delays)
+	})
+
+	t.Run("other status", func(t *testing.T) {
+		resp, calls, delays := run(t,
+			[]step{
+				{http.StatusBadRequest, "1"},
+			},
+			func(req *Request) {
+				req.WithMaxRetries(1).
+					WithRetryPolicy(DontRetry).
+					WithRetryAfter(time.Minute)
+			})
+
+		resp.Status(http.StatusBadRequest)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, delays)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		resp, calls, delays := run(t,
+			[]step{
+				{http.StatusTooManyRequests, "10"},
+			},
+			func(req *Request) {
+				req.WithMaxRetries(1).
+					WithRetryPolicy(RetryTimeoutAndServerErrors).
+					WithRetryDelay(time.Millisecond, time.Millisecond)
+			})
+
+		resp.Status(http.StatusOK)
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, []time.Duration{time.Millisecond}, delays)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Reporter: newMockReporter(t),
+			Client:   &mockClient{},
+		}, http.MethodGet, "/")
+
+		req.WithRetryAfter(0)
+		req.chain.assertFailed(t)
+	})
+}
+
+func TestRequest_ContextErrors(t *testing.T) {
+	t.Run("timed out request", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		client := &mockClient{
+			err: &url.Error{
+				Op:  "Post",
+				URL: "/url",
+				Err: context.DeadlineExceeded,
+			},
+		}
+
+		config := Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithTimeout(time.Millisecond)
+		req.chain.assertNotFailed(t)
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors, errors.New("request timed out"))
+	})
+
+	t.Run("cancelled request", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := &mockClient{
+			err: &url.Error{
+				Op:  "Post",
+				URL: "/url",
+				Err: context.Canceled,
+			},
+		}
+
+		config := Config{
+			Client:           client,
+			AssertionHandler: handler,
+		}
+
+		req := NewRequestC(config, http.MethodPost, "/url").
+			WithContext(ctx)
+		req.chain.assertNotFailed(t)
+
+		resp := req.Expect()
+		resp.chain.assertFailed(t)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Contains(t, handler.failure.Errors,
+			errors.New("request was cancelled"))
+	})
+}
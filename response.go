@@ -2,20 +2,35 @@ package httpexpect
 
 import (
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime"
+	"net"
 	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ajg/form"
+	"github.com/andybalholm/brotli"
+	"github.com/gavv/httpexpect/v2/openapi"
 	"github.com/gorilla/websocket"
+	"golang.org/x/text/encoding/htmlindex"
+	"gopkg.in/yaml.v2"
 )
 
 // Response provides methods to inspect attached http.Response object.
@@ -26,10 +41,14 @@ type Response struct {
 
 	httpResp  *http.Response
 	websocket *websocket.Conn
+	redirects []*http.Response
 	rtt       *time.Duration
+	trace     *responseTrace
+	download  *responseDownload
 
-	content []byte
-	cookies []*http.Cookie
+	content    []byte
+	rawContent []byte
+	cookies    []*http.Cookie
 }
 
 // NewResponse returns a new Response instance.
@@ -78,7 +97,10 @@ type responseOpts struct {
 	chain     *chain
 	httpResp  *http.Response
 	websocket *websocket.Conn
+	redirects []*http.Response
 	rtt       []time.Duration
+	trace     *responseTrace
+	download  *responseDownload
 }
 
 func newResponse(opts responseOpts) *Response {
@@ -115,8 +137,22 @@ func newResponse(opts responseOpts) *Response {
 
 	r.httpResp = opts.httpResp
 	r.websocket = opts.websocket
+	r.redirects = opts.redirects
+	r.trace = opts.trace
+	r.download = opts.download
 
-	r.content = getResponseContent(opChain, r.httpResp)
+	if r.download != nil && r.download.err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to write response body to %q", r.download.path),
+				r.download.err,
+			},
+		})
+	}
+
+	r.rawContent = getResponseContent(opChain, r.httpResp)
+	r.content = decodeResponseContent(opChain, r.httpResp, r.rawContent)
 	r.cookies = r.httpResp.Cookies()
 
 	if len(opts.rtt) > 0 {
@@ -140,6 +176,15 @@ func getResponseContent(opChain *chain, resp *http.Response) []byte {
 
 	content, err := ioutil.ReadAll(resp.Body)
 
+	// endless event or NDJSON stream is interrupted by request timeout;
+	// keep content received so far
+	if err != nil && isTimeoutError(err) && isStreamingContent(resp) {
+		if bw, ok := resp.Body.(*bodyWrapper); ok {
+			content = bw.Bytes()
+		}
+		err = nil
+	}
+
 	closeErr := resp.Body.Close()
 	if err == nil {
 		err = closeErr
@@ -159,6 +204,106 @@ func getResponseContent(opChain *chain, resp *http.Response) []byte {
 	return content
 }
 
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+func isEventStream(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	return mediaType == "text/event-stream"
+}
+
+// event streams and NDJSON streams may be endless, so their bodies are read
+// until request timeout
+func isStreamingContent(resp *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "text/event-stream",
+		"application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return true
+	}
+
+	return false
+}
+
+// decodeResponseContent decodes content according to Content-Encoding
+// header; encodings are undone in reverse order of their application
+func decodeResponseContent(
+	opChain *chain, resp *http.Response, content []byte,
+) []byte {
+	if len(content) == 0 {
+		return content
+	}
+
+	var encodings []string
+	for _, h := range resp.Header.Values("Content-Encoding") {
+		for _, enc := range strings.Split(h, ",") {
+			encodings = append(encodings, strings.TrimSpace(enc))
+		}
+	}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		decoded, err := decodeContent(encodings[i], content)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to decode response body with %q encoding",
+						encodings[i]),
+					err,
+				},
+			})
+			return nil
+		}
+		content = decoded
+	}
+
+	return content
+}
+
+func decodeContent(encoding string, content []byte) ([]byte, error) {
+	var reader io.Reader
+
+	switch strings.ToLower(encoding) {
+	case "gzip", "x-gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		reader = gr
+
+	case "deflate":
+		// "deflate" is defined as zlib format, but some servers send
+		// raw deflate stream instead
+		zr, err := zlib.NewReader(bytes.NewReader(content))
+		if err != nil {
+			reader = flate.NewReader(bytes.NewReader(content))
+		} else {
+			reader = zr
+		}
+
+	case "br":
+		reader = brotli.NewReader(bytes.NewReader(content))
+
+	default:
+		// identity or unsupported encoding; leave content as is
+		return content, nil
+	}
+
+	return ioutil.ReadAll(reader)
+}
+
 // Raw returns underlying http.Response object.
 // This is the value originally passed to NewResponse.
 func (r *Response) Raw() *http.Response {
@@ -186,6 +331,118 @@ func (r *Response) RoundTripTime() *Duration {
 	return newDuration(opChain, r.rtt)
 }
 
+// Timings returns a new Object instance with durations of request phases,
+// collected using net/http/httptrace. Durations are in milliseconds.
+//
+// Object has the following keys:
+//   - "dns" - DNS lookup
+//   - "connect" - TCP connection establishment
+//   - "tls" - TLS handshake
+//   - "ttfb" - time to first response byte, since request was sent
+//   - "total" - round-trip time, same as RoundTripTime
+//
+// Phases that didn't happen, e.g. DNS lookup and connection establishment
+// when connection was reused, have zero duration. Phases are reported only
+// by clients which support httptrace, like http.Client with default
+// transport.
+//
+// Timings are available only for responses returned by Request.Expect.
+//
+// Example:
+//
+//	resp := e.GET("/path").Expect()
+//	resp.Timings().Value("ttfb").Number().Lt(200)
+//	resp.Timings().Value("tls").Number().Lt(50)
+func (r *Response) Timings() *Object {
+	opChain := r.chain.enter("Timings()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if !r.checkTrace(opChain, "timings") {
+		return newObject(opChain, nil)
+	}
+
+	timings := r.trace.timings()
+
+	var total time.Duration
+	if r.rtt != nil {
+		total = *r.rtt
+	}
+
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+
+	return newObject(opChain, map[string]interface{}{
+		"dns":     ms(timings.dns),
+		"connect": ms(timings.connect),
+		"tls":     ms(timings.tls),
+		"ttfb":    ms(timings.ttfb),
+		"total":   ms(total),
+	})
+}
+
+// ConnectionReused returns a new Boolean instance with true value if
+// response was received over a connection reused from the pool of
+// idle connections, and false if a new connection was established.
+//
+// This can be used to verify keep-alive behavior.
+//
+// Connection info is available only for responses returned by Request.Expect,
+// and only if client supports httptrace, like http.Client with default
+// transport. If request was retried or redirected, the last connection
+// is reported.
+//
+// Example:
+//
+//	e.GET("/path").Expect().ConnectionReused().False()
+//	e.GET("/path").Expect().ConnectionReused().True()
+func (r *Response) ConnectionReused() *Boolean {
+	opChain := r.chain.enter("ConnectionReused()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBoolean(opChain, false)
+	}
+
+	conn, ok := r.getConn(opChain)
+	if !ok {
+		return newBoolean(opChain, false)
+	}
+
+	return newBoolean(opChain, conn.reused)
+}
+
+// RemoteAddr returns a new String instance with remote address of the
+// connection over which response was received, in "host:port" form.
+//
+// This can be used to verify load-balancer affinity behavior.
+//
+// Same restrictions as for ConnectionReused apply.
+//
+// Example:
+//
+//	resp := e.GET("/path").Expect()
+//	resp.RemoteAddr().Equal("127.0.0.1:8080")
+func (r *Response) RemoteAddr() *String {
+	opChain := r.chain.enter("RemoteAddr()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	conn, ok := r.getConn(opChain)
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, conn.remoteAddr)
+}
+
 // Deprecated: use RoundTripTime instead.
 func (r *Response) Duration() *Number {
 	opChain := r.chain.enter("Duration()")
@@ -404,6 +661,80 @@ func (r *Response) Header(header string) *String {
 	return newString(opChain, value)
 }
 
+// HeaderValues returns a new Array instance with all values of given
+// header field. Returned Array contains a String value for every
+// occurrence of the header in response.
+//
+// Unlike Header, which returns only the first value, this method may be
+// used to inspect repeated headers like Vary or Set-Cookie.
+// Comma-separated values within a single header line are not split.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HeaderValues("Vary").ContainsOnly("Accept", "Accept-Encoding")
+func (r *Response) HeaderValues(header string) *Array {
+	opChain := r.chain.enter("HeaderValues(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	values := []interface{}{}
+	for _, v := range r.httpResp.Header.Values(header) {
+		values = append(values, v)
+	}
+
+	return newArray(opChain, values)
+}
+
+// Trailers returns a new Object instance with response trailer map.
+//
+// Trailers are sent by server after response body, e.g. in chunked
+// responses. If response has no trailers, returned Object is empty.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Trailers().ContainsKey("Checksum")
+func (r *Response) Trailers() *Object {
+	opChain := r.chain.enter("Trailers()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if r.httpResp.Trailer == nil {
+		return newObject(opChain, map[string]interface{}{})
+	}
+
+	var value map[string]interface{}
+	value, _ = canonMap(opChain, r.httpResp.Trailer)
+
+	return newObject(opChain, value)
+}
+
+// Trailer returns a new String instance with given trailer field.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Trailer("Checksum").Equal("1234")
+func (r *Response) Trailer(trailer string) *String {
+	opChain := r.chain.enter("Trailer(%q)", trailer)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value := r.httpResp.Trailer.Get(trailer)
+
+	return newString(opChain, value)
+}
+
 // Cookies returns a new Array instance with all cookie names set by this response.
 // Returned Array contains a String value for every cookie name.
 //
@@ -475,202 +806,2238 @@ func (r *Response) Cookie(name string) *Cookie {
 	return cookie
 }
 
-// Websocket returns Websocket instance for interaction with WebSocket server.
+// Redirects returns a new slice of Response instances, one for every
+// intermediate redirection response received before the final response.
 //
-// May be called only if the WithWebsocketUpgrade was called on the request.
-// That is responsibility of the caller to explicitly disconnect websocket after use.
+// Redirects are listed in the order in which they were received. If no
+// redirects were followed (e.g. because of DontFollowRedirects policy),
+// the returned slice is empty.
+//
+// Intermediate responses are available only if Client is *http.Client,
+// since we rely on it in redirect handling.
 //
 // Example:
 //
-//	req := NewRequestC(config, "GET", "/path")
-//	req.WithWebsocketUpgrade()
-//	ws := req.Expect().Websocket()
-//	defer ws.Disconnect()
-func (r *Response) Websocket() *Websocket {
-	opChain := r.chain.enter("Websocket()")
+//	resp := req.WithRedirectPolicy(FollowAllRedirects).Expect()
+//	resp.Status(http.StatusOK)
+//
+//	redirects := resp.Redirects()
+//	redirects[0].Status(http.StatusFound)
+//	redirects[0].Header("Location").Equal("/login")
+func (r *Response) Redirects() []*Response {
+	opChain := r.chain.enter("Redirects()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newWebsocket(opChain, r.config, nil)
+		return []*Response{}
 	}
 
-	if r.websocket == nil {
-		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
-			Errors: []error{
-				errors.New(
-					"Websocket() requires WithWebsocketUpgrade() to be called on request"),
-			},
-		})
-		return newWebsocket(opChain, r.config, nil)
+	ret := []*Response{}
+
+	for index, redirect := range r.redirects {
+		func() {
+			respChain := opChain.replace("Redirects[%v]", index)
+			defer respChain.leave()
+
+			ret = append(ret, newResponse(responseOpts{
+				config:   r.config,
+				chain:    respChain,
+				httpResp: redirect,
+			}))
+		}()
 	}
 
-	return newWebsocket(opChain, r.config, r.websocket)
+	return ret
 }
 
-// Body returns a new String instance with response body.
+// HasNextPage returns a new Boolean instance with true value if paginated
+// response has the next page.
+//
+// See PageOpts for how the next page is found.
 //
 // Example:
 //
-//	resp := NewResponse(t, response)
-//	resp.Body().NotEmpty()
-//	resp.Body().Length().Equal(100)
-func (r *Response) Body() *String {
-	opChain := r.chain.enter("Body()")
-	defer opChain.leave()
-
-	return newString(opChain, string(r.content))
-}
-
-// NoContent succeeds if response contains empty Content-Type header and
-// empty body.
-func (r *Response) NoContent() *Response {
-	opChain := r.chain.enter("NoContent()")
+//	resp := e.GET("/items").Expect()
+//	resp.HasNextPage().True()
+//
+//	resp = e.GET("/items").WithQuery("page", 10).Expect()
+//	resp.HasNextPage().False()
+func (r *Response) HasNextPage(options ...PageOpts) *Boolean {
+	opChain := r.chain.enter("HasNextPage()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return r
+		return newBoolean(opChain, false)
 	}
 
-	contentType := r.httpResp.Header.Get("Content-Type")
+	if !r.checkPageOptions(opChain, options) {
+		return newBoolean(opChain, false)
+	}
 
-	r.checkEqual(opChain, `"Content-Type" header`, "", contentType)
-	r.checkEqual(opChain, "body", "", string(r.content))
+	u, ok := r.nextPageURL(opChain, r.pageOptions(options))
+	if !ok {
+		return newBoolean(opChain, false)
+	}
 
-	return r
+	return newBoolean(opChain, u != nil)
 }
 
-// ContentType succeeds if response contains Content-Type header with given
-// media type and charset.
+// NextPage sends request for the next page of paginated response and
+// returns a new Response instance.
 //
-// If charset is omitted, and mediaType is non-empty, Content-Type header
-// should contain empty or utf-8 charset.
+// See PageOpts for how the next page is found. The next page is requested
+// using GET method and same headers as the request of current response,
+// except cookies (which are handled by client) and Content-* headers.
+// If the next page is on another host (not a subdomain), "Authorization"
+// header is not sent, like http.Client does on redirects.
 //
-// If charset is omitted, and mediaType is also empty, Content-Type header
-// should contain no charset.
-func (r *Response) ContentType(mediaType string, charset ...string) *Response {
-	opChain := r.chain.enter("ContentType()")
+// If response has no next page, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/items").Expect()
+//	resp.NextPage().Status(http.StatusOK).JSON().Array().NotEmpty()
+//
+//	resp.NextPage(PageOpts{CursorPath: "$.next_cursor"}).
+//		Status(http.StatusOK)
+func (r *Response) NextPage(options ...PageOpts) *Response {
+	opChain := r.chain.enter("NextPage()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return r
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
 	}
 
-	if len(charset) > 1 {
+	if !r.checkPageOptions(opChain, options) {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	u, ok := r.nextPageURL(opChain, r.pageOptions(options))
+	if !ok {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	if u == nil {
 		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
+			Type: AssertOperation,
 			Errors: []error{
-				errors.New("unexpected multiple charset arguments"),
+				errors.New("expected: response has next page"),
 			},
 		})
-		return r
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
 	}
 
-	r.checkContentType(opChain, mediaType, charset...)
-
-	return r
+	return r.resend(opChain, http.MethodGet, u, nil)
 }
 
-// ContentEncoding succeeds if response has exactly given Content-Encoding list.
-// Common values are empty, "gzip", "compress", "deflate", "identity" and "br".
-func (r *Response) ContentEncoding(encoding ...string) *Response {
-	opChain := r.chain.enter("ContentEncoding()")
+// AllPages follows pagination starting from current response and returns
+// a new slice of Response instances, one for every page, including the
+// current one.
+//
+// See NextPage for how the pages are requested. Pagination stops when a
+// page has no next page, or when a page request fails. If number of pages
+// exceeds PageOpts.MaxPages, or if pages form a loop, failure is reported.
+//
+// Example:
+//
+//	var items []interface{}
+//
+//	for _, page := range e.GET("/items").Expect().AllPages() {
+//		items = append(items, page.JSON().Array().Raw()...)
+//	}
+//
+//	assert.Equal(t, 42, len(items))
+func (r *Response) AllPages(options ...PageOpts) []*Response {
+	opChain := r.chain.enter("AllPages()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return r
+		return []*Response{}
 	}
 
-	r.checkEqual(opChain, `"Content-Encoding" header`,
-		encoding,
-		r.httpResp.Header["Content-Encoding"])
+	if !r.checkPageOptions(opChain, options) {
+		return []*Response{}
+	}
 
-	return r
-}
+	opts := r.pageOptions(options)
 
-// TransferEncoding succeeds if response contains given Transfer-Encoding list.
-// Common values are empty, "chunked" and "identity".
-func (r *Response) TransferEncoding(encoding ...string) *Response {
-	opChain := r.chain.enter("TransferEncoding()")
-	defer opChain.leave()
+	ret := []*Response{r}
 
-	if opChain.failed() {
-		return r
+	visited := map[string]bool{}
+	if u := r.requestURL(); u != nil {
+		visited[u.String()] = true
 	}
 
-	r.checkEqual(opChain, `"Transfer-Encoding" header`,
-		encoding,
-		r.httpResp.TransferEncoding)
+	for page := r; ; {
+		u, ok := page.nextPageURL(opChain, opts)
+		if !ok || u == nil {
+			break
+		}
+
+		if visited[u.String()] {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("unexpected pagination loop: page was already visited"),
+					fmt.Errorf("page url: %s", u.String()),
+				},
+			})
+			break
+		}
+
+		if len(ret) == opts.maxPages() {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("unexpected number of pages: more than %d",
+						opts.maxPages()),
+				},
+			})
+			break
+		}
+
+		visited[u.String()] = true
+
+		var failed bool
+
+		func() {
+			pageChain := opChain.replace("AllPages[%v]", len(ret))
+			defer pageChain.leave()
+
+			page = r.resend(pageChain, http.MethodGet, u, nil)
+			failed = page.chain.failed()
+		}()
+
+		if failed {
+			break
+		}
+
+		ret = append(ret, page)
+	}
+
+	return ret
+}
+
+func (r *Response) checkPageOptions(opChain *chain, options []PageOpts) bool {
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return false
+	}
+	return true
+}
+
+func (r *Response) pageOptions(options []PageOpts) PageOpts {
+	if len(options) != 0 {
+		return options[0]
+	}
+	return PageOpts{}
+}
+
+// Websocket returns Websocket instance for interaction with WebSocket server.
+//
+// May be called only if the WithWebsocketUpgrade was called on the request.
+// That is responsibility of the caller to explicitly disconnect websocket after use.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "/path")
+//	req.WithWebsocketUpgrade()
+//	ws := req.Expect().Websocket()
+//	defer ws.Disconnect()
+func (r *Response) Websocket() *Websocket {
+	opChain := r.chain.enter("Websocket()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newWebsocket(opChain, r.config, nil)
+	}
+
+	if r.websocket == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"Websocket() requires WithWebsocketUpgrade() to be called on request"),
+			},
+		})
+		return newWebsocket(opChain, r.config, nil)
+	}
+
+	return newWebsocket(opChain, r.config, r.websocket)
+}
+
+// Proto returns a new String instance with protocol of response,
+// e.g. "HTTP/1.1" or "HTTP/2.0".
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Proto().Equal("HTTP/2.0")
+func (r *Response) Proto() *String {
+	opChain := r.chain.enter("Proto()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, r.httpResp.Proto)
+}
+
+// ProtoAtLeast succeeds if protocol version of response is at least
+// major.minor.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ProtoAtLeast(2, 0)
+func (r *Response) ProtoAtLeast(major, minor int) *Response {
+	opChain := r.chain.enter("ProtoAtLeast()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.httpResp.ProtoAtLeast(major, minor) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertGe,
+			Actual:   &AssertionValue{r.httpResp.Proto},
+			Expected: &AssertionValue{fmt.Sprintf("HTTP/%d.%d", major, minor)},
+			Errors: []error{
+				errors.New("expected: response protocol version is at least given one"),
+			},
+		})
+	}
 
 	return r
 }
 
-// ContentOpts define parameters for matching the response content parameters.
-type ContentOpts struct {
-	// The media type Content-Type part, e.g. "application/json"
+// RateLimit returns a new Object instance with rate limit info parsed
+// from response headers.
+//
+// Object may have the following keys:
+//   - "limit" - from RateLimit-Limit or X-RateLimit-Limit header
+//   - "remaining" - from RateLimit-Remaining or X-RateLimit-Remaining header
+//   - "reset" - from RateLimit-Reset or X-RateLimit-Reset header, in seconds
+//   - "retry_after" - from Retry-After header, in seconds
+//
+// Keys are present only if corresponding headers are present. If both
+// standard and X- headers are present, standard one is used. If limit
+// or remaining contains a quota policy, like "100, 100;w=60", only the
+// first number is used.
+//
+// Reset and Retry-After are converted to delay from now: Retry-After may
+// be an HTTP date, and X-RateLimit-Reset may be a unix time. Reset values
+// larger than 100000000 are treated as unix time.
+//
+// If any of the headers has invalid value, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.RateLimit().Value("remaining").Number().Gt(0)
+//	resp.RateLimit().Value("retry_after").Number().Le(60)
+func (r *Response) RateLimit() *Object {
+	opChain := r.chain.enter("RateLimit()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	now := time.Now()
+
+	result := map[string]interface{}{}
+
+	invalid := func(key, value string) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("invalid %q response header", key),
+			},
+		})
+	}
+
+	for _, name := range []string{"Limit", "Remaining"} {
+		key, value := rateLimitHeader(r.httpResp.Header, name)
+		if key == "" {
+			continue
+		}
+
+		n, ok := parseRateLimitValue(value)
+		if !ok {
+			invalid(key, value)
+			return newObject(opChain, nil)
+		}
+
+		result[strings.ToLower(name)] = float64(n)
+	}
+
+	if key, value := rateLimitHeader(r.httpResp.Header, "Reset"); key != "" {
+		d, ok := parseRateLimitReset(value, now)
+		if !ok {
+			invalid(key, value)
+			return newObject(opChain, nil)
+		}
+
+		result["reset"] = d.Seconds()
+	}
+
+	if value := r.httpResp.Header.Get("Retry-After"); value != "" {
+		d, ok := parseRetryAfter(value, now)
+		if !ok {
+			invalid("Retry-After", value)
+			return newObject(opChain, nil)
+		}
+
+		result["retry_after"] = d.Seconds()
+	}
+
+	return newObject(opChain, result)
+}
+
+// ETag returns a new String instance with value of "ETag" response header.
+//
+// Value is returned as is, including quotes and weak validator prefix,
+// e.g. `"abc"` or `W/"abc"`. If header is missing, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ETag().Equal(`"abc"`)
+//	resp.ETag().HasPrefix("W/")
+func (r *Response) ETag() *String {
+	opChain := r.chain.enter("ETag()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value, ok := r.getHeader(opChain, "ETag")
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
+
+// LastModified returns a new DateTime instance with value of "Last-Modified"
+// response header.
+//
+// If header is missing or is not a valid HTTP date, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.LastModified().Le(time.Now())
+func (r *Response) LastModified() *DateTime {
+	opChain := r.chain.enter("LastModified()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	value, ok := r.getHeader(opChain, "Last-Modified")
+	if !ok {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Last-Modified" response header`),
+				err,
+			},
+		})
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, t)
+}
+
+// Revalidate repeats request that produced current response as a
+// conditional request and returns a new Response instance.
+//
+// Request is repeated with "If-None-Match" header set to the value of
+// "ETag" header of current response, and "If-Modified-Since" header set
+// to the value of "Last-Modified" header, if they are present. Other
+// headers are same as in the original request, except cookies (which are
+// handled by client) and Content-* headers.
+//
+// This is useful to verify that server responds with 304 Not Modified
+// when resource was not changed.
+//
+// If response has neither "ETag" nor "Last-Modified" header, or if original
+// request method is not GET or HEAD, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/fruits/apple").Expect()
+//	resp.Status(http.StatusOK).ETag().NotEmpty()
+//
+//	resp.Revalidate().Status(http.StatusNotModified).NoContent()
+func (r *Response) Revalidate() *Response {
+	opChain := r.chain.enter("Revalidate()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	req := r.httpResp.Request
+	if req == nil || req.URL == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("can't repeat request: response has no request"),
+			},
+		})
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected request method %q, want GET or HEAD",
+					req.Method),
+			},
+		})
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	header := http.Header{}
+
+	if etag := r.httpResp.Header.Get("ETag"); etag != "" {
+		header.Set("If-None-Match", etag)
+	}
+
+	if lastModified := r.httpResp.Header.Get("Last-Modified"); lastModified != "" {
+		header.Set("If-Modified-Since", lastModified)
+	}
+
+	if len(header) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New(
+					`expected: response has "ETag" or "Last-Modified" header`),
+			},
+		})
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	u := *req.URL
+
+	return r.resend(opChain, req.Method, &u, header)
+}
+
+// CacheControl returns a new CacheControl instance with parsed value of
+// "Cache-Control" response header.
+//
+// If header is repeated, all values are combined. If header is missing
+// or invalid, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CacheControl().Public().True()
+//	resp.CacheControl().MaxAge().Ge(time.Minute)
+//	resp.CacheControl().NoStore().False()
+func (r *Response) CacheControl() *CacheControl {
+	opChain := r.chain.enter("CacheControl()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCacheControl(opChain, "")
+	}
+
+	if _, ok := r.getHeader(opChain, "Cache-Control"); !ok {
+		return newCacheControl(opChain, "")
+	}
+
+	value := strings.Join(r.httpResp.Header.Values("Cache-Control"), ", ")
+
+	return newCacheControl(opChain, value)
+}
+
+// CORS returns a new CORS instance with CORS headers of response.
+//
+// Example:
+//
+//	resp := e.OPTIONS("/fruits").
+//		WithCORSPreflight("https://example.com", "PUT", "Content-Type").
+//		Expect()
+//
+//	resp.CORS().
+//		AllowsOrigin("https://example.com").
+//		AllowsMethod("PUT").
+//		AllowsHeader("Content-Type")
+//	resp.CORS().MaxAge().Ge(time.Hour)
+func (r *Response) CORS() *CORS {
+	opChain := r.chain.enter("CORS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCORS(opChain, nil)
+	}
+
+	return newCORS(opChain, r.httpResp.Header)
+}
+
+// SecurityHeaders returns a new SecurityHeaders instance with headers
+// of response.
+//
+// Example:
+//
+//	resp := e.GET("/").Expect()
+//
+//	// check all baseline headers at once
+//	resp.SecurityHeaders().Audit()
+//
+//	// or only some of them
+//	resp.SecurityHeaders().HaveNoSniff().HaveFrameOptions()
+func (r *Response) SecurityHeaders() *SecurityHeaders {
+	opChain := r.chain.enter("SecurityHeaders()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newSecurityHeaders(opChain, nil)
+	}
+
+	return newSecurityHeaders(opChain, r.httpResp.Header)
+}
+
+// ContentRange returns a new Object instance with parsed value of
+// "Content-Range" response header.
+//
+// Object contains the following keys:
+//   - "unit": range unit, typically "bytes"
+//   - "start", "end": first and last position of range, inclusive;
+//     missing for unsatisfied range (e.g. "bytes */1000" in 416 response)
+//   - "size": complete length of representation; missing if unknown
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/file").WithRange(0, 99).Expect()
+//	resp.Status(http.StatusPartialContent)
+//	resp.ContentRange().Equal(map[string]interface{}{
+//		"unit":  "bytes",
+//		"start": 0,
+//		"end":   99,
+//		"size":  1000,
+//	})
+func (r *Response) ContentRange() *Object {
+	opChain := r.chain.enter("ContentRange()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	value, ok := r.getHeader(opChain, "Content-Range")
+	if !ok {
+		return newObject(opChain, nil)
+	}
+
+	cr, err := parseContentRange(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Content-Range" response header`),
+				err,
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, cr.object())
+}
+
+// Ranges returns a new Array instance with ranges of partial content
+// response.
+//
+// Response status should be 206 Partial Content. If response has
+// "multipart/byteranges" content type, every part becomes an element
+// of the array. Otherwise, the array contains single element built from
+// "Content-Range" header and response body.
+//
+// Every element is an Object with same keys as returned by ContentRange,
+// plus "body" key with range content as string. For multipart responses,
+// "content_type" key is also present if part has "Content-Type" header.
+//
+// If response is not a valid partial content response, failure is reported.
+//
+// Example:
+//
+//	resp := e.GET("/file").WithRange(0, 4).WithRange(10, 14).Expect()
+//
+//	ranges := resp.Ranges()
+//	ranges.Length().Equal(2)
+//	ranges.Element(0).Object().Value("body").String().Equal("hello")
+//	ranges.Element(1).Object().Value("start").Number().Equal(10)
+func (r *Response) Ranges() *Array {
+	opChain := r.chain.enter("Ranges()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	r.checkEqual(opChain, "http status",
+		statusCodeText(http.StatusPartialContent),
+		statusCodeText(r.httpResp.StatusCode))
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	var ranges []byteRange
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType == "multipart/byteranges" {
+		parts, err := parseByteRanges(contentType, r.content)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(r.content)},
+				Errors: []error{
+					errors.New("invalid multipart/byteranges response body"),
+					err,
+				},
+			})
+			return newArray(opChain, nil)
+		}
+
+		ranges = parts
+	} else {
+		value, ok := r.getHeader(opChain, "Content-Range")
+		if !ok {
+			return newArray(opChain, nil)
+		}
+
+		cr, err := parseContentRange(value)
+		if err == nil && !cr.hasRange {
+			err = errors.New("unexpected unsatisfied range")
+		}
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{value},
+				Errors: []error{
+					errors.New(`invalid "Content-Range" response header`),
+					err,
+				},
+			})
+			return newArray(opChain, nil)
+		}
+
+		if int64(len(r.content)) != cr.end-cr.start+1 {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{len(r.content)},
+				Expected: &AssertionValue{cr.end - cr.start + 1},
+				Errors: []error{
+					errors.New(
+						`expected: response body length matches "Content-Range"`),
+				},
+			})
+			return newArray(opChain, nil)
+		}
+
+		ranges = []byteRange{{
+			contentRange: cr,
+			body:         r.content,
+		}}
+	}
+
+	elements := make([]interface{}, 0, len(ranges))
+	for _, br := range ranges {
+		elements = append(elements, br.object())
+	}
+
+	return newArray(opChain, elements)
+}
+
+// TLS returns a new TLS instance with TLS connection state of response.
+//
+// If response was not received over TLS connection, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.TLS().Version().Equal("TLS 1.3")
+//	resp.TLS().Certificate().NotAfter().Gt(time.Now().Add(time.Hour * 24 * 30))
+func (r *Response) TLS() *TLS {
+	opChain := r.chain.enter("TLS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newTLS(opChain, nil)
+	}
+
+	if r.httpResp.TLS == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{r.httpResp.TLS},
+			Errors: []error{
+				errors.New("expected: response received over TLS connection"),
+			},
+		})
+		return newTLS(opChain, nil)
+	}
+
+	return newTLS(opChain, r.httpResp.TLS)
+}
+
+// Body returns a new String instance with response body.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Body().NotEmpty()
+//	resp.Body().Length().Equal(100)
+func (r *Response) Body() *String {
+	opChain := r.chain.enter("Body()")
+	defer opChain.leave()
+
+	return newString(opChain, string(r.content))
+}
+
+// Binary returns a new Binary instance with response body bytes.
+//
+// Unlike Body, which treats body as text, Binary is intended for
+// files, images, and other binary payloads; failure reports include
+// hex dump of the body.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Binary().HasPrefix([]byte("\x89PNG\r\n\x1a\n"))
+//	resp.Binary().SHA256().Equal(expectedChecksum)
+func (r *Response) Binary() *Binary {
+	opChain := r.chain.enter("Binary()")
+	defer opChain.leave()
+
+	return newBinary(opChain, r.content)
+}
+
+// Download writes response body to file with given path and returns
+// a new Binary instance with written bytes, which may be used to check
+// size and checksum of downloaded file.
+//
+// If file already exists, it is truncated. If body is compressed,
+// decoded body is written.
+//
+// Note that response body is read into memory before assertions, so that
+// it can be printed and inspected multiple times. For large bodies, use
+// Request.WithDownload instead, which streams body to file.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	bin := resp.Download("/tmp/artifact.tar.gz")
+//	bin.Length().Equal(1024)
+//	bin.SHA256().Equal(expectedChecksum)
+func (r *Response) Download(path string) *Binary {
+	opChain := r.chain.enter("Download()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newBinary(opChain, nil)
+	}
+
+	if err := ioutil.WriteFile(path, r.content, 0644); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to write response body to %q", path),
+				err,
+			},
+		})
+		return newBinary(opChain, nil)
+	}
+
+	return newBinary(opChain, r.content)
+}
+
+// DownloadedLength returns a new Number instance with number of bytes
+// written to file, when response body was streamed to file using
+// Request.WithDownload.
+//
+// Example:
+//
+//	resp := req.WithDownload("/tmp/artifact.tar.gz").Expect()
+//	resp.DownloadedLength().Equal(1024)
+func (r *Response) DownloadedLength() *Number {
+	opChain := r.chain.enter("DownloadedLength()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if !r.checkDownload(opChain) {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(r.download.length))
+}
+
+// DownloadedSHA256 returns a new String instance with hex-encoded SHA-256
+// checksum of bytes written to file, when response body was streamed to
+// file using Request.WithDownload.
+//
+// Example:
+//
+//	resp := req.WithDownload("/tmp/artifact.tar.gz").Expect()
+//	resp.DownloadedSHA256().Equal(expectedChecksum)
+func (r *Response) DownloadedSHA256() *String {
+	opChain := r.chain.enter("DownloadedSHA256()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if !r.checkDownload(opChain) {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, r.download.sha256)
+}
+
+func (r *Response) checkDownload(opChain *chain) bool {
+	if r.download == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New(
+					"response body was not streamed to file, use Request.WithDownload()"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// MatchSnapshot succeeds if response body matches golden file with
+// given name, stored in Config.SnapshotDir.
+//
+// If body has JSON content type, it is stored as indented JSON and
+// compared as JSON value; if Config.UsePlaceholders is enabled, the golden
+// file may be edited to contain placeholders, like "$any" (see
+// PlaceholderAny). Given paths are ignored during comparison, using the
+// same syntax as in Object.EqualIgnoring. Other bodies are compared
+// byte-by-byte, and ignored paths are not allowed.
+//
+// If Config.UpdateSnapshots is true or HTTPEXPECT_UPDATE_SNAPSHOTS
+// environment variable is set, golden file is (re)written from the
+// response instead. Missing golden file is reported as failure.
+//
+// Name may contain slashes to group snapshots into subdirectories.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.MatchSnapshot("users/get", "created_at", "items[*].id")
+func (r *Response) MatchSnapshot(name string, ignorePaths ...string) *Response {
+	opChain := r.chain.enter("MatchSnapshot()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	isJSON := isJSONContentType(r.httpResp.Header.Get("Content-Type"))
+
+	matchSnapshot(opChain, r.config, name, r.content, isJSON, ignorePaths)
+
+	return r
+}
+
+// NoContent succeeds if response contains empty Content-Type header and
+// empty body.
+func (r *Response) NoContent() *Response {
+	opChain := r.chain.enter("NoContent()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	r.checkEqual(opChain, `"Content-Type" header`, "", contentType)
+	r.checkEqual(opChain, "body", "", string(r.content))
+
+	return r
+}
+
+// ContentType succeeds if response contains Content-Type header with given
+// media type and charset.
+//
+// If charset is omitted, and mediaType is non-empty, Content-Type header
+// should contain empty or utf-8 charset.
+//
+// If charset is omitted, and mediaType is also empty, Content-Type header
+// should contain no charset.
+func (r *Response) ContentType(mediaType string, charset ...string) *Response {
+	opChain := r.chain.enter("ContentType()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if len(charset) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple charset arguments"),
+			},
+		})
+		return r
+	}
+
+	r.checkContentType(opChain, mediaType, charset...)
+
+	return r
+}
+
+// SniffedContentType returns a new String instance with content type
+// detected from response body, ignoring Content-Type header.
+//
+// Detection is performed using http.DetectContentType, which implements
+// https://mimesniff.spec.whatwg.org/ and looks at the first 512 bytes
+// of the body. Result is a media type with optional charset, e.g.
+// "image/png" or "text/plain; charset=utf-8". Bodies with unknown
+// content are reported as "application/octet-stream".
+//
+// This is useful to test servers that omit or mislabel Content-Type.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.SniffedContentType().Equal("image/png")
+//	resp.SniffedContentType().HasPrefix("text/html")
+func (r *Response) SniffedContentType() *String {
+	opChain := r.chain.enter("SniffedContentType()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, http.DetectContentType(r.content))
+}
+
+// ContentEncoding succeeds if response has exactly given Content-Encoding list.
+// Common values are empty, "gzip", "compress", "deflate", "identity" and "br".
+//
+// Note that http.Transport transparently decompresses gzip responses and
+// removes Content-Encoding header, unless Accept-Encoding header was set
+// explicitly in request or DisableCompression is enabled.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ContentEncoding("gzip")
+func (r *Response) ContentEncoding(encoding ...string) *Response {
+	opChain := r.chain.enter("ContentEncoding()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkEqual(opChain, `"Content-Encoding" header`,
+		encoding,
+		r.httpResp.Header["Content-Encoding"])
+
+	return r
+}
+
+// CompressionRatio returns a new Number instance with ratio of decoded
+// response body size to encoded body size, as received from server.
+//
+// Response body is decoded according to Content-Encoding header, with
+// "gzip", "deflate" and "br" encodings supported. If response body is
+// not encoded, or is empty, ratio is 1.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CompressionRatio().Gt(2)
+func (r *Response) CompressionRatio() *Number {
+	opChain := r.chain.enter("CompressionRatio()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if len(r.rawContent) == 0 {
+		return newNumber(opChain, 1)
+	}
+
+	return newNumber(opChain, float64(len(r.content))/float64(len(r.rawContent)))
+}
+
+// TransferEncoding succeeds if response contains given Transfer-Encoding list.
+// Common values are empty, "chunked" and "identity".
+func (r *Response) TransferEncoding(encoding ...string) *Response {
+	opChain := r.chain.enter("TransferEncoding()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return r
+	}
+
+	r.checkEqual(opChain, `"Transfer-Encoding" header`,
+		encoding,
+		r.httpResp.TransferEncoding)
+
+	return r
+}
+
+// ContentOpts define parameters for matching the response content parameters.
+type ContentOpts struct {
+	// The media type Content-Type part, e.g. "application/json"
+	MediaType string
+	// The character set Content-Type part, e.g. "utf-8"
+	Charset string
+}
+
+// Text returns a new String instance with response body.
+//
+// Text succeeds if response contains "text/plain" Content-Type header
+// with empty, "utf-8", or other known charset. Body in other charsets is
+// converted to UTF-8. See also Config.FallbackCharset.
+//
+// Leading byte order mark is removed. If Content-Type doesn't specify
+// charset, UTF-16 body is detected by its byte order mark.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Text().Equal("hello, world!")
+//	resp.Text(ContentOpts{
+//	  MediaType: "text/plain",
+//	}).Equal("hello, world!")
+func (r *Response) Text(options ...ContentOpts) *String {
+	opChain := r.chain.enter("Text()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	content, ok := r.getContent(opChain, options, "text/plain")
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, string(content))
+}
+
+// Form returns a new Object instance with form decoded from response body.
+//
+// Form succeeds if response contains "application/x-www-form-urlencoded"
+// Content-Type header and if form may be decoded from response body.
+// Decoding is performed using https://github.com/ajg/form.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Form().Value("foo").Equal("bar")
+//	resp.Form(ContentOpts{
+//	  MediaType: "application/x-www-form-urlencoded",
+//	}).Value("foo").Equal("bar")
+func (r *Response) Form(options ...ContentOpts) *Object {
+	opChain := r.chain.enter("Form()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	object := r.getForm(opChain, options...)
+
+	return newObject(opChain, object)
+}
+
+func (r *Response) checkTrace(opChain *chain, what string) bool {
+	if r.trace == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("response %s are not available", what),
+				fmt.Errorf("%s are collected only for responses of Request.Expect", what),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (r *Response) getConn(opChain *chain) (responseConn, bool) {
+	if !r.checkTrace(opChain, "connection details") {
+		return responseConn{}, false
+	}
+
+	conn, ok := r.trace.conn()
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("response connection details are not available"),
+				errors.New("client didn't report connection via httptrace"),
+			},
+		})
+		return responseConn{}, false
+	}
+
+	return conn, true
+}
+
+func (r *Response) getHeader(opChain *chain, key string) (string, bool) {
+	return checkHeader(opChain, r.httpResp.Header, key)
+}
+
+// returns first value of header, or reports failure if header is missing
+func checkHeader(opChain *chain, header http.Header, key string) (string, bool) {
+	values := header.Values(key)
+
+	if len(values) == 0 {
+		keys := []string{}
+		for k := range header {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{keys},
+			Expected: &AssertionValue{key},
+			Errors: []error{
+				errors.New("expected: response contains header"),
+			},
+		})
+		return "", false
+	}
+
+	return values[0], true
+}
+
+// resend sends a new request with given method and URL, with same headers as
+// request that produced current response (except credentials, if URL is on
+// another host), overridden by given headers
+func (r *Response) resend(
+	opChain *chain, method string, u *url.URL, header http.Header,
+) *Response {
+	reqChain := opChain.enter("")
+	defer reqChain.leave()
+
+	reqChain.clearRequest()
+
+	req := newRequest(reqChain, r.config, method, "")
+
+	if reqChain.failed() {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  reqChain,
+		})
+	}
+
+	req.httpReq.URL = u
+
+	if r.httpResp.Request != nil {
+		// like http.Client on redirects, credentials are not sent to
+		// another host
+		sameHost := r.httpResp.Request.URL == nil ||
+			isSameOrSubdomain(u.Host, r.httpResp.Request.URL.Host)
+
+		for key, values := range r.httpResp.Request.Header {
+			// cookies are added by client and body headers are not relevant
+			if key == "Cookie" || strings.HasPrefix(key, "Content-") {
+				continue
+			}
+			if !sameHost && isSensitiveHeader(key) {
+				continue
+			}
+			req.httpReq.Header[key] = append([]string(nil), values...)
+		}
+	}
+
+	for key, values := range header {
+		req.httpReq.Header[key] = append([]string(nil), values...)
+	}
+
+	return req.Expect()
+}
+
+// check if host is equal to parent host or is its subdomain;
+// hosts may include port
+func isSameOrSubdomain(host, parent string) bool {
+	host, parent = strings.ToLower(host), strings.ToLower(parent)
+
+	if host == parent {
+		return true
+	}
+
+	return strings.HasSuffix(host, "."+parent)
+}
+
+func isSensitiveHeader(key string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case "Authorization", "Www-Authenticate", "Cookie", "Cookie2":
+		return true
+	}
+	return false
+}
+
+func (r *Response) getForm(
+	opChain *chain, options ...ContentOpts,
+) map[string]interface{} {
+	if !r.checkContentOptions(opChain, options, "application/x-www-form-urlencoded", "") {
+		return nil
+	}
+
+	decoder := form.NewDecoder(bytes.NewReader(r.content))
+
+	var object map[string]interface{}
+
+	if err := decoder.Decode(&object); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(r.content),
+			},
+			Errors: []error{
+				errors.New("failed to decode form"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return object
+}
+
+// JSON returns a new Value instance with JSON decoded from response body.
+//
+// JSON succeeds if response contains "application/json" Content-Type header
+// with empty, "utf-8", or other known charset and if JSON may be decoded from
+// response body. Body in other charsets is converted to UTF-8 before decoding.
+// Leading byte order mark is ignored, like in Text.
+//
+// If Config.StrictJSON is enabled, JSON also fails if the body contains
+// duplicate object keys.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.JSON().Array().Elements("foo", "bar")
+//	resp.JSON(ContentOpts{
+//	  MediaType: "application/json",
+//	}).Array.Elements("foo", "bar")
+func (r *Response) JSON(options ...ContentOpts) *Value {
+	opChain := r.chain.enter("JSON()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	value := r.getJSON(opChain, options...)
+
+	return newValue(opChain, value)
+}
+
+func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
+	content, ok := r.getContent(opChain, options, "application/json")
+	if !ok {
+		return nil
+	}
+
+	var value interface{}
+
+	if err := unmarshalJSON(opChain, content, &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("failed to decode json"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return value
+}
+
+// MsgPack returns a new Value instance with MessagePack document decoded
+// from response body.
+//
+// MsgPack succeeds if response contains "application/msgpack" Content-Type
+// header without charset and if document may be decoded from response body.
+//
+// Decoded value has the same representation as value returned by JSON:
+// maps become objects, all numbers become float64, and binary data
+// becomes string.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.MsgPack().Object().ValueEqual("foo", 123)
+//	resp.MsgPack(ContentOpts{
+//	  MediaType: "application/x-msgpack",
+//	}).Object().ValueEqual("foo", 123)
+func (r *Response) MsgPack(options ...ContentOpts) *Value {
+	opChain := r.chain.enter("MsgPack()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	if !r.checkContentOptions(opChain, options, "application/msgpack", "") {
+		return newValue(opChain, nil)
+	}
+
+	value, err := decodeMsgPack(r.content)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				binaryDump(r.content),
+			},
+			Errors: []error{
+				errors.New("failed to decode msgpack"),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, value)
+}
+
+// YAML returns a new Value instance with YAML document decoded from
+// response body.
+//
+// YAML succeeds if response contains "application/yaml" Content-Type header
+// with empty or "utf-8" charset and if document may be decoded from response
+// body. Decoding is performed using gopkg.in/yaml.v2.
+//
+// Decoded value has the same representation as value returned by JSON:
+// maps become objects with string keys and all numbers become float64.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.YAML().Object().ValueEqual("foo", 123)
+//	resp.YAML(ContentOpts{
+//	  MediaType: "text/yaml",
+//	}).Object().ValueEqual("foo", 123)
+func (r *Response) YAML(options ...ContentOpts) *Value {
+	opChain := r.chain.enter("YAML()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	if !r.checkContentOptions(opChain, options, "application/yaml") {
+		return newValue(opChain, nil)
+	}
+
+	var value interface{}
+
+	if err := yaml.Unmarshal(r.content, &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(r.content),
+			},
+			Errors: []error{
+				errors.New("failed to decode yaml"),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, canonYAML(value))
+}
+
+// Decoded returns a new Value instance with response body decoded using
+// codec registered in Config.Codecs.
+//
+// By default, codec is chosen by media type of Content-Type header. If
+// MediaType option is set, Decoded checks that Content-Type header has
+// given media type and uses codec registered for it.
+//
+// Decoded value is converted to the same representation as value returned
+// by JSON: maps become objects with string keys and all numbers become
+// float64.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Decoded().Object().ValueEqual("foo", 123)
+//	resp.Decoded(ContentOpts{
+//	  MediaType: "application/cbor",
+//	}).Object().ValueEqual("foo", 123)
+func (r *Response) Decoded(options ...ContentOpts) *Value {
+	opChain := r.chain.enter("Decoded()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	if len(r.config.Codecs) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("no codecs registered in Config.Codecs"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	var mediaType string
+
+	if len(options) != 0 && options[0].MediaType != "" {
+		mediaType = options[0].MediaType
+
+		if _, ok := findCodec(r.config.Codecs, mediaType); !ok {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("no codec registered in Config.Codecs for %q",
+						mediaType),
+				},
+			})
+			return newValue(opChain, nil)
+		}
+
+		if !r.checkContentOptions(opChain, options, mediaType) {
+			return newValue(opChain, nil)
+		}
+	} else {
+		contentType := r.httpResp.Header.Get("Content-Type")
+
+		parsedType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{contentType},
+				Errors: []error{
+					errors.New(`invalid "Content-Type" response header`),
+					err,
+				},
+			})
+			return newValue(opChain, nil)
+		}
+
+		if _, ok := findCodec(r.config.Codecs, parsedType); !ok {
+			opChain.fail(AssertionFailure{
+				Type:     AssertBelongs,
+				Actual:   &AssertionValue{parsedType},
+				Expected: &AssertionValue{codecTypes(r.config.Codecs)},
+				Errors: []error{
+					errors.New(
+						`expected: "Content-Type" response header has media type` +
+							` registered in Config.Codecs`),
+				},
+			})
+			return newValue(opChain, nil)
+		}
+
+		if len(options) != 0 && options[0].Charset != "" {
+			if !r.checkContentOptions(opChain, options, parsedType) {
+				return newValue(opChain, nil)
+			}
+		}
+
+		mediaType = parsedType
+	}
+
+	codec, _ := findCodec(r.config.Codecs, mediaType)
+
+	var value interface{}
+
+	if err := codec.Unmarshal(r.content, &value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				binaryDump(r.content),
+			},
+			Errors: []error{
+				fmt.Errorf("failed to decode %q body", mediaType),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, canonYAML(value))
+}
+
+// EventStream returns a new EventStream instance with Server-Sent Events
+// parsed from response body.
+//
+// EventStream succeeds if response contains "text/event-stream"
+// Content-Type header with empty or "utf-8" charset.
+//
+// Event stream is usually endless; use request timeout to stop reading it.
+// If timeout expires while reading event stream, events received before
+// timeout are available and no failure is reported.
+//
+// Example:
+//
+//	resp := req.WithTimeout(time.Second).Expect()
+//	stream := resp.EventStream()
+//	stream.NextEvent().Name().Equal("status")
+//	stream.NextEvent().JSON().Object().ValueEqual("progress", 100)
+func (r *Response) EventStream() *EventStream {
+	opChain := r.chain.enter("EventStream()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newEventStream(opChain, nil)
+	}
+
+	if !r.checkContentType(opChain, "text/event-stream") {
+		return newEventStream(opChain, nil)
+	}
+
+	return newEventStream(opChain, r.content)
+}
+
+// JSONLinesOpts define options for Response.JSONLines.
+type JSONLinesOpts struct {
+	// The media type Content-Type part, "application/x-ndjson" by default
 	MediaType string
-	// The character set Content-Type part, e.g. "utf-8"
+	// The character set Content-Type part, empty or "utf-8" by default
 	Charset string
+	// If non-zero, only first MaxLines lines are decoded and the rest
+	// of received body is ignored
+	MaxLines int
 }
 
-// Text returns a new String instance with response body.
+// JSONLines returns a new Array instance with values decoded from
+// newline-delimited JSON (NDJSON, JSON Lines) response body.
 //
-// Text succeeds if response contains "text/plain" Content-Type header
-// with empty or "utf-8" charset.
+// Every non-empty line of body is decoded as a separate JSON value and
+// becomes an element of returned Array. If MaxLines option is set,
+// decoding stops after given number of lines.
+//
+// Response body is read completely before JSONLines is called. To inspect
+// an endless stream, use Request.WithTimeout: when "application/x-ndjson",
+// "application/jsonl" or "application/x-jsonlines" body is interrupted by
+// request timeout, lines received so far are kept. MaxLines then allows
+// to ignore the last line, which may be incomplete.
+//
+// JSONLines succeeds if response contains "application/x-ndjson"
+// Content-Type header with empty or "utf-8" charset and if every line
+// is valid JSON.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.Text().Equal("hello, world!")
-//	resp.Text(ContentOpts{
-//	  MediaType: "text/plain",
-//	}).Equal("hello, world!")
-func (r *Response) Text(options ...ContentOpts) *String {
-	opChain := r.chain.enter("Text()")
+//	resp.JSONLines().Length().Equal(3)
+//	resp.JSONLines().Element(0).Object().Value("event").Equal("started")
+//	resp.JSONLines(JSONLinesOpts{
+//	  MediaType: "application/jsonl",
+//	  MaxLines:  1,
+//	}).Length().Equal(1)
+func (r *Response) JSONLines(options ...JSONLinesOpts) *Array {
+	opChain := r.chain.enter("JSONLines()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	var opts JSONLinesOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	if opts.MaxLines < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative MaxLines option"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	contentOpts := ContentOpts{
+		MediaType: opts.MediaType,
+		Charset:   opts.Charset,
+	}
+
+	if !r.checkContentOptions(opChain,
+		[]ContentOpts{contentOpts}, "application/x-ndjson") {
+		return newArray(opChain, nil)
+	}
+
+	values := []interface{}{}
+
+	for n, line := range bytes.Split(r.content, []byte("\n")) {
+		if opts.MaxLines != 0 && len(values) == opts.MaxLines {
+			break
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var value interface{}
+
+		if err := unmarshalJSON(opChain, line, &value); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertValid,
+				Actual: &AssertionValue{
+					string(line),
+				},
+				Errors: []error{
+					fmt.Errorf("failed to decode json on line %d", n+1),
+					err,
+				},
+			})
+			return newArray(opChain, nil)
+		}
+
+		values = append(values, value)
+	}
+
+	return newArray(opChain, values)
+}
+
+// CSVOpts define parameters for decoding CSV response body.
+type CSVOpts struct {
+	// The media type Content-Type part, "text/csv" by default
+	MediaType string
+	// The character set Content-Type part, empty or "utf-8" by default
+	Charset string
+	// Field delimiter, ',' by default
+	Comma rune
+	// If true, first row is treated as header, and other rows are
+	// converted to objects with header fields as keys
+	Header bool
+}
+
+// CSV returns a new Array instance with rows decoded from CSV response body.
+//
+// By default, every row becomes an array of strings. If Header option is
+// set, first row is used as a list of column names, and every other row
+// becomes an object mapping column names to values.
+//
+// CSV succeeds if response contains "text/csv" Content-Type header with
+// empty or "utf-8" charset and if body is a valid CSV document with equal
+// number of fields in every row.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CSV().Element(0).Array().Elements("id", "name")
+//	resp.CSV(CSVOpts{
+//	  Comma:  ';',
+//	  Header: true,
+//	}).Element(0).Object().ValueEqual("name", "john")
+func (r *Response) CSV(options ...CSVOpts) *Array {
+	opChain := r.chain.enter("CSV()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	var opts CSVOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+
+	if opts.Comma == '"' || opts.Comma == '\r' || opts.Comma == '\n' ||
+		!utf8.ValidRune(opts.Comma) || opts.Comma == utf8.RuneError {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected Comma option %q", opts.Comma),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	contentOpts := ContentOpts{
+		MediaType: opts.MediaType,
+		Charset:   opts.Charset,
+	}
+
+	if !r.checkContentOptions(opChain, []ContentOpts{contentOpts}, "text/csv") {
+		return newArray(opChain, nil)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(r.content))
+	reader.Comma = opts.Comma
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(r.content),
+			},
+			Errors: []error{
+				errors.New("failed to decode csv"),
+				err,
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	rows := []interface{}{}
+
+	if !opts.Header {
+		for _, record := range records {
+			row := make([]interface{}, len(record))
+			for i, field := range record {
+				row[i] = field
+			}
+			rows = append(rows, row)
+		}
+
+		return newArray(opChain, rows)
+	}
+
+	if len(records) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(r.content),
+			},
+			Errors: []error{
+				errors.New("expected: csv has header row"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	header := records[0]
+
+	for i, name := range header {
+		for _, prev := range header[:i] {
+			if name == prev {
+				opChain.fail(AssertionFailure{
+					Type: AssertValid,
+					Actual: &AssertionValue{
+						header,
+					},
+					Errors: []error{
+						fmt.Errorf("duplicate csv column %q", name),
+					},
+				})
+				return newArray(opChain, nil)
+			}
+		}
+	}
+
+	for _, record := range records[1:] {
+		row := make(map[string]interface{}, len(record))
+		for i, field := range record {
+			row[header[i]] = field
+		}
+		rows = append(rows, row)
+	}
+
+	return newArray(opChain, rows)
+}
+
+// GraphQL returns a new GraphQL instance with GraphQL response envelope
+// decoded from response body.
+//
+// GraphQL succeeds if response contains "application/json" Content-Type
+// header with empty or "utf-8" charset, and response body is a JSON object
+// with "data" and/or "errors" keys.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.GraphQL().NoErrors()
+//	resp.GraphQL().Data().Object().ContainsKey("user")
+//	resp.GraphQL(ContentOpts{
+//	  MediaType: "application/graphql-response+json",
+//	}).HasErrors()
+func (r *Response) GraphQL(options ...ContentOpts) *GraphQL {
+	opChain := r.chain.enter("GraphQL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newGraphQL(opChain, nil)
+	}
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newGraphQL(opChain, nil)
+	}
+
+	value := r.getJSON(opChain, options...)
+
+	return newGraphQL(opChain, value)
+}
+
+// Problem returns a new Problem instance with problem details (RFC 7807)
+// decoded from response body.
+//
+// Problem succeeds if response contains "application/problem+json"
+// Content-Type header with empty or "utf-8" charset, response body is a
+// valid problem details object, and its "status" member, if present,
+// matches response status code.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Problem().Title().Equal("User not found")
+//	resp.Problem().Status().Equal(http.StatusNotFound)
+func (r *Response) Problem() *Problem {
+	opChain := r.chain.enter("Problem()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newProblem(opChain, nil)
+	}
+
+	value := r.getJSON(opChain, ContentOpts{
+		MediaType: "application/problem+json",
+	})
+
+	if object, ok := value.(map[string]interface{}); ok {
+		if status, ok := object["status"]; ok && isCanonNumber(status) &&
+			compareNumbers(status, float64(r.httpResp.StatusCode)) != 0 {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{status},
+				Expected: &AssertionValue{r.httpResp.StatusCode},
+				Errors: []error{
+					errors.New(
+						`expected: problem details "status" member` +
+							` matches response status code`),
+				},
+			})
+		}
+	}
+
+	return newProblem(opChain, value)
+}
+
+// JSONAPI returns a new JSONAPI instance with JSON:API document decoded
+// from response body.
+//
+// JSONAPI succeeds if response contains "application/vnd.api+json"
+// Content-Type header with empty or "utf-8" charset, and response body is
+// a valid JSON:API document.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.JSONAPI().NoErrors()
+//	resp.JSONAPI().Data().Array().Length().Equal(10)
+//	resp.JSONAPI().Related("author").Object().ValueEqual("id", "9")
+func (r *Response) JSONAPI() *JSONAPI {
+	opChain := r.chain.enter("JSONAPI()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newJSONAPI(opChain, nil)
+	}
+
+	value := r.getJSON(opChain, ContentOpts{
+		MediaType: "application/vnd.api+json",
+	})
+
+	return newJSONAPI(opChain, value)
+}
+
+// HAL returns a new HAL instance with HAL resource decoded from response
+// body.
+//
+// HAL succeeds if response contains "application/hal+json" Content-Type
+// header with empty or "utf-8" charset, and response body is a valid HAL
+// resource.
+//
+// Links of returned HAL may be followed using HAL.FollowLink, which sends
+// requests using same config as the request of current response.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.HAL().Link("self").Href().Equal("/orders/123")
+//	resp.HAL().FollowLink("customer").Status(http.StatusOK)
+func (r *Response) HAL() *HAL {
+	opChain := r.chain.enter("HAL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHAL(opChain, r.config, nil, r)
+	}
+
+	value := r.getJSON(opChain, ContentOpts{
+		MediaType: "application/hal+json",
+	})
+
+	return newHAL(opChain, r.config, value, r)
+}
+
+// GRPCWeb returns a new GRPCWeb instance with gRPC-Web frames decoded
+// from response body.
+//
+// GRPCWeb succeeds if response contains "application/grpc-web",
+// "application/grpc-web+proto", or "application/grpc-web+json" Content-Type
+// header, or their "application/grpc-web-text" counterparts, in which case
+// response body is base64-decoded first.
+//
+// If response carries "grpc-status" and other "grpc-" fields in headers
+// instead of trailers frame (so-called trailers-only response), they are
+// available as trailers too.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.GRPCWeb().Status(0)
+func (r *Response) GRPCWeb() *GRPCWeb {
+	opChain := r.chain.enter("GRPCWeb()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newString(opChain, "")
+		return newGRPCWeb(opChain, nil, nil)
 	}
 
-	if len(options) > 1 {
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
 		opChain.fail(AssertionFailure{
-			Type: AssertUsage,
+			Type:   AssertValid,
+			Actual: &AssertionValue{contentType},
 			Errors: []error{
-				errors.New("unexpected multiple options arguments"),
+				errors.New(`invalid "Content-Type" response header`),
+				err,
 			},
 		})
-		return newString(opChain, "")
+		return newGRPCWeb(opChain, nil, nil)
 	}
 
-	if !r.checkContentOptions(opChain, options, "text/plain") {
-		return newString(opChain, "")
-	}
+	content := r.content
 
-	content := string(r.content)
+	switch mediaType {
+	case "application/grpc-web",
+		"application/grpc-web+proto",
+		"application/grpc-web+json":
+
+	case "application/grpc-web-text",
+		"application/grpc-web-text+proto",
+		"application/grpc-web-text+json":
+		decoded, err := base64.StdEncoding.DecodeString(
+			strings.TrimSpace(string(content)))
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(content)},
+				Errors: []error{
+					errors.New("expected: valid base64-encoded gRPC-Web body"),
+					err,
+				},
+			})
+			return newGRPCWeb(opChain, nil, nil)
+		}
+		content = decoded
+
+	default:
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{mediaType},
+			Expected: &AssertionValue{"application/grpc-web"},
+			Errors: []error{
+				errors.New(`unexpected media type in "Content-Type" response header`),
+			},
+		})
+		return newGRPCWeb(opChain, nil, nil)
+	}
 
-	return newString(opChain, content)
+	return newGRPCWeb(opChain, content, r.httpResp.Header)
 }
 
-// Form returns a new Object instance with form decoded from response body.
+// JSON returns a new Value instance with JSONP decoded from response body.
 //
-// Form succeeds if response contains "application/x-www-form-urlencoded"
-// Content-Type header and if form may be decoded from response body.
-// Decoding is performed using https://github.com/ajg/form.
+// JSONP succeeds if response contains "application/javascript" Content-Type
+// header with empty or "utf-8" charset and response body of the following form:
+//
+//	callback(<valid json>);
+//
+// or:
+//
+//	callback(<valid json>)
+//
+// Whitespaces are allowed.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.Form().Value("foo").Equal("bar")
-//	resp.Form(ContentOpts{
-//	  MediaType: "application/x-www-form-urlencoded",
-//	}).Value("foo").Equal("bar")
-func (r *Response) Form(options ...ContentOpts) *Object {
-	opChain := r.chain.enter("Form()")
+//	resp.JSONP("myCallback").Array().Elements("foo", "bar")
+//	resp.JSONP("myCallback", ContentOpts{
+//	  MediaType: "application/javascript",
+//	}).Array.Elements("foo", "bar")
+func (r *Response) JSONP(callback string, options ...ContentOpts) *Value {
+	opChain := r.chain.enter("JSONP()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newObject(opChain, nil)
+		return newValue(opChain, nil)
 	}
 
 	if len(options) > 1 {
@@ -680,56 +3047,84 @@ func (r *Response) Form(options ...ContentOpts) *Object {
 				errors.New("unexpected multiple options arguments"),
 			},
 		})
-		return newObject(opChain, nil)
+		return newValue(opChain, nil)
 	}
 
-	object := r.getForm(opChain, options...)
+	value := r.getJSONP(opChain, callback, options...)
 
-	return newObject(opChain, object)
+	return newValue(opChain, value)
 }
 
-func (r *Response) getForm(
-	opChain *chain, options ...ContentOpts,
-) map[string]interface{} {
-	if !r.checkContentOptions(opChain, options, "application/x-www-form-urlencoded", "") {
+var (
+	jsonp = regexp.MustCompile(`^\s*([^\s(]+)\s*\((.*)\)\s*;*\s*$`)
+)
+
+func (r *Response) getJSONP(
+	opChain *chain, callback string, options ...ContentOpts,
+) interface{} {
+	if !r.checkContentOptions(opChain, options, "application/javascript") {
 		return nil
 	}
 
-	decoder := form.NewDecoder(bytes.NewReader(r.content))
+	m := jsonp.FindSubmatch(r.content)
 
-	var object map[string]interface{}
+	if len(m) != 3 || string(m[1]) != callback {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(r.content),
+			},
+			Errors: []error{
+				fmt.Errorf(`expected: JSONP body in form of "%s(<valid json>)"`,
+					callback),
+			},
+		})
+		return nil
+	}
 
-	if err := decoder.Decode(&object); err != nil {
+	var value interface{}
+
+	if err := unmarshalJSON(opChain, m[2], &value); err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
 				string(r.content),
 			},
 			Errors: []error{
-				errors.New("failed to decode form"),
+				errors.New("failed to decode json"),
 				err,
 			},
 		})
 		return nil
 	}
 
-	return object
+	return value
 }
 
-// JSON returns a new Value instance with JSON decoded from response body.
+// XML returns a new Value instance with XML decoded from response body.
 //
-// JSON succeeds if response contains "application/json" Content-Type header
-// with empty or "utf-8" charset and if JSON may be decoded from response body.
+// XML succeeds if response contains "application/xml" or "text/xml"
+// Content-Type header with empty or "utf-8" charset and if XML may be
+// decoded from response body.
+//
+// XML document is converted to a map with single key (name of the root
+// element) using the following rules:
+//   - element attributes are stored as keys prefixed with "-"
+//   - child elements are stored as keys equal to their names; repeated
+//     elements with the same name are collected into array
+//   - element text is stored as "#text" key, or, if element has neither
+//     attributes nor child elements, element is converted to string
 //
 // Example:
 //
+//	// <user id="1"><name>john</name></user>
 //	resp := NewResponse(t, response)
-//	resp.JSON().Array().Elements("foo", "bar")
-//	resp.JSON(ContentOpts{
-//	  MediaType: "application/json",
-//	}).Array.Elements("foo", "bar")
-func (r *Response) JSON(options ...ContentOpts) *Value {
-	opChain := r.chain.enter("JSON()")
+//	resp.XML().Path("$.user.name").String().Equal("john")
+//	resp.XML(ContentOpts{
+//	  MediaType: "application/atom+xml",
+//	}).Object().ContainsKey("feed")
+func (r *Response) XML(options ...ContentOpts) *Value {
+	opChain := r.chain.enter("XML()")
 	defer opChain.leave()
 
 	if opChain.failed() {
@@ -746,26 +3141,33 @@ func (r *Response) JSON(options ...ContentOpts) *Value {
 		return newValue(opChain, nil)
 	}
 
-	value := r.getJSON(opChain, options...)
+	value := r.getXML(opChain, options...)
 
 	return newValue(opChain, value)
 }
 
-func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
-	if !r.checkContentOptions(opChain, options, "application/json") {
+func (r *Response) getXML(opChain *chain, options ...ContentOpts) interface{} {
+	expectedType := "application/xml"
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "text/xml" {
+		expectedType = "text/xml"
+	}
+
+	if !r.checkContentOptions(opChain, options, expectedType) {
 		return nil
 	}
 
-	var value interface{}
+	value, err := decodeXML(r.content)
 
-	if err := json.Unmarshal(r.content, &value); err != nil {
+	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
 				string(r.content),
 			},
 			Errors: []error{
-				errors.New("failed to decode json"),
+				errors.New("failed to decode xml"),
 				err,
 			},
 		})
@@ -775,32 +3177,24 @@ func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
 	return value
 }
 
-// JSON returns a new Value instance with JSONP decoded from response body.
-//
-// JSONP succeeds if response contains "application/javascript" Content-Type
-// header with empty or "utf-8" charset and response body of the following form:
-//
-//	callback(<valid json>);
-//
-// or:
-//
-//	callback(<valid json>)
+// HTML returns a new HTML instance with document parsed from response body.
 //
-// Whitespaces are allowed.
+// HTML succeeds if response contains "text/html" Content-Type header
+// with empty or "utf-8" charset.
 //
 // Example:
 //
 //	resp := NewResponse(t, response)
-//	resp.JSONP("myCallback").Array().Elements("foo", "bar")
-//	resp.JSONP("myCallback", ContentOpts{
-//	  MediaType: "application/javascript",
-//	}).Array.Elements("foo", "bar")
-func (r *Response) JSONP(callback string, options ...ContentOpts) *Value {
-	opChain := r.chain.enter("JSONP()")
+//	resp.HTML().Select("h1.title").Text().Equal("Welcome")
+//	resp.HTML(ContentOpts{
+//	  MediaType: "application/xhtml+xml",
+//	}).Select("a#login").Attr("href").Equal("/login")
+func (r *Response) HTML(options ...ContentOpts) *HTML {
+	opChain := r.chain.enter("HTML()")
 	defer opChain.leave()
 
 	if opChain.failed() {
-		return newValue(opChain, nil)
+		return newHTMLSelection(opChain, nil)
 	}
 
 	if len(options) > 1 {
@@ -810,44 +3204,131 @@ func (r *Response) JSONP(callback string, options ...ContentOpts) *Value {
 				errors.New("unexpected multiple options arguments"),
 			},
 		})
-		return newValue(opChain, nil)
+		return newHTMLSelection(opChain, nil)
 	}
 
-	value := r.getJSONP(opChain, callback, options...)
+	if !r.checkContentOptions(opChain, options, "text/html") {
+		return newHTMLSelection(opChain, nil)
+	}
 
-	return newValue(opChain, value)
+	return newHTML(opChain, r.content)
 }
 
-var (
-	jsonp = regexp.MustCompile(`^\s*([^\s(]+)\s*\((.*)\)\s*;*\s*$`)
-)
+// MatchOpenAPI succeeds if response conforms to given OpenAPI specification.
+//
+// Operation is looked up in the spec by method and path of the request that
+// produced the response. Then the following is checked:
+//   - response status code is documented for the operation
+//   - response content type is documented for the status code
+//   - JSON response body matches schema documented for the content type
+//
+// Schemas are validated using gojsonschema, hence OpenAPI-specific keywords
+// like "nullable" and "discriminator" are ignored. References to other parts
+// of the spec (like "#/components/schemas/User") are supported.
+//
+// Example:
+//
+//	spec, _ := openapi.Load("openapi.yaml")
+//
+//	resp := NewResponse(t, response)
+//	resp.MatchOpenAPI(spec)
+func (r *Response) MatchOpenAPI(spec *openapi.Spec) *Response {
+	opChain := r.chain.enter("MatchOpenAPI()")
+	defer opChain.leave()
 
-func (r *Response) getJSONP(
-	opChain *chain, callback string, options ...ContentOpts,
-) interface{} {
-	if !r.checkContentOptions(opChain, options, "application/javascript") {
-		return nil
+	if opChain.failed() {
+		return r
 	}
 
-	m := jsonp.FindSubmatch(r.content)
+	if spec == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil argument"),
+			},
+		})
+		return r
+	}
 
-	if len(m) != 3 || string(m[1]) != callback {
+	httpReq := r.httpResp.Request
+	if httpReq == nil || httpReq.URL == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("response has no associated request"),
+			},
+		})
+		return r
+	}
+
+	op := spec.FindOperation(httpReq.Method, httpReq.URL.Path)
+	if op == nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
-				string(r.content),
+				httpReq.Method + " " + httpReq.URL.Path,
 			},
 			Errors: []error{
-				fmt.Errorf(`expected: JSONP body in form of "%s(<valid json>)"`,
-					callback),
+				errors.New("expected: operation is defined in OpenAPI spec"),
 			},
 		})
-		return nil
+		return r
 	}
 
-	var value interface{}
+	specResp := op.FindResponse(r.httpResp.StatusCode)
+	if specResp == nil {
+		statuses := op.Statuses()
+		expected := make([]interface{}, len(statuses))
+		for i := range statuses {
+			expected[i] = statuses[i]
+		}
+		opChain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{r.httpResp.StatusCode},
+			Expected: &AssertionValue{AssertionList(expected)},
+			Errors: []error{
+				fmt.Errorf(
+					"expected: status code is documented for %s %s in OpenAPI spec",
+					op.Method, op.Path),
+			},
+		})
+		return r
+	}
+
+	specTypes := specResp.MediaTypes()
+	if len(specTypes) == 0 {
+		return r
+	}
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	specMediaType := specResp.FindMediaType(mediaType)
+	if specMediaType == nil {
+		expected := make([]interface{}, len(specTypes))
+		for i := range specTypes {
+			expected[i] = specTypes[i]
+		}
+		opChain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{contentType},
+			Expected: &AssertionValue{AssertionList(expected)},
+			Errors: []error{
+				fmt.Errorf(
+					"expected: content type is documented for %s %s in OpenAPI spec",
+					op.Method, op.Path),
+			},
+		})
+		return r
+	}
+
+	schema, ok := specMediaType.Schema()
+	if !ok || !isJSONContentType(mediaType) {
+		return r
+	}
 
-	if err := json.Unmarshal(m[2], &value); err != nil {
+	var value interface{}
+	if err := json.Unmarshal(r.content, &value); err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
@@ -858,10 +3339,12 @@ func (r *Response) getJSONP(
 				err,
 			},
 		})
-		return nil
+		return r
 	}
 
-	return value
+	jsonSchema(opChain, value, schema)
+
+	return r
 }
 
 func (r *Response) checkContentOptions(
@@ -878,6 +3361,93 @@ func (r *Response) checkContentOptions(
 	return r.checkContentType(opChain, expectedType, expectedCharset...)
 }
 
+// getContent checks Content-Type header and returns response body converted
+// to UTF-8 from the charset specified in the header, if it's a known one;
+// byte order mark is stripped, and UTF-16 is detected by it when there is
+// no charset in the header
+func (r *Response) getContent(
+	opChain *chain, options []ContentOpts, expectedType string,
+) ([]byte, bool) {
+	var charset string
+	if _, params, err := mime.ParseMediaType(
+		r.httpResp.Header.Get("Content-Type")); err == nil {
+		charset = params["charset"]
+	}
+
+	if charset == "" || strings.EqualFold(charset, "utf-8") ||
+		!isKnownCharset(charset) {
+		if !r.checkContentOptions(opChain, options, expectedType) {
+			return nil, false
+		}
+
+		switch {
+		case charset != "":
+			return bytes.TrimPrefix(r.content, utf8BOM), true
+
+		case bytes.HasPrefix(r.content, utf16LEBOM):
+			charset = "utf-16le"
+
+		case bytes.HasPrefix(r.content, utf16BEBOM):
+			charset = "utf-16be"
+
+		case r.config.FallbackCharset == "" || utf8.Valid(r.content):
+			return bytes.TrimPrefix(r.content, utf8BOM), true
+
+		case !isKnownCharset(r.config.FallbackCharset):
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("unsupported Config.FallbackCharset %q",
+						r.config.FallbackCharset),
+				},
+			})
+			return nil, false
+
+		default:
+			charset = r.config.FallbackCharset
+		}
+	} else if !r.checkContentOptions(opChain, options, expectedType, charset) {
+		return nil, false
+	}
+
+	content, err := decodeCharset(charset, r.content)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(r.content),
+			},
+			Errors: []error{
+				fmt.Errorf("failed to decode response body from %q charset", charset),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return bytes.TrimPrefix(content, utf8BOM), true
+}
+
+// byte order marks
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+func isKnownCharset(charset string) bool {
+	_, err := htmlindex.Get(charset)
+	return err == nil
+}
+
+func decodeCharset(charset string, content []byte) ([]byte, error) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, err
+	}
+	return enc.NewDecoder().Bytes(content)
+}
+
 func (r *Response) checkContentType(
 	opChain *chain, expectedType string, expectedCharset ...string,
 ) bool {
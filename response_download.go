@@ -0,0 +1,87 @@
+package httpexpect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// responseDownload streams response body to file, see Request.WithDownload
+//
+// Body of every attempt is first written to temporary file in the same
+// directory. If request is retried, temporary file is removed; the file
+// of the last attempt is renamed to the target path. So the target file
+// is never rewritten by a response that was retried.
+type responseDownload struct {
+	path   string
+	length int64
+	sha256 string
+	err    error
+
+	// temporary file of the last attempt, if not yet committed
+	tmpPath string
+}
+
+func newResponseDownload(path string) *responseDownload {
+	return &responseDownload{path: path}
+}
+
+// must be called right after receiving response; body is consumed and closed
+func (d *responseDownload) write(body io.ReadCloser) {
+	d.discard()
+
+	defer body.Close()
+
+	file, err := ioutil.TempFile(filepath.Dir(d.path), "."+filepath.Base(d.path))
+	if err != nil {
+		d.err = err
+		return
+	}
+
+	d.tmpPath = file.Name()
+
+	hash := sha256.New()
+
+	d.length, d.err = io.Copy(io.MultiWriter(file, hash), body)
+
+	if err := file.Close(); err != nil && d.err == nil {
+		d.err = err
+	}
+
+	if d.err != nil {
+		_ = os.Remove(d.tmpPath)
+		d.tmpPath = ""
+		return
+	}
+
+	d.sha256 = hex.EncodeToString(hash.Sum(nil))
+}
+
+// must be called when attempt is retried; removes its file and resets values
+func (d *responseDownload) discard() {
+	if d.tmpPath != "" {
+		_ = os.Remove(d.tmpPath)
+		d.tmpPath = ""
+	}
+
+	d.length = 0
+	d.sha256 = ""
+	d.err = nil
+}
+
+// must be called after the last attempt; moves its file to target path
+func (d *responseDownload) commit() {
+	if d.tmpPath == "" {
+		return
+	}
+
+	if err := os.Rename(d.tmpPath, d.path); err != nil {
+		_ = os.Remove(d.tmpPath)
+		d.err = err
+	}
+
+	d.tmpPath = ""
+}
@@ -2,14 +2,28 @@ package httpexpect
 
 import (
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/andybalholm/brotli"
+	"github.com/gavv/httpexpect/v2/openapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResponse_Failed(t *testing.T) {
@@ -18,27 +32,90 @@ func TestResponse_Failed(t *testing.T) {
 
 		assert.NotNil(t, resp.RoundTripTime())
 		assert.NotNil(t, resp.Duration())
+		assert.NotNil(t, resp.Timings())
+		assert.NotNil(t, resp.ConnectionReused())
+		assert.NotNil(t, resp.RemoteAddr())
+		assert.NotNil(t, resp.Proto())
+		assert.NotNil(t, resp.ProtoAtLeast(1, 1))
+		assert.NotNil(t, resp.RateLimit())
+		assert.NotNil(t, resp.ETag())
+		assert.NotNil(t, resp.LastModified())
+		assert.NotNil(t, resp.Revalidate())
+		assert.NotNil(t, resp.CacheControl())
+		assert.NotNil(t, resp.CORS())
+		assert.NotNil(t, resp.SecurityHeaders())
+		assert.NotNil(t, resp.ContentRange())
+		assert.NotNil(t, resp.Ranges())
+		assert.NotNil(t, resp.RemoteAddr())
+		assert.NotNil(t, resp.CompressionRatio())
 		assert.NotNil(t, resp.Headers())
 		assert.NotNil(t, resp.Header("foo"))
+		assert.NotNil(t, resp.HeaderValues("foo"))
+		assert.NotNil(t, resp.Trailers())
+		assert.NotNil(t, resp.Trailer("foo"))
 		assert.NotNil(t, resp.Cookies())
 		assert.NotNil(t, resp.Cookie("foo"))
+		assert.NotNil(t, resp.Redirects())
+		assert.NotNil(t, resp.HasNextPage())
+		assert.NotNil(t, resp.NextPage())
+		assert.NotNil(t, resp.AllPages())
 		assert.NotNil(t, resp.Body())
+		assert.NotNil(t, resp.Binary())
+		assert.NotNil(t, resp.HTML())
+		assert.NotNil(t, resp.MsgPack())
+		assert.NotNil(t, resp.YAML())
+		assert.NotNil(t, resp.CSV())
+		assert.NotNil(t, resp.Decoded())
+		assert.NotNil(t, resp.Download("file"))
+		assert.NotNil(t, resp.DownloadedLength())
+		assert.NotNil(t, resp.DownloadedSHA256())
 		assert.NotNil(t, resp.Text())
+		assert.NotNil(t, resp.SniffedContentType())
 		assert.NotNil(t, resp.Form())
 		assert.NotNil(t, resp.JSON())
+		assert.NotNil(t, resp.JSONLines())
+		assert.NotNil(t, resp.EventStream())
 		assert.NotNil(t, resp.JSONP(""))
+		assert.NotNil(t, resp.GraphQL())
+		assert.NotNil(t, resp.Problem())
+		assert.NotNil(t, resp.JSONAPI())
+		assert.NotNil(t, resp.HAL())
+		assert.NotNil(t, resp.GRPCWeb())
+		assert.NotNil(t, resp.XML())
 		assert.NotNil(t, resp.Websocket())
+		assert.NotNil(t, resp.TLS())
 
+		resp.CompressionRatio().chain.assertFailed(t)
 		resp.Headers().chain.assertFailed(t)
 		resp.Header("foo").chain.assertFailed(t)
+		resp.HeaderValues("foo").chain.assertFailed(t)
+		resp.Trailers().chain.assertFailed(t)
+		resp.Trailer("foo").chain.assertFailed(t)
 		resp.Cookies().chain.assertFailed(t)
 		resp.Cookie("foo").chain.assertFailed(t)
 		resp.Body().chain.assertFailed(t)
+		resp.Binary().chain.assertFailed(t)
+		resp.HTML().chain.assertFailed(t)
+		resp.MsgPack().chain.assertFailed(t)
+		resp.YAML().chain.assertFailed(t)
+		resp.CSV().chain.assertFailed(t)
+		resp.Decoded().chain.assertFailed(t)
+		resp.Download("file").chain.assertFailed(t)
 		resp.Text().chain.assertFailed(t)
+		resp.SniffedContentType().chain.assertFailed(t)
 		resp.Form().chain.assertFailed(t)
 		resp.JSON().chain.assertFailed(t)
+		resp.JSONLines().chain.assertFailed(t)
+		resp.EventStream().chain.assertFailed(t)
 		resp.JSONP("").chain.assertFailed(t)
+		resp.GraphQL().chain.assertFailed(t)
+		resp.Problem().chain.assertFailed(t)
+		resp.JSONAPI().chain.assertFailed(t)
+		resp.HAL().chain.assertFailed(t)
+		resp.GRPCWeb().chain.assertFailed(t)
+		resp.XML().chain.assertFailed(t)
 		resp.Websocket().chain.assertFailed(t)
+		resp.TLS().chain.assertFailed(t)
 
 		resp.Status(123)
 		resp.StatusRange(Status2xx)
@@ -47,6 +124,8 @@ func TestResponse_Failed(t *testing.T) {
 		resp.ContentType("", "")
 		resp.ContentEncoding("")
 		resp.TransferEncoding("")
+		resp.MatchOpenAPI(nil)
+		resp.MatchSnapshot("foo")
 	}
 
 	t.Run("failed_chain", func(t *testing.T) {
@@ -191,6 +270,111 @@ func TestResponse_Duration(t *testing.T) {
 	})
 }
 
+func TestResponse_Timings(t *testing.T) {
+	t.Run("expect", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(time.Millisecond * 10)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		resp := e.GET("/").Expect()
+
+		timings := resp.Timings()
+		resp.chain.assertNotFailed(t)
+		timings.chain.assertNotFailed(t)
+
+		timings.Keys().ContainsOnly("dns", "connect", "tls", "ttfb", "total")
+
+		timings.Value("dns").Number().Ge(0)
+		timings.Value("connect").Number().Gt(0)
+		timings.Value("tls").Number().Equal(0)
+		timings.Value("ttfb").Number().Ge(10)
+		timings.Value("total").Number().Ge(10)
+
+		timings.chain.assertNotFailed(t)
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{}, time.Second)
+
+		timings := resp.Timings()
+		resp.chain.assertFailed(t)
+		timings.chain.assertFailed(t)
+
+		assert.Nil(t, timings.Raw())
+	})
+}
+
+func TestResponse_Connection(t *testing.T) {
+	t.Run("expect", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
+
+		resp1 := e.GET("/").Expect()
+		resp1.ConnectionReused().False()
+		resp1.RemoteAddr().Equal(server.Listener.Addr().String())
+		resp1.chain.assertNotFailed(t)
+
+		resp2 := e.GET("/").Expect()
+		resp2.ConnectionReused().True()
+		resp2.RemoteAddr().Equal(server.Listener.Addr().String())
+		resp2.chain.assertNotFailed(t)
+	})
+
+	t.Run("not traced", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter: newMockReporter(t),
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+		})
+
+		resp := e.GET("/").Expect()
+		resp.chain.assertNotFailed(t)
+
+		value := resp.ConnectionReused()
+		resp.chain.assertFailed(t)
+		value.chain.assertFailed(t)
+
+		resp.chain.clearFailed()
+
+		addr := resp.RemoteAddr()
+		resp.chain.assertFailed(t)
+		addr.chain.assertFailed(t)
+	})
+
+	t.Run("not available", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{})
+
+		value := resp.ConnectionReused()
+		resp.chain.assertFailed(t)
+		value.chain.assertFailed(t)
+
+		resp.chain.clearFailed()
+
+		addr := resp.RemoteAddr()
+		resp.chain.assertFailed(t)
+		addr.chain.assertFailed(t)
+	})
+}
+
 func TestResponse_StatusRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -319,6 +503,79 @@ func TestResponse_Headers(t *testing.T) {
 	resp.Header("Bad-Header").Empty().chain.assertNotFailed(t)
 }
 
+func TestResponse_HeaderValues(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Vary":       {"Accept", "Accept-Encoding"},
+			"Set-Cookie": {"a=1", "b=2"},
+			"Allow":      {"GET, POST"},
+		},
+	}
+
+	resp := NewResponse(reporter, httpResp)
+	resp.chain.assertNotFailed(t)
+
+	for _, h := range []string{"Vary", "vary", "VARY"} {
+		resp.HeaderValues(h).
+			Equal([]interface{}{"Accept", "Accept-Encoding"}).
+			chain.assertNotFailed(t)
+	}
+
+	resp.HeaderValues("Set-Cookie").
+		Equal([]interface{}{"a=1", "b=2"}).
+		chain.assertNotFailed(t)
+
+	resp.HeaderValues("Allow").
+		Equal([]interface{}{"GET, POST"}).
+		chain.assertNotFailed(t)
+
+	resp.HeaderValues("Bad-Header").Empty().chain.assertNotFailed(t)
+
+	resp.HeaderValues("Vary").Contains("Origin").chain.assertFailed(t)
+}
+
+func TestResponse_Trailers(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("trailers", func(t *testing.T) {
+		trailers := map[string][]string{
+			"First-Trailer":  {"foo"},
+			"Second-Trailer": {"bar"},
+		}
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Trailer": {"First-Trailer, Second-Trailer"}},
+			Trailer:    http.Header(trailers),
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp.Trailers().Equal(trailers).chain.assertNotFailed(t)
+
+		for k, v := range trailers {
+			for _, h := range []string{k, strings.ToLower(k), strings.ToUpper(k)} {
+				resp.Trailer(h).Equal(v[0]).chain.assertNotFailed(t)
+			}
+		}
+
+		resp.Trailer("Bad-Trailer").Empty().chain.assertNotFailed(t)
+		resp.Header("First-Trailer").Empty().chain.assertNotFailed(t)
+	})
+
+	t.Run("no trailers", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp.Trailers().Empty().chain.assertNotFailed(t)
+		resp.Trailer("Foo").Empty().chain.assertNotFailed(t)
+	})
+}
+
 func TestResponse_Cookies(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -326,7 +583,7 @@ func TestResponse_Cookies(t *testing.T) {
 		"Set-Cookie": {
 			"foo=aaa",
 			"bar=bbb; expires=Fri, 31 Dec 2010 23:59:59 GMT; " +
-				"path=/xxx; domain=example.com",
+				"path=/xxx; domain=example.com; secure; httponly",
 		},
 	}
 
@@ -359,6 +616,12 @@ func TestResponse_Cookies(t *testing.T) {
 	assert.True(t, time.Date(2010, 12, 31, 23, 59, 59, 0, time.UTC).
 		Equal(c2.Raw().Expires))
 
+	c1.NotHaveSecure().NotHaveHTTPOnly()
+	c1.chain.assertNotFailed(t)
+
+	c2.HaveSecure().HaveHTTPOnly()
+	c2.chain.assertNotFailed(t)
+
 	c3 := resp.Cookie("baz")
 	resp.chain.assertFailed(t)
 	c3.chain.assertFailed(t)
@@ -387,545 +650,949 @@ func TestResponse_NoCookies(t *testing.T) {
 	assert.True(t, c.Raw() == nil)
 }
 
-func TestResponse_Body(t *testing.T) {
+func TestResponse_Redirects(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Body:       ioutil.NopCloser(bytes.NewBufferString("body")),
-	}
+	t.Run("no redirects", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
 
-	resp := NewResponse(reporter, httpResp)
+		redirects := resp.Redirects()
+		resp.chain.assertNotFailed(t)
 
-	assert.Equal(t, "body", resp.Body().Raw())
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		assert.Equal(t, 0, len(redirects))
+	})
+
+	t.Run("multiple redirects", func(t *testing.T) {
+		config := newMockConfig(reporter)
+
+		resp := newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("test", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+			},
+			redirects: []*http.Response{
+				{
+					StatusCode: http.StatusFound,
+					Header: http.Header{
+						"Location": {"/foo"},
+					},
+					Body: ioutil.NopCloser(bytes.NewBufferString("redirect")),
+				},
+				{
+					StatusCode: http.StatusMovedPermanently,
+					Header: http.Header{
+						"Location": {"/bar"},
+					},
+				},
+			},
+		})
+
+		redirects := resp.Redirects()
+		resp.chain.assertNotFailed(t)
+
+		require.Equal(t, 2, len(redirects))
+
+		redirects[0].Status(http.StatusFound)
+		redirects[0].Header("Location").Equal("/foo")
+		redirects[0].Body().Equal("redirect")
+		redirects[0].chain.assertNotFailed(t)
+
+		redirects[1].Status(http.StatusMovedPermanently)
+		redirects[1].Header("Location").Equal("/bar")
+		redirects[1].Body().Empty()
+		redirects[1].chain.assertNotFailed(t)
+
+		redirects[1].Status(http.StatusOK)
+		redirects[1].chain.assertFailed(t)
+		assert.True(t, resp.chain.treeFailed())
+	})
 }
 
-func TestResponse_BodyClose(t *testing.T) {
+func TestResponse_Proto(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	body := newMockBody("test_body")
-
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Body:       body,
+	cases := []struct {
+		name       string
+		proto      string
+		major      int
+		minor      int
+		atLeast10  bool
+		atLeast11  bool
+		atLeast111 bool
+		atLeast20  bool
+	}{
+		{"http1.0", "HTTP/1.0", 1, 0, true, false, false, false},
+		{"http1.1", "HTTP/1.1", 1, 1, true, true, false, false},
+		{"http2.0", "HTTP/2.0", 2, 0, true, true, true, true},
 	}
 
-	resp := NewResponse(reporter, httpResp)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := NewResponse(reporter, &http.Response{
+				Proto:      tc.proto,
+				ProtoMajor: tc.major,
+				ProtoMinor: tc.minor,
+			})
 
-	assert.Equal(t, "test_body", resp.Body().Raw())
-	assert.True(t, body.closed)
+			resp.Proto().Equal(tc.proto)
+			resp.chain.assertNotFailed(t)
 
-	resp.chain.assertNotFailed(t)
+			check := func(major, minor int, expected bool) {
+				resp.chain.clearFailed()
+				resp.ProtoAtLeast(major, minor)
+				if expected {
+					resp.chain.assertNotFailed(t)
+				} else {
+					resp.chain.assertFailed(t)
+				}
+			}
+
+			check(1, 0, tc.atLeast10)
+			check(1, 1, tc.atLeast11)
+			check(1, 11, tc.atLeast111)
+			check(2, 0, tc.atLeast20)
+		})
+	}
 }
 
-func TestResponse_BodyError(t *testing.T) {
+func TestResponse_Conditional(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	t.Run("read_err", func(t *testing.T) {
-		body := newMockBody("test_body")
-		body.readErr = errors.New("test_error")
-
-		httpResp := &http.Response{
+	t.Run("present", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
 			StatusCode: http.StatusOK,
-			Body:       body,
-		}
+			Header: http.Header{
+				"Etag":          {`W/"abc"`},
+				"Last-Modified": {"Wed, 21 Oct 2015 07:28:00 GMT"},
+			},
+		})
 
-		resp := NewResponse(reporter, httpResp)
+		resp.ETag().Equal(`W/"abc"`)
+		resp.LastModified().Equal(time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC))
+		resp.chain.assertNotFailed(t)
+	})
 
-		assert.Equal(t, "", resp.Body().Raw())
-		assert.True(t, body.closed)
+	t.Run("missing etag", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
 
+		resp.ETag()
 		resp.chain.assertFailed(t)
 	})
 
-	t.Run("close_err", func(t *testing.T) {
-		body := newMockBody("test_body")
-		body.closeErr = errors.New("test_error")
-
-		httpResp := &http.Response{
+	t.Run("missing last-modified", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
 			StatusCode: http.StatusOK,
-			Body:       body,
-		}
+		})
 
-		resp := NewResponse(reporter, httpResp)
+		resp.LastModified()
+		resp.chain.assertFailed(t)
+	})
 
-		assert.Equal(t, "", resp.Body().Raw())
-		assert.True(t, body.closed)
+	t.Run("invalid last-modified", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Last-Modified": {"yesterday"},
+			},
+		})
 
+		resp.LastModified()
 		resp.chain.assertFailed(t)
 	})
 }
 
-func TestResponse_NoContentEmpty(t *testing.T) {
-	reporter := newMockReporter(t)
+func TestResponse_Revalidate(t *testing.T) {
+	lastModified := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
 
-	headers := map[string][]string{
-		"Content-Type": {""},
-	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
-	}
+		switch r.URL.Path {
+		case "/etag":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
 
-	resp := NewResponse(reporter, httpResp)
+		case "/modified":
+			http.ServeContent(w, r, "", lastModified, strings.NewReader("hello"))
+			return
 
-	assert.Equal(t, "", resp.Body().Raw())
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		case "/broken":
+			w.Header().Set("ETag", `"v1"`)
+		}
 
-	resp.NoContent()
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		_, _ = w.Write([]byte("hello"))
+	})
 
-	resp.ContentType("")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	server := httptest.NewServer(handler)
+	defer server.Close()
 
-	resp.Text()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	t.Run("etag", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+		})
 
-	resp.Form()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp := e.GET("/etag").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
 
-	resp.JSON()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp.Status(http.StatusOK).ETag().Equal(`"v1"`)
+		resp.Revalidate().Status(http.StatusNotModified).NoContent()
+	})
 
-	resp.JSONP("")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
-}
+	t.Run("last-modified", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: NewAssertReporter(t),
+		})
 
-func TestResponse_NoContentNil(t *testing.T) {
-	reporter := newMockReporter(t)
+		resp := e.GET("/modified").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
 
-	headers := map[string][]string{
-		"Content-Type": {""},
-	}
+		resp.Status(http.StatusOK).LastModified().Equal(lastModified)
+		resp.Revalidate().Status(http.StatusNotModified)
+	})
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       nil,
-	}
+	t.Run("not supported by server", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
 
-	resp := NewResponse(reporter, httpResp)
+		resp := e.GET("/broken").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
 
-	assert.Equal(t, "", resp.Body().Raw())
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		next := resp.Revalidate()
+		next.chain.assertNotFailed(t)
 
-	resp.NoContent()
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		next.Status(http.StatusNotModified)
+		next.chain.assertFailed(t)
+	})
 
-	resp.ContentType("")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	t.Run("no validators", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
 
-	resp.Text()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp := e.GET("/other").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
 
-	resp.Form()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp.Revalidate()
+		resp.chain.assertFailed(t)
+	})
 
-	resp.JSON()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	t.Run("unsupported method", func(t *testing.T) {
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: newMockReporter(t),
+		})
 
-	resp.JSONP("")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp := e.POST("/etag").
+			WithHeader("Authorization", "Bearer token").
+			Expect()
+
+		resp.Revalidate()
+		resp.chain.assertFailed(t)
+	})
 }
 
-func TestResponse_NoContentFailed(t *testing.T) {
+func TestResponse_CacheControl(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	headers := map[string][]string{
-		"Content-Type": {"text/plain; charset=utf-8"},
-	}
-
-	body := ``
+	t.Run("single", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Cache-Control": {"public, max-age=60"},
+			},
+		})
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-	}
+		resp.CacheControl().Public().True()
+		resp.CacheControl().MaxAge().Equal(time.Minute)
+		resp.chain.assertNotFailed(t)
+	})
 
-	resp := NewResponse(reporter, httpResp)
+	t.Run("repeated", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Cache-Control": {"private", "no-store"},
+			},
+		})
 
-	assert.Equal(t, body, resp.Body().Raw())
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		resp.CacheControl().Private().True()
+		resp.CacheControl().NoStore().True()
+		resp.chain.assertNotFailed(t)
+	})
 
-	resp.NoContent()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	t.Run("missing", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
+
+		resp.CacheControl()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Cache-Control": {`private="`},
+			},
+		})
+
+		resp.CacheControl()
+		resp.chain.assertFailed(t)
+	})
 }
 
-func TestResponse_ContentType(t *testing.T) {
+func TestResponse_ContentRange(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	headers := map[string][]string{
-		"Content-Type": {"text/plain; charset=utf-8"},
-	}
+	t.Run("partial", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Range": {"bytes 0-4/11"},
+			},
+			Body: newMockBody("hello"),
+		})
 
-	resp := NewResponse(reporter, &http.Response{
-		Header: http.Header(headers),
+		resp.ContentRange().Equal(map[string]interface{}{
+			"unit":  "bytes",
+			"start": 0,
+			"end":   4,
+			"size":  11,
+		})
+		resp.Ranges().Equal([]interface{}{
+			map[string]interface{}{
+				"unit":  "bytes",
+				"start": 0,
+				"end":   4,
+				"size":  11,
+				"body":  "hello",
+			},
+		})
+		resp.chain.assertNotFailed(t)
 	})
 
-	resp.ContentType("text/plain")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	t.Run("unsatisfied", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusRequestedRangeNotSatisfiable,
+			Header: http.Header{
+				"Content-Range": {"bytes */11"},
+			},
+		})
 
-	resp.ContentType("text/plain", "utf-8")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		resp.ContentRange().Equal(map[string]interface{}{
+			"unit": "bytes",
+			"size": 11,
+		})
+		resp.chain.assertNotFailed(t)
 
-	resp.ContentType("text/plain", "UTF-8")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		resp.Ranges()
+		resp.chain.assertFailed(t)
+	})
 
-	resp.ContentType("bad")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	t.Run("missing", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+		})
 
-	resp.ContentType("text/plain", "bad")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp.ContentRange()
+		resp.chain.assertFailed(t)
+	})
 
-	resp.ContentType("")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	t.Run("invalid", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Range": {"bytes 5-4/11"},
+			},
+		})
 
-	resp.ContentType("text/plain", "")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp.ContentRange()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("body length mismatch", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Range": {"bytes 0-9/11"},
+			},
+			Body: newMockBody("hello"),
+		})
+
+		resp.Ranges()
+		resp.chain.assertFailed(t)
+	})
 }
 
-func TestResponse_ContentTypeEmptyCharset(t *testing.T) {
-	reporter := newMockReporter(t)
+func TestResponse_Ranges(t *testing.T) {
+	modTime := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
 
-	headers := map[string][]string{
-		"Content-Type": {"text/plain"},
-	}
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "hello.txt", modTime,
+				strings.NewReader("hello, world"))
+		}))
+	defer server.Close()
 
-	resp := NewResponse(reporter, &http.Response{
-		Header: http.Header(headers),
+	e := WithConfig(Config{
+		BaseURL:  server.URL,
+		Reporter: NewAssertReporter(t),
 	})
 
-	resp.ContentType("text/plain")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	t.Run("single range", func(t *testing.T) {
+		resp := e.GET("/").WithRange(7, -1).Expect()
 
-	resp.ContentType("text/plain", "")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		resp.Status(http.StatusPartialContent)
+		resp.ContentRange().Value("start").Number().Equal(7)
+		resp.Ranges().Element(0).Object().Value("body").String().Equal("world")
+	})
 
-	resp.ContentType("text/plain", "utf-8")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
-}
+	t.Run("multiple ranges", func(t *testing.T) {
+		resp := e.GET("/").WithRange(0, 4).WithRange(7, 11).Expect()
+
+		resp.Status(http.StatusPartialContent)
+		resp.Ranges().Equal([]interface{}{
+			map[string]interface{}{
+				"unit":         "bytes",
+				"start":        0,
+				"end":          4,
+				"size":         12,
+				"content_type": "text/plain; charset=utf-8",
+				"body":         "hello",
+			},
+			map[string]interface{}{
+				"unit":         "bytes",
+				"start":        7,
+				"end":          11,
+				"size":         12,
+				"content_type": "text/plain; charset=utf-8",
+				"body":         "world",
+			},
+		})
+	})
 
-func TestResponse_ContentTypeInvalid(t *testing.T) {
-	reporter := newMockReporter(t)
+	t.Run("not satisfiable", func(t *testing.T) {
+		resp := e.GET("/").WithRange(100, -1).Expect()
 
-	headers1 := map[string][]string{
-		"Content-Type": {";"},
-	}
+		resp.Status(http.StatusRequestedRangeNotSatisfiable)
+		resp.ContentRange().NotContainsKey("start").Value("size").Number().Equal(12)
+	})
+}
 
-	headers2 := map[string][]string{
-		"Content-Type": {"charset=utf-8"},
-	}
+func TestResponse_RateLimit(t *testing.T) {
+	reporter := newMockReporter(t)
 
-	resp1 := NewResponse(reporter, &http.Response{
-		Header: http.Header(headers1),
-	})
+	t.Run("standard", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header: http.Header{
+				"Ratelimit-Limit":     {"100, 100;w=60"},
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"30"},
+				"Retry-After":         {"60"},
+			},
+		})
 
-	resp2 := NewResponse(reporter, &http.Response{
-		Header: http.Header(headers2),
+		resp.RateLimit().Equal(map[string]interface{}{
+			"limit":       100,
+			"remaining":   0,
+			"reset":       30,
+			"retry_after": 60,
+		})
+		resp.chain.assertNotFailed(t)
 	})
 
-	resp1.ContentType("")
-	resp1.chain.assertFailed(t)
-	resp1.chain.clearFailed()
+	t.Run("legacy", func(t *testing.T) {
+		reset := time.Now().Add(time.Hour).Unix()
 
-	resp1.ContentType("", "")
-	resp1.chain.assertFailed(t)
-	resp1.chain.clearFailed()
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"X-Ratelimit-Limit":     {"5000"},
+				"X-Ratelimit-Remaining": {"4999"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(reset, 10)},
+			},
+		})
 
-	resp2.ContentType("")
-	resp2.chain.assertFailed(t)
-	resp2.chain.clearFailed()
+		rl := resp.RateLimit()
 
-	resp2.ContentType("", "")
-	resp2.chain.assertFailed(t)
-	resp2.chain.clearFailed()
-}
+		rl.Keys().ContainsOnly("limit", "remaining", "reset")
+		rl.Value("limit").Number().Equal(5000)
+		rl.Value("remaining").Number().Equal(4999)
+		rl.Value("reset").Number().InRange(3500, 3600)
 
-func TestResponse_ContentEncoding(t *testing.T) {
-	reporter := newMockReporter(t)
+		resp.chain.assertNotFailed(t)
+	})
 
-	headers := map[string][]string{
-		"Content-Encoding": {"gzip", "deflate"},
-	}
+	t.Run("no headers", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
 
-	resp := NewResponse(reporter, &http.Response{
-		Header: http.Header(headers),
+		resp.RateLimit().Empty()
+		resp.chain.assertNotFailed(t)
 	})
 
-	resp.ContentEncoding("gzip", "deflate")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
-
-	resp.ContentEncoding("deflate", "gzip")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	t.Run("invalid", func(t *testing.T) {
+		headers := []http.Header{
+			{"Ratelimit-Limit": {"many"}},
+			{"X-Ratelimit-Remaining": {"-1"}},
+			{"Ratelimit-Reset": {"soon"}},
+			{"Retry-After": {"later"}},
+		}
 
-	resp.ContentEncoding("gzip")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		for _, header := range headers {
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+			})
 
-	resp.ContentEncoding()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+			rl := resp.RateLimit()
+			resp.chain.assertFailed(t)
+			rl.chain.assertFailed(t)
+		}
+	})
 }
 
-func TestResponse_TransferEncoding(t *testing.T) {
+func TestResponse_TLS(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	resp := NewResponse(reporter, &http.Response{
-		TransferEncoding: []string{"foo", "bar"},
+	t.Run("tls", func(t *testing.T) {
+		state := &tls.ConnectionState{
+			Version:            tls.VersionTLS12,
+			NegotiatedProtocol: "h2",
+		}
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			TLS:        state,
+		})
+
+		value := resp.TLS()
+		resp.chain.assertNotFailed(t)
+
+		assert.Same(t, state, value.Raw())
+
+		value.Version().Equal("TLS 1.2")
+		value.NegotiatedProtocol().Equal("h2")
+		resp.chain.assertNotFailed(t)
 	})
 
-	resp.TransferEncoding("foo", "bar")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	t.Run("no tls", func(t *testing.T) {
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
 
-	resp.TransferEncoding("foo")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		value := resp.TLS()
+		resp.chain.assertFailed(t)
+		value.chain.assertFailed(t)
 
-	resp.TransferEncoding()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		assert.Nil(t, value.Raw())
+	})
 }
 
-func TestResponse_Text(t *testing.T) {
+func TestResponse_Body(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	headers := map[string][]string{
-		"Content-Type": {"text/plain; charset=utf-8"},
-	}
-
-	body := `hello, world!`
-
 	httpResp := &http.Response{
 		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Body:       ioutil.NopCloser(bytes.NewBufferString("body")),
 	}
 
 	resp := NewResponse(reporter, httpResp)
 
-	assert.Equal(t, body, resp.Body().Raw())
+	assert.Equal(t, "body", resp.Body().Raw())
 	resp.chain.assertNotFailed(t)
 	resp.chain.clearFailed()
+}
 
-	resp.ContentType("text/plain")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+func TestResponse_Binary(t *testing.T) {
+	reporter := newMockReporter(t)
 
-	resp.ContentType("text/plain", "utf-8")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	body := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00}
 
-	resp.ContentType("application/json")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	resp := NewResponse(reporter, &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	})
 
-	resp.Text()
+	assert.Equal(t, body, resp.Binary().Raw())
+
+	resp.Binary().
+		Length().Equal(len(body))
+	resp.Binary().
+		HasPrefix([]byte("\x89PNG\r\n\x1a\n")).
+		Equal(body)
 	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
 
-	assert.Equal(t, "hello, world!", resp.Text().Raw())
+	resp.Binary().HasPrefix([]byte("%PDF")).chain.assertFailed(t)
 }
 
-func TestResponse_Form(t *testing.T) {
-	reporter := newMockReporter(t)
+func TestResponse_Download(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
 
-	headers := map[string][]string{
-		"Content-Type": {"application/x-www-form-urlencoded"},
-	}
+	body := []byte("hello")
 
-	body := `a=1&b=2`
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-	}
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		})
 
-	resp := NewResponse(reporter, httpResp)
+		path := filepath.Join(dir, "file")
 
-	assert.Equal(t, body, resp.Body().Raw())
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		bin := resp.Download(path)
+		bin.Length().Equal(len(body))
+		bin.SHA256().Equal(
+			"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+		bin.chain.assertNotFailed(t)
 
-	resp.ContentType("application/x-www-form-urlencoded")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, body, data)
+	})
 
-	resp.ContentType("application/x-www-form-urlencoded", "")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+	t.Run("write error", func(t *testing.T) {
+		reporter := newMockReporter(t)
 
-	resp.ContentType("text/plain")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		})
 
-	resp.Form()
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		bin := resp.Download(filepath.Join(dir, "missing", "file"))
+		bin.chain.assertFailed(t)
+		assert.Nil(t, bin.Raw())
+	})
 
-	expected := map[string]interface{}{
-		"a": "1",
-		"b": "2",
-	}
+	t.Run("not streamed", func(t *testing.T) {
+		reporter := newMockReporter(t)
 
-	assert.Equal(t, expected, resp.Form().Raw())
-}
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		})
 
-func TestResponse_FormBadBody(t *testing.T) {
-	reporter := newMockReporter(t)
+		resp.DownloadedLength().chain.assertFailed(t)
+		resp.DownloadedSHA256().chain.assertFailed(t)
+	})
+}
 
-	headers := map[string][]string{
-		"Content-Type": {"application/x-www-form-urlencoded"},
+func TestResponse_MatchSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	newResp := func(
+		reporter Reporter, update bool, contentType, body string,
+	) *Response {
+		return NewResponseC(Config{
+			Reporter:        reporter,
+			SnapshotDir:     dir,
+			UpdateSnapshots: update,
+			UsePlaceholders: true,
+		}, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
 	}
 
-	body := "%"
+	t.Run("json", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := `{"id":123,"name":"john","created_at":"2023-01-02T15:04:05Z"}`
+
+		resp := newResp(reporter, true, "application/json", body)
+		resp.MatchSnapshot("users/get")
+		resp.chain.assertNotFailed(t)
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "users", "get.golden"))
+		require.NoError(t, err)
+		assert.Equal(t,
+			"{\n  \"created_at\": \"2023-01-02T15:04:05Z\",\n"+
+				"  \"id\": 123,\n  \"name\": \"john\"\n}\n",
+			string(data))
+
+		resp = newResp(reporter, false, "application/json; charset=utf-8", body)
+		resp.MatchSnapshot("users/get")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(reporter, false, "application/json",
+			`{"id":123,"name":"bob","created_at":"2023-01-02T15:04:05Z"}`)
+		resp.MatchSnapshot("users/get")
+		resp.chain.assertFailed(t)
+
+		resp = newResp(reporter, false, "application/json",
+			`{"id":123,"name":"john","created_at":"2024-05-06T00:00:00Z"}`)
+		resp.MatchSnapshot("users/get")
+		resp.chain.assertFailed(t)
+
+		resp = newResp(reporter, false, "application/json",
+			`{"id":123,"name":"john","created_at":"2024-05-06T00:00:00Z"}`)
+		resp.MatchSnapshot("users/get", "created_at")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(reporter, false, "application/json", `{"id":`)
+		resp.MatchSnapshot("users/get")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("json placeholders", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		err := ioutil.WriteFile(filepath.Join(dir, "placeholders.golden"),
+			[]byte(`{"id": "$uuid", "name": "john"}`), 0644)
+		require.NoError(t, err)
+
+		resp := newResp(reporter, false, "application/problem+json",
+			`{"id":"123e4567-e89b-12d3-a456-426614174000","name":"john"}`)
+		resp.MatchSnapshot("placeholders")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newResp(reporter, true, "text/plain", "hello")
+		resp.MatchSnapshot("text")
+		resp.chain.assertNotFailed(t)
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "text.golden"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+
+		resp = newResp(reporter, false, "text/plain", "hello")
+		resp.MatchSnapshot("text")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(reporter, false, "text/plain", "hello!")
+		resp.MatchSnapshot("text")
+		resp.chain.assertFailed(t)
+
+		resp = newResp(reporter, false, "text/plain", "hello")
+		resp.MatchSnapshot("text", "foo")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("env", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		os.Setenv(snapshotUpdateEnv, "1")
+		defer os.Unsetenv(snapshotUpdateEnv)
+
+		resp := newResp(reporter, false, "text/plain", "env")
+		resp.MatchSnapshot("env")
+		resp.chain.assertNotFailed(t)
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "env.golden"))
+		require.NoError(t, err)
+		assert.Equal(t, "env", string(data))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newResp(reporter, false, "text/plain", "hello")
+		resp.MatchSnapshot("missing")
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		for _, name := range []string{"", "..", "../foo", "foo/../../bar"} {
+			reporter := newMockReporter(t)
+
+			resp := newResp(reporter, true, "text/plain", "hello")
+			resp.MatchSnapshot(name)
+			resp.chain.assertFailed(t)
+		}
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := newResp(reporter, true, "application/json", "{}")
+		resp.MatchSnapshot("json", "foo..bar")
+		resp.chain.assertFailed(t)
+	})
+}
+func TestResponse_BodyClose(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := newMockBody("test_body")
 
 	httpResp := &http.Response{
 		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Body:       body,
 	}
 
 	resp := NewResponse(reporter, httpResp)
 
-	resp.Form()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+	assert.Equal(t, "test_body", resp.Body().Raw())
+	assert.True(t, body.closed)
 
-	assert.True(t, resp.Form().Raw() == nil)
+	resp.chain.assertNotFailed(t)
 }
 
-func TestResponse_FormBadType(t *testing.T) {
+func TestResponse_BodyError(t *testing.T) {
 	reporter := newMockReporter(t)
 
-	headers := map[string][]string{
-		"Content-Type": {"bad"},
-	}
+	t.Run("read_err", func(t *testing.T) {
+		body := newMockBody("test_body")
+		body.readErr = errors.New("test_error")
 
-	body := "foo=bar"
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       body,
+		}
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-	}
+		resp := NewResponse(reporter, httpResp)
 
-	resp := NewResponse(reporter, httpResp)
+		assert.Equal(t, "", resp.Body().Raw())
+		assert.True(t, body.closed)
 
-	resp.Form()
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		resp.chain.assertFailed(t)
+	})
 
-	assert.True(t, resp.Form().Raw() == nil)
+	t.Run("close_err", func(t *testing.T) {
+		body := newMockBody("test_body")
+		body.closeErr = errors.New("test_error")
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       body,
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		assert.Equal(t, "", resp.Body().Raw())
+		assert.True(t, body.closed)
+
+		resp.chain.assertFailed(t)
+	})
 }
 
-func TestResponse_JSON(t *testing.T) {
+func TestResponse_NoContentEmpty(t *testing.T) {
 	reporter := newMockReporter(t)
 
 	headers := map[string][]string{
-		"Content-Type": {"application/json; charset=utf-8"},
+		"Content-Type": {""},
 	}
 
-	body := `{"key": "value"}`
-
 	httpResp := &http.Response{
 		StatusCode: http.StatusOK,
 		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Body:       ioutil.NopCloser(bytes.NewBufferString("")),
 	}
 
 	resp := NewResponse(reporter, httpResp)
 
-	assert.Equal(t, body, resp.Body().Raw())
+	assert.Equal(t, "", resp.Body().Raw())
 	resp.chain.assertNotFailed(t)
 	resp.chain.clearFailed()
 
-	resp.ContentType("application/json")
+	resp.NoContent()
 	resp.chain.assertNotFailed(t)
 	resp.chain.clearFailed()
 
-	resp.ContentType("application/json", "utf-8")
+	resp.ContentType("")
 	resp.chain.assertNotFailed(t)
 	resp.chain.clearFailed()
 
-	resp.ContentType("text/plain")
+	resp.Text()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Form()
 	resp.chain.assertFailed(t)
 	resp.chain.clearFailed()
 
 	resp.JSON()
-	resp.chain.assertNotFailed(t)
+	resp.chain.assertFailed(t)
 	resp.chain.clearFailed()
 
-	assert.Equal(t,
-		map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
+	resp.JSONP("")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
 }
 
-func TestResponse_JSONBadBody(t *testing.T) {
+func TestResponse_NoContentNil(t *testing.T) {
 	reporter := newMockReporter(t)
 
 	headers := map[string][]string{
-		"Content-Type": {"application/json; charset=utf-8"},
+		"Content-Type": {""},
 	}
 
-	body := "{"
-
 	httpResp := &http.Response{
 		StatusCode: http.StatusOK,
 		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Body:       nil,
 	}
 
 	resp := NewResponse(reporter, httpResp)
 
+	assert.Equal(t, "", resp.Body().Raw())
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.NoContent()
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Text()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Form()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
 	resp.JSON()
 	resp.chain.assertFailed(t)
 	resp.chain.clearFailed()
 
-	assert.True(t, resp.JSON().Raw() == nil)
+	resp.JSONP("")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
 }
 
-func TestResponse_JSONCharsetEmpty(t *testing.T) {
+func TestResponse_NoContentFailed(t *testing.T) {
 	reporter := newMockReporter(t)
 
 	headers := map[string][]string{
-		"Content-Type": {"application/json"},
+		"Content-Type": {"text/plain; charset=utf-8"},
 	}
 
-	body := `{"key": "value"}`
+	body := ``
 
 	httpResp := &http.Response{
 		StatusCode: http.StatusOK,
@@ -935,167 +1602,2076 @@ func TestResponse_JSONCharsetEmpty(t *testing.T) {
 
 	resp := NewResponse(reporter, httpResp)
 
-	resp.JSON()
+	assert.Equal(t, body, resp.Body().Raw())
 	resp.chain.assertNotFailed(t)
 	resp.chain.clearFailed()
 
-	assert.Equal(t,
-		map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
+	resp.NoContent()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
 }
 
-func TestResponse_JSONCharsetBad(t *testing.T) {
+func TestResponse_ContentType(t *testing.T) {
 	reporter := newMockReporter(t)
 
 	headers := map[string][]string{
-		"Content-Type": {"application/json; charset=bad"},
+		"Content-Type": {"text/plain; charset=utf-8"},
 	}
 
-	body := `{"key": "value"}`
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header(headers),
+	})
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-	}
+	resp.ContentType("text/plain")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
 
-	resp := NewResponse(reporter, httpResp)
+	resp.ContentType("text/plain", "utf-8")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
 
-	resp.JSON()
+	resp.ContentType("text/plain", "UTF-8")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("bad")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain", "bad")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("")
 	resp.chain.assertFailed(t)
 	resp.chain.clearFailed()
 
-	assert.Equal(t, nil, resp.JSON().Raw())
-}
+	resp.ContentType("text/plain", "")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+}
+
+func TestResponse_ContentTypeEmptyCharset(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"text/plain"},
+	}
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header(headers),
+	})
+
+	resp.ContentType("text/plain")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain", "")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain", "utf-8")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+}
+
+func TestResponse_ContentTypeInvalid(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers1 := map[string][]string{
+		"Content-Type": {";"},
+	}
+
+	headers2 := map[string][]string{
+		"Content-Type": {"charset=utf-8"},
+	}
+
+	resp1 := NewResponse(reporter, &http.Response{
+		Header: http.Header(headers1),
+	})
+
+	resp2 := NewResponse(reporter, &http.Response{
+		Header: http.Header(headers2),
+	})
+
+	resp1.ContentType("")
+	resp1.chain.assertFailed(t)
+	resp1.chain.clearFailed()
+
+	resp1.ContentType("", "")
+	resp1.chain.assertFailed(t)
+	resp1.chain.clearFailed()
+
+	resp2.ContentType("")
+	resp2.chain.assertFailed(t)
+	resp2.chain.clearFailed()
+
+	resp2.ContentType("", "")
+	resp2.chain.assertFailed(t)
+	resp2.chain.clearFailed()
+}
+
+func TestResponse_SniffedContentType(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     []byte
+		expected string
+	}{
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00"), "image/png"},
+		{"html", []byte("<!DOCTYPE html><html></html>"), "text/html; charset=utf-8"},
+		{"json", []byte(`{"a": 1}`), "text/plain; charset=utf-8"},
+		{"utf-16 bom", []byte{0xff, 0xfe, 'a', 0}, "text/plain; charset=utf-16le"},
+		{"binary", []byte{0x00, 0x01, 0x02}, "application/octet-stream"},
+		{"empty", []byte{}, "text/plain; charset=utf-8"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader(tc.body)),
+			})
+
+			resp.SniffedContentType().Equal(tc.expected).
+				chain.assertNotFailed(t)
+		})
+	}
+}
+
+func TestResponse_ContentEncoding(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Encoding": {"gzip", "deflate"},
+	}
+
+	resp := NewResponse(reporter, &http.Response{
+		Header: http.Header(headers),
+	})
+
+	resp.ContentEncoding("gzip", "deflate")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentEncoding("deflate", "gzip")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentEncoding("gzip")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentEncoding()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+}
+
+func TestResponse_Decompression(t *testing.T) {
+	content := strings.Repeat("hello, world! ", 100)
+
+	encode := func(encoding string, data []byte) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		case "raw-deflate":
+			w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+		case "br":
+			w = brotli.NewWriter(&buf)
+		}
+
+		_, _ = w.Write(data)
+		_ = w.Close()
+
+		return buf.Bytes()
+	}
+
+	cases := []struct {
+		name     string
+		encoding []string
+		body     []byte
+	}{
+		{
+			name:     "gzip",
+			encoding: []string{"gzip"},
+			body:     encode("gzip", []byte(content)),
+		},
+		{
+			name:     "x-gzip",
+			encoding: []string{"x-gzip"},
+			body:     encode("gzip", []byte(content)),
+		},
+		{
+			name:     "deflate",
+			encoding: []string{"deflate"},
+			body:     encode("deflate", []byte(content)),
+		},
+		{
+			name:     "raw deflate",
+			encoding: []string{"deflate"},
+			body:     encode("raw-deflate", []byte(content)),
+		},
+		{
+			name:     "br",
+			encoding: []string{"br"},
+			body:     encode("br", []byte(content)),
+		},
+		{
+			name:     "multiple headers",
+			encoding: []string{"gzip", "br"},
+			body:     encode("br", encode("gzip", []byte(content))),
+		},
+		{
+			name:     "multiple values",
+			encoding: []string{"deflate, gzip"},
+			body:     encode("gzip", encode("deflate", []byte(content))),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Encoding": tc.encoding,
+				},
+				Body: ioutil.NopCloser(bytes.NewReader(tc.body)),
+			})
+
+			resp.ContentEncoding(tc.encoding...)
+			resp.Body().Equal(content)
+			resp.CompressionRatio().Equal(
+				float64(len(content)) / float64(len(tc.body)))
+			resp.CompressionRatio().Gt(1)
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("identity", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"identity"},
+			},
+			Body: ioutil.NopCloser(strings.NewReader(content)),
+		})
+
+		resp.Body().Equal(content)
+		resp.CompressionRatio().Equal(1)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"compress"},
+			},
+			Body: ioutil.NopCloser(strings.NewReader("data")),
+		})
+
+		resp.Body().Equal("data")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip"},
+			},
+			Body: ioutil.NopCloser(strings.NewReader("")),
+		})
+
+		resp.Body().Empty()
+		resp.CompressionRatio().Equal(1)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip"},
+			},
+			Body: ioutil.NopCloser(strings.NewReader("not gzip")),
+		})
+
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_TransferEncoding(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	resp := NewResponse(reporter, &http.Response{
+		TransferEncoding: []string{"foo", "bar"},
+	})
+
+	resp.TransferEncoding("foo", "bar")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.TransferEncoding("foo")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.TransferEncoding()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+}
+
+func TestResponse_Text(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"text/plain; charset=utf-8"},
+	}
+
+	body := `hello, world!`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	assert.Equal(t, body, resp.Body().Raw())
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain", "utf-8")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("application/json")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Text()
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Equal(t, "hello, world!", resp.Text().Raw())
+}
+
+func TestResponse_TextCharset(t *testing.T) {
+	newResp := func(config Config, contentType string, body []byte) *Response {
+		return newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("test", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewReader(body)),
+			},
+		})
+	}
+
+	// "café" in different charsets
+	latin1 := []byte{'c', 'a', 'f', 0xe9}
+	utf16le := []byte{0xff, 0xfe, 'c', 0, 'a', 0, 'f', 0, 0xe9, 0}
+
+	t.Run("iso-8859-1", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "text/plain; charset=iso-8859-1", latin1)
+		resp.Text().Equal("café")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(config, "text/plain; charset=ISO-8859-1", latin1)
+		resp.Text(ContentOpts{Charset: "iso-8859-1"}).Equal("café")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("utf-16", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "text/plain; charset=utf-16", utf16le)
+		resp.Text().Equal("café")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		body := append([]byte(`{"name": "`), latin1...)
+		body = append(body, []byte(`"}`)...)
+
+		resp := newResp(config, "application/json; charset=windows-1252", body)
+		resp.JSON().Object().ValueEqual("name", "café")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("charset mismatch", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "text/plain; charset=iso-8859-1", latin1)
+		resp.Text(ContentOpts{Charset: "utf-8"}).chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("unknown charset", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "text/plain; charset=bad", latin1)
+		resp.Text().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "text/plain", latin1)
+		resp.Text().Equal(string(latin1))
+		resp.chain.assertNotFailed(t)
+
+		config.FallbackCharset = "iso-8859-1"
+
+		resp = newResp(config, "text/plain", latin1)
+		resp.Text().Equal("café")
+		resp.chain.assertNotFailed(t)
+
+		// valid utf-8 is kept as is
+		resp = newResp(config, "text/plain", []byte("café"))
+		resp.Text().Equal("café")
+		resp.chain.assertNotFailed(t)
+
+		// explicit utf-8 charset disables fallback
+		resp = newResp(config, "text/plain; charset=utf-8", latin1)
+		resp.Text().Equal(string(latin1))
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bom", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "text/plain", []byte("\xef\xbb\xbfcafé"))
+		resp.Text().Equal("café").
+			chain.assertNotFailed(t)
+
+		resp = newResp(config, "text/plain; charset=utf-8", []byte("\xef\xbb\xbfcafé"))
+		resp.Text().Equal("café").
+			chain.assertNotFailed(t)
+
+		resp = newResp(config, "text/plain", utf16le)
+		resp.Text().Equal("café").
+			chain.assertNotFailed(t)
+
+		resp = newResp(config, "text/plain",
+			[]byte{0xfe, 0xff, 0, 'c', 0, 'a', 0, 'f', 0, 0xe9})
+		resp.Text().Equal("café").
+			chain.assertNotFailed(t)
+
+		resp = newResp(config, "text/plain; charset=utf-16be",
+			[]byte{0xfe, 0xff, 0, 'c', 0, 'a', 0, 'f', 0, 0xe9})
+		resp.Text().Equal("café").
+			chain.assertNotFailed(t)
+
+		resp = newResp(config, "application/json", []byte("\xef\xbb\xbf{\"a\": 1}"))
+		resp.JSON().Object().ValueEqual("a", 1).
+			chain.assertNotFailed(t)
+
+		resp = newResp(config, "application/json",
+			[]byte{0xff, 0xfe, '[', 0, '1', 0, ']', 0})
+		resp.JSON().Array().Elements(1).
+			chain.assertNotFailed(t)
+	})
+
+	t.Run("bad fallback", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.FallbackCharset = "bad"
+
+		resp := newResp(config, "text/plain", latin1)
+		resp.Text().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_Form(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/x-www-form-urlencoded"},
+	}
+
+	body := `a=1&b=2`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	assert.Equal(t, body, resp.Body().Raw())
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("application/x-www-form-urlencoded")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("application/x-www-form-urlencoded", "")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.Form()
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	expected := map[string]interface{}{
+		"a": "1",
+		"b": "2",
+	}
+
+	assert.Equal(t, expected, resp.Form().Raw())
+}
+
+func TestResponse_FormBadBody(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/x-www-form-urlencoded"},
+	}
+
+	body := "%"
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.Form()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.True(t, resp.Form().Raw() == nil)
+}
+
+func TestResponse_FormBadType(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"bad"},
+	}
+
+	body := "foo=bar"
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.Form()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.True(t, resp.Form().Raw() == nil)
+}
+
+func TestResponse_JSON(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/json; charset=utf-8"},
+	}
+
+	body := `{"key": "value"}`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	assert.Equal(t, body, resp.Body().Raw())
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("application/json")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("application/json", "utf-8")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	resp.ContentType("text/plain")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.JSON()
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Equal(t,
+		map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
+}
+
+func TestResponse_JSONNumber(t *testing.T) {
+	body := `{"id": 9007199254740993, "count": 3}`
+
+	newResp := func(config Config) *Response {
+		return newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("test", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			},
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config)
+
+		// precision is lost when decoding
+		num := resp.JSON().Object().Value("id").Number()
+		num.Equal(int64(9007199254740992))
+		num.chain.assertNotFailed(t)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.UseJSONNumber = true
+
+		resp := newResp(config)
+
+		obj := resp.JSON().Object()
+
+		obj.Value("id").Number().Equal(int64(9007199254740993)).
+			chain.assertNotFailed(t)
+		obj.Value("id").Number().NotEqual(int64(9007199254740992)).
+			chain.assertNotFailed(t)
+		obj.Value("count").Number().Equal(3).
+			chain.assertNotFailed(t)
+
+		obj.ValueEqual("id", uint64(9007199254740993))
+		obj.Equal(map[string]interface{}{
+			"id":    int64(9007199254740993),
+			"count": 3,
+		})
+		obj.chain.assertNotFailed(t)
+
+		obj.ValueEqual("id", int64(9007199254740992))
+		obj.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_StrictJSON(t *testing.T) {
+	newResp := func(config Config, contentType, body string) *Response {
+		return newResponse(responseOpts{
+			config: config,
+			chain:  newChainWithConfig("test", config),
+			httpResp: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			},
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+
+		resp := newResp(config, "application/json", `{"role": "user", "role": "admin"}`)
+
+		// last value silently wins
+		obj := resp.JSON().Object()
+		obj.ValueEqual("role", "admin")
+		obj.chain.assertNotFailed(t)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		config := newMockConfig(newMockReporter(t))
+		config.StrictJSON = true
+
+		resp := newResp(config, "application/json", `{"role": "user", "admin": true}`)
+		resp.JSON().Object().ValueEqual("role", "user")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(config, "application/json", `{"role": "user", "role": "admin"}`)
+		resp.JSON().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		resp = newResp(config, "application/json", `{"a": {"b": 1, "b": 2}}`)
+		resp.JSON().chain.assertFailed(t)
+
+		resp = newResp(config, "application/json", `{"a": 1} {"a": 2}`)
+		resp.JSON().chain.assertFailed(t)
+
+		resp = newResp(config, "application/x-ndjson", "{\"a\": 1}\n{\"a\": 1, \"a\": 2}\n")
+		resp.JSONLines().chain.assertFailed(t)
+
+		resp = newResp(config, "application/javascript", `cb({"a": 1, "a": 2})`)
+		resp.JSONP("cb").chain.assertFailed(t)
+	})
+}
+
+func TestResponse_JSONLines(t *testing.T) {
+	newResp := func(t *testing.T, contentType, body string) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {contentType}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+	}
+
+	body := "{\"n\": 1}\n\n{\"n\": 2}\r\n[3]\n\"four\"\n"
+
+	t.Run("basic", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson; charset=utf-8", body)
+
+		lines := resp.JSONLines()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"n": 1.0},
+			map[string]interface{}{"n": 2.0},
+			[]interface{}{3.0},
+			"four",
+		}, lines.Raw())
+
+		lines.Element(1).Object().Value("n").Equal(2)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson", "")
+
+		assert.Equal(t, []interface{}{}, resp.JSONLines().Raw())
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("max lines", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson", body+`{"trunc`)
+
+		resp.JSONLines().chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		lines := resp.JSONLines(JSONLinesOpts{MaxLines: 2})
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"n": 1.0},
+			map[string]interface{}{"n": 2.0},
+		}, lines.Raw())
+
+		resp.JSONLines(JSONLinesOpts{MaxLines: 4}).Length().Equal(4)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		resp := newResp(t, "application/jsonl", body)
+
+		resp.JSONLines().chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.JSONLines(JSONLinesOpts{MediaType: "application/jsonl"}).
+			Length().Equal(4)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson", "{\"n\": 1}\n{bad}\n")
+
+		lines := resp.JSONLines()
+		resp.chain.assertFailed(t)
+
+		assert.Nil(t, lines.Raw())
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		resp := newResp(t, "application/x-ndjson", body)
+
+		resp.JSONLines(JSONLinesOpts{}, JSONLinesOpts{})
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.JSONLines(JSONLinesOpts{MaxLines: -1})
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_EventStream(t *testing.T) {
+	body := "event: a\ndata: 1\n\nevent: b\ndata: 2\n\n"
+
+	t.Run("basic", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/event-stream; charset=utf-8"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+
+		stream := resp.EventStream()
+		stream.Length().Equal(2)
+		stream.NextEvent().Name().Equal("a")
+		stream.NextEvent().Name().Equal("b")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad content type", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+
+		resp.EventStream().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/event-stream"},
+			},
+			Body: &mockBody{
+				reader: bytes.NewBufferString(body),
+				eofErr: context.DeadlineExceeded,
+			},
+		})
+		resp.chain.assertNotFailed(t)
+
+		resp.EventStream().Length().Equal(2)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("timeout not event stream", func(t *testing.T) {
+		resp := NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain"},
+			},
+			Body: &mockBody{
+				reader: bytes.NewBufferString(body),
+				eofErr: context.DeadlineExceeded,
+			},
+		})
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_JSONBadBody(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/json; charset=utf-8"},
+	}
+
+	body := "{"
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSON()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.True(t, resp.JSON().Raw() == nil)
+}
+
+func TestResponse_JSONCharsetEmpty(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/json"},
+	}
+
+	body := `{"key": "value"}`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSON()
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Equal(t,
+		map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
+}
+
+func TestResponse_JSONCharsetBad(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/json; charset=bad"},
+	}
+
+	body := `{"key": "value"}`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSON()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Equal(t, nil, resp.JSON().Raw())
+}
+
+func TestResponse_JSONP(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/javascript; charset=utf-8"},
+	}
+
+	body1 := `foo({"key": "value"})`
+	body2 := `foo({"key": "value"});`
+	body3 := ` foo ( {"key": "value"} ) ; `
+
+	for _, body := range []string{body1, body2, body3} {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		assert.Equal(t, body, resp.Body().Raw())
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.ContentType("application/javascript")
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.ContentType("application/javascript", "utf-8")
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		resp.ContentType("text/plain")
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.JSONP("foo")
+		resp.chain.assertNotFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Equal(t,
+			map[string]interface{}{"key": "value"}, resp.JSONP("foo").Object().Raw())
+
+		resp.JSONP("fo")
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.JSONP("")
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+	}
+}
+
+func TestResponse_JSONPBadBody(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/javascript; charset=utf-8"},
+	}
+
+	body1 := `foo`
+	body2 := `foo();`
+	body3 := `foo(`
+	body4 := `foo({);`
+
+	for _, body := range []string{body1, body2, body3, body4} {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.JSONP("foo")
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		assert.True(t, resp.JSONP("foo").Raw() == nil)
+	}
+}
+
+func TestResponse_JSONPCharsetEmpty(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/javascript"},
+	}
+
+	body := `foo({"key": "value"})`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSONP("foo")
+	resp.chain.assertNotFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Equal(t,
+		map[string]interface{}{"key": "value"}, resp.JSONP("foo").Object().Raw())
+}
+
+func TestResponse_JSONPCharsetBad(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	headers := map[string][]string{
+		"Content-Type": {"application/javascript; charset=bad"},
+	}
+
+	body := `foo({"key": "value"})`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header(headers),
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.JSONP("foo")
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.Nil(t, resp.JSONP("foo").Raw())
+}
+
+func TestResponse_XML(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<user id="1">
+  <name>john</name>
+  <role>admin</role>
+  <role>dev</role>
+</user>`
+
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{
+			"-id":  "1",
+			"name": "john",
+			"role": []interface{}{"admin", "dev"},
+		},
+	}
+
+	for _, contentType := range []string{
+		"application/xml",
+		"application/xml; charset=utf-8",
+		"text/xml",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.XML()
+			resp.chain.assertNotFailed(t)
+			resp.chain.clearFailed()
+
+			assert.Equal(t, expected, resp.XML().Object().Raw())
+
+			resp.XML().Path("$.user.name").String().Equal("john")
+			resp.chain.assertNotFailed(t)
+		})
+	}
+}
+
+func TestResponse_XMLBadBody(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"application/xml"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString("<user>")),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.XML()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	assert.True(t, resp.XML().Raw() == nil)
+}
+
+func TestResponse_XMLBadType(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+		Body: ioutil.NopCloser(bytes.NewBufferString("<user/>")),
+	}
+
+	resp := NewResponse(reporter, httpResp)
+
+	resp.XML()
+	resp.chain.assertFailed(t)
+	resp.chain.clearFailed()
+
+	resp.XML(ContentOpts{
+		MediaType: "application/json",
+	})
+	resp.chain.assertNotFailed(t)
+}
+
+func TestResponse_HTML(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := `<html><body><h1 class="title">Hello</h1></body></html>`
+
+	for _, contentType := range []string{
+		"text/html",
+		"text/html; charset=utf-8",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.HTML().Select("h1.title").Text().Equal("Hello")
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("bad type", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/xhtml+xml"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.HTML()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.HTML(ContentOpts{
+			MediaType: "application/xhtml+xml",
+		}).Select("h1").Length().Equal(1)
+		resp.chain.assertNotFailed(t)
+	})
+}
+
+func TestResponse_MsgPack(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := "\x82\xa3foo\x7b\xa3bar\x92\xc3\xa1x"
+
+	t.Run("success", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/msgpack"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		assert.Equal(t,
+			map[string]interface{}{
+				"foo": 123.0,
+				"bar": []interface{}{true, "x"},
+			},
+			resp.MsgPack().Raw())
+
+		resp.MsgPack().Object().ValueEqual("foo", 123)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad type", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/x-msgpack"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.MsgPack()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.MsgPack(ContentOpts{
+			MediaType: "application/x-msgpack",
+		})
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad charset", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/msgpack; charset=utf-8"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.MsgPack()
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/msgpack"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("\x82\xa3foo")),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.MsgPack()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Nil(t, resp.MsgPack().Raw())
+	})
+}
+
+func TestResponse_YAML(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	body := "foo: 123\nbar:\n  - true\n  - x\n1: one\n"
+
+	for _, contentType := range []string{
+		"application/yaml",
+		"application/yaml; charset=utf-8",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			assert.Equal(t,
+				map[string]interface{}{
+					"foo": 123.0,
+					"bar": []interface{}{true, "x"},
+					"1":   "one",
+				},
+				resp.YAML().Raw())
+
+			resp.YAML().Object().ValueEqual("foo", 123)
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("bad type", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/yaml"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.YAML()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.YAML(ContentOpts{
+			MediaType: "text/yaml",
+		})
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/yaml"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("foo: [")),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.YAML()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		assert.Nil(t, resp.YAML().Raw())
+	})
+}
+
+func TestResponse_CSV(t *testing.T) {
+	newResp := func(t *testing.T, contentType, body string) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		resp := newResp(t, "text/csv", "id,name\n1,\"doe, john\"\n")
+
+		assert.Equal(t,
+			[]interface{}{
+				[]interface{}{"id", "name"},
+				[]interface{}{"1", "doe, john"},
+			},
+			resp.CSV().Raw())
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("header", func(t *testing.T) {
+		resp := newResp(t, "text/csv; charset=utf-8", "id;name\n1;john\n2;jane\n")
+
+		assert.Equal(t,
+			[]interface{}{
+				map[string]interface{}{"id": "1", "name": "john"},
+				map[string]interface{}{"id": "2", "name": "jane"},
+			},
+			resp.CSV(CSVOpts{Comma: ';', Header: true}).Raw())
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		resp := newResp(t, "text/csv", "")
+
+		resp.CSV().Length().Equal(0)
+		resp.chain.assertNotFailed(t)
+
+		resp.CSV(CSVOpts{Header: true})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("only header", func(t *testing.T) {
+		resp := newResp(t, "text/csv", "id,name\n")
+
+		resp.CSV(CSVOpts{Header: true}).Length().Equal(0)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("media type", func(t *testing.T) {
+		resp := newResp(t, "application/csv", "a,b\n")
+
+		resp.CSV()
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.CSV(CSVOpts{MediaType: "application/csv"})
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		for _, body := range []string{
+			"a,b\n1,2,3\n",
+			"a,\"b\n",
+		} {
+			resp := newResp(t, "text/csv", body)
+
+			resp.CSV()
+			resp.chain.assertFailed(t)
+		}
+	})
+
+	t.Run("duplicate column", func(t *testing.T) {
+		resp := newResp(t, "text/csv", "a,a\n1,2\n")
+
+		resp.CSV()
+		resp.chain.assertNotFailed(t)
+
+		resp.CSV(CSVOpts{Header: true})
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("bad options", func(t *testing.T) {
+		resp := newResp(t, "text/csv", "a,b\n")
+
+		resp.CSV(CSVOpts{Comma: '\n'})
+		resp.chain.assertFailed(t)
+		resp.chain.clearFailed()
+
+		resp.CSV(CSVOpts{}, CSVOpts{})
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_GraphQL(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		fail        bool
+	}{
+		{
+			name:        "data",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"data": {"user": {"name": "john"}}}`,
+		},
+		{
+			name:        "errors",
+			contentType: "application/json",
+			body:        `{"data": null, "errors": [{"message": "not found"}]}`,
+		},
+		{
+			name:        "bad content type",
+			contentType: "text/plain",
+			body:        `{"data": null}`,
+			fail:        true,
+		},
+		{
+			name:        "bad json",
+			contentType: "application/json",
+			body:        `{"data": `,
+			fail:        true,
+		},
+		{
+			name:        "not envelope",
+			contentType: "application/json",
+			body:        `{"user": "john"}`,
+			fail:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {tc.contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			gql := resp.GraphQL()
+
+			if tc.fail {
+				resp.chain.assertFailed(t)
+				gql.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+				gql.chain.assertNotFailed(t)
+			}
+		})
+	}
+
+	t.Run("content opts", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/graphql-response+json"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(`{"data": {"a": 1}}`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.GraphQL(ContentOpts{
+			MediaType: "application/graphql-response+json",
+		}).Data().Object().ValueEqual("a", 1)
+
+		resp.chain.assertNotFailed(t)
+	})
+}
+
+func TestResponse_Problem(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		fail        bool
+	}{
+		{
+			name:        "problem",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"title": "not found", "status": 404}`,
+		},
+		{
+			name:        "no status member",
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json; charset=utf-8",
+			body:        `{"title": "bad request"}`,
+		},
+		{
+			name:        "status mismatch",
+			status:      http.StatusInternalServerError,
+			contentType: "application/problem+json",
+			body:        `{"title": "not found", "status": 404}`,
+			fail:        true,
+		},
+		{
+			name:        "plain json",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"title": "not found", "status": 404}`,
+			fail:        true,
+		},
+		{
+			name:        "bad json",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"title": `,
+			fail:        true,
+		},
+		{
+			name:        "not problem",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"title": 123}`,
+			fail:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			httpResp := &http.Response{
+				StatusCode: tc.status,
+				Header: http.Header{
+					"Content-Type": {tc.contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+			}
+
+			resp := NewResponse(reporter, httpResp)
 
-func TestResponse_JSONP(t *testing.T) {
-	reporter := newMockReporter(t)
+			problem := resp.Problem()
 
-	headers := map[string][]string{
-		"Content-Type": {"application/javascript; charset=utf-8"},
+			if tc.fail {
+				resp.chain.assertFailed(t)
+				problem.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+				problem.chain.assertNotFailed(t)
+			}
+		})
 	}
+}
 
-	body1 := `foo({"key": "value"})`
-	body2 := `foo({"key": "value"});`
-	body3 := ` foo ( {"key": "value"} ) ; `
+func TestResponse_JSONAPI(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		fail        bool
+	}{
+		{
+			name:        "data",
+			contentType: "application/vnd.api+json",
+			body:        `{"data": {"type": "articles", "id": "1"}}`,
+		},
+		{
+			name:        "errors",
+			contentType: "application/vnd.api+json",
+			body:        `{"errors": [{"status": "404"}]}`,
+		},
+		{
+			name:        "plain json",
+			contentType: "application/json",
+			body:        `{"data": null}`,
+			fail:        true,
+		},
+		{
+			name:        "bad json",
+			contentType: "application/vnd.api+json",
+			body:        `{"data": `,
+			fail:        true,
+		},
+		{
+			name:        "not document",
+			contentType: "application/vnd.api+json",
+			body:        `{"data": {"id": "1"}}`,
+			fail:        true,
+		},
+	}
 
-	for _, body := range []string{body1, body2, body3} {
-		httpResp := &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     http.Header(headers),
-			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-		}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
 
-		resp := NewResponse(reporter, httpResp)
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {tc.contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+			}
 
-		assert.Equal(t, body, resp.Body().Raw())
-		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
+			resp := NewResponse(reporter, httpResp)
 
-		resp.ContentType("application/javascript")
-		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
+			doc := resp.JSONAPI()
 
-		resp.ContentType("application/javascript", "utf-8")
-		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
+			if tc.fail {
+				resp.chain.assertFailed(t)
+				doc.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+				doc.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
 
-		resp.ContentType("text/plain")
-		resp.chain.assertFailed(t)
-		resp.chain.clearFailed()
+func TestResponse_HAL(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		fail        bool
+	}{
+		{
+			name:        "links",
+			contentType: "application/hal+json",
+			body:        `{"_links": {"self": {"href": "/orders/123"}}}`,
+		},
+		{
+			name:        "plain json",
+			contentType: "application/json",
+			body:        `{"_links": {"self": {"href": "/orders/123"}}}`,
+			fail:        true,
+		},
+		{
+			name:        "bad json",
+			contentType: "application/hal+json",
+			body:        `{"_links": `,
+			fail:        true,
+		},
+		{
+			name:        "not resource",
+			contentType: "application/hal+json",
+			body:        `{"_links": {"self": {}}}`,
+			fail:        true,
+		},
+	}
 
-		resp.JSONP("foo")
-		resp.chain.assertNotFailed(t)
-		resp.chain.clearFailed()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
 
-		assert.Equal(t,
-			map[string]interface{}{"key": "value"}, resp.JSONP("foo").Object().Raw())
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": {tc.contentType},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+			}
 
-		resp.JSONP("fo")
-		resp.chain.assertFailed(t)
-		resp.chain.clearFailed()
+			resp := NewResponse(reporter, httpResp)
 
-		resp.JSONP("")
-		resp.chain.assertFailed(t)
-		resp.chain.clearFailed()
+			hal := resp.HAL()
+
+			if tc.fail {
+				resp.chain.assertFailed(t)
+				hal.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+				hal.chain.assertNotFailed(t)
+			}
+		})
 	}
 }
 
-func TestResponse_JSONPBadBody(t *testing.T) {
-	reporter := newMockReporter(t)
+func TestResponse_GRPCWeb(t *testing.T) {
+	frames := append(
+		grpcWebEncode([][]byte{[]byte("hello")}),
+		0x80, 0, 0, 0, 15)
+	frames = append(frames, []byte("grpc-status:0\r\n")...)
 
-	headers := map[string][]string{
-		"Content-Type": {"application/javascript; charset=utf-8"},
+	cases := []struct {
+		name        string
+		contentType string
+		header      http.Header
+		body        []byte
+		fail        bool
+		messages    [][]byte
+		status      string
+	}{
+		{
+			name:        "proto",
+			contentType: "application/grpc-web+proto",
+			body:        frames,
+			messages:    [][]byte{[]byte("hello")},
+			status:      "0",
+		},
+		{
+			name:        "text",
+			contentType: "application/grpc-web-text",
+			body:        []byte(base64.StdEncoding.EncodeToString(frames)),
+			messages:    [][]byte{[]byte("hello")},
+			status:      "0",
+		},
+		{
+			name:        "trailers only",
+			contentType: "application/grpc-web",
+			header: http.Header{
+				"Grpc-Status":  {"5"},
+				"Grpc-Message": {"not found"},
+			},
+			body:     []byte{},
+			messages: [][]byte{},
+			status:   "5",
+		},
+		{
+			name:        "bad content type",
+			contentType: "application/json",
+			body:        frames,
+			fail:        true,
+		},
+		{
+			name:        "bad base64",
+			contentType: "application/grpc-web-text",
+			body:        []byte("!!!"),
+			fail:        true,
+		},
+		{
+			name:        "bad frames",
+			contentType: "application/grpc-web",
+			body:        []byte{0, 0, 0},
+			fail:        true,
+		},
 	}
 
-	body1 := `foo`
-	body2 := `foo();`
-	body3 := `foo(`
-	body4 := `foo({);`
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
 
-	for _, body := range []string{body1, body2, body3, body4} {
-		httpResp := &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     http.Header(headers),
-			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-		}
+			header := http.Header{
+				"Content-Type": {tc.contentType},
+			}
+			for k, v := range tc.header {
+				header[k] = v
+			}
 
-		resp := NewResponse(reporter, httpResp)
+			httpResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       ioutil.NopCloser(bytes.NewReader(tc.body)),
+			}
 
-		resp.JSONP("foo")
-		resp.chain.assertFailed(t)
-		resp.chain.clearFailed()
+			resp := NewResponse(reporter, httpResp)
 
-		assert.True(t, resp.JSONP("foo").Raw() == nil)
+			grpc := resp.GRPCWeb()
+
+			if tc.fail {
+				resp.chain.assertFailed(t)
+				grpc.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+				grpc.chain.assertNotFailed(t)
+
+				assert.Equal(t, tc.messages, grpc.Raw())
+				grpc.Trailer("grpc-status").Equal(tc.status)
+				grpc.chain.assertNotFailed(t)
+			}
+		})
 	}
 }
 
-func TestResponse_JSONPCharsetEmpty(t *testing.T) {
-	reporter := newMockReporter(t)
+func TestResponse_MatchOpenAPI(t *testing.T) {
+	spec, err := openapi.New([]byte(testOpenAPIYAML))
+	require.NoError(t, err)
 
-	headers := map[string][]string{
-		"Content-Type": {"application/javascript"},
+	cases := []struct {
+		name        string
+		method      string
+		url         string
+		status      int
+		contentType string
+		body        string
+		fail        bool
+	}{
+		{
+			name:        "array with refs",
+			method:      "GET",
+			url:         "http://example.com/api/v1/users",
+			status:      http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"name": "john", "age": 42}, {"name": "bob"}]`,
+		},
+		{
+			name:        "array with refs, bad element",
+			method:      "GET",
+			url:         "http://example.com/api/v1/users",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `[{"name": "john"}, {"age": 42}]`,
+			fail:        true,
+		},
+		{
+			name:        "object with ref",
+			method:      "GET",
+			url:         "http://example.com/users/123",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"name": "john"}`,
+		},
+		{
+			name:        "object with ref, bad type",
+			method:      "GET",
+			url:         "http://example.com/users/123",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"name": "john", "age": "old"}`,
+			fail:        true,
+		},
+		{
+			name:        "invalid json",
+			method:      "GET",
+			url:         "http://example.com/users/123",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{`,
+			fail:        true,
+		},
+		{
+			name:        "default response",
+			method:      "GET",
+			url:         "http://example.com/users/123",
+			status:      http.StatusNotFound,
+			contentType: "application/problem+json",
+			body:        `{"title": "not found"}`,
+		},
+		{
+			name:        "default response, bad content type",
+			method:      "GET",
+			url:         "http://example.com/users/123",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"title": "not found"}`,
+			fail:        true,
+		},
+		{
+			name:   "response without content",
+			method: "GET",
+			url:    "http://example.com/users/me",
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "undocumented status",
+			method: "GET",
+			url:    "http://example.com/users/me",
+			status: http.StatusOK,
+			fail:   true,
+		},
+		{
+			name:        "status range and wildcard content type",
+			method:      "POST",
+			url:         "http://example.com/users",
+			status:      http.StatusBadRequest,
+			contentType: "text/plain",
+			body:        "bad request",
+		},
+		{
+			name:   "status range, missing content type",
+			method: "POST",
+			url:    "http://example.com/users",
+			status: http.StatusConflict,
+			fail:   true,
+		},
+		{
+			name:   "undocumented method",
+			method: "DELETE",
+			url:    "http://example.com/users/123",
+			status: http.StatusOK,
+			fail:   true,
+		},
+		{
+			name:   "undocumented path",
+			method: "GET",
+			url:    "http://example.com/posts",
+			status: http.StatusOK,
+			fail:   true,
+		},
 	}
 
-	body := `foo({"key": "value"})`
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			httpReq, err := http.NewRequest(tc.method, tc.url, nil)
+			require.NoError(t, err)
+
+			httpResp := &http.Response{
+				StatusCode: tc.status,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(tc.body)),
+				Request:    httpReq,
+			}
+			if tc.contentType != "" {
+				httpResp.Header.Set("Content-Type", tc.contentType)
+			}
+
+			resp := NewResponse(reporter, httpResp)
+
+			resp.MatchOpenAPI(spec)
+			if tc.fail {
+				resp.chain.assertFailed(t)
+			} else {
+				resp.chain.assertNotFailed(t)
+			}
+		})
 	}
 
-	resp := NewResponse(reporter, httpResp)
+	t.Run("missing request", func(t *testing.T) {
+		reporter := newMockReporter(t)
 
-	resp.JSONP("foo")
-	resp.chain.assertNotFailed(t)
-	resp.chain.clearFailed()
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+		})
 
-	assert.Equal(t,
-		map[string]interface{}{"key": "value"}, resp.JSONP("foo").Object().Raw())
-}
+		resp.MatchOpenAPI(spec)
+		resp.chain.assertFailed(t)
+	})
 
-func TestResponse_JSONPCharsetBad(t *testing.T) {
-	reporter := newMockReporter(t)
+	t.Run("nil spec", func(t *testing.T) {
+		reporter := newMockReporter(t)
 
-	headers := map[string][]string{
-		"Content-Type": {"application/javascript; charset=bad"},
-	}
+		httpReq, _ := http.NewRequest("GET", "http://example.com/users/me", nil)
 
-	body := `foo({"key": "value"})`
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusNoContent,
+			Request:    httpReq,
+		})
 
-	httpResp := &http.Response{
-		StatusCode: http.StatusOK,
-		Header:     http.Header(headers),
-		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
-	}
+		resp.MatchOpenAPI(nil)
+		resp.chain.assertFailed(t)
+	})
 
-	resp := NewResponse(reporter, httpResp)
+	t.Run("matcher", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/users/1" {
+				_, _ = w.Write([]byte(`{"name": "john"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"age": 42}`))
+			}
+		})
 
-	resp.JSONP("foo")
-	resp.chain.assertFailed(t)
-	resp.chain.clearFailed()
+		reporter := newMockReporter(t)
 
-	assert.Nil(t, resp.JSONP("foo").Raw())
+		e := WithConfig(Config{
+			BaseURL:  "http://example.com",
+			Reporter: reporter,
+			Client: &http.Client{
+				Transport: NewBinder(handler),
+			},
+		}).Matcher(OpenAPIMatcher(spec))
+
+		e.GET("/users/{id}", 1).Expect().chain.assertNotFailed(t)
+		e.GET("/users/{id}", 2).Expect().chain.assertFailed(t)
+	})
 }
 
 func TestResponse_ContentOpts(t *testing.T) {
@@ -1334,6 +3910,31 @@ func TestResponse_UsageChecks(t *testing.T) {
 		resp.chain.assertFailed(t)
 	})
 
+	t.Run("GraphQL multiple arguments", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		headers := map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+		}
+
+		body := `{"data": null}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		ContentOpts1 := ContentOpts{
+			MediaType: "text/plain",
+		}
+		ContentOpts2 := ContentOpts{
+			MediaType: "application/json",
+		}
+		resp.GraphQL(ContentOpts1, ContentOpts2)
+		resp.chain.assertFailed(t)
+	})
+
 	t.Run("JSONP multiple arguments", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
@@ -1359,4 +3960,29 @@ func TestResponse_UsageChecks(t *testing.T) {
 		resp.JSONP("foo", ContentOpts1, ContentOpts2)
 		resp.chain.assertFailed(t)
 	})
+	t.Run("XML multiple arguments", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/xml; charset=utf-8"},
+		}
+
+		body := `<key>value</key>`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+		ContentOpts1 := ContentOpts{
+			MediaType: "text/xml",
+		}
+		ContentOpts2 := ContentOpts{
+			MediaType: "application/xml",
+		}
+		resp.XML(ContentOpts1, ContentOpts2)
+		resp.chain.assertFailed(t)
+	})
 }
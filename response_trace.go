@@ -0,0 +1,140 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// responseTrace collects timings of request phases using net/http/httptrace
+//
+// If request is retried, values of the last attempt are kept. If request is
+// redirected, values of the last hop are kept, except start time.
+type responseTrace struct {
+	mu   sync.Mutex
+	data responseTraceData
+}
+
+type responseTraceData struct {
+	start time.Time
+
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+
+	haveConn   bool
+	connReused bool
+	remoteAddr string
+}
+
+// responseConn is a snapshot of connection info collected by responseTrace
+type responseConn struct {
+	reused     bool
+	remoteAddr string
+}
+
+// responseTimings is a snapshot of timings collected by responseTrace
+type responseTimings struct {
+	dns     time.Duration
+	connect time.Duration
+	tls     time.Duration
+	ttfb    time.Duration
+}
+
+func newResponseTrace() *responseTrace {
+	return &responseTrace{}
+}
+
+// must be called right before sending request
+func (t *responseTrace) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.data = responseTraceData{
+		start: time.Now(),
+	}
+}
+
+func (t *responseTrace) clientTrace() *httptrace.ClientTrace {
+	now := func(field *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		*field = time.Now()
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			now(&t.data.dnsStart)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			now(&t.data.dnsDone)
+		},
+		ConnectStart: func(string, string) {
+			now(&t.data.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			now(&t.data.connectDone)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			t.data.haveConn = true
+			t.data.connReused = info.Reused
+			t.data.remoteAddr = ""
+			if info.Conn != nil && info.Conn.RemoteAddr() != nil {
+				t.data.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+		},
+		TLSHandshakeStart: func() {
+			now(&t.data.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			now(&t.data.tlsDone)
+		},
+		GotFirstResponseByte: func() {
+			now(&t.data.firstByte)
+		},
+	}
+}
+
+func (t *responseTrace) timings() responseTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	since := func(start, end time.Time) time.Duration {
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			return 0
+		}
+		return end.Sub(start)
+	}
+
+	return responseTimings{
+		dns:     since(t.data.dnsStart, t.data.dnsDone),
+		connect: since(t.data.connectStart, t.data.connectDone),
+		tls:     since(t.data.tlsStart, t.data.tlsDone),
+		ttfb:    since(t.data.start, t.data.firstByte),
+	}
+}
+
+// returns false if connection wasn't reported, e.g. when client
+// doesn't support httptrace
+func (t *responseTrace) conn() (responseConn, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.data.haveConn {
+		return responseConn{}, false
+	}
+
+	return responseConn{
+		reused:     t.data.connReused,
+		remoteAddr: t.data.remoteAddr,
+	}, true
+}
@@ -0,0 +1,88 @@
+package httpexpect
+
+import (
+	"errors"
+)
+
+// Scenario groups a sequence of named steps, e.g. requests of a multi-step
+// user flow like login, add to cart, and checkout.
+//
+// Every step receives an Expect instance, derived from the Expect which
+// created the scenario. Failures reported within a step include scenario
+// and step names in the assertion path, followed by the usual request
+// description, e.g.:
+//
+//	Scenario("checkout").Step("login").Request("POST", "/login").Expect()
+//
+// Steps share Expect environment, so values extracted in one step (see
+// Value.Store) may be used in the following ones.
+//
+// If a step fails, the following steps of the scenario are skipped, because
+// they usually depend on the results of the previous ones.
+type Scenario struct {
+	noCopy noCopy
+	expect *Expect
+	name   string
+	failed bool
+}
+
+// Scenario returns a new Scenario with given name.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Scenario("checkout").
+//		Step("login", func(e *httpexpect.Expect) {
+//			e.POST("/login").WithJSON(credentials).
+//				Expect().
+//				Status(http.StatusOK).JSON().Path("$.token").Store("token")
+//		}).
+//		Step("pay", func(e *httpexpect.Expect) {
+//			e.POST("/checkout").
+//				WithHeaderFromEnv("Authorization", "Bearer {token}").
+//				Expect().
+//				Status(http.StatusOK)
+//		})
+func (e *Expect) Scenario(name string) *Scenario {
+	return &Scenario{
+		expect: e,
+		name:   name,
+	}
+}
+
+// Step runs function with given step name.
+//
+// If any of the previous steps failed, function is not invoked.
+func (s *Scenario) Step(name string, fn func(e *Expect)) *Scenario {
+	stepChain := s.expect.chain.clone()
+
+	stepChain.appendPath("Scenario(%q)", s.name)
+	stepChain.appendPath("Step(%q)", name)
+
+	if stepChain.failed() || s.failed {
+		return s
+	}
+
+	if fn == nil {
+		opChain := stepChain.enter("")
+		defer opChain.leave()
+
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		s.failed = true
+		return s
+	}
+
+	fn(s.expect.withChain(stepChain))
+
+	if stepChain.treeFailed() {
+		s.failed = true
+	}
+
+	return s
+}
@@ -0,0 +1,141 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scenarioHandler struct {
+	failures []AssertionContext
+}
+
+func (h *scenarioHandler) Success(ctx *AssertionContext) {
+}
+
+func (h *scenarioHandler) Failure(
+	ctx *AssertionContext, failure *AssertionFailure,
+) {
+	h.failures = append(h.failures, *ctx)
+}
+
+func TestScenario_Steps(t *testing.T) {
+	handler := &scenarioHandler{}
+
+	client := &mockClient{
+		resp: http.Response{StatusCode: http.StatusOK},
+	}
+
+	e := WithConfig(Config{
+		Client:           client,
+		AssertionHandler: handler,
+	})
+
+	var invoked []string
+
+	e.Scenario("checkout").
+		Step("login", func(e *Expect) {
+			invoked = append(invoked, "login")
+			e.GET("/login").Expect().Status(http.StatusOK)
+		}).
+		Step("pay", func(e *Expect) {
+			invoked = append(invoked, "pay")
+			e.POST("/pay").Expect().Status(http.StatusCreated)
+		}).
+		Step("logout", func(e *Expect) {
+			invoked = append(invoked, "logout")
+		})
+
+	assert.Equal(t, []string{"login", "pay"}, invoked)
+
+	if assert.Equal(t, 1, len(handler.failures)) {
+		assert.Equal(t, []string{
+			`Scenario("checkout")`,
+			`Step("pay")`,
+			`Request("POST", "/pay")`,
+			`Expect()`,
+			`Status()`,
+		}, handler.failures[0].Path)
+	}
+
+	// other scenarios are not affected
+	invoked = nil
+
+	e.Scenario("browse").
+		Step("list", func(e *Expect) {
+			invoked = append(invoked, "list")
+			e.GET("/items").Expect().Status(http.StatusOK)
+		})
+
+	assert.Equal(t, []string{"list"}, invoked)
+	assert.Equal(t, 1, len(handler.failures))
+}
+
+func TestScenario_Env(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	e := WithConfig(Config{
+		Client:   &mockClient{},
+		Reporter: reporter,
+	})
+
+	e.Scenario("env").
+		Step("put", func(e *Expect) {
+			e.Env().Put("key", "value")
+		}).
+		Step("get", func(e *Expect) {
+			assert.Equal(t, "value", e.Env().GetString("key"))
+		})
+
+	assert.Equal(t, "value", e.Env().GetString("key"))
+}
+
+func TestScenario_BuildersAndMatchers(t *testing.T) {
+	client := &mockClient{
+		resp: http.Response{StatusCode: http.StatusOK},
+	}
+
+	e := WithConfig(Config{
+		Client:   client,
+		Reporter: newMockReporter(t),
+	})
+
+	var matched []int
+
+	e = e.
+		Builder(func(req *Request) {
+			req.WithHeader("X-Scenario", "checkout")
+		}).
+		Matcher(func(resp *Response) {
+			matched = append(matched, resp.Raw().StatusCode)
+		})
+
+	e.Scenario("checkout").
+		Step("login", func(e *Expect) {
+			e.GET("/login").Expect()
+		})
+
+	assert.Equal(t, "checkout", client.req.Header.Get("X-Scenario"))
+	assert.Equal(t, []int{http.StatusOK}, matched)
+}
+
+func TestScenario_NilStep(t *testing.T) {
+	handler := &scenarioHandler{}
+
+	e := WithConfig(Config{
+		Client:           &mockClient{},
+		AssertionHandler: handler,
+	})
+
+	invoked := false
+
+	e.Scenario("nil").
+		Step("nil", nil).
+		Step("next", func(e *Expect) {
+			invoked = true
+		})
+
+	assert.False(t, invoked)
+	assert.Equal(t, 1, len(handler.failures))
+}
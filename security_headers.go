@@ -0,0 +1,318 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SecurityHeaders provides methods to audit security-related response
+// headers.
+type SecurityHeaders struct {
+	noCopy noCopy
+	chain  *chain
+	header http.Header
+}
+
+// NewSecurityHeaders returns a new SecurityHeaders instance.
+//
+// If reporter is nil, the function panics.
+// If header is nil, failure is reported.
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, resp.Header)
+//	sh.Audit()
+func NewSecurityHeaders(reporter Reporter, header http.Header) *SecurityHeaders {
+	return newSecurityHeaders(
+		newChainWithDefaults("SecurityHeaders()", reporter), header)
+}
+
+// NewSecurityHeadersC returns a new SecurityHeaders instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If header is nil, failure is reported.
+//
+// See NewSecurityHeaders for usage example.
+func NewSecurityHeadersC(config Config, header http.Header) *SecurityHeaders {
+	return newSecurityHeaders(
+		newChainWithConfig("SecurityHeaders()", config.withDefaults()), header)
+}
+
+func newSecurityHeaders(parent *chain, val http.Header) *SecurityHeaders {
+	s := &SecurityHeaders{chain: parent.clone(), header: nil}
+
+	opChain := s.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil header"),
+			},
+		})
+	} else {
+		s.header = val
+	}
+
+	return s
+}
+
+// Raw returns underlying http.Header value attached to SecurityHeaders.
+// This is the value originally passed to NewSecurityHeaders.
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, header)
+//	assert.Equal(t, header, sh.Raw())
+func (s *SecurityHeaders) Raw() http.Header {
+	return s.header
+}
+
+// Audit succeeds if response passes all checks of the security baseline:
+// HaveHSTS, HaveNoSniff, HaveFrameOptions, HaveCSP, and HaveReferrerPolicy.
+//
+// All violations are reported in a single failure.
+//
+// Example:
+//
+//	resp := e.GET("/").Expect()
+//	resp.SecurityHeaders().Audit()
+func (s *SecurityHeaders) Audit() *SecurityHeaders {
+	opChain := s.chain.enter("Audit()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	var errs []error
+
+	for _, check := range []func(http.Header) error{
+		checkHSTS,
+		checkNoSniff,
+		checkFrameOptions,
+		checkCSP,
+		checkReferrerPolicy,
+	} {
+		if err := check(s.header); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.auditedHeaders()},
+			Errors: append([]error{
+				errors.New("expected: response passes security headers audit"),
+			}, errs...),
+		})
+	}
+
+	return s
+}
+
+// HaveHSTS succeeds if response has "Strict-Transport-Security" header
+// with positive "max-age" directive.
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, header)
+//	sh.HaveHSTS()
+func (s *SecurityHeaders) HaveHSTS() *SecurityHeaders {
+	return s.check("HaveHSTS()", "Strict-Transport-Security", checkHSTS)
+}
+
+// HaveNoSniff succeeds if response has "X-Content-Type-Options" header
+// set to "nosniff".
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, header)
+//	sh.HaveNoSniff()
+func (s *SecurityHeaders) HaveNoSniff() *SecurityHeaders {
+	return s.check("HaveNoSniff()", "X-Content-Type-Options", checkNoSniff)
+}
+
+// HaveFrameOptions succeeds if response forbids framing by other origins,
+// either with "X-Frame-Options" header set to "DENY" or "SAMEORIGIN", or
+// with "frame-ancestors" directive in "Content-Security-Policy" header.
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, header)
+//	sh.HaveFrameOptions()
+func (s *SecurityHeaders) HaveFrameOptions() *SecurityHeaders {
+	return s.check("HaveFrameOptions()", "X-Frame-Options", checkFrameOptions)
+}
+
+// HaveCSP succeeds if response has non-empty "Content-Security-Policy"
+// header.
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, header)
+//	sh.HaveCSP()
+func (s *SecurityHeaders) HaveCSP() *SecurityHeaders {
+	return s.check("HaveCSP()", "Content-Security-Policy", checkCSP)
+}
+
+// HaveReferrerPolicy succeeds if response has "Referrer-Policy" header
+// with known policy other than "unsafe-url".
+//
+// Example:
+//
+//	sh := NewSecurityHeaders(t, header)
+//	sh.HaveReferrerPolicy()
+func (s *SecurityHeaders) HaveReferrerPolicy() *SecurityHeaders {
+	return s.check("HaveReferrerPolicy()", "Referrer-Policy", checkReferrerPolicy)
+}
+
+func (s *SecurityHeaders) check(
+	method, key string, check func(http.Header) error,
+) *SecurityHeaders {
+	opChain := s.chain.enter(method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if err := check(s.header); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.header.Get(key)},
+			Errors: []error{err},
+		})
+	}
+
+	return s
+}
+
+func (s *SecurityHeaders) auditedHeaders() map[string]string {
+	values := map[string]string{}
+
+	for _, key := range []string{
+		"Strict-Transport-Security",
+		"X-Content-Type-Options",
+		"X-Frame-Options",
+		"Content-Security-Policy",
+		"Referrer-Policy",
+	} {
+		values[key] = s.header.Get(key)
+	}
+
+	return values
+}
+
+func checkHSTS(header http.Header) error {
+	value := header.Get("Strict-Transport-Security")
+	if value == "" {
+		return errors.New(`missing "Strict-Transport-Security" header`)
+	}
+
+	// same grammar as Cache-Control, but directives are separated by ";"
+	directives, err := parseCacheControl(strings.Replace(value, ";", ",", -1))
+	if err != nil {
+		return fmt.Errorf(`invalid "Strict-Transport-Security" header: %s`, err)
+	}
+
+	maxAge, ok := directives["max-age"]
+	if !ok {
+		return errors.New(
+			`missing "max-age" directive in "Strict-Transport-Security" header`)
+	}
+
+	if n, err := strconv.ParseUint(maxAge, 10, 64); err != nil || n == 0 {
+		return fmt.Errorf(
+			`expected positive "max-age" in "Strict-Transport-Security", got %q`,
+			maxAge)
+	}
+
+	return nil
+}
+
+func checkNoSniff(header http.Header) error {
+	value := strings.TrimSpace(header.Get("X-Content-Type-Options"))
+
+	if !strings.EqualFold(value, "nosniff") {
+		return fmt.Errorf(
+			`expected "X-Content-Type-Options" header "nosniff", got %q`, value)
+	}
+
+	return nil
+}
+
+func checkFrameOptions(header http.Header) error {
+	value := strings.TrimSpace(header.Get("X-Frame-Options"))
+
+	if strings.EqualFold(value, "DENY") || strings.EqualFold(value, "SAMEORIGIN") {
+		return nil
+	}
+
+	for _, csp := range header.Values("Content-Security-Policy") {
+		for _, directive := range strings.Split(csp, ";") {
+			fields := strings.Fields(directive)
+			if len(fields) != 0 && strings.EqualFold(fields[0], "frame-ancestors") {
+				return nil
+			}
+		}
+	}
+
+	if value != "" {
+		return fmt.Errorf(
+			`expected "X-Frame-Options" header "DENY" or "SAMEORIGIN", got %q`, value)
+	}
+
+	return errors.New(
+		`missing "X-Frame-Options" header or "frame-ancestors" CSP directive`)
+}
+
+func checkCSP(header http.Header) error {
+	if strings.TrimSpace(header.Get("Content-Security-Policy")) == "" {
+		return errors.New(`missing "Content-Security-Policy" header`)
+	}
+
+	return nil
+}
+
+func checkReferrerPolicy(header http.Header) error {
+	value := header.Get("Referrer-Policy")
+	if value == "" {
+		return errors.New(`missing "Referrer-Policy" header`)
+	}
+
+	// if multiple policies are listed, the last known one is used
+	policy := ""
+
+	for _, p := range strings.Split(value, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+
+		switch p {
+		case "no-referrer",
+			"no-referrer-when-downgrade",
+			"origin",
+			"origin-when-cross-origin",
+			"same-origin",
+			"strict-origin",
+			"strict-origin-when-cross-origin",
+			"unsafe-url":
+			policy = p
+		}
+	}
+
+	switch policy {
+	case "":
+		return fmt.Errorf(`unknown "Referrer-Policy" header value %q`, value)
+
+	case "unsafe-url":
+		return errors.New(`unexpected "Referrer-Policy" header value "unsafe-url"`)
+	}
+
+	return nil
+}
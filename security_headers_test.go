@@ -0,0 +1,242 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeaders_Failed(t *testing.T) {
+	check := func(value *SecurityHeaders, isNil bool) {
+		value.chain.assertFailed(t)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+
+		value.Audit()
+		value.HaveHSTS()
+		value.HaveNoSniff()
+		value.HaveFrameOptions()
+		value.HaveCSP()
+		value.HaveReferrerPolicy()
+	}
+
+	t.Run("failed_chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newSecurityHeaders(chain, http.Header{})
+
+		check(value, false)
+	})
+
+	t.Run("nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newSecurityHeaders(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed_chain_nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newSecurityHeaders(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func secureHeaders() http.Header {
+	return http.Header{
+		"Strict-Transport-Security": {"max-age=31536000; includeSubDomains"},
+		"X-Content-Type-Options":    {"nosniff"},
+		"X-Frame-Options":           {"DENY"},
+		"Content-Security-Policy":   {"default-src 'self'"},
+		"Referrer-Policy":           {"strict-origin-when-cross-origin"},
+	}
+}
+
+func TestSecurityHeaders_Constructors(t *testing.T) {
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewSecurityHeaders(reporter, secureHeaders())
+		value.Audit()
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewSecurityHeadersC(Config{
+			Reporter: reporter,
+		}, secureHeaders())
+		value.Audit()
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newSecurityHeaders(chain, secureHeaders())
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestSecurityHeaders_Audit(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	t.Run("secure", func(t *testing.T) {
+		value := NewSecurityHeaders(reporter, secureHeaders())
+		value.Audit()
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		value := NewSecurityHeaders(reporter, http.Header{})
+		value.Audit()
+		value.chain.assertFailed(t)
+	})
+
+	for _, key := range []string{
+		"Strict-Transport-Security",
+		"X-Content-Type-Options",
+		"X-Frame-Options",
+		"Content-Security-Policy",
+		"Referrer-Policy",
+	} {
+		t.Run("without "+key, func(t *testing.T) {
+			header := secureHeaders()
+			header.Del(key)
+
+			value := NewSecurityHeaders(reporter, header)
+			value.Audit()
+			value.chain.assertFailed(t)
+		})
+	}
+}
+
+func TestSecurityHeaders_Checks(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	cases := []struct {
+		name    string
+		header  http.Header
+		check   func(*SecurityHeaders)
+		isValid bool
+	}{
+		{
+			name: "hsts",
+			header: http.Header{
+				"Strict-Transport-Security": {"max-age=63072000"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveHSTS() },
+			isValid: true,
+		},
+		{
+			name: "hsts zero max-age",
+			header: http.Header{
+				"Strict-Transport-Security": {"max-age=0; includeSubDomains"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveHSTS() },
+			isValid: false,
+		},
+		{
+			name: "hsts without max-age",
+			header: http.Header{
+				"Strict-Transport-Security": {"includeSubDomains; preload"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveHSTS() },
+			isValid: false,
+		},
+		{
+			name: "nosniff case",
+			header: http.Header{
+				"X-Content-Type-Options": {"NoSniff"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveNoSniff() },
+			isValid: true,
+		},
+		{
+			name: "nosniff invalid",
+			header: http.Header{
+				"X-Content-Type-Options": {"sniff"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveNoSniff() },
+			isValid: false,
+		},
+		{
+			name: "frame options sameorigin",
+			header: http.Header{
+				"X-Frame-Options": {"SAMEORIGIN"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveFrameOptions() },
+			isValid: true,
+		},
+		{
+			name: "frame options allow-from",
+			header: http.Header{
+				"X-Frame-Options": {"ALLOW-FROM https://example.com"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveFrameOptions() },
+			isValid: false,
+		},
+		{
+			name: "frame ancestors",
+			header: http.Header{
+				"Content-Security-Policy": {"default-src 'self'; frame-ancestors 'none'"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveFrameOptions() },
+			isValid: true,
+		},
+		{
+			name: "csp empty",
+			header: http.Header{
+				"Content-Security-Policy": {" "},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveCSP() },
+			isValid: false,
+		},
+		{
+			name: "referrer policy fallback",
+			header: http.Header{
+				"Referrer-Policy": {"no-referrer, strict-origin-when-cross-origin"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveReferrerPolicy() },
+			isValid: true,
+		},
+		{
+			name: "referrer policy unsafe",
+			header: http.Header{
+				"Referrer-Policy": {"unsafe-url"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveReferrerPolicy() },
+			isValid: false,
+		},
+		{
+			name: "referrer policy unknown",
+			header: http.Header{
+				"Referrer-Policy": {"whatever"},
+			},
+			check:   func(s *SecurityHeaders) { s.HaveReferrerPolicy() },
+			isValid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := NewSecurityHeaders(reporter, tc.header)
+			tc.check(value)
+
+			if tc.isValid {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
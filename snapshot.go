@@ -0,0 +1,229 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Snapshots are golden files with expected response bodies, used by
+// Response.MatchSnapshot.
+//
+// Snapshots are stored in Config.SnapshotDir, one file per snapshot name,
+// with ".golden" extension. When Config.UpdateSnapshots is true or
+// HTTPEXPECT_UPDATE_SNAPSHOTS environment variable is non-empty, snapshots
+// are (re)written from actual responses instead of being compared.
+
+const (
+	defaultSnapshotDir = "testdata/snapshots"
+	snapshotExt        = ".golden"
+	snapshotUpdateEnv  = "HTTPEXPECT_UPDATE_SNAPSHOTS"
+)
+
+func snapshotPath(config Config, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("empty snapshot name")
+	}
+
+	clean := filepath.Clean(filepath.FromSlash(name))
+
+	if filepath.IsAbs(clean) || clean == "." || clean == ".." ||
+		strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf(
+			"snapshot name %q should be a relative path inside snapshot directory",
+			name)
+	}
+
+	dir := config.SnapshotDir
+	if dir == "" {
+		dir = defaultSnapshotDir
+	}
+
+	return filepath.Join(dir, clean+snapshotExt), nil
+}
+
+func snapshotUpdate(config Config) bool {
+	return config.UpdateSnapshots || os.Getenv(snapshotUpdateEnv) != ""
+}
+
+// snapshot contents for JSON bodies are indented canonical JSON, so that
+// golden files are readable and produce small diffs on review
+func formatSnapshot(value interface{}) ([]byte, error) {
+	b, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func matchSnapshot(
+	opChain *chain, config Config, name string,
+	content []byte, isJSON bool, paths []string,
+) {
+	path, err := snapshotPath(config, name)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid snapshot name"),
+				err,
+			},
+		})
+		return
+	}
+
+	if len(paths) != 0 && !isJSON {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("ignored paths are supported only for JSON bodies"),
+			},
+		})
+		return
+	}
+
+	ignored, ok := parseIgnorePaths(opChain, paths)
+	if !ok {
+		return
+	}
+
+	var actualValue interface{}
+
+	actual := content
+	if isJSON {
+		if err := json.Unmarshal(content, &actualValue); err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(content)},
+				Errors: []error{
+					errors.New("expected: response body is valid json"),
+					err,
+				},
+			})
+			return
+		}
+
+		if actual, err = formatSnapshot(actualValue); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to format response body"),
+					err,
+				},
+			})
+			return
+		}
+	}
+
+	if snapshotUpdate(config) {
+		writeSnapshot(opChain, path, actual)
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("snapshot file %q does not exist", path),
+					fmt.Errorf("set %s=1 or Config.UpdateSnapshots to create it",
+						snapshotUpdateEnv),
+				},
+			})
+		} else {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("failed to read snapshot file %q", path),
+					err,
+				},
+			})
+		}
+		return
+	}
+
+	if !isJSON {
+		if !bytes.Equal(expected, actual) {
+			opChain.fail(AssertionFailure{
+				Type:     AssertEqual,
+				Actual:   &AssertionValue{string(actual)},
+				Expected: &AssertionValue{string(expected)},
+				Errors: []error{
+					fmt.Errorf("expected: response body matches snapshot %q", name),
+				},
+			})
+		}
+		return
+	}
+
+	var expectedValue interface{}
+
+	if err := json.Unmarshal(expected, &expectedValue); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to decode snapshot file %q", path),
+				err,
+			},
+		})
+		return
+	}
+
+	if !checkPlaceholders(opChain, expectedValue) {
+		return
+	}
+
+	expectedValue = removeIgnoredPaths(expectedValue, ignored)
+	actualValue = removeIgnoredPaths(actualValue, ignored)
+
+	if !equalValues(opChain, expectedValue, actualValue) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actualValue},
+			Expected: &AssertionValue{expectedValue},
+			Errors: []error{
+				fmt.Errorf("expected: response body matches snapshot %q", name),
+			},
+		})
+	}
+}
+
+func writeSnapshot(opChain *chain, path string, data []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to create snapshot directory for %q", path),
+				err,
+			},
+		})
+		return
+	}
+
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				fmt.Errorf("failed to write snapshot file %q", path),
+				err,
+			},
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package httpexpect
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot_Path(t *testing.T) {
+	cases := []struct {
+		dir      string
+		name     string
+		expected string
+	}{
+		{"", "foo", filepath.Join("testdata", "snapshots", "foo.golden")},
+		{"dir", "foo", filepath.Join("dir", "foo.golden")},
+		{"dir", "foo/bar", filepath.Join("dir", "foo", "bar.golden")},
+		{"dir", "foo/../bar", filepath.Join("dir", "bar.golden")},
+		{"dir", "foo.json", filepath.Join("dir", "foo.json.golden")},
+	}
+
+	for _, tc := range cases {
+		path, err := snapshotPath(Config{SnapshotDir: tc.dir}, tc.name)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, path)
+	}
+
+	for _, name := range []string{"", ".", "..", "../foo", "/foo"} {
+		_, err := snapshotPath(Config{}, name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestSnapshot_IsJSON(t *testing.T) {
+	assert.True(t, isJSONContentType("application/json"))
+	assert.True(t, isJSONContentType("application/json; charset=utf-8"))
+	assert.True(t, isJSONContentType("application/problem+json"))
+	assert.False(t, isJSONContentType("text/plain"))
+	assert.False(t, isJSONContentType(""))
+}
@@ -1,9 +1,13 @@
 package httpexpect
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/mail"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -56,6 +60,20 @@ func (s *String) Raw() string {
 	return s.value
 }
 
+// Alias is similar to Value.Alias.
+//
+// Example:
+//
+//	str := NewString(t, "Hello")
+//	str.Alias("greeting").IsASCII()
+func (s *String) Alias(name string) *String {
+	opChain := s.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	s.chain.setAlias(name)
+	return s
+}
+
 // Decode unmarshals the underlying value attached to the String to a target variable.
 // target should be one of these:
 //
@@ -99,6 +117,29 @@ func (s *String) Schema(schema interface{}) *String {
 	return s
 }
 
+// Store saves string in environment with given key, so that it may be used
+// by subsequent requests, e.g. via Request.WithHeaderFromEnv or Expect.Env.
+//
+// If string chain is already failed, nothing is stored.
+//
+// Example:
+//
+//	e.POST("/users").WithJSON(user).
+//		Expect().
+//		Header("Location").Store("user.location")
+func (s *String) Store(key string) *String {
+	opChain := s.chain.enter("Store(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	opChain.env().Put(key, s.value)
+
+	return s
+}
+
 // Length returns a new Number instance with string length.
 //
 // Example:
@@ -284,6 +325,66 @@ func (s *String) NotEqualFold(value string) *String {
 	return s
 }
 
+// EqualNormalized succeeds if string is equal to given Go string after
+// normalizing whitespace in both of them: leading and trailing whitespace
+// is removed, and every sequence of Unicode whitespace characters is
+// replaced with a single space.
+//
+// Example:
+//
+//	str := NewString(t, "  Hello,\n\tworld ")
+//	str.EqualNormalized("Hello, world")
+func (s *String) EqualNormalized(value string) *String {
+	opChain := s.chain.enter("EqualNormalized()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if normalizeSpace(s.value) != normalizeSpace(value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{s.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: strings are equal (if whitespace normalized)"),
+			},
+		})
+	}
+
+	return s
+}
+
+// NotEqualNormalized succeeds if string is not equal to given Go string
+// after normalizing whitespace in both of them, as in EqualNormalized.
+//
+// Example:
+//
+//	str := NewString(t, "Hello, world")
+//	str.NotEqualNormalized("Hello,world")
+func (s *String) NotEqualNormalized(value string) *String {
+	opChain := s.chain.enter("NotEqualNormalized()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if normalizeSpace(s.value) == normalizeSpace(value) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{s.value},
+			Expected: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: strings are non-equal (if whitespace normalized)"),
+			},
+		})
+	}
+
+	return s
+}
+
 // Contains succeeds if string contains given Go string as a substring.
 //
 // Example:
@@ -861,6 +962,193 @@ func (s *String) NotIsASCII() *String {
 	return s.NotASCII()
 }
 
+// IsUUID succeeds if string is a UUID in canonical textual form, e.g.
+// "123e4567-e89b-12d3-a456-426614174000". Both lower and upper case
+// hex digits are accepted.
+//
+// Example:
+//
+//	str := NewString(t, "123e4567-e89b-12d3-a456-426614174000")
+//	str.IsUUID()
+func (s *String) IsUUID() *String {
+	opChain := s.chain.enter("IsUUID()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if !placeholderUUIDRegexp.MatchString(s.value) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is a uuid"),
+			},
+		})
+	}
+
+	return s
+}
+
+// IsEmail succeeds if string is a valid email address, as defined
+// by RFC 5322, without display name and angle brackets.
+//
+// Example:
+//
+//	str := NewString(t, "john@example.com")
+//	str.IsEmail()
+func (s *String) IsEmail() *String {
+	opChain := s.chain.enter("IsEmail()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	addr, err := mail.ParseAddress(s.value)
+	if err == nil && (addr.Name != "" || addr.Address != s.value) {
+		err = errors.New("unexpected display name or angle brackets")
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is an email address"),
+				err,
+			},
+		})
+	}
+
+	return s
+}
+
+// IsURL succeeds if string is an absolute URL, i.e. it has non-empty
+// scheme and host.
+//
+// If schemes are given, URL scheme should be one of them (case-insensitive).
+//
+// Example:
+//
+//	str := NewString(t, "https://example.com/path")
+//	str.IsURL()
+//	str.IsURL("http", "https")
+func (s *String) IsURL(schemes ...string) *String {
+	opChain := s.chain.enter("IsURL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	u, err := url.Parse(s.value)
+	if err == nil && (u.Scheme == "" || u.Host == "") {
+		err = errors.New("missing scheme or host")
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is an absolute url"),
+				err,
+			},
+		})
+		return s
+	}
+
+	if len(schemes) == 0 {
+		return s
+	}
+
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return s
+		}
+	}
+
+	expected := AssertionList{}
+	for _, scheme := range schemes {
+		expected = append(expected, scheme)
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:     AssertBelongs,
+		Actual:   &AssertionValue{u.Scheme},
+		Expected: &AssertionValue{expected},
+		Errors: []error{
+			errors.New("expected: url scheme belongs to given list"),
+		},
+	})
+
+	return s
+}
+
+// IsIP succeeds if string is an IPv4 address in dotted decimal form
+// ("192.0.2.1") or IPv6 address ("2001:db8::68").
+//
+// Example:
+//
+//	str := NewString(t, "192.0.2.1")
+//	str.IsIP()
+func (s *String) IsIP() *String {
+	opChain := s.chain.enter("IsIP()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	if net.ParseIP(s.value) == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is an ip address"),
+			},
+		})
+	}
+
+	return s
+}
+
+// IsBase64 succeeds if string is a valid padded base64 encoding,
+// as defined in RFC 4648, with either standard or URL-safe alphabet.
+//
+// Example:
+//
+//	str := NewString(t, "aGVsbG8=")
+//	str.IsBase64()
+func (s *String) IsBase64() *String {
+	opChain := s.chain.enter("IsBase64()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	_, err := base64.StdEncoding.DecodeString(s.value)
+	if err != nil {
+		_, err = base64.URLEncoding.DecodeString(s.value)
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value},
+			Errors: []error{
+				errors.New("expected: string is base64-encoded"),
+				err,
+			},
+		})
+	}
+
+	return s
+}
+
 // AsNumber parses float from string and returns a new Number instance
 // with result.
 //
@@ -1118,3 +1406,7 @@ func (s *String) Number() *Number {
 func (s *String) DateTime(layout ...string) *DateTime {
 	return s.AsDateTime(layout...)
 }
+
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
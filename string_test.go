@@ -14,6 +14,7 @@ func TestString_Failed(t *testing.T) {
 
 	value := newString(chain, "")
 
+	value.Alias("foo")
 	value.Path("$")
 	value.Schema("")
 
@@ -30,6 +31,9 @@ func TestString_Failed(t *testing.T) {
 	value.NotEqual("")
 	value.EqualFold("")
 	value.NotEqualFold("")
+	value.Store("foo")
+	value.EqualNormalized("")
+	value.NotEqualNormalized("")
 	value.Contains("")
 	value.NotContains("")
 	value.ContainsFold("")
@@ -47,6 +51,11 @@ func TestString_Failed(t *testing.T) {
 	value.MatchAll("")
 	value.IsASCII()
 	value.NotASCII()
+	value.IsUUID()
+	value.IsEmail()
+	value.IsURL()
+	value.IsIP()
+	value.IsBase64()
 }
 
 func TestString_Constructors(t *testing.T) {
@@ -142,6 +151,22 @@ func TestString_Getters(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestString_Store(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	env := NewEnvironment(reporter)
+
+	value := NewStringC(Config{
+		Reporter:    reporter,
+		Environment: env,
+	}, "foo")
+
+	value.Store("key")
+	value.chain.assertNotFailed(t)
+
+	assert.Equal(t, "foo", env.GetString("key"))
+}
+
 func TestString_Length(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -231,6 +256,42 @@ func TestString_EqualFold(t *testing.T) {
 	value.chain.clearFailed()
 }
 
+func TestString_EqualNormalized(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewString(reporter, "  Hello,\n\tworld ")
+
+	value.EqualNormalized("Hello, world")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualNormalized(" Hello,   world\n")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualNormalized("Hello,world")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualNormalized("hello, world")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualNormalized("Hello, world")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualNormalized("Hello,world")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	empty := NewString(reporter, " \t\n")
+
+	empty.EqualNormalized("")
+	empty.chain.assertNotFailed(t)
+	empty.chain.clearFailed()
+}
+
 func TestString_Contains(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -434,6 +495,74 @@ func TestString_IsNotAscii(t *testing.T) {
 	value5.chain.clearFailed()
 }
 
+func TestString_Formats(t *testing.T) {
+	isURL := func(s *String) *String {
+		return s.IsURL()
+	}
+	isHTTP := func(s *String) *String {
+		return s.IsURL("http", "https")
+	}
+
+	cases := []struct {
+		name   string
+		check  func(s *String) *String
+		value  string
+		result bool
+	}{
+		{"uuid", (*String).IsUUID, "123e4567-e89b-12d3-a456-426614174000", true},
+		{"uuid", (*String).IsUUID, "123E4567-E89B-12D3-A456-426614174000", true},
+		{"uuid", (*String).IsUUID, "123e4567e89b12d3a456426614174000", false},
+		{"uuid", (*String).IsUUID, "123e4567-e89b-12d3-a456-42661417400g", false},
+		{"uuid", (*String).IsUUID, "", false},
+
+		{"email", (*String).IsEmail, "john@example.com", true},
+		{"email", (*String).IsEmail, "john.doe+tag@sub.example.com", true},
+		{"email", (*String).IsEmail, "john", false},
+		{"email", (*String).IsEmail, "john@", false},
+		{"email", (*String).IsEmail, "John <john@example.com>", false},
+		{"email", (*String).IsEmail, "<john@example.com>", false},
+		{"email", (*String).IsEmail, "", false},
+
+		{"url", isURL, "https://example.com/path?q=1", true},
+		{"url", isURL, "ftp://example.com", true},
+		{"url", isURL, "/path", false},
+		{"url", isURL, "example.com", false},
+		{"url", isURL, "http://", false},
+		{"url", isURL, "http://a b.com/%zz", false},
+		{"url scheme", isHTTP, "HTTPS://example.com", true},
+		{"url scheme", isHTTP, "ftp://example.com", false},
+
+		{"ip", (*String).IsIP, "192.0.2.1", true},
+		{"ip", (*String).IsIP, "2001:db8::68", true},
+		{"ip", (*String).IsIP, "::ffff:192.0.2.1", true},
+		{"ip", (*String).IsIP, "192.0.2.256", false},
+		{"ip", (*String).IsIP, "192.0.2", false},
+		{"ip", (*String).IsIP, "example.com", false},
+
+		{"base64", (*String).IsBase64, "aGVsbG8=", true},
+		{"base64", (*String).IsBase64, "", true},
+		{"base64", (*String).IsBase64, "-_-_", true},
+		{"base64", (*String).IsBase64, "+/+/", true},
+		{"base64", (*String).IsBase64, "aGVsbG8", false},
+		{"base64", (*String).IsBase64, "hello!", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name+" "+tc.value, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewString(reporter, tc.value)
+			tc.check(value)
+
+			if tc.result {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
 func TestString_AsNumber(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -0,0 +1,292 @@
+package httpexpect
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// NewCertPool returns a new x509.CertPool with given PEM-encoded
+// certificates.
+//
+// Returned pool may be used as RootCAs (to trust custom server CA)
+// or ClientCAs (to verify client certificates) in tls.Config.
+//
+// Example:
+//
+//	pool, err := NewCertPool(caPEM)
+//
+//	e := WithConfig(Config{
+//		BaseURL:  "https://example.com",
+//		Reporter: NewAssertReporter(t),
+//		TLSClientConfig: &tls.Config{
+//			RootCAs: pool,
+//		},
+//	})
+func NewCertPool(pemCerts ...[]byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	for n, pem := range pemCerts {
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates in PEM block %d", n)
+		}
+	}
+
+	return pool, nil
+}
+
+// LoadCertPool returns a new x509.CertPool with certificates loaded
+// from given PEM files.
+//
+// Example:
+//
+//	pool, err := LoadCertPool("testdata/ca.pem")
+func LoadCertPool(paths ...string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	for _, path := range paths {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates in %q", path)
+		}
+	}
+
+	return pool, nil
+}
+
+// NewTLSTransport returns a new http.Transport with given TLS config.
+//
+// Other transport settings are copied from http.DefaultTransport.
+//
+// Example:
+//
+//	cert, err := tls.LoadX509KeyPair("client.pem", "client-key.pem")
+//
+//	client := &http.Client{
+//		Transport: NewTLSTransport(&tls.Config{
+//			Certificates: []tls.Certificate{cert},
+//		}),
+//	}
+func NewTLSTransport(config *tls.Config) *http.Transport {
+	var transport *http.Transport
+
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	} else {
+		transport = &http.Transport{}
+	}
+
+	transport.TLSClientConfig = config
+
+	return transport
+}
+
+// tlsTransport returns a copy of client's http.Transport, which TLS config
+// may be then changed without affecting the original client
+func tlsTransport(client *http.Client) (*http.Transport, error) {
+	switch transport := client.Transport.(type) {
+	case nil:
+		return NewTLSTransport(nil), nil
+
+	case *http.Transport:
+		return transport.Clone(), nil
+
+	default:
+		return nil, errors.New(
+			"client transport should be http.Transport to configure TLS")
+	}
+}
+
+// TLS provides methods to inspect TLS connection state of response.
+type TLS struct {
+	noCopy noCopy
+	chain  *chain
+	value  *tls.ConnectionState
+}
+
+// NewTLS returns a new TLS instance.
+//
+// If reporter is nil, the function panics.
+// If value is nil, failure is reported.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//
+//	state.Version().Equal("TLS 1.3")
+//	state.NegotiatedProtocol().Equal("h2")
+//	state.Certificate().CommonName().Equal("example.com")
+func NewTLS(reporter Reporter, value *tls.ConnectionState) *TLS {
+	return newTLS(newChainWithDefaults("TLS()", reporter), value)
+}
+
+// NewTLSC returns a new TLS instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is nil, failure is reported.
+//
+// See NewTLS for usage example.
+func NewTLSC(config Config, value *tls.ConnectionState) *TLS {
+	return newTLS(newChainWithConfig("TLS()", config.withDefaults()), value)
+}
+
+func newTLS(parent *chain, val *tls.ConnectionState) *TLS {
+	t := &TLS{chain: parent.clone(), value: nil}
+
+	opChain := t.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil TLS connection state"),
+			},
+		})
+	} else {
+		t.value = val
+	}
+
+	return t
+}
+
+// Raw returns underlying tls.ConnectionState value attached to TLS.
+// This is the value originally passed to NewTLS.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	assert.Equal(t, resp.TLS, state.Raw())
+func (t *TLS) Raw() *tls.ConnectionState {
+	return t.value
+}
+
+// Version returns a new String instance with name of negotiated
+// TLS version, e.g. "TLS 1.2" or "TLS 1.3".
+//
+// Unknown versions are formatted as hex number, e.g. "0x0305".
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.Version().Equal("TLS 1.3")
+func (t *TLS) Version() *String {
+	opChain := t.chain.enter("Version()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, tlsVersionName(t.value.Version))
+}
+
+// CipherSuite returns a new String instance with name of negotiated
+// cipher suite, e.g. "TLS_AES_128_GCM_SHA256".
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.CipherSuite().NotContains("CBC")
+func (t *TLS) CipherSuite() *String {
+	opChain := t.chain.enter("CipherSuite()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, tls.CipherSuiteName(t.value.CipherSuite))
+}
+
+// NegotiatedProtocol returns a new String instance with application
+// protocol negotiated via ALPN, e.g. "h2".
+//
+// If no protocol was negotiated, the string is empty.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.NegotiatedProtocol().Equal("h2")
+func (t *TLS) NegotiatedProtocol() *String {
+	opChain := t.chain.enter("NegotiatedProtocol()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, t.value.NegotiatedProtocol)
+}
+
+// ServerName returns a new String instance with server name requested
+// by client via SNI.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.ServerName().Equal("example.com")
+func (t *TLS) ServerName() *String {
+	opChain := t.chain.enter("ServerName()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, t.value.ServerName)
+}
+
+// Certificate returns a new Certificate instance with leaf certificate
+// presented by peer.
+//
+// If peer didn't present any certificates, failure is reported.
+//
+// Example:
+//
+//	state := NewTLS(t, resp.TLS)
+//	state.Certificate().DNSNames().Contains("example.com")
+func (t *TLS) Certificate() *Certificate {
+	opChain := t.chain.enter("Certificate()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCertificate(opChain, nil)
+	}
+
+	if len(t.value.PeerCertificates) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotEmpty,
+			Actual: &AssertionValue{t.value.PeerCertificates},
+			Errors: []error{
+				errors.New("expected: peer presented certificate"),
+			},
+		})
+		return newCertificate(opChain, nil)
+	}
+
+	return newCertificate(opChain, t.value.PeerCertificates[0])
+}
+
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("0x%04X", version)
+	}
+}
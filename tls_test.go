@@ -0,0 +1,287 @@
+package httpexpect
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generates self-signed certificate and returns PEM-encoded
+// certificate and key
+func createTestCert(t *testing.T, name string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth,
+		},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{name},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template,
+		&key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+// writes certificate and key to temporary files and returns their paths
+func writeTestCert(
+	t *testing.T, dir string, certPEM, keyPEM []byte,
+) (string, string) {
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+
+	return certFile, keyFile
+}
+
+func TestTLS_NewCertPool(t *testing.T) {
+	cert1, _ := createTestCert(t, "cert1")
+	cert2, _ := createTestCert(t, "cert2")
+
+	t.Run("valid", func(t *testing.T) {
+		pool, err := NewCertPool(cert1, cert2)
+		require.NoError(t, err)
+		require.NotNil(t, pool)
+		assert.Equal(t, 2, len(pool.Subjects())) //nolint
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		pool, err := NewCertPool()
+		require.NoError(t, err)
+		require.NotNil(t, pool)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		pool, err := NewCertPool(cert1, []byte("bad"))
+		assert.Error(t, err)
+		assert.Nil(t, pool)
+	})
+}
+
+func TestTLS_LoadCertPool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cert, key := createTestCert(t, "cert")
+	certFile, keyFile := writeTestCert(t, dir, cert, key)
+
+	t.Run("valid", func(t *testing.T) {
+		pool, err := LoadCertPool(certFile)
+		require.NoError(t, err)
+		require.NotNil(t, pool)
+		assert.Equal(t, 1, len(pool.Subjects())) //nolint
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		pool, err := LoadCertPool(filepath.Join(dir, "missing.pem"))
+		assert.Error(t, err)
+		assert.Nil(t, pool)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		pool, err := LoadCertPool(keyFile)
+		assert.Error(t, err)
+		assert.Nil(t, pool)
+	})
+}
+
+func TestTLS_NewTLSTransport(t *testing.T) {
+	config := &tls.Config{ServerName: "example.com"}
+
+	transport := NewTLSTransport(config)
+
+	assert.Same(t, config, transport.TLSClientConfig)
+	assert.NotNil(t, transport.Proxy)
+	assert.NotSame(t, http.DefaultTransport, transport)
+}
+
+func TestTLS_Config(t *testing.T) {
+	config := &tls.Config{ServerName: "example.com"}
+
+	t.Run("default client", func(t *testing.T) {
+		e := WithConfig(Config{
+			Reporter:        newMockReporter(t),
+			TLSClientConfig: config,
+		})
+
+		client, ok := e.config.Client.(*http.Client)
+		require.True(t, ok)
+		require.NotNil(t, client.Jar)
+
+		transport, ok := client.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Same(t, config, transport.TLSClientConfig)
+	})
+
+	t.Run("user client", func(t *testing.T) {
+		client := &http.Client{}
+
+		e := WithConfig(Config{
+			Reporter:        newMockReporter(t),
+			Client:          client,
+			TLSClientConfig: config,
+		})
+
+		assert.Same(t, client, e.config.Client)
+		assert.Nil(t, client.Transport)
+	})
+}
+
+func TestTLS_Failed(t *testing.T) {
+	check := func(value *TLS, isNil bool) {
+		value.chain.assertFailed(t)
+
+		if isNil {
+			assert.Nil(t, value.Raw())
+		} else {
+			assert.NotNil(t, value.Raw())
+		}
+		assert.NotNil(t, value.Version())
+		assert.NotNil(t, value.CipherSuite())
+		assert.NotNil(t, value.NegotiatedProtocol())
+		assert.NotNil(t, value.ServerName())
+		assert.NotNil(t, value.Certificate())
+
+		value.Certificate().chain.assertFailed(t)
+	}
+
+	t.Run("failed_chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newTLS(chain, &tls.ConnectionState{})
+
+		check(value, false)
+	})
+
+	t.Run("nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newTLS(chain, nil)
+
+		check(value, true)
+	})
+
+	t.Run("failed_chain_nil_value", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newTLS(chain, nil)
+
+		check(value, true)
+	})
+}
+
+func TestTLS_Constructors(t *testing.T) {
+	state := &tls.ConnectionState{
+		Version: tls.VersionTLS12,
+	}
+
+	t.Run("Constructor without config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTLS(reporter, state)
+		value.Version().Equal("TLS 1.2")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("Constructor with config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewTLSC(Config{
+			Reporter: reporter,
+		}, state)
+		value.Version().Equal("TLS 1.2")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain Constructor", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newTLS(chain, state)
+		assert.NotSame(t, value.chain, &chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestTLS_Getters(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "example.com"},
+	}
+
+	t.Run("full", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewTLS(reporter, &tls.ConnectionState{
+			Version:            tls.VersionTLS13,
+			CipherSuite:        tls.TLS_AES_128_GCM_SHA256,
+			NegotiatedProtocol: "h2",
+			ServerName:         "example.com",
+			PeerCertificates:   []*x509.Certificate{cert},
+		})
+
+		value.chain.assertNotFailed(t)
+
+		assert.Equal(t, "TLS 1.3", value.Version().Raw())
+		assert.Equal(t, "TLS_AES_128_GCM_SHA256", value.CipherSuite().Raw())
+		assert.Equal(t, "h2", value.NegotiatedProtocol().Raw())
+		assert.Equal(t, "example.com", value.ServerName().Raw())
+		assert.Same(t, cert, value.Certificate().Raw())
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewTLS(reporter, &tls.ConnectionState{})
+
+		value.Certificate().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestTLS_VersionName(t *testing.T) {
+	cases := []struct {
+		version uint16
+		name    string
+	}{
+		{tls.VersionTLS10, "TLS 1.0"},
+		{tls.VersionTLS11, "TLS 1.1"},
+		{tls.VersionTLS12, "TLS 1.2"},
+		{tls.VersionTLS13, "TLS 1.3"},
+		{0x0305, "0x0305"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.name, tlsVersionName(tc.version))
+	}
+}
@@ -0,0 +1,161 @@
+package httpexpect
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// W3C Trace Context header, used by OpenTelemetry and most tracing systems
+// to propagate trace identifiers between services.
+//
+// See https://www.w3.org/TR/trace-context/.
+const traceparentHeader = "Traceparent"
+
+// TraceSpan describes client span of a request sent with trace context.
+// See Request.WithTraceContext and Config.Tracer.
+//
+// Every attempt of request (see Request.WithRetryPolicy) has its own span.
+// Identifiers are lowercase hex strings, as in "traceparent" header.
+type TraceSpan struct {
+	// Trace identifier, 32 hex digits.
+	TraceID string
+
+	// Span identifier, 16 hex digits.
+	// Sent to server as parent span in "traceparent" header.
+	SpanID string
+
+	// Identifier of parent span, set by Request.WithTraceParent.
+	// Empty if request started a new trace.
+	ParentSpanID string
+
+	// Span name, which is HTTP method, as recommended by OpenTelemetry
+	// semantic conventions for HTTP client spans.
+	Name string
+
+	// Time when request was sent and when response was received or
+	// request failed.
+	Start time.Time
+	End   time.Time
+
+	// Sent request, and received response or error.
+	// Response is nil if request failed. Tracer should not read
+	// response body.
+	Request  *http.Request
+	Response *http.Response
+	Error    error
+}
+
+// Tracer receives client spans of requests sent with trace context.
+//
+// Tracer may be used to export spans to a tracing system, e.g. by
+// converting them to OpenTelemetry spans, so that test requests appear
+// in the same trace as backend spans.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Tracer: httpexpect.TracerFunc(func(span httpexpect.TraceSpan) {
+//			t.Logf("trace %s span %s", span.TraceID, span.SpanID)
+//		}),
+//	})
+type Tracer interface {
+	// Span is invoked for every attempt of request with trace context,
+	// after response is received or request fails.
+	Span(span TraceSpan)
+}
+
+// TracerFunc is an adapter that allows a function to be used as Tracer.
+type TracerFunc func(span TraceSpan)
+
+// Span implements Tracer.
+func (f TracerFunc) Span(span TraceSpan) {
+	f(span)
+}
+
+// traceContext holds trace of request, see Request.WithTraceContext
+type traceContext struct {
+	traceID      string
+	parentSpanID string
+	flags        string
+
+	// if non-empty, span identifier from user-provided header,
+	// used for every attempt
+	spanID string
+}
+
+// newTraceContext returns trace context with random trace identifier
+// and sampled flag set
+func newTraceContext() *traceContext {
+	return &traceContext{
+		traceID: randomTraceID(16),
+		flags:   "01",
+	}
+}
+
+// parseTraceparent parses traceparent header value of version 00
+func parseTraceparent(traceparent string) (*traceContext, error) {
+	parts := strings.Split(traceparent, "-")
+
+	if len(parts) != 4 || parts[0] != "00" ||
+		!isTraceID(parts[1], 16) || !isTraceID(parts[2], 8) ||
+		!isTraceID(parts[3], 1) {
+		return nil, fmt.Errorf(
+			"invalid traceparent %q, expected \"00-<trace-id>-<parent-id>-<flags>\"",
+			traceparent)
+	}
+
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return nil, errors.New("trace and parent identifiers should be non-zero")
+	}
+
+	return &traceContext{
+		traceID:      parts[1],
+		parentSpanID: parts[2],
+		flags:        parts[3],
+	}, nil
+}
+
+// startSpan sets traceparent header of request and returns new span
+func (tc *traceContext) startSpan(req *http.Request) TraceSpan {
+	span := TraceSpan{
+		TraceID:      tc.traceID,
+		SpanID:       tc.spanID,
+		ParentSpanID: tc.parentSpanID,
+		Name:         req.Method,
+		Request:      req,
+	}
+
+	if span.SpanID == "" {
+		span.SpanID = randomTraceID(8)
+	}
+
+	req.Header.Set(traceparentHeader,
+		"00-"+span.TraceID+"-"+span.SpanID+"-"+tc.flags)
+
+	return span
+}
+
+func isTraceID(s string, size int) bool {
+	if len(s) != size*2 || strings.ToLower(s) != s {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func randomTraceID(size int) string {
+	id := make([]byte, size)
+
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,56 @@
+package httpexpect
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// NewUnixSocketTransport returns a new http.Transport that connects to
+// given unix domain socket instead of host from request URL.
+//
+// Host part of the URL is still sent in Host header, so it may be set to
+// any value expected by server, e.g. "localhost".
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:  "http://localhost",
+//		Reporter: httpexpect.NewAssertReporter(t),
+//		Client: &http.Client{
+//			Transport: httpexpect.NewUnixSocketTransport("/var/run/docker.sock"),
+//		},
+//		WebsocketDialer: httpexpect.NewUnixSocketDialer("/var/run/docker.sock"),
+//	})
+func NewUnixSocketTransport(path string) *http.Transport {
+	return &http.Transport{
+		DialContext: unixSocketDialContext(path),
+	}
+}
+
+// NewUnixSocketDialer returns a new websocket.Dialer that connects to
+// given unix domain socket instead of host from request URL.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//		BaseURL:         "http://localhost",
+//		Reporter:        httpexpect.NewAssertReporter(t),
+//		WebsocketDialer: httpexpect.NewUnixSocketDialer("/var/run/app.sock"),
+//	})
+func NewUnixSocketDialer(path string) *websocket.Dialer {
+	return &websocket.Dialer{
+		NetDialContext: unixSocketDialContext(path),
+	}
+}
+
+func unixSocketDialContext(
+	path string,
+) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", path)
+	}
+}
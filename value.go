@@ -1,16 +1,18 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
-	"reflect"
+	"fmt"
 )
 
 // Value provides methods to inspect attached interface{} object
 // (Go representation of arbitrary JSON value) and cast it to
 // concrete type.
 type Value struct {
-	chain *chain
-	value interface{}
+	chain   *chain
+	value   interface{}
+	missing bool
 }
 
 // NewValue returns a new Value instance.
@@ -52,7 +54,7 @@ func NewValueC(config Config, value interface{}) *Value {
 }
 
 func newValue(parent *chain, val interface{}) *Value {
-	v := &Value{parent.clone(), nil}
+	v := &Value{chain: parent.clone(), value: nil}
 
 	opChain := v.chain.enter("")
 	defer opChain.leave()
@@ -75,6 +77,64 @@ func (v *Value) Raw() interface{} {
 	return v.value
 }
 
+// Alias replaces assertion path with given name in failure messages.
+//
+// By default, failure message includes the full path of assertion, e.g.:
+//
+//	Request("GET", "/users/1").Expect().JSON().Path("$.id").Number().Gt(0)
+//
+// After Alias, the path starts from the alias instead, e.g.:
+//
+//	user.id.Number().Gt(0)
+//
+// This makes failures easier to read in loops and helpers. Alias is
+// inherited by all values derived from this one.
+//
+// Example:
+//
+//	value := NewValue(t, 123)
+//	value.Alias("user.id").Number().Gt(0)
+func (v *Value) Alias(name string) *Value {
+	opChain := v.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	v.chain.setAlias(name)
+	return v
+}
+
+// Decode unmarshals the underlying value attached to the Value to a target variable.
+// target should be one of this:
+//
+// - pointer to an empty interface
+// - pointer to any type, that can be unmarshaled from JSON
+//
+// This is useful to mix fluent assertions with typed Go comparisons.
+//
+// Example:
+//
+//	type User struct {
+//		Name string `json:"name"`
+//		Age  int    `json:"age"`
+//	}
+//
+//	var user User
+//	e.GET("/users/john").
+//		Expect().
+//		JSON().Decode(&user)
+//
+//	assert.Equal(t, User{"john", 42}, user)
+func (v *Value) Decode(target interface{}) *Value {
+	opChain := v.chain.enter("Decode()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	canonDecode(opChain, v.value, target)
+	return v
+}
+
 // Path returns a new Value object for child object(s) matching given
 // JSONPath expression.
 //
@@ -82,8 +142,19 @@ func (v *Value) Raw() interface{} {
 // See http://goessner.net/articles/JsonPath/.
 //
 // We currently use https://github.com/yalp/jsonpath, which implements
-// only a subset of JSONPath, yet useful for simple queries. It doesn't
-// support filters and requires double quotes for strings.
+// only a subset of JSONPath, yet useful for simple queries. It requires
+// double quotes for strings in brackets.
+//
+// Wildcards ("*") and recursive descent ("..") are supported. Such paths
+// may match multiple values; in this case the returned Value holds an array
+// of all matches, and Array().Every() can be used to check each of them.
+//
+// Filters ("[?(...)]") select array elements or object values for which
+// filter expression is true. Expression may use "@" paths relative to
+// current element (e.g. "@.price"), number, string, true, false, and null
+// literals, comparisons (==, !=, <, <=, >, >=), logical operators
+// (&&, ||, !), and parentheses. "@" path alone checks that it exists.
+// Paths with filters always return an array of matches, possibly empty.
 //
 // Example 1:
 //
@@ -101,6 +172,22 @@ func (v *Value) Raw() interface{} {
 //	for _, user := range value.Path("$..user").Array().Iter() {
 //	    user.String().Equal("john")
 //	}
+//
+// Example 3:
+//
+//	json := `{"items": [{"id": 1}, {"id": 2}]}`
+//	value := NewValue(t, json)
+//
+//	value.Path("$.items[*].id").Array().Every(func(_ int, id *Value) {
+//	    id.Number().Gt(0)
+//	})
+//
+// Example 4:
+//
+//	json := `{"items": [{"id": 1, "price": 5}, {"id": 2, "price": 20}]}`
+//	value := NewValue(t, json)
+//
+//	value.Path("$.items[?(@.price > 10)].id").Array().ContainsOnly(2)
 func (v *Value) Path(path string) *Value {
 	opChain := v.chain.enter("Path(%q)", path)
 	defer opChain.leave()
@@ -108,11 +195,62 @@ func (v *Value) Path(path string) *Value {
 	return jsonPath(opChain, v.value, path)
 }
 
+// Pointer returns a new Value object for child object(s) matching given
+// JSON Pointer, as defined in RFC 6901.
+//
+// Pointer is a sequence of reference tokens, each prefixed by "/"; empty
+// pointer refers to the whole value. Characters "~" and "/" in tokens
+// are escaped as "~0" and "~1". Tokens are object keys or decimal array
+// indexes.
+//
+// Unlike Path, Pointer always refers to a single value; if it can't be
+// resolved, failure is reported, citing the longest prefix of the pointer
+// that can't be resolved.
+//
+// Example:
+//
+//	json := `{"data": {"items": [{"id": 1}, {"id": 2}]}}`
+//	value := NewValue(t, json)
+//
+//	value.Pointer("/data/items/0/id").Number().Equal(1)
+func (v *Value) Pointer(pointer string) *Value {
+	opChain := v.chain.enter("Pointer(%q)", pointer)
+	defer opChain.leave()
+
+	return jsonPointer(opChain, v.value, pointer)
+}
+
+// Store saves value in environment with given key, so that it may be used
+// by subsequent requests, e.g. via Request.WithHeaderFromEnv or Expect.Env.
+//
+// If value chain is already failed, nothing is stored.
+//
+// Example:
+//
+//	e.POST("/users").WithJSON(user).
+//		Expect().
+//		JSON().Path("$.id").Store("user.id")
+//
+//	userID := e.Env().Get("user.id")
+func (v *Value) Store(key string) *Value {
+	opChain := v.chain.enter("Store(%q)", key)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	opChain.env().Put(key, v.value)
+
+	return v
+}
+
 // Schema succeeds if value matches given JSON Schema.
 //
 // JSON Schema specifies a JSON-based format to define the structure of
 // JSON data. See http://json-schema.org/.
-// We use https://github.com/xeipuuv/gojsonschema implementation.
+// We use https://github.com/xeipuuv/gojsonschema implementation, which
+// supports draft-04, draft-06, and draft-07 (selected by "$schema" keyword).
 //
 // schema should be one of the following:
 //   - go value that can be json.Marshal-ed to a valid schema
@@ -154,6 +292,120 @@ func (v *Value) Schema(schema interface{}) *Value {
 	return v
 }
 
+// Satisfies succeeds if value matches given Matcher.
+//
+// Matcher receives value in canonical form, see Matcher. Note that if
+// Config.UseJSONNumber is enabled, large integers are json.Number instead
+// of float64.
+//
+// If matcher returns error, failure is reported with this error.
+//
+// Example:
+//
+//	isPositive := MatcherFunc(func(value interface{}) error {
+//		if n, ok := value.(float64); !ok || n <= 0 {
+//			return errors.New("expected: positive number")
+//		}
+//		return nil
+//	})
+//
+//	value := NewValue(t, map[string]interface{}{"amount": 100})
+//	value.Path("$.amount").Satisfies(isPositive)
+func (v *Value) Satisfies(matcher Matcher) *Value {
+	opChain := v.chain.enter("Satisfies()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if matcher == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil matcher argument"),
+			},
+		})
+		return v
+	}
+
+	if err := matcher.Match(v.value); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				fmt.Errorf("expected: value satisfies %s", matcherName(matcher)),
+				err,
+			},
+		})
+	}
+
+	return v
+}
+
+// Should succeeds if value matches given Gomega matcher, which eases
+// migration of existing tests written with Gomega.
+//
+// Any type with the same method set as types.GomegaMatcher may be used,
+// see GomegaMatcher. Matcher receives value in canonical form, e.g. numbers
+// are float64, so use BeNumerically instead of Equal for numbers. If
+// Config.UseJSONNumber is enabled, integers that can't be exactly
+// represented as float64 are json.Number instead, which BeNumerically
+// doesn't support.
+//
+// If matcher fails, failure is reported with its failure message.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"tags": []interface{}{"a", "b"}})
+//	value.Path("$.tags").Should(gomega.ContainElement("a"))
+//	value.Path("$.tags").Should(gomega.HaveLen(2))
+func (v *Value) Should(matcher GomegaMatcher) *Value {
+	opChain := v.chain.enter("Should()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if matcher == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil matcher argument"),
+			},
+		})
+		return v
+	}
+
+	success, err := matcher.Match(v.value)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				fmt.Errorf("matcher %T failed", matcher),
+				err,
+			},
+		})
+		return v
+	}
+
+	if !success {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				fmt.Errorf("expected: value satisfies %T", matcher),
+				errors.New(matcher.FailureMessage(v.value)),
+			},
+		})
+	}
+
+	return v
+}
+
 // Object returns a new Object attached to underlying value.
 //
 // If underlying value is not an object (map[string]interface{}), failure is reported
@@ -270,6 +522,11 @@ func (v *Value) Number() *Number {
 		return newNumber(opChain, 0)
 	}
 
+	// large integer, see Config.UseJSONNumber
+	if num, ok := v.value.(json.Number); ok {
+		return newNumberExact(opChain, num)
+	}
+
 	data, ok := v.value.(float64)
 
 	if !ok {
@@ -325,6 +582,9 @@ func (v *Value) Boolean() *Boolean {
 // is also treated as null value. Empty (non-nil) slice or map, empty string, and
 // zero number are not treated as null value.
 //
+// Missing value (see Object.Lookup) is treated as null value too; use IsNull
+// to distinguish null value from missing one.
+//
 // Example:
 //
 //	value := NewValue(t, nil)
@@ -387,9 +647,174 @@ func (v *Value) NotNull() *Value {
 	return v
 }
 
+// IsNull succeeds if value is present and is nil.
+//
+// Unlike Null, IsNull fails if value is missing, i.e. it was obtained from
+// Object.Lookup for a key that object doesn't contain.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//	object.Lookup("foo").IsNull()
+func (v *Value) IsNull() *Value {
+	opChain := v.chain.enter("IsNull()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if v.missing {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNil,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is null"),
+				errors.New("value is missing"),
+			},
+		})
+		return v
+	}
+
+	if !(v.value == nil) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNil,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is null"),
+			},
+		})
+	}
+
+	return v
+}
+
+// IsBool succeeds if value is boolean.
+//
+// Example:
+//
+//	value := NewValue(t, false)
+//	value.IsBool()
+func (v *Value) IsBool() *Value {
+	opChain := v.chain.enter("IsBool()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if _, ok := v.value.(bool); !ok || v.missing {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value is boolean"),
+			},
+		})
+	}
+
+	return v
+}
+
+// Exists succeeds if value is present, i.e. it was not obtained from
+// Object.Lookup for a key that object doesn't contain.
+//
+// Present value may be null.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//	object.Lookup("foo").Exists()
+func (v *Value) Exists() *Value {
+	opChain := v.chain.enter("Exists()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if v.missing {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("expected: value exists"),
+			},
+		})
+	}
+
+	return v
+}
+
+// NotExists succeeds if value is missing, i.e. it was obtained from
+// Object.Lookup for a key that object doesn't contain.
+//
+// Present null value is not treated as missing.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": nil})
+//	object.Lookup("bar").NotExists()
+func (v *Value) NotExists() *Value {
+	opChain := v.chain.enter("NotExists()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	if !v.missing {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotValid,
+			Actual: &AssertionValue{v.value},
+			Errors: []error{
+				errors.New("expected: value does not exist"),
+			},
+		})
+	}
+
+	return v
+}
+
+// Not returns a new Value instance, for which the result of the next
+// assertion is inverted: it succeeds if the assertion fails and fails if
+// the assertion succeeds. Assertions following the next one, and values
+// returned by it, are not inverted.
+//
+// Not is useful when there is no dedicated NotXxx method, e.g. for custom
+// matchers. Usage errors, like invalid arguments, are still reported.
+//
+// Calling Not twice cancels negation. If the next assertion returns a new
+// value, e.g. Object, and fails as expected, the returned value is marked
+// as failed, and further assertions on it are skipped.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"id": "abc"})
+//	value.Path("$.id").Not().Satisfies(isULID)
+//	value.Path("$.id").Not().Schema(`{"type": "number"}`)
+func (v *Value) Not() *Value {
+	// Not itself is never negated, instead it toggles pending negation
+	negate := !v.chain.takeNegateNext()
+
+	opChain := v.chain.enter("Not()")
+	defer opChain.leave()
+
+	value := newValue(opChain, v.value)
+	value.missing = v.missing
+
+	if !opChain.failed() {
+		value.chain.setNegateNext(negate)
+	}
+
+	return value
+}
+
 // Equal succeeds if value is equal to another value (e.g. map, slice, string, etc).
 // Before comparison, both values are converted to canonical form.
 //
+// Expected value may contain placeholders, like "$any" or "$uuid";
+// see PlaceholderAny for details.
+//
 // Example:
 //
 //	value := NewValue(t, "foo")
@@ -407,7 +832,11 @@ func (v *Value) Equal(value interface{}) *Value {
 		return v
 	}
 
-	if !reflect.DeepEqual(expected, v.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return v
+	}
+
+	if !equalValues(opChain, expected, v.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{v.value},
@@ -421,6 +850,59 @@ func (v *Value) Equal(value interface{}) *Value {
 	return v
 }
 
+// EqualIgnoring succeeds if value is equal to another value, ignoring
+// given paths in both values.
+// Before comparison, both values are converted to canonical form.
+//
+// Paths have the same syntax as in Object.EqualIgnoring.
+//
+// Example:
+//
+//	value := NewValue(t, []interface{}{
+//		map[string]interface{}{"id": 1, "created_at": "2023-01-02T15:04:05Z"},
+//	})
+//	value.EqualIgnoring([]interface{}{
+//		map[string]interface{}{"id": 1},
+//	}, "[*].created_at")
+func (v *Value) EqualIgnoring(value interface{}, paths ...string) *Value {
+	opChain := v.chain.enter("EqualIgnoring()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	expected, ok := canonValue(opChain, value)
+	if !ok {
+		return v
+	}
+
+	if !checkPlaceholders(opChain, expected) {
+		return v
+	}
+
+	ignored, ok := parseIgnorePaths(opChain, paths)
+	if !ok {
+		return v
+	}
+
+	expectedStripped := removeIgnoredPaths(expected, ignored)
+	actualStripped := removeIgnoredPaths(v.value, ignored)
+
+	if !equalValues(opChain, expectedStripped, actualStripped) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{actualStripped},
+			Expected: &AssertionValue{expectedStripped},
+			Errors: []error{
+				errors.New("expected: values are equal, ignoring given paths"),
+			},
+		})
+	}
+
+	return v
+}
+
 // NotEqual succeeds if value is not equal to another value (e.g. map, slice,
 // string, etc). Before comparison, both values are converted to canonical form.
 //
@@ -441,7 +923,11 @@ func (v *Value) NotEqual(value interface{}) *Value {
 		return v
 	}
 
-	if reflect.DeepEqual(expected, v.value) {
+	if !checkPlaceholders(opChain, expected) {
+		return v
+	}
+
+	if equalValues(opChain, expected, v.value) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{v.value},
@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -16,8 +17,20 @@ func TestValue_Failed(t *testing.T) {
 
 	value := newValue(chain, nil)
 
+	value.Alias("foo")
 	value.Path("$")
+	value.Pointer("")
+	value.Store("foo")
 	value.Schema("")
+	value.Satisfies(MatcherFunc(func(interface{}) error { return nil }))
+	value.Should(mockGomegaMatcher{})
+
+	assert.NotNil(t, value.Not())
+	value.Not().chain.assertFailed(t)
+	value.Not().Null()
+
+	var target interface{}
+	value.Decode(&target)
 
 	assert.NotNil(t, value.Path("/"))
 
@@ -35,9 +48,14 @@ func TestValue_Failed(t *testing.T) {
 
 	value.Null()
 	value.NotNull()
+	value.IsNull()
+	value.IsBool()
+	value.Exists()
+	value.NotExists()
 
 	value.Equal(nil)
 	value.NotEqual(nil)
+	value.EqualIgnoring(nil)
 }
 
 func TestValue_Constructors(t *testing.T) {
@@ -67,6 +85,85 @@ func TestValue_Constructors(t *testing.T) {
 	})
 }
 
+func TestValue_Decode(t *testing.T) {
+	t.Run("Decode into empty interface", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, []interface{}{"foo", 123.0})
+
+		var target interface{}
+		value.Decode(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, []interface{}{"foo", 123.0}, target)
+	})
+
+	t.Run("Decode into struct", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		type S struct {
+			Name string   `json:"name"`
+			Age  int      `json:"age"`
+			Tags []string `json:"tags"`
+		}
+
+		value := NewValue(reporter, map[string]interface{}{
+			"name": "john",
+			"age":  42,
+			"tags": []interface{}{"a", "b"},
+		})
+
+		var target S
+		value.Decode(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, S{"john", 42, []string{"a", "b"}}, target)
+	})
+
+	t.Run("Decode null", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, nil)
+
+		target := &struct{}{}
+		value.Decode(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Nil(t, target)
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "foo")
+
+		var target int
+		value.Decode(&target)
+
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("Target is unmarshable", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, 123)
+
+		value.Decode(123)
+
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("Target is nil", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, 123)
+
+		value.Decode(nil)
+
+		value.chain.assertFailed(t)
+	})
+}
+
 func TestValue_CastNull(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -109,6 +206,54 @@ func TestValue_CastBad(t *testing.T) {
 	NewValue(reporter, data).Null().chain.assertFailed(t)
 }
 
+func TestValue_TriState(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	object := NewObject(reporter, map[string]interface{}{
+		"null":  nil,
+		"false": false,
+		"zero":  0,
+	})
+
+	t.Run("null", func(t *testing.T) {
+		object.Lookup("null").Exists().chain.assertNotFailed(t)
+		object.Lookup("null").NotExists().chain.assertFailed(t)
+		object.Lookup("null").Null().chain.assertNotFailed(t)
+		object.Lookup("null").IsNull().chain.assertNotFailed(t)
+		object.Lookup("null").IsBool().chain.assertFailed(t)
+	})
+
+	t.Run("boolean", func(t *testing.T) {
+		object.Lookup("false").Exists().chain.assertNotFailed(t)
+		object.Lookup("false").NotExists().chain.assertFailed(t)
+		object.Lookup("false").Null().chain.assertFailed(t)
+		object.Lookup("false").IsNull().chain.assertFailed(t)
+		object.Lookup("false").IsBool().chain.assertNotFailed(t)
+	})
+
+	t.Run("number", func(t *testing.T) {
+		object.Lookup("zero").Exists().chain.assertNotFailed(t)
+		object.Lookup("zero").IsNull().chain.assertFailed(t)
+		object.Lookup("zero").IsBool().chain.assertFailed(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		object.Lookup("missing").Exists().chain.assertFailed(t)
+		object.Lookup("missing").NotExists().chain.assertNotFailed(t)
+		object.Lookup("missing").Null().chain.assertNotFailed(t)
+		object.Lookup("missing").IsNull().chain.assertFailed(t)
+		object.Lookup("missing").IsBool().chain.assertFailed(t)
+	})
+
+	t.Run("standalone", func(t *testing.T) {
+		NewValue(reporter, nil).Exists().chain.assertNotFailed(t)
+		NewValue(reporter, nil).NotExists().chain.assertFailed(t)
+		NewValue(reporter, nil).IsNull().chain.assertNotFailed(t)
+		NewValue(reporter, true).IsBool().chain.assertNotFailed(t)
+		NewValue(reporter, "true").IsBool().chain.assertFailed(t)
+	})
+}
+
 func TestValue_CastObject(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -295,6 +440,53 @@ func TestValue_GetBoolean(t *testing.T) {
 	assert.Equal(t, false, inner2.Raw())
 }
 
+func TestValue_EqualIgnoring(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewValue(reporter, []interface{}{
+		map[string]interface{}{"id": 1.0, "created_at": "2023-01-02T15:04:05Z"},
+		map[string]interface{}{"id": 2.0, "created_at": "2023-01-03T15:04:05Z"},
+	})
+
+	value.EqualIgnoring([]interface{}{
+		map[string]interface{}{"id": 1.0},
+		map[string]interface{}{"id": 2.0},
+	}, "[*].created_at")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring([]interface{}{
+		map[string]interface{}{"id": 1.0},
+		map[string]interface{}{"id": 2.0, "created_at": "2023-01-03T15:04:05Z"},
+	}, "[0].created_at")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring([]interface{}{
+		map[string]interface{}{"id": 1.0},
+		map[string]interface{}{"id": 2.0},
+	}, "[0].created_at")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring([]interface{}{
+		map[string]interface{}{"id": 1.0},
+	}, "[*].created_at")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring([]interface{}{
+		map[string]interface{}{"id": 1.0},
+		"anything",
+	}, "[0].created_at", "[1]")
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.EqualIgnoring([]interface{}{}, "[x]")
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
 func TestValue_Equal(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -322,6 +514,122 @@ func TestValue_Equal(t *testing.T) {
 	NewValue(reporter, data1).NotEqual(func() {}).chain.assertFailed(t)
 }
 
+func TestValue_Not(t *testing.T) {
+	isPositive := MatcherFunc(func(value interface{}) error {
+		if n, ok := value.(float64); !ok || n <= 0 {
+			return errors.New("expected: positive number")
+		}
+		return nil
+	})
+
+	t.Run("inverted", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{
+			"id":    "abc",
+			"debt":  -5,
+			"count": 3,
+		})
+
+		value.Path("$.id").Not().Equal("xyz").
+			chain.assertNotFailed(t)
+		value.Path("$.id").Not().Equal("abc").
+			chain.assertFailed(t)
+
+		value.Path("$.debt").Not().Satisfies(isPositive).
+			chain.assertNotFailed(t)
+		value.Path("$.count").Not().Satisfies(isPositive).
+			chain.assertFailed(t)
+
+		value.Path("$.id").Not().Schema(`{"type": "number"}`).
+			chain.assertNotFailed(t)
+		value.Path("$.id").Not().Should(mockGomegaMatcher{"xyz"}).
+			chain.assertNotFailed(t)
+
+		value.Path("$.id").Not().Null().
+			chain.assertNotFailed(t)
+		value.Path("$.id").Not().NotNull().
+			chain.assertFailed(t)
+	})
+
+	t.Run("double negation", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "abc")
+
+		value.Not().Not().Equal("abc").
+			chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+
+		value.Not().Not().Equal("xyz").
+			chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+
+		reporter = newMockReporter(t)
+
+		value = NewValue(reporter, "abc")
+
+		value.Not().Not().Not().Equal("xyz").
+			chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("failed accessor", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "abc")
+
+		object := value.Not().Object()
+		object.chain.assertFailed(t)
+
+		object.ContainsKey("foo")
+		object.chain.assertFailed(t)
+
+		value.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("succeeded accessor", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{"foo": 1})
+
+		value.Not().Object()
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("only next assertion", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "abc")
+
+		value.Not().Equal("xyz").Equal("abc").
+			chain.assertNotFailed(t)
+
+		value.Not().Equal("xyz").Equal("xyz").
+			chain.assertFailed(t)
+	})
+
+	t.Run("original value", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "abc")
+
+		value.Not()
+		value.Equal("abc")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("usage error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "abc")
+
+		value.Not().Satisfies(nil).
+			chain.assertFailed(t)
+	})
+}
+
 func TestValue_PathObject(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -437,6 +745,145 @@ func TestValue_PathError(t *testing.T) {
 	}
 }
 
+func TestValue_Pointer(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": 1.0},
+				map[string]interface{}{"id": 2.0},
+			},
+		},
+		"a/b": "slash",
+		"m~n": "tilde",
+		"":    "empty",
+		"nil": nil,
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		cases := []struct {
+			pointer  string
+			expected interface{}
+		}{
+			{"", data},
+			{"/data/items", data["data"].(map[string]interface{})["items"]},
+			{"/data/items/0", map[string]interface{}{"id": 1.0}},
+			{"/data/items/1/id", 2.0},
+			{"/a~1b", "slash"},
+			{"/m~0n", "tilde"},
+			{"/", "empty"},
+			{"/nil", nil},
+		}
+
+		for _, tc := range cases {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, data)
+
+			assert.Equal(t, tc.expected, value.Pointer(tc.pointer).Raw(), tc.pointer)
+			value.chain.assertNotFailed(t)
+		}
+	})
+
+	t.Run("not resolved", func(t *testing.T) {
+		for _, pointer := range []string{
+			"data",
+			"/data/bad",
+			"/data/items/2",
+			"/data/items/-",
+			"/data/items/01",
+			"/data/items/+1",
+			"/data/items/x",
+			"/data/items/0/id/foo",
+			"/nil/foo",
+			"/a~2b",
+			"/a~",
+		} {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, data)
+
+			bad := value.Pointer(pointer)
+			assert.NotNil(t, bad)
+			assert.Nil(t, bad.Raw())
+			value.chain.assertFailed(t)
+		}
+	})
+
+	t.Run("object and array", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		object := NewObject(reporter, data)
+		object.Pointer("/data/items/0/id").Number().Equal(1)
+		object.chain.assertNotFailed(t)
+
+		array := object.Value("data").Object().Value("items").Array()
+		array.Pointer("/1/id").Number().Equal(2)
+		array.chain.assertNotFailed(t)
+
+		array.Pointer("/2")
+		array.chain.assertFailed(t)
+	})
+}
+
+func TestValue_Store(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	env := NewEnvironment(reporter)
+
+	config := Config{
+		Reporter:    reporter,
+		Environment: env,
+	}
+
+	value := NewValueC(config, map[string]interface{}{
+		"token": "abc",
+		"id":    123,
+	})
+
+	value.Path("$.token").Store("token")
+	value.Path("$.id").Store("id")
+	value.chain.assertNotFailed(t)
+
+	assert.Equal(t, "abc", env.GetString("token"))
+	assert.Equal(t, 123.0, env.GetFloat("id"))
+
+	value.Path("$.missing").Store("missing")
+	value.chain.assertFailed(t)
+
+	assert.False(t, env.Has("missing"))
+}
+
+func TestValue_Alias(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	config := Config{
+		AssertionHandler: handler,
+	}
+
+	value := NewValueC(config, map[string]interface{}{
+		"user": map[string]interface{}{
+			"id": 123,
+		},
+	})
+
+	value.Path("$.user").Object().
+		Alias("user").
+		Value("id").Number().Gt(200)
+
+	assert.NotNil(t, handler.failure)
+	assert.Equal(t,
+		[]string{"user", `Value("id")`, "Number()", "Gt()"},
+		handler.ctx.AliasedPath)
+	assert.Equal(t,
+		[]string{"Value()", `Path("$.user")`, "Object()", `Value("id")`,
+			"Number()", "Gt()"},
+		handler.ctx.Path)
+
+	msg := (&DefaultFormatter{}).FormatFailure(handler.ctx, handler.failure)
+	assert.Contains(t, msg, `user.Value("id").Number().Gt()`)
+	assert.NotContains(t, msg, "Path(")
+}
+
 // based on github.com/yalp/jsonpath
 func TestValue_PathExpressions(t *testing.T) {
 	data := map[string]interface{}{
@@ -694,3 +1141,241 @@ func TestValue_Schema(t *testing.T) {
 	NewValue(reporter, data1).Schema("file:///bad/path").chain.assertFailed(t)
 	NewValue(reporter, data1).Schema("{ bad json").chain.assertFailed(t)
 }
+
+func TestValue_Satisfies(t *testing.T) {
+	isPositive := MatcherFunc(func(value interface{}) error {
+		if n, ok := value.(float64); !ok || n <= 0 {
+			return errors.New("expected: positive number")
+		}
+		return nil
+	})
+
+	reporter := newMockReporter(t)
+
+	value := NewValue(reporter, map[string]interface{}{
+		"amount": 100,
+		"debt":   -5,
+	})
+
+	value.Path("$.amount").Satisfies(isPositive).
+		chain.assertNotFailed(t)
+
+	value.Path("$.debt").Satisfies(isPositive).
+		chain.assertFailed(t)
+
+	value.Object().Value("amount").Satisfies(isPositive).
+		chain.assertNotFailed(t)
+
+	value.Object().Value("missing").Satisfies(isPositive).
+		chain.assertFailed(t)
+
+	value.Satisfies(nil).
+		chain.assertFailed(t)
+}
+
+func TestValue_Should(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{
+			"tags":  []interface{}{"a", "b"},
+			"count": 2,
+		})
+
+		value.Path("$.tags").Should(mockGomegaMatcher{[]interface{}{"a", "b"}}).
+			chain.assertNotFailed(t)
+
+		// numbers are float64
+		value.Path("$.count").Should(mockGomegaMatcher{2.0}).
+			chain.assertNotFailed(t)
+
+		value.Path("$.count").Should(mockGomegaMatcher{2}).
+			chain.assertFailed(t)
+	})
+
+	t.Run("failure message", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{AssertionHandler: handler}, "foo")
+
+		value.Should(mockGomegaMatcher{"bar"})
+		value.chain.assertFailed(t)
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, AssertValid, handler.failure.Type)
+			assert.EqualError(t, handler.failure.Errors[1],
+				"Expected\n    foo\nto equal\n    bar")
+		}
+	})
+
+	t.Run("matcher error", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, "foo").Should(mockGomegaMatcher{nil}).
+			chain.assertFailed(t)
+	})
+
+	t.Run("nil matcher", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, "foo").Should(nil).
+			chain.assertFailed(t)
+	})
+}
+
+func TestValue_SchemaDraft07(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	schema := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"properties": {
+			"kind": {
+				"const": "user"
+			}
+		},
+		"if": {
+			"properties": {
+				"kind": {
+					"const": "user"
+				}
+			}
+		},
+		"then": {
+			"required": ["name"]
+		}
+	}`
+
+	NewValue(reporter, map[string]interface{}{
+		"kind": "user",
+		"name": "john",
+	}).Schema(schema).chain.assertNotFailed(t)
+
+	NewValue(reporter, map[string]interface{}{
+		"kind": "user",
+	}).Schema(schema).chain.assertFailed(t)
+
+	NewValue(reporter, map[string]interface{}{
+		"kind": "group",
+	}).Schema(schema).chain.assertFailed(t)
+}
+
+func TestValue_PathWildcardEvery(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"id": 2},
+			map[string]interface{}{"id": 3},
+		},
+	}
+
+	value := NewValue(reporter, data)
+
+	ids := value.Path("$.items[*].id").Array()
+	ids.chain.assertNotFailed(t)
+
+	assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, ids.Raw())
+
+	ids.Every(func(_ int, id *Value) {
+		id.Number().Gt(0)
+	})
+	ids.chain.assertNotFailed(t)
+
+	ids.Every(func(_ int, id *Value) {
+		id.Number().Lt(3)
+	})
+	ids.chain.assertFailed(t)
+}
+
+func TestValue_PathFilter(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 1, "name": "foo", "tags": []interface{}{"a"}},
+			map[string]interface{}{"id": 2, "name": "bar"},
+			map[string]interface{}{"id": 3, "name": "baz", "tags": []interface{}{"b"}},
+		},
+		"users": map[string]interface{}{
+			"john": map[string]interface{}{"age": 30, "admin": true},
+			"bob":  map[string]interface{}{"age": 20, "admin": false},
+		},
+	}
+
+	cases := []struct {
+		path   string
+		result []interface{}
+	}{
+		{`$.items[?(@.id == 2)].name`, []interface{}{"bar"}},
+		{`$.items[?(@.id != 2)].name`, []interface{}{"foo", "baz"}},
+		{`$.items[?(@.id > 1)].id`, []interface{}{2.0, 3.0}},
+		{`$.items[?(@.id >= 2 && @.id < 3)].id`, []interface{}{2.0}},
+		{`$.items[?(@.id <= 1 || @.name == "baz")].id`, []interface{}{1.0, 3.0}},
+		{`$.items[?(@.name == 'foo')].id`, []interface{}{1.0}},
+		{`$.items[?(@.name > "bar")].name`, []interface{}{"foo", "baz"}},
+		{`$.items[?(@.tags)].id`, []interface{}{1.0, 3.0}},
+		{`$.items[?(!@.tags)].id`, []interface{}{2.0}},
+		{`$.items[?(!(@.id == 1))].id`, []interface{}{2.0, 3.0}},
+		{`$.items[?(@.tags[0] == "b")].id`, []interface{}{3.0}},
+		{`$.items[?(@.tags)].tags[*]`, []interface{}{"a", "b"}},
+		{`$.items[?(@.id == 1)]`, []interface{}{
+			map[string]interface{}{"id": 1.0, "name": "foo", "tags": []interface{}{"a"}},
+		}},
+		{`$.items[?(@.id > 100)].name`, []interface{}{}},
+		{`$.items[?(@.name == "a) ]")].id`, []interface{}{}},
+		{`$.users[?(@.admin == true)].age`, []interface{}{30.0}},
+		{`$.users[?(@.age < 25)].admin`, []interface{}{false}},
+		{`$.users[?(@.age)][?(@ > 25)]`, []interface{}{30.0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, data)
+			result := value.Path(tc.path)
+
+			value.chain.assertNotFailed(t)
+			assert.Equal(t, tc.result, result.Raw())
+		})
+	}
+
+	t.Run("every match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, data)
+
+		ids := value.Path(`$.items[?(@.tags)].id`).Array()
+		ids.chain.assertNotFailed(t)
+
+		ids.Every(func(_ int, id *Value) {
+			id.Number().NotEqual(2)
+		})
+		ids.chain.assertNotFailed(t)
+
+		ids.Every(func(_ int, id *Value) {
+			id.Number().Equal(1)
+		})
+		ids.chain.assertFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, path := range []string{
+			`$.items[?(@.id == 1]`,
+			`$.items[?(@.id == 1)`,
+			`$.items[?(@.id ==)]`,
+			`$.items[?(@.id == 1 &&)]`,
+			`$.items[?(@.id == foo)]`,
+			`$.items[?(@.id == "foo)]`,
+			`$.items[?((@.id == 1)]`,
+		} {
+			reporter := newMockReporter(t)
+
+			value := NewValue(reporter, data)
+			result := value.Path(path)
+
+			value.chain.assertFailed(t)
+			assert.Nil(t, result.Raw(), path)
+		}
+	})
+}
@@ -650,6 +650,10 @@ func (ws *Websocket) setWriteDeadline(opChain *chain) bool {
 }
 
 func (ws *Websocket) printRead(typ int, content []byte, closeCode int) {
+	if ws.config.Redactor != nil {
+		content = ws.config.Redactor.RedactBody(content)
+	}
+
 	for _, printer := range ws.config.Printers {
 		if p, ok := printer.(WebsocketPrinter); ok {
 			p.WebsocketRead(typ, content, closeCode)
@@ -658,6 +662,10 @@ func (ws *Websocket) printRead(typ int, content []byte, closeCode int) {
 }
 
 func (ws *Websocket) printWrite(typ int, content []byte, closeCode int) {
+	if ws.config.Redactor != nil {
+		content = ws.config.Redactor.RedactBody(content)
+	}
+
 	for _, printer := range ws.config.Printers {
 		if p, ok := printer.(WebsocketPrinter); ok {
 			p.WebsocketWrite(typ, content, closeCode)
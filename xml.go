@@ -0,0 +1,132 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Decode XML document into generic Go value (map[string]interface{}).
+//
+// Conversion rules:
+//   - document is converted to a map with single key, the root element name
+//   - element attributes are stored as keys prefixed with "-"
+//   - child elements are stored as keys equal to element name; if element
+//     with same name occurs multiple times, values are collected into array
+//   - element text is stored under "#text" key; however, if element has
+//     neither attributes nor child elements, it's converted to plain string
+//
+// Namespace prefixes are dropped, only local names are used.
+//
+// Example:
+//
+//	<user id="1"><name>john</name><role>admin</role><role>dev</role></user>
+//
+// is converted to:
+//
+//	{
+//	  "user": {
+//	    "-id": "1",
+//	    "name": "john",
+//	    "role": ["admin", "dev"]
+//	  }
+//	}
+func decodeXML(data []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var root map[string]interface{}
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if root != nil {
+				return nil, errors.New("unexpected multiple root elements")
+			}
+			value, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			root = map[string]interface{}{
+				t.Name.Local: value,
+			}
+
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) != 0 {
+				return nil, errors.New("unexpected text outside of root element")
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("missing root element")
+	}
+
+	return root, nil
+}
+
+func decodeXMLElement(
+	decoder *xml.Decoder, start xml.StartElement,
+) (interface{}, error) {
+	elem := map[string]interface{}{}
+
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		elem["-"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+
+			name := t.Name.Local
+
+			switch prev := elem[name].(type) {
+			case nil:
+				elem[name] = child
+			case []interface{}:
+				elem[name] = append(prev, child)
+			default:
+				elem[name] = []interface{}{prev, child}
+			}
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+
+			if len(elem) == 0 {
+				return s, nil
+			}
+			if s != "" {
+				elem["#text"] = s
+			}
+
+			return elem, nil
+		}
+	}
+}
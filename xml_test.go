@@ -0,0 +1,112 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXML_Decode(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected interface{}
+	}{
+		{
+			name:  "empty element",
+			input: `<a/>`,
+			expected: map[string]interface{}{
+				"a": "",
+			},
+		},
+		{
+			name:  "text element",
+			input: `<a> foo </a>`,
+			expected: map[string]interface{}{
+				"a": "foo",
+			},
+		},
+		{
+			name:  "attributes",
+			input: `<a x="1" y="2"/>`,
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"-x": "1",
+					"-y": "2",
+				},
+			},
+		},
+		{
+			name:  "attributes and text",
+			input: `<a x="1">foo</a>`,
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"-x":    "1",
+					"#text": "foo",
+				},
+			},
+		},
+		{
+			name:  "children",
+			input: `<a><b>1</b><c><d>2</d></c></a>`,
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": "1",
+					"c": map[string]interface{}{
+						"d": "2",
+					},
+				},
+			},
+		},
+		{
+			name:  "repeated children",
+			input: `<a><b>1</b><b>2</b><b>3</b></a>`,
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": []interface{}{"1", "2", "3"},
+				},
+			},
+		},
+		{
+			name:  "namespaces",
+			input: `<x:a xmlns:x="urn:x" x:id="1"><x:b>2</x:b></x:a>`,
+			expected: map[string]interface{}{
+				"a": map[string]interface{}{
+					"-id": "1",
+					"b":   "2",
+				},
+			},
+		},
+		{
+			name:  "prolog and comments",
+			input: "<?xml version=\"1.0\"?>\n<!-- comment -->\n<a>1</a>\n",
+			expected: map[string]interface{}{
+				"a": "1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := decodeXML([]byte(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestXML_DecodeErrors(t *testing.T) {
+	inputs := []string{
+		``,
+		`foo`,
+		`<a>`,
+		`<a></b>`,
+		`<a/><b/>`,
+		`<a/>foo`,
+	}
+
+	for _, input := range inputs {
+		_, err := decodeXML([]byte(input))
+		assert.Error(t, err, input)
+	}
+}